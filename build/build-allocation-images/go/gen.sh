@@ -48,3 +48,18 @@ mv ./allocation.pb.gw.go ./${outputpath}/allocation.gw.pb.go
 rm ./${protopath}/allocation.pb.go
 rm ./${protopath}/allocation.pb.gw.go
 rm ./${protopath}/allocation.swagger.json
+
+# the gRPC service of the FleetAutoscaler webhooks, which has no REST gateway
+outputpath=pkg/autoscaling/go
+protopath=proto/autoscaling
+protofile=${protopath}/autoscaling.proto
+
+protoc -I ${googleapis} -I . -I ./vendor ${protofile} --go_out=plugins=grpc:.
+
+cat ./build/boilerplate.go.txt ./${protopath}/autoscaling.pb.go >> ./autoscaling.pb.go
+
+goimports -w ./autoscaling.pb.go
+
+mv ./autoscaling.pb.go ./${outputpath}/autoscaling.pb.go
+
+rm ./${protopath}/autoscaling.pb.go
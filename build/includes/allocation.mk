@@ -52,6 +52,7 @@ build-build-allocation-image: ensure-build-image-base
 	docker build --tag=$(ALLOCATION_IMAGE_TAG) --build-arg BASE_IMAGE=$(build_base_tag) -f $(build_path)$(sdk_build_folder)/$(ALLOCATION_FOLDER)/Dockerfile $(build_path)$(allocation_build_folder)$(ALLOCATION_FOLDER)
 
 # Generates grpc server and client for a single allocation, use ALLOCATION_FOLDER variable to specify the allocation folder.
+# This also generates the grpc service of the FleetAutoscaler webhooks.
 gen-allocation-grpc:
 	cd $(allocation_build_folder); \
 	cd - ; \
@@ -61,6 +61,10 @@ const (
 	certDir = "/home/allocator/client-ca/"
 	tlsDir  = "/home/allocator/tls/"
 	sslPort = "8443"
+
+	// maxBatchAllocationCount is the maximum number of GameServers that can be allocated in one batch,
+	// which matches the size of the allocator's pending request queue
+	maxBatchAllocationCount = 100
 )
 
 // grpcHandlerFunc returns an http.Handler that delegates to grpcServer on incoming gRPC
@@ -235,6 +239,7 @@ func newServiceHandler(kubeClient kubernetes.Interface, agonesClient versioned.I
 
 	allocator := gameserverallocations.NewAllocator(
 		agonesInformerFactory.Multicluster().V1().GameServerAllocationPolicies(),
+		agonesClient.MulticlusterV1(),
 		kubeInformerFactory.Core().V1().Secrets(),
 		kubeClient,
 		gameserverallocations.NewReadyGameServerCache(agonesInformerFactory.Agones().V1().GameServers(), agonesClient.AgonesV1(), gsCounter, health),
@@ -433,3 +438,36 @@ func (h *serviceHandler) Allocate(ctx context.Context, in *pb.AllocationRequest)
 
 	return response, err
 }
+
+// BatchAllocate implements the BatchAllocate gRPC method definition.
+// Each allocation in the batch is made concurrently through the same process as Allocate,
+// so it is batched together with the others, and every GameServer is only allocated once.
+func (h *serviceHandler) BatchAllocate(ctx context.Context, in *pb.BatchAllocationRequest) (*pb.BatchAllocationResponse, error) {
+	logger.WithField("request", in).Infof("batch allocation request received.")
+	if in.GetCount() < 1 || in.GetCount() > maxBatchAllocationCount {
+		return nil, status.Errorf(codes.InvalidArgument, "count must be between 1 and %d, found %d", maxBatchAllocationCount, in.GetCount())
+	}
+	request := in.GetRequest()
+	if request == nil {
+		request = &pb.AllocationRequest{}
+	}
+
+	results := make([]*pb.BatchAllocationResponse_Result, in.GetCount())
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			response, err := h.Allocate(ctx, request)
+			st, _ := status.FromError(err)
+			results[i] = &pb.BatchAllocationResponse_Result{
+				Response: response,
+				Code:     int32(st.Code()),
+				Message:  st.Message(),
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return &pb.BatchAllocationResponse{Results: results}, nil
+}
@@ -21,6 +21,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"sync/atomic"
 	"testing"
 
 	pb "agones.dev/agones/pkg/allocation/go"
@@ -84,6 +85,67 @@ func TestAllocateHandlerReturnsError(t *testing.T) {
 	}
 }
 
+func TestBatchAllocateHandler(t *testing.T) {
+	t.Parallel()
+
+	// the first 3 allocations succeed, the rest have no GameServer left
+	var count int32
+	h := serviceHandler{
+		allocationCallback: func(gsa *allocationv1.GameServerAllocation) (k8sruntime.Object, error) {
+			assert.Equal(t, "ns", gsa.ObjectMeta.Namespace)
+			if atomic.AddInt32(&count, 1) > 3 {
+				return &allocationv1.GameServerAllocation{
+					Status: allocationv1.GameServerAllocationStatus{State: allocationv1.GameServerAllocationUnAllocated},
+				}, nil
+			}
+			return &allocationv1.GameServerAllocation{
+				Status: allocationv1.GameServerAllocationStatus{State: allocationv1.GameServerAllocationAllocated, GameServerName: "gs"},
+			}, nil
+		},
+	}
+
+	response, err := h.BatchAllocate(context.Background(), &pb.BatchAllocationRequest{
+		Request: &pb.AllocationRequest{Namespace: "ns"},
+		Count:   5,
+	})
+	if !assert.NoError(t, err) || !assert.Len(t, response.Results, 5) {
+		return
+	}
+
+	codeCount := map[codes.Code]int{}
+	for _, r := range response.Results {
+		code := codes.Code(r.Code)
+		codeCount[code]++
+		if code == codes.OK {
+			assert.Equal(t, "gs", r.Response.GameServerName)
+			assert.Empty(t, r.Message)
+		} else {
+			assert.Nil(t, r.Response)
+			assert.Equal(t, "there is no available GameServer to allocate", r.Message)
+		}
+	}
+	assert.Equal(t, map[codes.Code]int{codes.OK: 3, codes.ResourceExhausted: 2}, codeCount)
+}
+
+func TestBatchAllocateHandlerInvalidCount(t *testing.T) {
+	t.Parallel()
+
+	h := serviceHandler{
+		allocationCallback: func(gsa *allocationv1.GameServerAllocation) (k8sruntime.Object, error) {
+			assert.FailNow(t, "should not be called")
+			return nil, nil
+		},
+	}
+
+	for _, count := range []int32{0, -1, maxBatchAllocationCount + 1} {
+		_, err := h.BatchAllocate(context.Background(), &pb.BatchAllocationRequest{Count: count})
+		st, ok := status.FromError(err)
+		if assert.True(t, ok) {
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+		}
+	}
+}
+
 func TestGetTlsCert(t *testing.T) {
 	t.Parallel()
 	cert1, err := tls.X509KeyPair(serverCert1, serverKey1)
@@ -46,6 +46,10 @@ const (
 	defaultGRPCPort = 9357
 	defaultHTTPPort = 9358
 
+	// allocationPath is the path of the GameServerAllocation endpoint of the Kubernetes API,
+	// which is mimicked by the local sdk server in strict mode
+	allocationPath = "/apis/allocation.agones.dev/v1/namespaces/"
+
 	// specifically env vars
 	gameServerNameEnv = "GAMESERVER_NAME"
 	podNamespaceEnv   = "POD_NAMESPACE"
@@ -53,6 +57,11 @@ const (
 	// Flags (that can also be env vars)
 	localFlag       = "local"
 	fileFlag        = "file"
+	strictFlag      = "strict"
+	scenarioFlag    = "scenario"
+	traceFlag       = "trace"
+	replayFlag      = "replay"
+	persistFlag     = "persist"
 	testFlag        = "test"
 	testSdkNameFlag = "sdk-name"
 	addressFlag     = "address"
@@ -78,16 +87,31 @@ func main() {
 
 	stop := signals.NewStopChannel()
 	timedStop := make(chan struct{})
-	grpcServer := grpc.NewServer()
+	var opts []grpc.ServerOption
+	switch ctlConf.Trace {
+	case "":
+	case "-":
+		opts = sdkserver.NewTracer(os.Stdout).ServerOptions()
+	default:
+		file, err := os.OpenFile(ctlConf.Trace, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			logger.WithError(err).WithField("trace", ctlConf.Trace).Fatal("Could not open trace file")
+		}
+		defer file.Close() // nolint: errcheck
+		opts = sdkserver.NewTracer(file).ServerOptions()
+	}
+	grpcServer := grpc.NewServer(opts...)
 	// don't graceful stop, because if we get a kill signal
 	// then the gameserver is being shut down, and we no longer
 	// care about running RPC calls.
 	defer grpcServer.Stop()
 
 	mux := gwruntime.NewServeMux()
+	httpMux := http.NewServeMux()
+	httpMux.Handle("/", mux)
 	httpServer := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", ctlConf.Address, ctlConf.HTTPPort),
-		Handler: mux,
+		Handler: httpMux,
 	}
 	defer httpServer.Close() // nolint: errcheck
 	ctx, cancel := context.WithCancel(context.Background())
@@ -95,7 +119,7 @@ func main() {
 
 	switch {
 	case ctlConf.IsLocal:
-		cancel, err := registerLocal(grpcServer, ctlConf)
+		cancel, err := registerLocal(grpcServer, httpMux, ctlConf)
 		if err != nil {
 			logger.WithError(err).Fatal("Could not start local sdk server")
 		}
@@ -161,9 +185,18 @@ func main() {
 	go runGrpc(grpcServer, grpcEndpoint)
 	go runGateway(ctx, grpcEndpoint, mux, httpServer)
 
+	replayDone := make(chan struct{})
+	if ctlConf.IsLocal && ctlConf.Replay != "" {
+		go func() {
+			defer close(replayDone)
+			runReplay(ctx, grpcEndpoint, ctlConf.Replay)
+		}()
+	}
+
 	select {
 	case <-stop:
 	case <-timedStop:
+	case <-replayDone:
 	}
 
 	logger.Info("shutting down sdk server")
@@ -171,7 +204,7 @@ func main() {
 
 // registerLocal registers the local SDK servers, and returns a cancel func that
 // closes all the SDK implementations
-func registerLocal(grpcServer *grpc.Server, ctlConf config) (func(), error) {
+func registerLocal(grpcServer *grpc.Server, httpMux *http.ServeMux, ctlConf config) (func(), error) {
 	filePath := ""
 	if ctlConf.LocalFile != "" {
 		var err error
@@ -189,6 +222,20 @@ func registerLocal(grpcServer *grpc.Server, ctlConf config) (func(), error) {
 	if err != nil {
 		return nil, err
 	}
+	if ctlConf.IsStrict {
+		s.SetStrictMode(true)
+		httpMux.HandleFunc(allocationPath, s.HandleAllocation)
+	}
+	if ctlConf.Persist != "" {
+		if err := s.SetPersistFilePath(ctlConf.Persist); err != nil {
+			return nil, err
+		}
+	}
+	if ctlConf.Scenario != "" {
+		if err := s.SetScenario(ctlConf.Scenario); err != nil {
+			return nil, err
+		}
+	}
 
 	sdk.RegisterSDKServer(grpcServer, s)
 	sdkalpha.RegisterSDKServer(grpcServer, s)
@@ -220,6 +267,33 @@ func registerTestSdkServer(grpcServer *grpc.Server, ctlConf config) (func(), err
 	}, err
 }
 
+// runReplay drives a fake game server from the trace at tracePath, against the local sdk server
+func runReplay(ctx context.Context, grpcEndpoint string, tracePath string) {
+	file, err := os.Open(tracePath) // nolint: gosec
+	if err != nil {
+		logger.WithError(err).WithField("replay", tracePath).Fatal("Could not open trace file to replay")
+	}
+	defer file.Close() // nolint: errcheck
+
+	conn, err := grpc.DialContext(ctx, grpcEndpoint, grpc.WithBlock(), grpc.WithInsecure())
+	if err != nil {
+		logger.WithError(err).Fatal("Could not dial grpc server to replay...")
+	}
+	defer conn.Close() // nolint: errcheck
+
+	logger.WithField("replay", tracePath).Info("Replaying trace...")
+	mismatches, err := sdkserver.ReplayTrace(ctx, conn, file, logger.WithField("replay", tracePath))
+	if err != nil {
+		logger.WithError(err).Error("Could not replay trace")
+		return
+	}
+	if mismatches > 0 {
+		logger.WithField("mismatches", mismatches).Error("Replay finished with requests that have a different status code than in the trace")
+		return
+	}
+	logger.Info("Replay finished, all requests have the same status code as in the trace")
+}
+
 // runGrpc runs the grpc service
 func runGrpc(grpcServer *grpc.Server, grpcEndpoint string) {
 	lis, err := net.Listen("tcp", grpcEndpoint)
@@ -263,6 +337,11 @@ func parseEnvFlags() config {
 	viper.AllowEmptyEnv(true)
 	viper.SetDefault(localFlag, false)
 	viper.SetDefault(fileFlag, "")
+	viper.SetDefault(strictFlag, false)
+	viper.SetDefault(scenarioFlag, "")
+	viper.SetDefault(traceFlag, "")
+	viper.SetDefault(replayFlag, "")
+	viper.SetDefault(persistFlag, "")
 	viper.SetDefault(testFlag, "")
 	viper.SetDefault(testSdkNameFlag, "")
 	viper.SetDefault(addressFlag, "localhost")
@@ -273,6 +352,12 @@ func parseEnvFlags() config {
 	pflag.Bool(localFlag, viper.GetBool(localFlag),
 		"Set this, or LOCAL env, to 'true' to run this binary in local development mode. Defaults to 'false'")
 	pflag.StringP(fileFlag, "f", viper.GetString(fileFlag), "Set this, or FILE env var to the path of a local yaml or json file that contains your GameServer resoure configuration")
+	pflag.Bool(strictFlag, viper.GetBool(strictFlag),
+		"Set this, or STRICT env, to 'true' to have the local development mode follow the GameServer lifecycle of a cluster: health checking, illegal state transitions, and allocation through a local GameServerAllocation endpoint. Defaults to 'false'")
+	pflag.String(scenarioFlag, viper.GetString(scenarioFlag), "Set this, or SCENARIO env var to the path of a local yaml or json file that contains a scenario of faults to inject and events to run in local development mode. Use with --timeout to get the report of the scenario")
+	pflag.String(traceFlag, viper.GetString(traceFlag), "Set this, or TRACE env var to the path of a file to append a trace of every SDK request to, as JSON lines. Set it to '-' to write the trace to stdout")
+	pflag.String(replayFlag, viper.GetString(replayFlag), "Set this, or REPLAY env var to the path of a trace file, to replay its SDK requests against the local development mode, then stop")
+	pflag.String(persistFlag, viper.GetString(persistFlag), "Set this, or PERSIST env var to the path of a yaml or json file to save the GameServer to after each change in local development mode, and to resume it from when starting")
 	pflag.String(addressFlag, viper.GetString(addressFlag), "The Address to bind the server grpcPort to. Defaults to 'localhost'")
 	pflag.Int(grpcPortFlag, viper.GetInt(grpcPortFlag), fmt.Sprintf("Port on which to bind the gRPC server. Defaults to %d", defaultGRPCPort))
 	pflag.Int(httpPortFlag, viper.GetInt(httpPortFlag), fmt.Sprintf("Port on which to bind the HTTP server. Defaults to %d", defaultHTTPPort))
@@ -285,6 +370,11 @@ func parseEnvFlags() config {
 
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	runtime.Must(viper.BindEnv(localFlag))
+	runtime.Must(viper.BindEnv(strictFlag))
+	runtime.Must(viper.BindEnv(scenarioFlag))
+	runtime.Must(viper.BindEnv(traceFlag))
+	runtime.Must(viper.BindEnv(replayFlag))
+	runtime.Must(viper.BindEnv(persistFlag))
 	runtime.Must(viper.BindEnv(addressFlag))
 	runtime.Must(viper.BindEnv(testFlag))
 	runtime.Must(viper.BindEnv(testSdkNameFlag))
@@ -301,6 +391,11 @@ func parseEnvFlags() config {
 
 	return config{
 		IsLocal:     viper.GetBool(localFlag),
+		IsStrict:    viper.GetBool(strictFlag),
+		Scenario:    viper.GetString(scenarioFlag),
+		Trace:       viper.GetString(traceFlag),
+		Replay:      viper.GetString(replayFlag),
+		Persist:     viper.GetString(persistFlag),
 		Address:     viper.GetString(addressFlag),
 		LocalFile:   viper.GetString(fileFlag),
 		Delay:       viper.GetInt(delayFlag),
@@ -316,7 +411,12 @@ func parseEnvFlags() config {
 type config struct {
 	Address     string
 	IsLocal     bool
+	IsStrict    bool
 	LocalFile   string
+	Scenario    string
+	Trace       string
+	Replay      string
+	Persist     string
 	Delay       int
 	Timeout     int
 	Test        string
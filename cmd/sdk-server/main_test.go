@@ -15,6 +15,7 @@
 package main
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -33,6 +34,6 @@ func TestRegisterTestSdkServer(t *testing.T) {
 	defer cancel()
 	ctx.Done()
 	ctlConf.LocalFile = "@@"
-	_, err = registerLocal(grpcServer, ctlConf)
+	_, err = registerLocal(grpcServer, http.NewServeMux(), ctlConf)
 	assert.Error(t, err, "Wrong file name should produce an error")
 }
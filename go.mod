@@ -40,6 +40,7 @@ require (
 	k8s.io/client-go v0.17.14
 	k8s.io/kube-openapi v0.0.0-20200410163147-594e756bea31 // indirect
 	k8s.io/utils v0.0.0-20200124190032-861946025e34
+	sigs.k8s.io/yaml v1.1.0
 )
 
 replace google.golang.org/grpc v1.23.1 => google.golang.org/grpc v1.20.1 // apiserver updated grpc, but we aren't using that, so it's fine.
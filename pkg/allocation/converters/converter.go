@@ -20,6 +20,7 @@ import (
 	"agones.dev/agones/pkg/apis"
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
 	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
+	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,14 +37,17 @@ func ConvertAllocationRequestToGSA(in *pb.AllocationRequest) *allocationv1.GameS
 			Namespace: in.GetNamespace(),
 		},
 		Spec: allocationv1.GameServerAllocationSpec{
-			Preferred:  convertLabelSelectorsToInternalLabelSelectors(in.GetPreferredGameServerSelectors()),
+			Preferred:  convertGameServerSelectorsToInternalGameServerSelectors(in.GetPreferredGameServerSelectors()),
 			Scheduling: convertAllocationSchedulingToGSASchedulingStrategy(in.GetScheduling()),
+			Priorities: convertPrioritiesToInternalPriorities(in.GetPriorities()),
 		},
 	}
 
 	if in.GetMultiClusterSetting() != nil {
 		gsa.Spec.MultiClusterSetting = allocationv1.MultiClusterSetting{
-			Enabled: in.GetMultiClusterSetting().GetEnabled(),
+			Enabled:          in.GetMultiClusterSetting().GetEnabled(),
+			Latencies:        convertLatenciesToInternalLatencies(in.GetMultiClusterSetting().GetLatencies()),
+			MaxLatencyMillis: in.GetMultiClusterSetting().GetMaxLatencyMillis(),
 		}
 		if ls := convertLabelSelectorToInternalLabelSelector(in.GetMultiClusterSetting().GetPolicySelector()); ls != nil {
 			gsa.Spec.MultiClusterSetting.PolicySelector = *ls
@@ -57,8 +61,8 @@ func ConvertAllocationRequestToGSA(in *pb.AllocationRequest) *allocationv1.GameS
 		}
 	}
 
-	if ls := convertLabelSelectorToInternalLabelSelector(in.GetRequiredGameServerSelector()); ls != nil {
-		gsa.Spec.Required = *ls
+	if selector := convertGameServerSelectorToInternalGameServerSelector(in.GetRequiredGameServerSelector()); selector != nil {
+		gsa.Spec.Required = *selector
 	}
 	return gsa
 }
@@ -71,16 +75,19 @@ func ConvertGSAToAllocationRequest(in *allocationv1.GameServerAllocation) *pb.Al
 
 	out := &pb.AllocationRequest{
 		Namespace:                    in.GetNamespace(),
-		PreferredGameServerSelectors: convertInternalLabelSelectorsToLabelSelectors(in.Spec.Preferred),
+		PreferredGameServerSelectors: convertInternalGameServerSelectorsToGameServerSelectors(in.Spec.Preferred),
 		Scheduling:                   convertGSASchedulingStrategyToAllocationScheduling(in.Spec.Scheduling),
 		MultiClusterSetting: &pb.MultiClusterSetting{
-			Enabled: in.Spec.MultiClusterSetting.Enabled,
+			Enabled:          in.Spec.MultiClusterSetting.Enabled,
+			Latencies:        convertInternalLatenciesToLatencies(in.Spec.MultiClusterSetting.Latencies),
+			MaxLatencyMillis: in.Spec.MultiClusterSetting.MaxLatencyMillis,
 		},
-		RequiredGameServerSelector: convertInternalLabelSelectorToLabelSelector(&in.Spec.Required),
+		RequiredGameServerSelector: convertInternalGameServerSelectorToGameServerSelector(&in.Spec.Required),
 		MetaPatch: &pb.MetaPatch{
 			Labels:      in.Spec.MetaPatch.Labels,
 			Annotations: in.Spec.MetaPatch.Annotations,
 		},
+		Priorities: convertInternalPrioritiesToPriorities(in.Spec.Priorities),
 	}
 
 	if in.Spec.MultiClusterSetting.Enabled {
@@ -116,36 +123,252 @@ func convertLabelSelectorToInternalLabelSelector(in *pb.LabelSelector) *metav1.L
 	if in == nil {
 		return nil
 	}
-	return &metav1.LabelSelector{MatchLabels: in.GetMatchLabels()}
+	return &metav1.LabelSelector{
+		MatchLabels:      in.GetMatchLabels(),
+		MatchExpressions: convertLabelSelectorRequirementsToInternalLabelSelectorRequirements(in.GetMatchExpressions()),
+	}
 }
 
 func convertInternalLabelSelectorToLabelSelector(in *metav1.LabelSelector) *pb.LabelSelector {
 	if in == nil {
 		return nil
 	}
-	return &pb.LabelSelector{MatchLabels: in.MatchLabels}
+	return &pb.LabelSelector{
+		MatchLabels:      in.MatchLabels,
+		MatchExpressions: convertInternalLabelSelectorRequirementsToLabelSelectorRequirements(in.MatchExpressions),
+	}
+}
+
+func convertLabelSelectorRequirementsToInternalLabelSelectorRequirements(in []*pb.LabelSelectorRequirement) []metav1.LabelSelectorRequirement {
+	var result []metav1.LabelSelectorRequirement
+	for _, r := range in {
+		if r == nil {
+			continue
+		}
+		result = append(result, metav1.LabelSelectorRequirement{
+			Key:      r.GetKey(),
+			Operator: metav1.LabelSelectorOperator(r.GetOperator()),
+			Values:   r.GetValues(),
+		})
+	}
+	return result
+}
+
+func convertInternalLabelSelectorRequirementsToLabelSelectorRequirements(in []metav1.LabelSelectorRequirement) []*pb.LabelSelectorRequirement {
+	var result []*pb.LabelSelectorRequirement
+	for _, r := range in {
+		result = append(result, &pb.LabelSelectorRequirement{
+			Key:      r.Key,
+			Operator: string(r.Operator),
+			Values:   r.Values,
+		})
+	}
+	return result
+}
+
+// convertLatenciesToInternalLatencies converts latency measurements from AllocationRequest to LatencyMeasurements
+func convertLatenciesToInternalLatencies(in []*pb.LatencyMeasurement) []allocationv1.LatencyMeasurement {
+	var result []allocationv1.LatencyMeasurement
+	for _, l := range in {
+		if l == nil {
+			continue
+		}
+		result = append(result, allocationv1.LatencyMeasurement{Name: l.GetName(), LatencyMillis: l.GetLatencyMillis()})
+	}
+	return result
+}
+
+// convertInternalLatenciesToLatencies converts LatencyMeasurements to latency measurements for AllocationRequest
+func convertInternalLatenciesToLatencies(in []allocationv1.LatencyMeasurement) []*pb.LatencyMeasurement {
+	var result []*pb.LatencyMeasurement
+	for _, l := range in {
+		result = append(result, &pb.LatencyMeasurement{Name: l.Name, LatencyMillis: l.LatencyMillis})
+	}
+	return result
+}
+
+func convertGameServerSelectorToInternalGameServerSelector(in *pb.GameServerSelector) *allocationv1.GameServerSelector {
+	if in == nil {
+		return nil
+	}
+	result := &allocationv1.GameServerSelector{
+		LabelSelector: metav1.LabelSelector{
+			MatchLabels:      in.GetMatchLabels(),
+			MatchExpressions: convertLabelSelectorRequirementsToInternalLabelSelectorRequirements(in.GetMatchExpressions()),
+		},
+	}
+
+	// Ready is the default, so only set the state when it is something else, as setting it
+	// at all requires the StateAllocationFilter feature flag.
+	if in.GetGameServerState() == pb.GameServerSelector_ALLOCATED {
+		allocated := agonesv1.GameServerStateAllocated
+		result.GameServerState = &allocated
+	}
+
+	if in.GetPlayers() != nil {
+		result.Players = &allocationv1.PlayerSelector{
+			MinAvailable: int64(in.GetPlayers().GetMinAvailable()),
+			MaxAvailable: int64(in.GetPlayers().GetMaxAvailable()),
+		}
+	}
+
+	if len(in.GetCounters()) > 0 {
+		result.Counters = make(map[string]allocationv1.CounterSelector, len(in.GetCounters()))
+		for name, c := range in.GetCounters() {
+			result.Counters[name] = allocationv1.CounterSelector{
+				MinCount:     c.GetMinCount(),
+				MaxCount:     c.GetMaxCount(),
+				MinAvailable: c.GetMinAvailable(),
+				MaxAvailable: c.GetMaxAvailable(),
+			}
+		}
+	}
+
+	if len(in.GetLists()) > 0 {
+		result.Lists = make(map[string]allocationv1.ListSelector, len(in.GetLists()))
+		for name, l := range in.GetLists() {
+			result.Lists[name] = allocationv1.ListSelector{
+				ContainsValue: l.GetContainsValue(),
+				MinAvailable:  l.GetMinAvailable(),
+				MaxAvailable:  l.GetMaxAvailable(),
+			}
+		}
+	}
+
+	return result
+}
+
+func convertInternalGameServerSelectorToGameServerSelector(in *allocationv1.GameServerSelector) *pb.GameServerSelector {
+	if in == nil {
+		return nil
+	}
+	result := &pb.GameServerSelector{
+		MatchLabels:      in.MatchLabels,
+		MatchExpressions: convertInternalLabelSelectorRequirementsToLabelSelectorRequirements(in.MatchExpressions),
+	}
+
+	if in.GameServerState != nil && *in.GameServerState == agonesv1.GameServerStateAllocated {
+		result.GameServerState = pb.GameServerSelector_ALLOCATED
+	}
+
+	if in.Players != nil {
+		result.Players = &pb.PlayerSelector{
+			MinAvailable: uint64(in.Players.MinAvailable),
+			MaxAvailable: uint64(in.Players.MaxAvailable),
+		}
+	}
+
+	if len(in.Counters) > 0 {
+		result.Counters = make(map[string]*pb.CounterSelector, len(in.Counters))
+		for name, c := range in.Counters {
+			result.Counters[name] = &pb.CounterSelector{
+				MinCount:     c.MinCount,
+				MaxCount:     c.MaxCount,
+				MinAvailable: c.MinAvailable,
+				MaxAvailable: c.MaxAvailable,
+			}
+		}
+	}
+
+	if len(in.Lists) > 0 {
+		result.Lists = make(map[string]*pb.ListSelector, len(in.Lists))
+		for name, l := range in.Lists {
+			result.Lists[name] = &pb.ListSelector{
+				ContainsValue: l.ContainsValue,
+				MinAvailable:  l.MinAvailable,
+				MaxAvailable:  l.MaxAvailable,
+			}
+		}
+	}
+
+	return result
 }
 
-func convertInternalLabelSelectorsToLabelSelectors(in []metav1.LabelSelector) []*pb.LabelSelector {
-	var result []*pb.LabelSelector
+func convertInternalGameServerSelectorsToGameServerSelectors(in []allocationv1.GameServerSelector) []*pb.GameServerSelector {
+	var result []*pb.GameServerSelector
 	for _, l := range in {
 		l := l
-		c := convertInternalLabelSelectorToLabelSelector(&l)
+		c := convertInternalGameServerSelectorToGameServerSelector(&l)
 		result = append(result, c)
 	}
 	return result
 }
 
-func convertLabelSelectorsToInternalLabelSelectors(in []*pb.LabelSelector) []metav1.LabelSelector {
-	var result []metav1.LabelSelector
+func convertGameServerSelectorsToInternalGameServerSelectors(in []*pb.GameServerSelector) []allocationv1.GameServerSelector {
+	var result []allocationv1.GameServerSelector
 	for _, l := range in {
-		if c := convertLabelSelectorToInternalLabelSelector(l); c != nil {
+		if c := convertGameServerSelectorToInternalGameServerSelector(l); c != nil {
 			result = append(result, *c)
 		}
 	}
 	return result
 }
 
+func convertPrioritiesToInternalPriorities(in []*pb.Priority) []allocationv1.Priority {
+	var result []allocationv1.Priority
+	for _, p := range in {
+		if p == nil {
+			continue
+		}
+		priority := allocationv1.Priority{
+			Type:  allocationv1.GameServerPriorityCounter,
+			Key:   p.GetKey(),
+			Order: allocationv1.GameServerPriorityDescending,
+		}
+		switch p.GetType() {
+		case pb.Priority_LIST:
+			priority.Type = allocationv1.GameServerPriorityList
+		case pb.Priority_LABEL:
+			priority.Type = allocationv1.GameServerPriorityLabel
+		case pb.Priority_ANNOTATION:
+			priority.Type = allocationv1.GameServerPriorityAnnotation
+		case pb.Priority_PLAYERS:
+			priority.Type = allocationv1.GameServerPriorityPlayers
+		}
+		if p.GetOrder() == pb.Priority_ASCENDING {
+			priority.Order = allocationv1.GameServerPriorityAscending
+		}
+		// the value type only applies to labels and annotations
+		if priority.Type == allocationv1.GameServerPriorityLabel || priority.Type == allocationv1.GameServerPriorityAnnotation {
+			priority.ValueType = allocationv1.GameServerPriorityString
+			if p.GetValueType() == pb.Priority_INT {
+				priority.ValueType = allocationv1.GameServerPriorityInt
+			}
+		}
+		result = append(result, priority)
+	}
+	return result
+}
+
+func convertInternalPrioritiesToPriorities(in []allocationv1.Priority) []*pb.Priority {
+	var result []*pb.Priority
+	for _, p := range in {
+		priority := &pb.Priority{
+			Type:  pb.Priority_COUNTER,
+			Key:   p.Key,
+			Order: pb.Priority_DESCENDING,
+		}
+		switch p.Type {
+		case allocationv1.GameServerPriorityList:
+			priority.Type = pb.Priority_LIST
+		case allocationv1.GameServerPriorityLabel:
+			priority.Type = pb.Priority_LABEL
+		case allocationv1.GameServerPriorityAnnotation:
+			priority.Type = pb.Priority_ANNOTATION
+		case allocationv1.GameServerPriorityPlayers:
+			priority.Type = pb.Priority_PLAYERS
+		}
+		if p.Order == allocationv1.GameServerPriorityAscending {
+			priority.Order = pb.Priority_ASCENDING
+		}
+		if p.ValueType == allocationv1.GameServerPriorityInt {
+			priority.ValueType = pb.Priority_INT
+		}
+		result = append(result, priority)
+	}
+	return result
+}
+
 // ConvertGSAToAllocationResponse converts GameServerAllocation V1 (GSA) to AllocationResponse
 func ConvertGSAToAllocationResponse(in *allocationv1.GameServerAllocation) (*pb.AllocationResponse, error) {
 	if in == nil {
@@ -156,12 +379,34 @@ func ConvertGSAToAllocationResponse(in *allocationv1.GameServerAllocation) (*pb.
 		return nil, err
 	}
 
-	return &pb.AllocationResponse{
+	out := &pb.AllocationResponse{
 		GameServerName: in.Status.GameServerName,
 		Address:        in.Status.Address,
 		NodeName:       in.Status.NodeName,
 		Ports:          convertGSAAgonesPortsToAllocationPorts(in.Status.Ports),
-	}, nil
+	}
+	if in.Status.Metadata != nil {
+		out.Metadata = &pb.AllocationResponse_GameServerMetadata{
+			Labels:      in.Status.Metadata.Labels,
+			Annotations: in.Status.Metadata.Annotations,
+		}
+	}
+	if in.Status.Players != nil {
+		out.Players = &pb.AllocationResponse_PlayerStatus{
+			Count:    in.Status.Players.Count,
+			Capacity: in.Status.Players.Capacity,
+			Ids:      in.Status.Players.IDs,
+		}
+	}
+	if in.Status.AllocationTimestamp != nil {
+		ts, err := ptypes.TimestampProto(in.Status.AllocationTimestamp.Time)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid allocation timestamp: %v", err)
+		}
+		out.AllocationTimestamp = ts
+	}
+
+	return out, nil
 }
 
 // ConvertAllocationResponseToGSA converts AllocationResponse to GameServerAllocation V1 (GSA)
@@ -179,6 +424,26 @@ func ConvertAllocationResponseToGSA(in *pb.AllocationResponse) *allocationv1.Gam
 			Ports:          convertAllocationPortsToGSAAgonesPorts(in.Ports),
 		},
 	}
+	if in.GetMetadata() != nil {
+		out.Status.Metadata = &allocationv1.GameServerMetadata{
+			Labels:      in.GetMetadata().GetLabels(),
+			Annotations: in.GetMetadata().GetAnnotations(),
+		}
+	}
+	if in.GetPlayers() != nil {
+		out.Status.Players = &agonesv1.PlayerStatus{
+			Count:    in.GetPlayers().GetCount(),
+			Capacity: in.GetPlayers().GetCapacity(),
+			IDs:      in.GetPlayers().GetIds(),
+		}
+	}
+	if in.GetAllocationTimestamp() != nil {
+		// an invalid timestamp is left out, as the allocation itself succeeded
+		if t, err := ptypes.Timestamp(in.GetAllocationTimestamp()); err == nil {
+			allocationTimestamp := metav1.NewTime(t)
+			out.Status.AllocationTimestamp = &allocationTimestamp
+		}
+	}
 	out.SetGroupVersionKind(allocationv1.SchemeGroupVersion.WithKind("GameServerAllocation"))
 
 	return out
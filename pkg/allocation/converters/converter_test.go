@@ -16,11 +16,13 @@ package converters
 
 import (
 	"testing"
+	"time"
 
 	pb "agones.dev/agones/pkg/allocation/go"
 	"agones.dev/agones/pkg/apis"
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
 	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -28,6 +30,8 @@ import (
 )
 
 func TestConvertAllocationRequestToGameServerAllocation(t *testing.T) {
+	allocated := agonesv1.GameServerStateAllocated
+
 	tests := []struct {
 		name               string
 		in                 *pb.AllocationRequest
@@ -44,18 +48,41 @@ func TestConvertAllocationRequestToGameServerAllocation(t *testing.T) {
 						MatchLabels: map[string]string{
 							"a": "b",
 						},
+						MatchExpressions: []*pb.LabelSelectorRequirement{
+							{Key: "region", Operator: "In", Values: []string{"eu", "us"}},
+						},
 					},
+					Latencies: []*pb.LatencyMeasurement{
+						{Name: "europe-west1", LatencyMillis: 30},
+						{Name: "us-east1", LatencyMillis: 110},
+					},
+					MaxLatencyMillis: 150,
 				},
-				RequiredGameServerSelector: &pb.LabelSelector{
+				RequiredGameServerSelector: &pb.GameServerSelector{
 					MatchLabels: map[string]string{
 						"c": "d",
 					},
+					MatchExpressions: []*pb.LabelSelectorRequirement{
+						{Key: "version", Operator: "NotIn", Values: []string{"1.0"}},
+						{Key: "canary", Operator: "DoesNotExist"},
+					},
+					GameServerState: pb.GameServerSelector_ALLOCATED,
+					Players: &pb.PlayerSelector{
+						MinAvailable: 5,
+						MaxAvailable: 10,
+					},
 				},
-				PreferredGameServerSelectors: []*pb.LabelSelector{
+				PreferredGameServerSelectors: []*pb.GameServerSelector{
 					{
 						MatchLabels: map[string]string{
 							"e": "f",
 						},
+						Counters: map[string]*pb.CounterSelector{
+							"rooms": {MinCount: 1, MaxCount: 5, MinAvailable: 2, MaxAvailable: 4},
+						},
+						Lists: map[string]*pb.ListSelector{
+							"tokens": {ContainsValue: "x", MinAvailable: 1, MaxAvailable: 3},
+						},
 					},
 					{
 						MatchLabels: map[string]string{
@@ -69,6 +96,13 @@ func TestConvertAllocationRequestToGameServerAllocation(t *testing.T) {
 						"i": "j",
 					},
 				},
+				Priorities: []*pb.Priority{
+					{Type: pb.Priority_COUNTER, Key: "rooms", Order: pb.Priority_DESCENDING},
+					{Type: pb.Priority_LIST, Key: "tokens", Order: pb.Priority_ASCENDING},
+					{Type: pb.Priority_LABEL, Key: "build", Order: pb.Priority_DESCENDING, ValueType: pb.Priority_STRING},
+					{Type: pb.Priority_ANNOTATION, Key: "tier", Order: pb.Priority_ASCENDING, ValueType: pb.Priority_INT},
+					{Type: pb.Priority_PLAYERS, Order: pb.Priority_DESCENDING},
+				},
 			},
 			want: &allocationv1.GameServerAllocation{
 				ObjectMeta: metav1.ObjectMeta{
@@ -81,26 +115,62 @@ func TestConvertAllocationRequestToGameServerAllocation(t *testing.T) {
 							MatchLabels: map[string]string{
 								"a": "b",
 							},
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "region", Operator: metav1.LabelSelectorOpIn, Values: []string{"eu", "us"}},
+							},
+						},
+						Latencies: []allocationv1.LatencyMeasurement{
+							{Name: "europe-west1", LatencyMillis: 30},
+							{Name: "us-east1", LatencyMillis: 110},
 						},
+						MaxLatencyMillis: 150,
 					},
-					Required: metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"c": "d",
+					Required: allocationv1.GameServerSelector{
+						LabelSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"c": "d",
+							},
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "version", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"1.0"}},
+								{Key: "canary", Operator: metav1.LabelSelectorOpDoesNotExist},
+							},
+						},
+						GameServerState: &allocated,
+						Players: &allocationv1.PlayerSelector{
+							MinAvailable: 5,
+							MaxAvailable: 10,
 						},
 					},
-					Preferred: []metav1.LabelSelector{
+					Preferred: []allocationv1.GameServerSelector{
 						{
-							MatchLabels: map[string]string{
-								"e": "f",
+							LabelSelector: metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"e": "f",
+								},
+							},
+							Counters: map[string]allocationv1.CounterSelector{
+								"rooms": {MinCount: 1, MaxCount: 5, MinAvailable: 2, MaxAvailable: 4},
+							},
+							Lists: map[string]allocationv1.ListSelector{
+								"tokens": {ContainsValue: "x", MinAvailable: 1, MaxAvailable: 3},
 							},
 						},
 						{
-							MatchLabels: map[string]string{
-								"g": "h",
+							LabelSelector: metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"g": "h",
+								},
 							},
 						},
 					},
 					Scheduling: apis.Packed,
+					Priorities: []allocationv1.Priority{
+						{Type: allocationv1.GameServerPriorityCounter, Key: "rooms", Order: allocationv1.GameServerPriorityDescending},
+						{Type: allocationv1.GameServerPriorityList, Key: "tokens", Order: allocationv1.GameServerPriorityAscending},
+						{Type: allocationv1.GameServerPriorityLabel, Key: "build", Order: allocationv1.GameServerPriorityDescending, ValueType: allocationv1.GameServerPriorityString},
+						{Type: allocationv1.GameServerPriorityAnnotation, Key: "tier", Order: allocationv1.GameServerPriorityAscending, ValueType: allocationv1.GameServerPriorityInt},
+						{Type: allocationv1.GameServerPriorityPlayers, Order: allocationv1.GameServerPriorityDescending},
+					},
 					MetaPatch: allocationv1.MetaPatch{
 						Labels: map[string]string{
 							"i": "j",
@@ -114,8 +184,8 @@ func TestConvertAllocationRequestToGameServerAllocation(t *testing.T) {
 			in: &pb.AllocationRequest{
 				Namespace:                    "",
 				MultiClusterSetting:          &pb.MultiClusterSetting{},
-				RequiredGameServerSelector:   &pb.LabelSelector{},
-				PreferredGameServerSelectors: []*pb.LabelSelector{},
+				RequiredGameServerSelector:   &pb.GameServerSelector{},
+				PreferredGameServerSelectors: []*pb.GameServerSelector{},
 				Scheduling:                   pb.AllocationRequest_Distributed,
 				MetaPatch:                    &pb.MetaPatch{},
 			},
@@ -190,7 +260,7 @@ func TestConvertGSAToAllocationRequestEmpty(t *testing.T) {
 			want: &pb.AllocationRequest{
 				Namespace:                  "",
 				MultiClusterSetting:        &pb.MultiClusterSetting{},
-				RequiredGameServerSelector: &pb.LabelSelector{},
+				RequiredGameServerSelector: &pb.GameServerSelector{},
 				Scheduling:                 pb.AllocationRequest_Distributed,
 				MetaPatch:                  &pb.MetaPatch{},
 			},
@@ -203,7 +273,7 @@ func TestConvertGSAToAllocationRequestEmpty(t *testing.T) {
 			},
 			want: &pb.AllocationRequest{
 				MultiClusterSetting:        &pb.MultiClusterSetting{},
-				RequiredGameServerSelector: &pb.LabelSelector{},
+				RequiredGameServerSelector: &pb.GameServerSelector{},
 				MetaPatch:                  &pb.MetaPatch{},
 			},
 		},
@@ -221,6 +291,8 @@ func TestConvertGSAToAllocationRequestEmpty(t *testing.T) {
 }
 
 func TestConvertGSAToAllocationResponse(t *testing.T) {
+	allocationTimestamp := metav1.NewTime(time.Unix(1600000000, 500).UTC())
+	invalidTimestamp := metav1.NewTime(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))
 	tests := []struct {
 		name             string
 		in               *allocationv1.GameServerAllocation
@@ -264,6 +336,50 @@ func TestConvertGSAToAllocationResponse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "status has metadata, players and allocation timestamp",
+			in: &allocationv1.GameServerAllocation{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "GameServerAllocation",
+					APIVersion: "allocation.agones.dev/v1",
+				},
+				Status: allocationv1.GameServerAllocationStatus{
+					State:          allocationv1.GameServerAllocationAllocated,
+					GameServerName: "GSN",
+					Metadata: &allocationv1.GameServerMetadata{
+						Labels:      map[string]string{"build": "1.2"},
+						Annotations: map[string]string{"map": "searide"},
+					},
+					Players:             &agonesv1.PlayerStatus{Count: 1, Capacity: 10, IDs: []string{"player1"}},
+					AllocationTimestamp: &allocationTimestamp,
+				},
+			},
+			want: &pb.AllocationResponse{
+				GameServerName: "GSN",
+				Metadata: &pb.AllocationResponse_GameServerMetadata{
+					Labels:      map[string]string{"build": "1.2"},
+					Annotations: map[string]string{"map": "searide"},
+				},
+				Players:             &pb.AllocationResponse_PlayerStatus{Count: 1, Capacity: 10, Ids: []string{"player1"}},
+				AllocationTimestamp: &timestamp.Timestamp{Seconds: 1600000000, Nanos: 500},
+			},
+		},
+		{
+			name: "invalid allocation timestamp",
+			in: &allocationv1.GameServerAllocation{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "GameServerAllocation",
+					APIVersion: "allocation.agones.dev/v1",
+				},
+				Status: allocationv1.GameServerAllocationStatus{
+					State:               allocationv1.GameServerAllocationAllocated,
+					GameServerName:      "GSN",
+					AllocationTimestamp: &invalidTimestamp,
+				},
+			},
+			wantErrCode:      codes.Internal,
+			skipConvertToGSA: true,
+		},
 		{
 			name: "status field is set to unallocated",
 			in: &allocationv1.GameServerAllocation{
@@ -384,6 +500,23 @@ func TestConvertAllocationResponseToGSA(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Invalid allocation timestamp",
+			in: &pb.AllocationResponse{
+				GameServerName:      "GSN",
+				AllocationTimestamp: &timestamp.Timestamp{Seconds: 1600000000, Nanos: -1},
+			},
+			want: &allocationv1.GameServerAllocation{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "GameServerAllocation",
+					APIVersion: "allocation.agones.dev/v1",
+				},
+				Status: allocationv1.GameServerAllocationStatus{
+					State:          allocationv1.GameServerAllocationAllocated,
+					GameServerName: "GSN",
+				},
+			},
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -30,6 +30,8 @@ import (
 	context "golang.org/x/net/context"
 
 	grpc "google.golang.org/grpc"
+
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -63,7 +65,108 @@ func (x AllocationRequest_SchedulingStrategy) String() string {
 	return proto.EnumName(AllocationRequest_SchedulingStrategy_name, int32(x))
 }
 func (AllocationRequest_SchedulingStrategy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_allocation_d5752a3ca48e8a0b, []int{0, 0}
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{0, 0}
+}
+
+type GameServerSelector_GameServerState int32
+
+const (
+	GameServerSelector_READY     GameServerSelector_GameServerState = 0
+	GameServerSelector_ALLOCATED GameServerSelector_GameServerState = 1
+)
+
+var GameServerSelector_GameServerState_name = map[int32]string{
+	0: "READY",
+	1: "ALLOCATED",
+}
+var GameServerSelector_GameServerState_value = map[string]int32{
+	"READY":     0,
+	"ALLOCATED": 1,
+}
+
+func (x GameServerSelector_GameServerState) String() string {
+	return proto.EnumName(GameServerSelector_GameServerState_name, int32(x))
+}
+func (GameServerSelector_GameServerState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{9, 0}
+}
+
+type Priority_Type int32
+
+const (
+	Priority_COUNTER    Priority_Type = 0
+	Priority_LIST       Priority_Type = 1
+	Priority_LABEL      Priority_Type = 2
+	Priority_ANNOTATION Priority_Type = 3
+	Priority_PLAYERS    Priority_Type = 4
+)
+
+var Priority_Type_name = map[int32]string{
+	0: "COUNTER",
+	1: "LIST",
+	2: "LABEL",
+	3: "ANNOTATION",
+	4: "PLAYERS",
+}
+var Priority_Type_value = map[string]int32{
+	"COUNTER":    0,
+	"LIST":       1,
+	"LABEL":      2,
+	"ANNOTATION": 3,
+	"PLAYERS":    4,
+}
+
+func (x Priority_Type) String() string {
+	return proto.EnumName(Priority_Type_name, int32(x))
+}
+func (Priority_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{13, 0}
+}
+
+type Priority_Order int32
+
+const (
+	Priority_DESCENDING Priority_Order = 0
+	Priority_ASCENDING  Priority_Order = 1
+)
+
+var Priority_Order_name = map[int32]string{
+	0: "DESCENDING",
+	1: "ASCENDING",
+}
+var Priority_Order_value = map[string]int32{
+	"DESCENDING": 0,
+	"ASCENDING":  1,
+}
+
+func (x Priority_Order) String() string {
+	return proto.EnumName(Priority_Order_name, int32(x))
+}
+func (Priority_Order) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{13, 1}
+}
+
+type Priority_ValueType int32
+
+const (
+	Priority_STRING Priority_ValueType = 0
+	Priority_INT    Priority_ValueType = 1
+)
+
+var Priority_ValueType_name = map[int32]string{
+	0: "STRING",
+	1: "INT",
+}
+var Priority_ValueType_value = map[string]int32{
+	"STRING": 0,
+	"INT":    1,
+}
+
+func (x Priority_ValueType) String() string {
+	return proto.EnumName(Priority_ValueType_name, int32(x))
+}
+func (Priority_ValueType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{13, 2}
 }
 
 type AllocationRequest struct {
@@ -71,26 +174,29 @@ type AllocationRequest struct {
 	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	// If specified, multi-cluster policies are applied. Otherwise, allocation will happen locally.
 	MultiClusterSetting *MultiClusterSetting `protobuf:"bytes,2,opt,name=multiClusterSetting,proto3" json:"multiClusterSetting,omitempty"`
-	// The required allocation. Defaults to all GameServers.
-	RequiredGameServerSelector *LabelSelector `protobuf:"bytes,3,opt,name=requiredGameServerSelector,proto3" json:"requiredGameServerSelector,omitempty"`
+	// The required allocation. Defaults to all Ready GameServers.
+	RequiredGameServerSelector *GameServerSelector `protobuf:"bytes,3,opt,name=requiredGameServerSelector,proto3" json:"requiredGameServerSelector,omitempty"`
 	// The ordered list of preferred allocations out of the `required` set.
 	// If the first selector is not matched, the selection attempts the second selector, and so on.
-	PreferredGameServerSelectors []*LabelSelector `protobuf:"bytes,4,rep,name=preferredGameServerSelectors,proto3" json:"preferredGameServerSelectors,omitempty"`
+	PreferredGameServerSelectors []*GameServerSelector `protobuf:"bytes,4,rep,name=preferredGameServerSelectors,proto3" json:"preferredGameServerSelectors,omitempty"`
 	// Scheduling strategy. Defaults to "Packed".
 	Scheduling AllocationRequest_SchedulingStrategy `protobuf:"varint,5,opt,name=scheduling,proto3,enum=allocation.AllocationRequest_SchedulingStrategy" json:"scheduling,omitempty"`
 	// MetaPatch is optional custom metadata that is added to the game server at
 	// allocation You can use this to tell the server necessary session data
-	MetaPatch            *MetaPatch `protobuf:"bytes,6,opt,name=metaPatch,proto3" json:"metaPatch,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	MetaPatch *MetaPatch `protobuf:"bytes,6,opt,name=metaPatch,proto3" json:"metaPatch,omitempty"`
+	// [Alpha, CountsAndLists or AllocationPriorities feature flag] The ordered list of priorities that alter the order in which
+	// GameServers are searched for matches to the required and preferred selectors.
+	Priorities           []*Priority `protobuf:"bytes,7,rep,name=priorities,proto3" json:"priorities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *AllocationRequest) Reset()         { *m = AllocationRequest{} }
 func (m *AllocationRequest) String() string { return proto.CompactTextString(m) }
 func (*AllocationRequest) ProtoMessage()    {}
 func (*AllocationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_allocation_d5752a3ca48e8a0b, []int{0}
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{0}
 }
 func (m *AllocationRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AllocationRequest.Unmarshal(m, b)
@@ -124,14 +230,14 @@ func (m *AllocationRequest) GetMultiClusterSetting() *MultiClusterSetting {
 	return nil
 }
 
-func (m *AllocationRequest) GetRequiredGameServerSelector() *LabelSelector {
+func (m *AllocationRequest) GetRequiredGameServerSelector() *GameServerSelector {
 	if m != nil {
 		return m.RequiredGameServerSelector
 	}
 	return nil
 }
 
-func (m *AllocationRequest) GetPreferredGameServerSelectors() []*LabelSelector {
+func (m *AllocationRequest) GetPreferredGameServerSelectors() []*GameServerSelector {
 	if m != nil {
 		return m.PreferredGameServerSelectors
 	}
@@ -152,21 +258,181 @@ func (m *AllocationRequest) GetMetaPatch() *MetaPatch {
 	return nil
 }
 
+func (m *AllocationRequest) GetPriorities() []*Priority {
+	if m != nil {
+		return m.Priorities
+	}
+	return nil
+}
+
+// BatchAllocationRequest allocates several GameServers with the same request at once.
+type BatchAllocationRequest struct {
+	// The allocation request that is made for each GameServer in the batch.
+	Request *AllocationRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// The number of GameServers to allocate, between 1 and 100.
+	Count                int32    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchAllocationRequest) Reset()         { *m = BatchAllocationRequest{} }
+func (m *BatchAllocationRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchAllocationRequest) ProtoMessage()    {}
+func (*BatchAllocationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{1}
+}
+func (m *BatchAllocationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchAllocationRequest.Unmarshal(m, b)
+}
+func (m *BatchAllocationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchAllocationRequest.Marshal(b, m, deterministic)
+}
+func (dst *BatchAllocationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchAllocationRequest.Merge(dst, src)
+}
+func (m *BatchAllocationRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchAllocationRequest.Size(m)
+}
+func (m *BatchAllocationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchAllocationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchAllocationRequest proto.InternalMessageInfo
+
+func (m *BatchAllocationRequest) GetRequest() *AllocationRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *BatchAllocationRequest) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// BatchAllocationResponse has a result for each GameServer requested in the batch.
+// Each allocation either succeeds or fails on its own, so a batch can partially succeed.
+type BatchAllocationResponse struct {
+	Results              []*BatchAllocationResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *BatchAllocationResponse) Reset()         { *m = BatchAllocationResponse{} }
+func (m *BatchAllocationResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchAllocationResponse) ProtoMessage()    {}
+func (*BatchAllocationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{2}
+}
+func (m *BatchAllocationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchAllocationResponse.Unmarshal(m, b)
+}
+func (m *BatchAllocationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchAllocationResponse.Marshal(b, m, deterministic)
+}
+func (dst *BatchAllocationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchAllocationResponse.Merge(dst, src)
+}
+func (m *BatchAllocationResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchAllocationResponse.Size(m)
+}
+func (m *BatchAllocationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchAllocationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchAllocationResponse proto.InternalMessageInfo
+
+func (m *BatchAllocationResponse) GetResults() []*BatchAllocationResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type BatchAllocationResponse_Result struct {
+	// The allocated GameServer. Not set if the allocation failed.
+	Response *AllocationResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	// The gRPC status code of a failed allocation, such as RESOURCE_EXHAUSTED
+	// if there is no GameServer left to allocate. OK (0) if the allocation succeeded.
+	Code int32 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	// The error message of a failed allocation.
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BatchAllocationResponse_Result) Reset()         { *m = BatchAllocationResponse_Result{} }
+func (m *BatchAllocationResponse_Result) String() string { return proto.CompactTextString(m) }
+func (*BatchAllocationResponse_Result) ProtoMessage()    {}
+func (*BatchAllocationResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{2, 0}
+}
+func (m *BatchAllocationResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchAllocationResponse_Result.Unmarshal(m, b)
+}
+func (m *BatchAllocationResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchAllocationResponse_Result.Marshal(b, m, deterministic)
+}
+func (dst *BatchAllocationResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchAllocationResponse_Result.Merge(dst, src)
+}
+func (m *BatchAllocationResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_BatchAllocationResponse_Result.Size(m)
+}
+func (m *BatchAllocationResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchAllocationResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchAllocationResponse_Result proto.InternalMessageInfo
+
+func (m *BatchAllocationResponse_Result) GetResponse() *AllocationResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *BatchAllocationResponse_Result) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *BatchAllocationResponse_Result) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
 type AllocationResponse struct {
-	GameServerName       string                                     `protobuf:"bytes,2,opt,name=gameServerName,proto3" json:"gameServerName,omitempty"`
-	Ports                []*AllocationResponse_GameServerStatusPort `protobuf:"bytes,3,rep,name=ports,proto3" json:"ports,omitempty"`
-	Address              string                                     `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
-	NodeName             string                                     `protobuf:"bytes,5,opt,name=nodeName,proto3" json:"nodeName,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                   `json:"-"`
-	XXX_unrecognized     []byte                                     `json:"-"`
-	XXX_sizecache        int32                                      `json:"-"`
+	GameServerName string                                     `protobuf:"bytes,2,opt,name=gameServerName,proto3" json:"gameServerName,omitempty"`
+	Ports          []*AllocationResponse_GameServerStatusPort `protobuf:"bytes,3,rep,name=ports,proto3" json:"ports,omitempty"`
+	Address        string                                     `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	NodeName       string                                     `protobuf:"bytes,5,opt,name=nodeName,proto3" json:"nodeName,omitempty"`
+	// The labels and annotations of the allocated gameserver.
+	Metadata *AllocationResponse_GameServerMetadata `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// [Alpha, PlayerTracking feature flag] The player status of the allocated gameserver.
+	Players *AllocationResponse_PlayerStatus `protobuf:"bytes,7,opt,name=players,proto3" json:"players,omitempty"`
+	// The time at which the gameserver was allocated.
+	AllocationTimestamp  *timestamp.Timestamp `protobuf:"bytes,8,opt,name=allocationTimestamp,proto3" json:"allocationTimestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *AllocationResponse) Reset()         { *m = AllocationResponse{} }
 func (m *AllocationResponse) String() string { return proto.CompactTextString(m) }
 func (*AllocationResponse) ProtoMessage()    {}
 func (*AllocationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_allocation_d5752a3ca48e8a0b, []int{1}
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{3}
 }
 func (m *AllocationResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AllocationResponse.Unmarshal(m, b)
@@ -214,6 +480,27 @@ func (m *AllocationResponse) GetNodeName() string {
 	return ""
 }
 
+func (m *AllocationResponse) GetMetadata() *AllocationResponse_GameServerMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *AllocationResponse) GetPlayers() *AllocationResponse_PlayerStatus {
+	if m != nil {
+		return m.Players
+	}
+	return nil
+}
+
+func (m *AllocationResponse) GetAllocationTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.AllocationTimestamp
+	}
+	return nil
+}
+
 // The gameserver port info that is allocated.
 type AllocationResponse_GameServerStatusPort struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -229,7 +516,7 @@ func (m *AllocationResponse_GameServerStatusPort) Reset() {
 func (m *AllocationResponse_GameServerStatusPort) String() string { return proto.CompactTextString(m) }
 func (*AllocationResponse_GameServerStatusPort) ProtoMessage()    {}
 func (*AllocationResponse_GameServerStatusPort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_allocation_d5752a3ca48e8a0b, []int{1, 0}
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{3, 0}
 }
 func (m *AllocationResponse_GameServerStatusPort) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AllocationResponse_GameServerStatusPort.Unmarshal(m, b)
@@ -263,22 +550,130 @@ func (m *AllocationResponse_GameServerStatusPort) GetPort() int32 {
 	return 0
 }
 
+// The metadata of the allocated gameserver.
+type AllocationResponse_GameServerMetadata struct {
+	Labels               map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations          map[string]string `protobuf:"bytes,2,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *AllocationResponse_GameServerMetadata) Reset()         { *m = AllocationResponse_GameServerMetadata{} }
+func (m *AllocationResponse_GameServerMetadata) String() string { return proto.CompactTextString(m) }
+func (*AllocationResponse_GameServerMetadata) ProtoMessage()    {}
+func (*AllocationResponse_GameServerMetadata) Descriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{3, 1}
+}
+func (m *AllocationResponse_GameServerMetadata) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AllocationResponse_GameServerMetadata.Unmarshal(m, b)
+}
+func (m *AllocationResponse_GameServerMetadata) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AllocationResponse_GameServerMetadata.Marshal(b, m, deterministic)
+}
+func (dst *AllocationResponse_GameServerMetadata) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllocationResponse_GameServerMetadata.Merge(dst, src)
+}
+func (m *AllocationResponse_GameServerMetadata) XXX_Size() int {
+	return xxx_messageInfo_AllocationResponse_GameServerMetadata.Size(m)
+}
+func (m *AllocationResponse_GameServerMetadata) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllocationResponse_GameServerMetadata.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllocationResponse_GameServerMetadata proto.InternalMessageInfo
+
+func (m *AllocationResponse_GameServerMetadata) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *AllocationResponse_GameServerMetadata) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+// The player status of the allocated gameserver.
+type AllocationResponse_PlayerStatus struct {
+	Count                int64    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Capacity             int64    `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Ids                  []string `protobuf:"bytes,3,rep,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AllocationResponse_PlayerStatus) Reset()         { *m = AllocationResponse_PlayerStatus{} }
+func (m *AllocationResponse_PlayerStatus) String() string { return proto.CompactTextString(m) }
+func (*AllocationResponse_PlayerStatus) ProtoMessage()    {}
+func (*AllocationResponse_PlayerStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{3, 2}
+}
+func (m *AllocationResponse_PlayerStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AllocationResponse_PlayerStatus.Unmarshal(m, b)
+}
+func (m *AllocationResponse_PlayerStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AllocationResponse_PlayerStatus.Marshal(b, m, deterministic)
+}
+func (dst *AllocationResponse_PlayerStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllocationResponse_PlayerStatus.Merge(dst, src)
+}
+func (m *AllocationResponse_PlayerStatus) XXX_Size() int {
+	return xxx_messageInfo_AllocationResponse_PlayerStatus.Size(m)
+}
+func (m *AllocationResponse_PlayerStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllocationResponse_PlayerStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllocationResponse_PlayerStatus proto.InternalMessageInfo
+
+func (m *AllocationResponse_PlayerStatus) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *AllocationResponse_PlayerStatus) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *AllocationResponse_PlayerStatus) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
 // Specifies settings for multi-cluster allocation.
 type MultiClusterSetting struct {
 	// If set to true, multi-cluster allocation is enabled.
 	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
 	// Selects multi-cluster allocation policies to apply. If not specified, all multi-cluster allocation policies are to be applied.
-	PolicySelector       *LabelSelector `protobuf:"bytes,2,opt,name=policySelector,proto3" json:"policySelector,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	PolicySelector *LabelSelector `protobuf:"bytes,2,opt,name=policySelector,proto3" json:"policySelector,omitempty"`
+	// [Alpha, AllocationLatency feature flag] The latencies measured by the player to clusters or regions, for example
+	// with the Agones ping service. If set, the clusters of the policies are tried in order of latency, rather than of priority.
+	Latencies []*LatencyMeasurement `protobuf:"bytes,3,rep,name=latencies,proto3" json:"latencies,omitempty"`
+	// [Alpha, AllocationLatency feature flag] The maximum acceptable latency, in milliseconds. If set, the clusters with
+	// a higher latency, or without a latency measurement, are not allocated from.
+	MaxLatencyMillis     int32    `protobuf:"varint,4,opt,name=maxLatencyMillis,proto3" json:"maxLatencyMillis,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *MultiClusterSetting) Reset()         { *m = MultiClusterSetting{} }
 func (m *MultiClusterSetting) String() string { return proto.CompactTextString(m) }
 func (*MultiClusterSetting) ProtoMessage()    {}
 func (*MultiClusterSetting) Descriptor() ([]byte, []int) {
-	return fileDescriptor_allocation_d5752a3ca48e8a0b, []int{2}
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{4}
 }
 func (m *MultiClusterSetting) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MultiClusterSetting.Unmarshal(m, b)
@@ -312,6 +707,70 @@ func (m *MultiClusterSetting) GetPolicySelector() *LabelSelector {
 	return nil
 }
 
+func (m *MultiClusterSetting) GetLatencies() []*LatencyMeasurement {
+	if m != nil {
+		return m.Latencies
+	}
+	return nil
+}
+
+func (m *MultiClusterSetting) GetMaxLatencyMillis() int32 {
+	if m != nil {
+		return m.MaxLatencyMillis
+	}
+	return 0
+}
+
+// LatencyMeasurement is a latency measured by the player to a cluster or region.
+type LatencyMeasurement struct {
+	// The name of the cluster, as in the clusterName of the connectionInfo of the multi-cluster allocation policies,
+	// or the name of the region, as in their multicluster.agones.dev/region label.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The measured round-trip latency, in milliseconds.
+	LatencyMillis        int32    `protobuf:"varint,2,opt,name=latencyMillis,proto3" json:"latencyMillis,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LatencyMeasurement) Reset()         { *m = LatencyMeasurement{} }
+func (m *LatencyMeasurement) String() string { return proto.CompactTextString(m) }
+func (*LatencyMeasurement) ProtoMessage()    {}
+func (*LatencyMeasurement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{5}
+}
+func (m *LatencyMeasurement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LatencyMeasurement.Unmarshal(m, b)
+}
+func (m *LatencyMeasurement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LatencyMeasurement.Marshal(b, m, deterministic)
+}
+func (dst *LatencyMeasurement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LatencyMeasurement.Merge(dst, src)
+}
+func (m *LatencyMeasurement) XXX_Size() int {
+	return xxx_messageInfo_LatencyMeasurement.Size(m)
+}
+func (m *LatencyMeasurement) XXX_DiscardUnknown() {
+	xxx_messageInfo_LatencyMeasurement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LatencyMeasurement proto.InternalMessageInfo
+
+func (m *LatencyMeasurement) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LatencyMeasurement) GetLatencyMillis() int32 {
+	if m != nil {
+		return m.LatencyMillis
+	}
+	return 0
+}
+
 // MetaPatch is the metadata used to patch the GameServer metadata on allocation
 type MetaPatch struct {
 	Labels               map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
@@ -325,7 +784,7 @@ func (m *MetaPatch) Reset()         { *m = MetaPatch{} }
 func (m *MetaPatch) String() string { return proto.CompactTextString(m) }
 func (*MetaPatch) ProtoMessage()    {}
 func (*MetaPatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_allocation_d5752a3ca48e8a0b, []int{3}
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{6}
 }
 func (m *MetaPatch) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MetaPatch.Unmarshal(m, b)
@@ -362,17 +821,19 @@ func (m *MetaPatch) GetAnnotations() map[string]string {
 // LabelSelector used for finding a GameServer with matching labels.
 type LabelSelector struct {
 	// Labels to match.
-	MatchLabels          map[string]string `protobuf:"bytes,1,rep,name=matchLabels,proto3" json:"matchLabels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	MatchLabels map[string]string `protobuf:"bytes,1,rep,name=matchLabels,proto3" json:"matchLabels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Label selector requirements to match. The requirements are ANDed, along with matchLabels.
+	MatchExpressions     []*LabelSelectorRequirement `protobuf:"bytes,2,rep,name=matchExpressions,proto3" json:"matchExpressions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
 }
 
 func (m *LabelSelector) Reset()         { *m = LabelSelector{} }
 func (m *LabelSelector) String() string { return proto.CompactTextString(m) }
 func (*LabelSelector) ProtoMessage()    {}
 func (*LabelSelector) Descriptor() ([]byte, []int) {
-	return fileDescriptor_allocation_d5752a3ca48e8a0b, []int{4}
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{7}
 }
 func (m *LabelSelector) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LabelSelector.Unmarshal(m, b)
@@ -399,17 +860,435 @@ func (m *LabelSelector) GetMatchLabels() map[string]string {
 	return nil
 }
 
+func (m *LabelSelector) GetMatchExpressions() []*LabelSelectorRequirement {
+	if m != nil {
+		return m.MatchExpressions
+	}
+	return nil
+}
+
+// LabelSelectorRequirement is a selector that contains values, a key, and an operator that
+// relates the key and values.
+type LabelSelectorRequirement struct {
+	// The label key that the selector applies to.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The operator that relates the key to the values. Valid operators are In, NotIn, Exists and DoesNotExist.
+	Operator string `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	// An array of string values. If the operator is In or NotIn, the values array must be non-empty.
+	// If the operator is Exists or DoesNotExist, the values array must be empty.
+	Values               []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LabelSelectorRequirement) Reset()         { *m = LabelSelectorRequirement{} }
+func (m *LabelSelectorRequirement) String() string { return proto.CompactTextString(m) }
+func (*LabelSelectorRequirement) ProtoMessage()    {}
+func (*LabelSelectorRequirement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{8}
+}
+func (m *LabelSelectorRequirement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelSelectorRequirement.Unmarshal(m, b)
+}
+func (m *LabelSelectorRequirement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelSelectorRequirement.Marshal(b, m, deterministic)
+}
+func (dst *LabelSelectorRequirement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelSelectorRequirement.Merge(dst, src)
+}
+func (m *LabelSelectorRequirement) XXX_Size() int {
+	return xxx_messageInfo_LabelSelectorRequirement.Size(m)
+}
+func (m *LabelSelectorRequirement) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelSelectorRequirement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelSelectorRequirement proto.InternalMessageInfo
+
+func (m *LabelSelectorRequirement) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *LabelSelectorRequirement) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *LabelSelectorRequirement) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// GameServerSelector used for finding a GameServer with matching filters.
+type GameServerSelector struct {
+	// Labels to match.
+	MatchLabels map[string]string `protobuf:"bytes,1,rep,name=matchLabels,proto3" json:"matchLabels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// [Alpha, StateAllocationFilter feature flag] The state the GameServer must be in. Defaults to READY.
+	GameServerState GameServerSelector_GameServerState `protobuf:"varint,2,opt,name=gameServerState,proto3,enum=allocation.GameServerSelector_GameServerState" json:"gameServerState,omitempty"`
+	// [Alpha, PlayerTracking feature flag] Filter on the available player capacity of the GameServer.
+	Players *PlayerSelector `protobuf:"bytes,3,opt,name=players,proto3" json:"players,omitempty"`
+	// [Alpha, CountsAndLists feature flag] Filters on the count and available capacity of named Counters.
+	Counters map[string]*CounterSelector `protobuf:"bytes,4,rep,name=counters,proto3" json:"counters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// [Alpha, CountsAndLists feature flag] Filters on the values and available capacity of named Lists.
+	Lists map[string]*ListSelector `protobuf:"bytes,5,rep,name=lists,proto3" json:"lists,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Label selector requirements to match. The requirements are ANDed, along with matchLabels.
+	MatchExpressions     []*LabelSelectorRequirement `protobuf:"bytes,6,rep,name=matchExpressions,proto3" json:"matchExpressions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *GameServerSelector) Reset()         { *m = GameServerSelector{} }
+func (m *GameServerSelector) String() string { return proto.CompactTextString(m) }
+func (*GameServerSelector) ProtoMessage()    {}
+func (*GameServerSelector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{9}
+}
+func (m *GameServerSelector) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GameServerSelector.Unmarshal(m, b)
+}
+func (m *GameServerSelector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GameServerSelector.Marshal(b, m, deterministic)
+}
+func (dst *GameServerSelector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GameServerSelector.Merge(dst, src)
+}
+func (m *GameServerSelector) XXX_Size() int {
+	return xxx_messageInfo_GameServerSelector.Size(m)
+}
+func (m *GameServerSelector) XXX_DiscardUnknown() {
+	xxx_messageInfo_GameServerSelector.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GameServerSelector proto.InternalMessageInfo
+
+func (m *GameServerSelector) GetMatchLabels() map[string]string {
+	if m != nil {
+		return m.MatchLabels
+	}
+	return nil
+}
+
+func (m *GameServerSelector) GetGameServerState() GameServerSelector_GameServerState {
+	if m != nil {
+		return m.GameServerState
+	}
+	return GameServerSelector_READY
+}
+
+func (m *GameServerSelector) GetPlayers() *PlayerSelector {
+	if m != nil {
+		return m.Players
+	}
+	return nil
+}
+
+func (m *GameServerSelector) GetCounters() map[string]*CounterSelector {
+	if m != nil {
+		return m.Counters
+	}
+	return nil
+}
+
+func (m *GameServerSelector) GetLists() map[string]*ListSelector {
+	if m != nil {
+		return m.Lists
+	}
+	return nil
+}
+
+func (m *GameServerSelector) GetMatchExpressions() []*LabelSelectorRequirement {
+	if m != nil {
+		return m.MatchExpressions
+	}
+	return nil
+}
+
+// PlayerSelector is filter for player capacity values.
+// minAvailable should always be less or equal to maxAvailable.
+type PlayerSelector struct {
+	// The minimum number of free player slots, e.g. the number of players this allocation needs room for.
+	MinAvailable uint64 `protobuf:"varint,1,opt,name=minAvailable,proto3" json:"minAvailable,omitempty"`
+	// The maximum number of free player slots. Defaults to no maximum.
+	MaxAvailable         uint64   `protobuf:"varint,2,opt,name=maxAvailable,proto3" json:"maxAvailable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlayerSelector) Reset()         { *m = PlayerSelector{} }
+func (m *PlayerSelector) String() string { return proto.CompactTextString(m) }
+func (*PlayerSelector) ProtoMessage()    {}
+func (*PlayerSelector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{10}
+}
+func (m *PlayerSelector) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerSelector.Unmarshal(m, b)
+}
+func (m *PlayerSelector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerSelector.Marshal(b, m, deterministic)
+}
+func (dst *PlayerSelector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerSelector.Merge(dst, src)
+}
+func (m *PlayerSelector) XXX_Size() int {
+	return xxx_messageInfo_PlayerSelector.Size(m)
+}
+func (m *PlayerSelector) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerSelector.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerSelector proto.InternalMessageInfo
+
+func (m *PlayerSelector) GetMinAvailable() uint64 {
+	if m != nil {
+		return m.MinAvailable
+	}
+	return 0
+}
+
+func (m *PlayerSelector) GetMaxAvailable() uint64 {
+	if m != nil {
+		return m.MaxAvailable
+	}
+	return 0
+}
+
+// CounterSelector is a filter for the count and available capacity of a named Counter.
+// A max value of 0 means no maximum.
+type CounterSelector struct {
+	// The minimum count of the Counter.
+	MinCount int64 `protobuf:"varint,1,opt,name=minCount,proto3" json:"minCount,omitempty"`
+	// The maximum count of the Counter. Defaults to no maximum.
+	MaxCount int64 `protobuf:"varint,2,opt,name=maxCount,proto3" json:"maxCount,omitempty"`
+	// The minimum available capacity of the Counter.
+	MinAvailable int64 `protobuf:"varint,3,opt,name=minAvailable,proto3" json:"minAvailable,omitempty"`
+	// The maximum available capacity of the Counter. Defaults to no maximum.
+	MaxAvailable         int64    `protobuf:"varint,4,opt,name=maxAvailable,proto3" json:"maxAvailable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CounterSelector) Reset()         { *m = CounterSelector{} }
+func (m *CounterSelector) String() string { return proto.CompactTextString(m) }
+func (*CounterSelector) ProtoMessage()    {}
+func (*CounterSelector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{11}
+}
+func (m *CounterSelector) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CounterSelector.Unmarshal(m, b)
+}
+func (m *CounterSelector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CounterSelector.Marshal(b, m, deterministic)
+}
+func (dst *CounterSelector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CounterSelector.Merge(dst, src)
+}
+func (m *CounterSelector) XXX_Size() int {
+	return xxx_messageInfo_CounterSelector.Size(m)
+}
+func (m *CounterSelector) XXX_DiscardUnknown() {
+	xxx_messageInfo_CounterSelector.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CounterSelector proto.InternalMessageInfo
+
+func (m *CounterSelector) GetMinCount() int64 {
+	if m != nil {
+		return m.MinCount
+	}
+	return 0
+}
+
+func (m *CounterSelector) GetMaxCount() int64 {
+	if m != nil {
+		return m.MaxCount
+	}
+	return 0
+}
+
+func (m *CounterSelector) GetMinAvailable() int64 {
+	if m != nil {
+		return m.MinAvailable
+	}
+	return 0
+}
+
+func (m *CounterSelector) GetMaxAvailable() int64 {
+	if m != nil {
+		return m.MaxAvailable
+	}
+	return 0
+}
+
+// ListSelector is a filter for the values and available capacity of a named List.
+// A max value of 0 means no maximum.
+type ListSelector struct {
+	// A value that must be in the List.
+	ContainsValue string `protobuf:"bytes,1,opt,name=containsValue,proto3" json:"containsValue,omitempty"`
+	// The minimum available capacity of the List.
+	MinAvailable int64 `protobuf:"varint,2,opt,name=minAvailable,proto3" json:"minAvailable,omitempty"`
+	// The maximum available capacity of the List. Defaults to no maximum.
+	MaxAvailable         int64    `protobuf:"varint,3,opt,name=maxAvailable,proto3" json:"maxAvailable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSelector) Reset()         { *m = ListSelector{} }
+func (m *ListSelector) String() string { return proto.CompactTextString(m) }
+func (*ListSelector) ProtoMessage()    {}
+func (*ListSelector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{12}
+}
+func (m *ListSelector) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListSelector.Unmarshal(m, b)
+}
+func (m *ListSelector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListSelector.Marshal(b, m, deterministic)
+}
+func (dst *ListSelector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSelector.Merge(dst, src)
+}
+func (m *ListSelector) XXX_Size() int {
+	return xxx_messageInfo_ListSelector.Size(m)
+}
+func (m *ListSelector) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSelector.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSelector proto.InternalMessageInfo
+
+func (m *ListSelector) GetContainsValue() string {
+	if m != nil {
+		return m.ContainsValue
+	}
+	return ""
+}
+
+func (m *ListSelector) GetMinAvailable() int64 {
+	if m != nil {
+		return m.MinAvailable
+	}
+	return 0
+}
+
+func (m *ListSelector) GetMaxAvailable() int64 {
+	if m != nil {
+		return m.MaxAvailable
+	}
+	return 0
+}
+
+// Priority is a sort order for the GameServers that are searched during allocation.
+type Priority struct {
+	// The type of GameServer value to sort by.
+	Type Priority_Type `protobuf:"varint,1,opt,name=type,proto3,enum=allocation.Priority_Type" json:"type,omitempty"`
+	// The name of the Counter, List, label or annotation to sort by. Not used by PLAYERS.
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// The sort order of the value. Counters, Lists and Players are sorted by their available capacity.
+	// Defaults to DESCENDING.
+	Order Priority_Order `protobuf:"varint,3,opt,name=order,proto3,enum=allocation.Priority_Order" json:"order,omitempty"`
+	// How the values of a LABEL or ANNOTATION are compared. Defaults to STRING.
+	ValueType            Priority_ValueType `protobuf:"varint,4,opt,name=valueType,proto3,enum=allocation.Priority_ValueType" json:"valueType,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *Priority) Reset()         { *m = Priority{} }
+func (m *Priority) String() string { return proto.CompactTextString(m) }
+func (*Priority) ProtoMessage()    {}
+func (*Priority) Descriptor() ([]byte, []int) {
+	return fileDescriptor_allocation_6bd0c324ca235233, []int{13}
+}
+func (m *Priority) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Priority.Unmarshal(m, b)
+}
+func (m *Priority) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Priority.Marshal(b, m, deterministic)
+}
+func (dst *Priority) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Priority.Merge(dst, src)
+}
+func (m *Priority) XXX_Size() int {
+	return xxx_messageInfo_Priority.Size(m)
+}
+func (m *Priority) XXX_DiscardUnknown() {
+	xxx_messageInfo_Priority.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Priority proto.InternalMessageInfo
+
+func (m *Priority) GetType() Priority_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Priority_COUNTER
+}
+
+func (m *Priority) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Priority) GetOrder() Priority_Order {
+	if m != nil {
+		return m.Order
+	}
+	return Priority_DESCENDING
+}
+
+func (m *Priority) GetValueType() Priority_ValueType {
+	if m != nil {
+		return m.ValueType
+	}
+	return Priority_STRING
+}
+
 func init() {
 	proto.RegisterType((*AllocationRequest)(nil), "allocation.AllocationRequest")
+	proto.RegisterType((*BatchAllocationRequest)(nil), "allocation.BatchAllocationRequest")
+	proto.RegisterType((*BatchAllocationResponse)(nil), "allocation.BatchAllocationResponse")
+	proto.RegisterType((*BatchAllocationResponse_Result)(nil), "allocation.BatchAllocationResponse.Result")
 	proto.RegisterType((*AllocationResponse)(nil), "allocation.AllocationResponse")
 	proto.RegisterType((*AllocationResponse_GameServerStatusPort)(nil), "allocation.AllocationResponse.GameServerStatusPort")
+	proto.RegisterType((*AllocationResponse_GameServerMetadata)(nil), "allocation.AllocationResponse.GameServerMetadata")
+	proto.RegisterMapType((map[string]string)(nil), "allocation.AllocationResponse.GameServerMetadata.AnnotationsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "allocation.AllocationResponse.GameServerMetadata.LabelsEntry")
+	proto.RegisterType((*AllocationResponse_PlayerStatus)(nil), "allocation.AllocationResponse.PlayerStatus")
 	proto.RegisterType((*MultiClusterSetting)(nil), "allocation.MultiClusterSetting")
+	proto.RegisterType((*LatencyMeasurement)(nil), "allocation.LatencyMeasurement")
 	proto.RegisterType((*MetaPatch)(nil), "allocation.MetaPatch")
 	proto.RegisterMapType((map[string]string)(nil), "allocation.MetaPatch.AnnotationsEntry")
 	proto.RegisterMapType((map[string]string)(nil), "allocation.MetaPatch.LabelsEntry")
 	proto.RegisterType((*LabelSelector)(nil), "allocation.LabelSelector")
 	proto.RegisterMapType((map[string]string)(nil), "allocation.LabelSelector.MatchLabelsEntry")
+	proto.RegisterType((*LabelSelectorRequirement)(nil), "allocation.LabelSelectorRequirement")
+	proto.RegisterType((*GameServerSelector)(nil), "allocation.GameServerSelector")
+	proto.RegisterMapType((map[string]*CounterSelector)(nil), "allocation.GameServerSelector.CountersEntry")
+	proto.RegisterMapType((map[string]*ListSelector)(nil), "allocation.GameServerSelector.ListsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "allocation.GameServerSelector.MatchLabelsEntry")
+	proto.RegisterType((*PlayerSelector)(nil), "allocation.PlayerSelector")
+	proto.RegisterType((*CounterSelector)(nil), "allocation.CounterSelector")
+	proto.RegisterType((*ListSelector)(nil), "allocation.ListSelector")
+	proto.RegisterType((*Priority)(nil), "allocation.Priority")
 	proto.RegisterEnum("allocation.AllocationRequest_SchedulingStrategy", AllocationRequest_SchedulingStrategy_name, AllocationRequest_SchedulingStrategy_value)
+	proto.RegisterEnum("allocation.GameServerSelector_GameServerState", GameServerSelector_GameServerState_name, GameServerSelector_GameServerState_value)
+	proto.RegisterEnum("allocation.Priority_Type", Priority_Type_name, Priority_Type_value)
+	proto.RegisterEnum("allocation.Priority_Order", Priority_Order_name, Priority_Order_value)
+	proto.RegisterEnum("allocation.Priority_ValueType", Priority_ValueType_name, Priority_ValueType_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -425,6 +1304,7 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type AllocationServiceClient interface {
 	Allocate(ctx context.Context, in *AllocationRequest, opts ...grpc.CallOption) (*AllocationResponse, error)
+	BatchAllocate(ctx context.Context, in *BatchAllocationRequest, opts ...grpc.CallOption) (*BatchAllocationResponse, error)
 }
 
 type allocationServiceClient struct {
@@ -444,9 +1324,19 @@ func (c *allocationServiceClient) Allocate(ctx context.Context, in *AllocationRe
 	return out, nil
 }
 
+func (c *allocationServiceClient) BatchAllocate(ctx context.Context, in *BatchAllocationRequest, opts ...grpc.CallOption) (*BatchAllocationResponse, error) {
+	out := new(BatchAllocationResponse)
+	err := c.cc.Invoke(ctx, "/allocation.AllocationService/BatchAllocate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AllocationServiceServer is the server API for AllocationService service.
 type AllocationServiceServer interface {
 	Allocate(context.Context, *AllocationRequest) (*AllocationResponse, error)
+	BatchAllocate(context.Context, *BatchAllocationRequest) (*BatchAllocationResponse, error)
 }
 
 func RegisterAllocationServiceServer(s *grpc.Server, srv AllocationServiceServer) {
@@ -471,6 +1361,24 @@ func _AllocationService_Allocate_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AllocationService_BatchAllocate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchAllocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AllocationServiceServer).BatchAllocate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/allocation.AllocationService/BatchAllocate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AllocationServiceServer).BatchAllocate(ctx, req.(*BatchAllocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AllocationService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "allocation.AllocationService",
 	HandlerType: (*AllocationServiceServer)(nil),
@@ -479,54 +1387,112 @@ var _AllocationService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Allocate",
 			Handler:    _AllocationService_Allocate_Handler,
 		},
+		{
+			MethodName: "BatchAllocate",
+			Handler:    _AllocationService_BatchAllocate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/allocation/allocation.proto",
 }
 
 func init() {
-	proto.RegisterFile("proto/allocation/allocation.proto", fileDescriptor_allocation_d5752a3ca48e8a0b)
-}
-
-var fileDescriptor_allocation_d5752a3ca48e8a0b = []byte{
-	// 619 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x4d, 0x6f, 0x13, 0x31,
-	0x10, 0x65, 0x93, 0x26, 0x34, 0x13, 0x51, 0xc2, 0xb4, 0x95, 0x96, 0x55, 0x81, 0x74, 0x0f, 0x55,
-	0xd5, 0x43, 0x02, 0xed, 0x85, 0xf6, 0x50, 0xa9, 0x02, 0x04, 0x48, 0x2d, 0x0a, 0x9b, 0x13, 0x07,
-	0x0e, 0xce, 0xee, 0x90, 0xae, 0xea, 0xac, 0xb7, 0xb6, 0xb7, 0x52, 0x6e, 0x88, 0x2b, 0x47, 0xce,
-	0xfc, 0x2a, 0xfe, 0x02, 0x3f, 0x82, 0x0b, 0x12, 0xb2, 0xf3, 0xe5, 0xa6, 0x69, 0x50, 0x6f, 0xf6,
-	0xcc, 0x7b, 0xcf, 0x33, 0xcf, 0x63, 0xc3, 0x76, 0x2e, 0x85, 0x16, 0x6d, 0xc6, 0xb9, 0x88, 0x99,
-	0x4e, 0x45, 0xe6, 0x2c, 0x5b, 0x36, 0x87, 0x30, 0x8b, 0x04, 0x5b, 0x7d, 0x21, 0xfa, 0x9c, 0xda,
-	0x2c, 0x4f, 0xdb, 0x2c, 0xcb, 0x84, 0xb6, 0x61, 0x35, 0x42, 0x86, 0x7f, 0xca, 0xf0, 0xe8, 0x64,
-	0x0a, 0x8e, 0xe8, 0xb2, 0x20, 0xa5, 0x71, 0x0b, 0x6a, 0x19, 0x1b, 0x90, 0xca, 0x59, 0x4c, 0xbe,
-	0xd7, 0xf4, 0x76, 0x6b, 0xd1, 0x2c, 0x80, 0x1f, 0x61, 0x7d, 0x50, 0x70, 0x9d, 0xbe, 0xe2, 0x85,
-	0xd2, 0x24, 0xbb, 0xa4, 0x75, 0x9a, 0xf5, 0xfd, 0x52, 0xd3, 0xdb, 0xad, 0xef, 0x3f, 0x6b, 0x39,
-	0xd5, 0x9c, 0xdd, 0x84, 0x45, 0x8b, 0xb8, 0xf8, 0x09, 0x02, 0x49, 0x97, 0x45, 0x2a, 0x29, 0x79,
-	0xcb, 0x06, 0xd4, 0x25, 0x79, 0x65, 0x92, 0x9c, 0x62, 0x2d, 0xa4, 0x5f, 0xb6, 0xca, 0x8f, 0x5d,
-	0xe5, 0x53, 0xd6, 0x23, 0x3e, 0x01, 0x44, 0x4b, 0xc8, 0xf8, 0x19, 0xb6, 0x72, 0x49, 0x5f, 0x48,
-	0x2e, 0x4c, 0x2b, 0x7f, 0xa5, 0x59, 0x5e, 0x2e, 0xbe, 0x94, 0x8e, 0x1d, 0x00, 0x15, 0x9f, 0x53,
-	0x52, 0x70, 0xe3, 0x41, 0xa5, 0xe9, 0xed, 0xae, 0xed, 0x3f, 0x77, 0xc5, 0x6e, 0xb8, 0xdb, 0xea,
-	0x4e, 0xf1, 0x5d, 0x2d, 0x99, 0xa6, 0xfe, 0x30, 0x72, 0x34, 0xf0, 0x00, 0x6a, 0x03, 0xd2, 0xac,
-	0xc3, 0x74, 0x7c, 0xee, 0x57, 0x6d, 0xeb, 0x9b, 0xd7, 0x4c, 0x9d, 0x24, 0xa3, 0x19, 0x2e, 0x7c,
-	0x01, 0x78, 0x53, 0x16, 0x01, 0xaa, 0x1d, 0x16, 0x5f, 0x50, 0xd2, 0xb8, 0x87, 0x0f, 0xa1, 0xfe,
-	0x3a, 0x55, 0x5a, 0xa6, 0xbd, 0x42, 0x53, 0xd2, 0xf0, 0xc2, 0xbf, 0x1e, 0xa0, 0x5b, 0x9c, 0xca,
-	0x45, 0xa6, 0x08, 0x77, 0x60, 0xad, 0x3f, 0xed, 0xf3, 0x03, 0x1b, 0x90, 0xbd, 0xd8, 0x5a, 0x34,
-	0x17, 0xc5, 0xf7, 0x50, 0xc9, 0x85, 0xd4, 0xca, 0x2f, 0x5b, 0x03, 0x0f, 0x6e, 0xeb, 0x79, 0x24,
-	0xdb, 0x72, 0xbc, 0xd3, 0x4c, 0x17, 0xaa, 0x23, 0xa4, 0x8e, 0x46, 0x0a, 0xe8, 0xc3, 0x7d, 0x96,
-	0x24, 0x92, 0x94, 0xb9, 0x0d, 0x73, 0xd6, 0x64, 0x8b, 0x01, 0xac, 0x66, 0x22, 0x21, 0x5b, 0x46,
-	0xc5, 0xa6, 0xa6, 0xfb, 0xe0, 0x18, 0x36, 0x16, 0x89, 0x22, 0xc2, 0x8a, 0x99, 0xd5, 0xf1, 0xdc,
-	0xda, 0xb5, 0x89, 0x99, 0xa3, 0x6c, 0x2b, 0x95, 0xc8, 0xae, 0x43, 0x09, 0xeb, 0x0b, 0xe6, 0xd3,
-	0x14, 0x43, 0x19, 0xeb, 0x71, 0x4a, 0xac, 0xc2, 0x6a, 0x34, 0xd9, 0xe2, 0x09, 0xac, 0xe5, 0x82,
-	0xa7, 0xf1, 0x70, 0x3a, 0x98, 0xa5, 0xff, 0x0d, 0xe6, 0x1c, 0x21, 0xfc, 0x5e, 0x82, 0xda, 0xf4,
-	0xfe, 0xf0, 0x10, 0xaa, 0xdc, 0xc0, 0x95, 0xef, 0x59, 0x0f, 0xb7, 0x17, 0x5e, 0xf3, 0x48, 0x52,
-	0xbd, 0xc9, 0xb4, 0x1c, 0x46, 0x63, 0x02, 0xbe, 0x83, 0xba, 0xf3, 0x98, 0xfd, 0x92, 0xe5, 0xef,
-	0x2c, 0xe6, 0x9f, 0xcc, 0x80, 0x23, 0x11, 0x97, 0x1a, 0x1c, 0x42, 0xdd, 0x39, 0x00, 0x1b, 0x50,
-	0xbe, 0xa0, 0xe1, 0xd8, 0x3c, 0xb3, 0xc4, 0x0d, 0xa8, 0x5c, 0x31, 0x5e, 0x4c, 0xe6, 0x60, 0xb4,
-	0x39, 0x2a, 0xbd, 0xf4, 0x82, 0x63, 0x68, 0xcc, 0x6b, 0xdf, 0x85, 0x1f, 0xfe, 0xf4, 0xe0, 0xc1,
-	0x35, 0xbf, 0xf0, 0x14, 0xea, 0x03, 0x53, 0xf3, 0xa9, 0x6b, 0xcb, 0xde, 0xad, 0xfe, 0xb6, 0xce,
-	0x66, 0xe0, 0x71, 0x6b, 0x0e, 0xdd, 0xd4, 0x37, 0x0f, 0xb8, 0x4b, 0x7d, 0xfb, 0x5f, 0x3d, 0xf7,
-	0x73, 0x34, 0x83, 0x96, 0xc6, 0x84, 0x17, 0xb0, 0x3a, 0x0e, 0x12, 0x3e, 0x59, 0xfa, 0xd2, 0x83,
-	0xa7, 0xcb, 0x1f, 0x45, 0xd8, 0xfc, 0xf6, 0xeb, 0xf7, 0x8f, 0x52, 0x10, 0x6e, 0xb6, 0xcd, 0xe3,
-	0x52, 0x76, 0x92, 0x67, 0x8c, 0x23, 0x6f, 0xaf, 0x57, 0xb5, 0xdf, 0xf4, 0xc1, 0xbf, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xc8, 0x15, 0x3d, 0x53, 0xf5, 0x05, 0x00, 0x00,
+	proto.RegisterFile("proto/allocation/allocation.proto", fileDescriptor_allocation_6bd0c324ca235233)
+}
+
+var fileDescriptor_allocation_6bd0c324ca235233 = []byte{
+	// 1474 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x5d, 0x6f, 0x13, 0x47,
+	0x17, 0x66, 0xfd, 0x91, 0xd8, 0xc7, 0xc4, 0xf1, 0x3b, 0x7c, 0xbc, 0xdb, 0x85, 0x42, 0xd8, 0xa2,
+	0x88, 0x86, 0xd6, 0x06, 0x83, 0xd4, 0x12, 0xb5, 0x54, 0xc6, 0xb1, 0x20, 0x92, 0xe3, 0x98, 0xb1,
+	0x41, 0x70, 0x53, 0x75, 0xbc, 0x1e, 0xcc, 0x8a, 0xf5, 0xee, 0xb2, 0x3b, 0x46, 0xb1, 0xd4, 0x8b,
+	0xaa, 0xed, 0x5d, 0xaf, 0xaa, 0xfe, 0x99, 0xfe, 0x08, 0xee, 0xb8, 0xed, 0x65, 0xef, 0xf9, 0x0b,
+	0xd5, 0xcc, 0xec, 0xa7, 0xbd, 0x71, 0x92, 0xab, 0xf6, 0x2a, 0xf3, 0xf1, 0x9c, 0xe7, 0x9c, 0x39,
+	0x7e, 0xe6, 0x9c, 0x9d, 0xc0, 0x0d, 0xd7, 0x73, 0x98, 0xd3, 0x20, 0x96, 0xe5, 0x18, 0x84, 0x99,
+	0x8e, 0x9d, 0x18, 0xd6, 0xc5, 0x1e, 0x82, 0x78, 0x45, 0xbb, 0x3a, 0x71, 0x9c, 0x89, 0x45, 0x1b,
+	0xc4, 0x35, 0x1b, 0xc4, 0xb6, 0x1d, 0x26, 0x96, 0x7d, 0x89, 0xd4, 0xae, 0x07, 0xbb, 0x62, 0x36,
+	0x9a, 0xbd, 0x6a, 0x30, 0x73, 0x4a, 0x7d, 0x46, 0xa6, 0xae, 0x04, 0xe8, 0x7f, 0x16, 0xe0, 0x7f,
+	0xad, 0x88, 0x0d, 0xd3, 0xb7, 0x33, 0xea, 0x33, 0x74, 0x15, 0xca, 0x36, 0x99, 0x52, 0xdf, 0x25,
+	0x06, 0x55, 0x95, 0x2d, 0xe5, 0x56, 0x19, 0xc7, 0x0b, 0xe8, 0x29, 0x5c, 0x98, 0xce, 0x2c, 0x66,
+	0xb6, 0xad, 0x99, 0xcf, 0xa8, 0x37, 0xa0, 0x8c, 0x99, 0xf6, 0x44, 0xcd, 0x6d, 0x29, 0xb7, 0x2a,
+	0xcd, 0xeb, 0xf5, 0x44, 0xb8, 0x07, 0xcb, 0x30, 0x9c, 0x65, 0x8b, 0xbe, 0x07, 0xcd, 0xa3, 0x6f,
+	0x67, 0xa6, 0x47, 0xc7, 0x8f, 0xc9, 0x94, 0x0e, 0xa8, 0xf7, 0x8e, 0x6f, 0x5a, 0xd4, 0x60, 0x8e,
+	0xa7, 0xe6, 0x05, 0xf3, 0xb5, 0x24, 0xf3, 0x32, 0x0a, 0xaf, 0x60, 0x40, 0x23, 0xb8, 0xea, 0x7a,
+	0xf4, 0x15, 0xf5, 0x32, 0xb7, 0x7d, 0xb5, 0xb0, 0x95, 0x3f, 0x85, 0x87, 0x95, 0x1c, 0xa8, 0x0f,
+	0xe0, 0x1b, 0xaf, 0xe9, 0x78, 0x66, 0xf1, 0x6c, 0x14, 0xb7, 0x94, 0x5b, 0xd5, 0xe6, 0x9d, 0x24,
+	0xe3, 0x52, 0x9e, 0xeb, 0x83, 0x08, 0x3f, 0x60, 0x1e, 0x61, 0x74, 0x32, 0xc7, 0x09, 0x0e, 0x74,
+	0x0f, 0xca, 0x53, 0xca, 0x48, 0x9f, 0x30, 0xe3, 0xb5, 0xba, 0x26, 0x92, 0x70, 0x29, 0x95, 0xde,
+	0x70, 0x13, 0xc7, 0x38, 0x74, 0x1f, 0xc0, 0xf5, 0x4c, 0xc7, 0x33, 0x99, 0x49, 0x7d, 0x75, 0x5d,
+	0x1c, 0xec, 0x62, 0xd2, 0xaa, 0x2f, 0x77, 0xe7, 0x38, 0x81, 0xd3, 0xef, 0x02, 0x5a, 0x0e, 0x06,
+	0x01, 0xac, 0xf5, 0x89, 0xf1, 0x86, 0x8e, 0x6b, 0xe7, 0xd0, 0x26, 0x54, 0xf6, 0x4c, 0x9f, 0x79,
+	0xe6, 0x68, 0xc6, 0xe8, 0xb8, 0xa6, 0xe8, 0x13, 0xb8, 0xfc, 0x88, 0x7b, 0x5c, 0x96, 0xcf, 0x57,
+	0xb0, 0xee, 0xc9, 0xa1, 0x10, 0x4f, 0xa5, 0xf9, 0xe9, 0xca, 0x34, 0xe0, 0x10, 0x8d, 0x2e, 0x42,
+	0xd1, 0x70, 0x66, 0x36, 0x13, 0x5a, 0x2a, 0x62, 0x39, 0xd1, 0x3f, 0x28, 0xf0, 0xff, 0x25, 0x4f,
+	0xbe, 0xeb, 0xd8, 0x3e, 0x45, 0x7b, 0xdc, 0x95, 0x3f, 0xb3, 0x98, 0xaf, 0x2a, 0xe2, 0xa8, 0x3b,
+	0x49, 0x57, 0xc7, 0x58, 0xd5, 0xb1, 0x30, 0xc1, 0xa1, 0xa9, 0xe6, 0xc1, 0x9a, 0x5c, 0x42, 0xbb,
+	0x50, 0xf2, 0x02, 0x54, 0x10, 0xfb, 0xb5, 0xe3, 0x62, 0x97, 0x28, 0x1c, 0xe1, 0x11, 0x82, 0x82,
+	0xe1, 0x8c, 0x69, 0x10, 0xbc, 0x18, 0x23, 0x15, 0xd6, 0xa7, 0xd4, 0xf7, 0xc9, 0x84, 0x0a, 0x15,
+	0x97, 0x71, 0x38, 0xd5, 0x7f, 0x5d, 0x07, 0x94, 0x71, 0xa0, 0x6d, 0xa8, 0x4e, 0x22, 0x71, 0xf5,
+	0xc8, 0x54, 0xd2, 0x95, 0xf1, 0xc2, 0x2a, 0xda, 0x87, 0xa2, 0xeb, 0x78, 0xcc, 0x57, 0xf3, 0xe2,
+	0xd8, 0xf7, 0x56, 0x47, 0x99, 0x54, 0x33, 0x23, 0x6c, 0xe6, 0xf7, 0x1d, 0x8f, 0x61, 0xc9, 0xc0,
+	0x63, 0x24, 0xe3, 0xb1, 0x47, 0x7d, 0x7e, 0x0f, 0x44, 0x8c, 0xc1, 0x14, 0x69, 0x50, 0xb2, 0x9d,
+	0x31, 0x15, 0x61, 0x14, 0xc5, 0x56, 0x34, 0x47, 0x07, 0x50, 0xe2, 0xa2, 0x1b, 0x13, 0x46, 0x02,
+	0x6d, 0xde, 0x3d, 0x75, 0x0c, 0x07, 0x81, 0x21, 0x8e, 0x28, 0x50, 0x07, 0xd6, 0x5d, 0x8b, 0xcc,
+	0xa9, 0xc7, 0x35, 0xcb, 0xd9, 0x6e, 0x9f, 0xc0, 0xd6, 0x17, 0x68, 0x79, 0x1a, 0x1c, 0xda, 0xa2,
+	0x2e, 0x5c, 0x88, 0xcd, 0x86, 0x61, 0xb1, 0x53, 0x4b, 0x82, 0x52, 0xab, 0xcb, 0x72, 0x58, 0x0f,
+	0xcb, 0x61, 0x3d, 0x42, 0xe0, 0x2c, 0x33, 0xed, 0x21, 0x5c, 0xcc, 0x4a, 0x1c, 0xff, 0xa5, 0x79,
+	0x39, 0x0c, 0x4a, 0xa3, 0x18, 0xf3, 0x35, 0x9e, 0xce, 0xf0, 0xd7, 0xe7, 0x63, 0xed, 0x7d, 0x0e,
+	0xd0, 0xf2, 0xa9, 0xd1, 0x33, 0x58, 0xb3, 0xc8, 0x88, 0x5a, 0xa1, 0x66, 0xbf, 0x3d, 0x73, 0xe2,
+	0xea, 0x5d, 0x61, 0xdf, 0xb1, 0x99, 0x37, 0xc7, 0x01, 0x19, 0x1a, 0x43, 0x25, 0xd1, 0x01, 0xd4,
+	0x9c, 0xe0, 0x7e, 0x74, 0x76, 0xee, 0x56, 0x4c, 0x22, 0x1d, 0x24, 0x69, 0xb5, 0x07, 0x50, 0x49,
+	0x38, 0x47, 0x35, 0xc8, 0xbf, 0xa1, 0xf3, 0x20, 0x13, 0x7c, 0xc8, 0x2f, 0xf1, 0x3b, 0x62, 0xcd,
+	0x42, 0xe1, 0xca, 0xc9, 0x6e, 0xee, 0x6b, 0x45, 0x7b, 0x08, 0xb5, 0x45, 0xee, 0x33, 0xd9, 0x63,
+	0x38, 0x9f, 0xfc, 0xd5, 0xe3, 0x72, 0xc1, 0xad, 0xf3, 0x41, 0xb9, 0xe0, 0xa2, 0x35, 0x88, 0x4b,
+	0x0c, 0x93, 0xcd, 0x05, 0x45, 0x1e, 0x47, 0x73, 0xee, 0xcd, 0x1c, 0xcb, 0x3b, 0x53, 0xc6, 0x7c,
+	0xa8, 0xff, 0xa5, 0xc0, 0x85, 0x8c, 0x36, 0xc5, 0x2f, 0x05, 0xb5, 0xc9, 0xc8, 0xa2, 0x63, 0xc1,
+	0x5e, 0xc2, 0xe1, 0x14, 0xb5, 0xa0, 0xea, 0x3a, 0x96, 0x69, 0xcc, 0xa3, 0xfe, 0x24, 0x3b, 0xdf,
+	0x27, 0xc9, 0x4c, 0x8b, 0x14, 0x85, 0x00, 0xbc, 0x60, 0x80, 0xbe, 0x81, 0xb2, 0x45, 0x18, 0xb5,
+	0x0d, 0x93, 0xca, 0x60, 0x16, 0xca, 0x4c, 0x57, 0x6c, 0xce, 0x0f, 0x28, 0xf1, 0x67, 0x1e, 0x9d,
+	0x52, 0x9b, 0xe1, 0xd8, 0x00, 0xed, 0x40, 0x6d, 0x4a, 0x8e, 0x42, 0x8c, 0x69, 0x59, 0xa6, 0xbc,
+	0xb8, 0x45, 0xbc, 0xb4, 0xae, 0xf7, 0x00, 0x2d, 0x93, 0x65, 0xea, 0xf7, 0x26, 0x6c, 0x58, 0x29,
+	0x4a, 0x29, 0xe4, 0xf4, 0xa2, 0xfe, 0x5b, 0x0e, 0xca, 0x51, 0xdb, 0x41, 0x0f, 0x16, 0x84, 0x7c,
+	0x23, 0xb3, 0x3b, 0x65, 0x8a, 0xf5, 0x49, 0x96, 0x58, 0xb7, 0xb3, 0xed, 0xff, 0xab, 0x82, 0xd4,
+	0x3f, 0x2a, 0xb0, 0x91, 0xfa, 0xa5, 0x51, 0x17, 0x2a, 0x53, 0x1e, 0x73, 0x37, 0x99, 0x96, 0x9d,
+	0x63, 0x95, 0x51, 0x3f, 0x88, 0xc1, 0xc1, 0xd1, 0x12, 0xe6, 0xa8, 0xcf, 0x7f, 0x69, 0x66, 0xbc,
+	0xee, 0x1c, 0xb9, 0xbc, 0x1e, 0x27, 0x32, 0x75, 0xf3, 0x78, 0xb1, 0xc9, 0xaf, 0x20, 0x21, 0x9a,
+	0x25, 0x6b, 0x7e, 0xe2, 0x45, 0x97, 0x67, 0x3a, 0xf1, 0x0f, 0xa0, 0x1e, 0xe7, 0x2d, 0x83, 0x47,
+	0x83, 0x92, 0xe3, 0x52, 0x8f, 0x84, 0x97, 0xa4, 0x8c, 0xa3, 0x39, 0xba, 0x0c, 0x6b, 0x82, 0x36,
+	0xbc, 0x8d, 0xc1, 0x4c, 0xff, 0x58, 0x4c, 0xd6, 0xcc, 0x28, 0xb1, 0x4f, 0xb3, 0x12, 0xdb, 0x58,
+	0xfd, 0xc1, 0x76, 0x42, 0x76, 0x5f, 0xc0, 0xe6, 0x24, 0x55, 0xdd, 0xe5, 0x79, 0xab, 0xcd, 0xfa,
+	0x09, 0xb4, 0xe9, 0x9e, 0x40, 0xf1, 0x22, 0x0d, 0xba, 0x1f, 0x37, 0xb3, 0x7c, 0xd0, 0x79, 0x92,
+	0x1f, 0x60, 0x62, 0x2b, 0xca, 0x60, 0x08, 0x45, 0x4f, 0xa0, 0x24, 0x2a, 0x18, 0x8d, 0x3e, 0x48,
+	0xbf, 0x38, 0x21, 0x90, 0x76, 0x00, 0x97, 0x87, 0x8b, 0xac, 0xd1, 0x77, 0x50, 0xb4, 0x4c, 0x9f,
+	0xf9, 0x6a, 0x51, 0xd0, 0x7c, 0x7e, 0x02, 0x4d, 0x97, 0x63, 0x25, 0x87, 0xb4, 0xcb, 0x14, 0xde,
+	0xda, 0xbf, 0x29, 0x3c, 0xed, 0x05, 0x6c, 0xa4, 0x4e, 0x9b, 0x61, 0x7c, 0x37, 0x69, 0x5c, 0x69,
+	0x5e, 0x49, 0x46, 0x1a, 0xd8, 0x46, 0xb1, 0xa6, 0xba, 0x0a, 0xc4, 0x09, 0xc8, 0xa0, 0xad, 0xa7,
+	0x69, 0xd5, 0x54, 0x02, 0x4c, 0x9f, 0x65, 0x70, 0xea, 0xb7, 0x61, 0x73, 0x41, 0x24, 0xa8, 0x0c,
+	0x45, 0xdc, 0x69, 0xed, 0xbd, 0xac, 0x9d, 0x43, 0x1b, 0x50, 0x6e, 0x75, 0xbb, 0x87, 0xed, 0xd6,
+	0xb0, 0xb3, 0x57, 0x53, 0xf4, 0x17, 0x50, 0x4d, 0x4b, 0x02, 0xe9, 0x70, 0x7e, 0x6a, 0xda, 0xad,
+	0x77, 0xc4, 0xb4, 0x78, 0xcf, 0x11, 0xd1, 0x14, 0x70, 0x6a, 0x4d, 0x60, 0xc8, 0x51, 0x8c, 0xc9,
+	0x05, 0x98, 0xc4, 0x9a, 0xfe, 0xbb, 0x02, 0x9b, 0x0b, 0x27, 0xe7, 0x77, 0x72, 0x6a, 0xda, 0xed,
+	0x44, 0xdf, 0x8c, 0xe6, 0x62, 0x8f, 0x1c, 0xb5, 0xa3, 0x4f, 0xf0, 0x3c, 0x8e, 0xe6, 0x4b, 0x31,
+	0xe5, 0xc5, 0xfe, 0xea, 0x98, 0x0a, 0x01, 0x26, 0x19, 0xd3, 0x8f, 0x70, 0x3e, 0x99, 0x35, 0xde,
+	0x77, 0x0c, 0xc7, 0x66, 0xc4, 0xb4, 0xfd, 0xe7, 0x22, 0xcd, 0x32, 0xf5, 0xe9, 0xc5, 0x25, 0xef,
+	0xb9, 0x53, 0x78, 0xcf, 0x67, 0x78, 0x7f, 0x9f, 0x83, 0x52, 0xf8, 0x00, 0x42, 0x5f, 0x42, 0x81,
+	0xcd, 0x5d, 0xe9, 0xb1, 0x9a, 0xee, 0xdf, 0x21, 0xa6, 0x3e, 0x9c, 0xbb, 0x14, 0x0b, 0x58, 0x28,
+	0x8d, 0x5c, 0x2c, 0x8d, 0x3b, 0x50, 0x74, 0xbc, 0x31, 0x95, 0x2f, 0xd4, 0x6a, 0x53, 0xcb, 0x64,
+	0x38, 0xe4, 0x08, 0x2c, 0x81, 0xbc, 0xf3, 0x0b, 0x95, 0x70, 0x5a, 0x91, 0x9e, 0x6a, 0xf3, 0x5a,
+	0xa6, 0xd5, 0xf3, 0x10, 0x85, 0x63, 0x03, 0xbd, 0x03, 0x05, 0xfe, 0x17, 0x55, 0x60, 0xbd, 0x7d,
+	0xf8, 0xac, 0x37, 0xec, 0xe0, 0xda, 0x39, 0x54, 0x82, 0x42, 0x77, 0x7f, 0x30, 0xac, 0x29, 0x5c,
+	0x62, 0xdd, 0xd6, 0xa3, 0x4e, 0xb7, 0x96, 0x43, 0x55, 0x80, 0x56, 0xaf, 0x77, 0x38, 0x6c, 0x0d,
+	0xf7, 0x0f, 0x7b, 0xb5, 0x3c, 0xb7, 0xe8, 0x77, 0x5b, 0x2f, 0x3b, 0x78, 0x50, 0x2b, 0xe8, 0xdb,
+	0x50, 0x14, 0x41, 0x71, 0xd4, 0x5e, 0x67, 0xd0, 0xee, 0xf4, 0xf6, 0xf6, 0x7b, 0x8f, 0x03, 0x61,
+	0x46, 0x53, 0x45, 0xdf, 0x82, 0x72, 0x14, 0x06, 0x7f, 0x0b, 0x0e, 0x86, 0x58, 0xe2, 0xd6, 0x21,
+	0xbf, 0xdf, 0x1b, 0xd6, 0x94, 0xe6, 0x2f, 0xb9, 0xe4, 0xbf, 0x0f, 0xb8, 0xdc, 0x4d, 0x83, 0xa2,
+	0x37, 0x50, 0x0a, 0x16, 0x29, 0x5a, 0xfd, 0xf4, 0xd3, 0x4e, 0x78, 0x5d, 0xe9, 0x5b, 0x3f, 0x7f,
+	0xf8, 0xfb, 0x8f, 0x9c, 0xa6, 0x5f, 0x6a, 0xf0, 0x1a, 0xeb, 0x8b, 0xfb, 0x14, 0x5b, 0xec, 0x2a,
+	0x3b, 0xe8, 0x27, 0x05, 0x36, 0x92, 0xef, 0x3c, 0x8a, 0xf4, 0x95, 0x4f, 0x40, 0xe9, 0xf7, 0xb3,
+	0x53, 0x3c, 0x13, 0xf5, 0x6d, 0xe1, 0x7c, 0x4b, 0xbf, 0x92, 0xe9, 0xbc, 0x31, 0xe2, 0x66, 0xbb,
+	0xca, 0xce, 0x68, 0x4d, 0xbc, 0x27, 0xee, 0xfd, 0x33, 0x00, 0x33, 0x15, 0x7d, 0x94, 0xbb, 0x11,
+	0x00, 0x00,
 }
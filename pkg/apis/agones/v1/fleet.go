@@ -81,6 +81,16 @@ type FleetStatus struct {
 	// Players are the current total player capacity and count for this Fleet
 	// +optional
 	Players *AggregatedPlayerStatus `json:"players,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:CountsAndLists]
+	// Counters are the total count and capacity of each named Counter for this Fleet
+	// +optional
+	Counters map[string]AggregatedCounterStatus `json:"counters,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:CountsAndLists]
+	// Lists are the total number of values and capacity of each named List for this Fleet
+	// +optional
+	Lists map[string]AggregatedListStatus `json:"lists,omitempty"`
 }
 
 // GameServerSet returns a single GameServerSet for this Fleet definition
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"sort"
 
 	"agones.dev/agones/pkg"
 	"agones.dev/agones/pkg/apis"
@@ -157,6 +158,14 @@ type GameServerSpec struct {
 	// (Alpha, PlayerTracking feature flag) Players provides the configuration for player tracking features.
 	// +optional
 	Players *PlayersSpec `json:"players,omitempty"`
+	// (Alpha, CountsAndLists feature flag) Counters provides the initial configuration of named integer
+	// counters that can be tracked and updated through the SDK.
+	// +optional
+	Counters map[string]CounterStatus `json:"counters,omitempty"`
+	// (Alpha, CountsAndLists feature flag) Lists provides the initial configuration of named lists of
+	// string values that can be tracked and updated through the SDK.
+	// +optional
+	Lists map[string]ListStatus `json:"lists,omitempty"`
 }
 
 // PlayersSpec tracks the initial player capacity
@@ -216,6 +225,12 @@ type SdkServer struct {
 	GRPCPort int32 `json:"grpcPort,omitempty"`
 	// HTTPPort is the port on which the SDK Server binds the HTTP gRPC gateway server to accept incoming connections
 	HTTPPort int32 `json:"httpPort,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:SDKTrace]
+	// Trace makes the SDK Server write a trace of every SDK request, with its arguments and results,
+	// to its logs as JSON lines. Defaults to false
+	// +optional
+	Trace bool `json:"trace,omitempty"`
 }
 
 // GameServerStatus is the status for a GameServer resource
@@ -230,6 +245,20 @@ type GameServerStatus struct {
 	// [FeatureFlag:PlayerTracking]
 	// +optional
 	Players *PlayerStatus `json:"players"`
+	// [Stage:Alpha]
+	// [FeatureFlag:CountsAndLists]
+	// +optional
+	Counters map[string]CounterStatus `json:"counters,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:CountsAndLists]
+	// +optional
+	Lists map[string]ListStatus `json:"lists,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:SDKDrain]
+	// DrainDeadline is set when the game server requests to be drained through the SDK: the GameServer
+	// is no longer allocated, and is moved to Shutdown once the deadline passes.
+	// +optional
+	DrainDeadline *metav1.Time `json:"drainDeadline,omitempty"`
 }
 
 // GameServerStatusPort shows the port that was allocated to a
@@ -244,6 +273,22 @@ type PlayerStatus struct {
 	Count    int64    `json:"count"`
 	Capacity int64    `json:"capacity"`
 	IDs      []string `json:"ids"`
+	// Reserved is the number of player slots that allocations with a player selector have reserved
+	// for players that have not connected yet. They are released as players connect, or when the
+	// GameServer moves back to Ready.
+	Reserved int64 `json:"reserved,omitempty"`
+}
+
+// CounterStatus stores the current count and capacity of a named counter
+type CounterStatus struct {
+	Count    int64 `json:"count"`
+	Capacity int64 `json:"capacity"`
+}
+
+// ListStatus stores the current values and capacity of a named list
+type ListStatus struct {
+	Capacity int64    `json:"capacity"`
+	Values   []string `json:"values"`
 }
 
 // ApplyDefaults applies default values to the GameServer if they are not already populated
@@ -324,6 +369,21 @@ func (gs *GameServer) applyStatusDefaults() {
 			gs.Status.Players.Capacity = gs.Spec.Players.InitialCapacity
 		}
 	}
+
+	if runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		if gs.Status.Counters == nil && gs.Spec.Counters != nil {
+			gs.Status.Counters = make(map[string]CounterStatus, len(gs.Spec.Counters))
+			for k, v := range gs.Spec.Counters {
+				gs.Status.Counters[k] = v
+			}
+		}
+		if gs.Status.Lists == nil && gs.Spec.Lists != nil {
+			gs.Status.Lists = make(map[string]ListStatus, len(gs.Spec.Lists))
+			for k, v := range gs.Spec.Lists {
+				gs.Status.Lists[k] = *v.DeepCopy()
+			}
+		}
+	}
 }
 
 // applyPortDefaults applies default values for all ports
@@ -367,6 +427,33 @@ func (gss *GameServerSpec) Validate(devAddress string) ([]metav1.StatusCause, bo
 		}
 	}
 
+	if !runtime.FeatureEnabled(runtime.FeatureSDKTrace) && gss.SdkServer.Trace {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Field:   "sdkServer.trace",
+			Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureSDKTrace),
+		})
+	}
+
+	if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		if gss.Counters != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   "counters",
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureCountsAndLists),
+			})
+		}
+		if gss.Lists != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   "lists",
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureCountsAndLists),
+			})
+		}
+	} else {
+		causes = append(causes, gss.validateCountsAndLists()...)
+	}
+
 	if !runtime.FeatureEnabled(runtime.FeatureContainerPortAllocation) {
 		for _, p := range gss.Ports {
 			if p.Container != nil {
@@ -483,6 +570,60 @@ func (gss *GameServerSpec) Validate(devAddress string) ([]metav1.StatusCause, bo
 	return causes, len(causes) == 0
 }
 
+// validateCountsAndLists validates the initial Counter and List values, making sure
+// no capacity is negative, and no count or list of values exceeds its capacity
+func (gss *GameServerSpec) validateCountsAndLists() []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	names := make([]string, 0, len(gss.Counters))
+	for name := range gss.Counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c := gss.Counters[name]
+		if c.Capacity < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("counters.%s.capacity", name),
+				Message: "Capacity must be greater than or equal to 0",
+			})
+		}
+		if c.Count < 0 || c.Count > c.Capacity {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("counters.%s.count", name),
+				Message: "Count must be between 0 and Capacity",
+			})
+		}
+	}
+
+	names = make([]string, 0, len(gss.Lists))
+	for name := range gss.Lists {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		l := gss.Lists[name]
+		if l.Capacity < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("lists.%s.capacity", name),
+				Message: "Capacity must be greater than or equal to 0",
+			})
+		}
+		if int64(len(l.Values)) > l.Capacity {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("lists.%s.values", name),
+				Message: "Number of Values must be less than or equal to Capacity",
+			})
+		}
+	}
+
+	return causes
+}
+
 // ValidateResource validates limit or Memory CPU resources used for containers in pods
 // If a GameServer is invalid there will be > 0 values in
 // the returned array
@@ -545,6 +686,11 @@ func (gs *GameServer) IsBeingDeleted() bool {
 	return !gs.ObjectMeta.DeletionTimestamp.IsZero() || gs.Status.State == GameServerStateShutdown
 }
 
+// IsDraining returns true if the game server has requested to be drained, and so should not be allocated
+func (gs *GameServer) IsDraining() bool {
+	return gs.Status.DrainDeadline != nil
+}
+
 // IsBeforeReady returns true if the GameServer Status has yet to move to or past the Ready
 // state in its lifecycle, such as Allocated or Reserved, or any of the Error/Unhealthy states
 func (gs *GameServer) IsBeforeReady() bool {
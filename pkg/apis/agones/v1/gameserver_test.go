@@ -141,6 +141,8 @@ func TestGameServerApplyDefaults(t *testing.T) {
 		scheduling          apis.SchedulingStrategy
 		sdkServer           SdkServer
 		alphaPlayerCapacity *int64
+		counters            map[string]CounterStatus
+		lists               map[string]ListStatus
 	}
 	data := map[string]struct {
 		gameServer   GameServer
@@ -179,6 +181,39 @@ func TestGameServerApplyDefaults(t *testing.T) {
 				alphaPlayerCapacity: &ten,
 			},
 		},
+		"copy counters and lists to status": {
+			featureFlags: string(runtime.FeatureCountsAndLists) + "=true",
+			gameServer: GameServer{
+				Spec: GameServerSpec{
+					Counters: map[string]CounterStatus{"rooms": {Count: 1, Capacity: 10}},
+					Lists:    map[string]ListStatus{"tokens": {Capacity: 5, Values: []string{"one"}}},
+					Ports:    []GameServerPort{{ContainerPort: 999}},
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{
+							{Name: "testing", Image: "testing/image"},
+						}}}},
+			},
+			container: "testing",
+			expected: expected{
+				protocol:   "UDP",
+				state:      GameServerStatePortAllocation,
+				policy:     Dynamic,
+				scheduling: apis.Packed,
+				health: Health{
+					Disabled:            false,
+					FailureThreshold:    3,
+					InitialDelaySeconds: 5,
+					PeriodSeconds:       5,
+				},
+				sdkServer: SdkServer{
+					LogLevel: SdkServerLogLevelInfo,
+					GRPCPort: 9357,
+					HTTPPort: 9358,
+				},
+				counters: map[string]CounterStatus{"rooms": {Count: 1, Capacity: 10}},
+				lists:    map[string]ListStatus{"tokens": {Capacity: 5, Values: []string{"one"}}},
+			},
+		},
 		"defaults on passthrough": {
 			gameServer: GameServer{
 				Spec: GameServerSpec{
@@ -422,6 +457,8 @@ func TestGameServerApplyDefaults(t *testing.T) {
 				assert.Nil(t, test.gameServer.Spec.Players)
 				assert.Nil(t, test.gameServer.Status.Players)
 			}
+			assert.Equal(t, test.expected.counters, test.gameServer.Status.Counters)
+			assert.Equal(t, test.expected.lists, test.gameServer.Status.Lists)
 		})
 	}
 }
@@ -1162,6 +1199,84 @@ func TestGameServerValidateFeatures(t *testing.T) {
 			isValid:        true,
 			causesExpected: []metav1.StatusCause{},
 		},
+		{
+			description: "CountsAndLists is disabled, Counters and Lists fields specified",
+			feature:     fmt.Sprintf("%s=false", runtime.FeatureCountsAndLists),
+			gs: GameServer{
+				Spec: GameServerSpec{
+					Container: "testing",
+					Counters:  map[string]CounterStatus{"rooms": {Capacity: 10}},
+					Lists:     map[string]ListStatus{"tokens": {Capacity: 10}},
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+			},
+			isValid: false,
+			causesExpected: []metav1.StatusCause{
+				{Type: metav1.CauseTypeFieldValueNotSupported, Message: "Value cannot be set unless feature flag CountsAndLists is enabled", Field: "counters"},
+				{Type: metav1.CauseTypeFieldValueNotSupported, Message: "Value cannot be set unless feature flag CountsAndLists is enabled", Field: "lists"},
+			},
+		},
+		{
+			description: "CountsAndLists is enabled, Counters and Lists fields specified",
+			feature:     fmt.Sprintf("%s=true", runtime.FeatureCountsAndLists),
+			gs: GameServer{
+				Spec: GameServerSpec{
+					Container: "testing",
+					Counters:  map[string]CounterStatus{"rooms": {Count: 1, Capacity: 10}},
+					Lists:     map[string]ListStatus{"tokens": {Capacity: 10, Values: []string{"one"}}},
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+			},
+			isValid:        true,
+			causesExpected: []metav1.StatusCause{},
+		},
+		{
+			description: "CountsAndLists is enabled, Counters and Lists values exceed capacity",
+			feature:     fmt.Sprintf("%s=true", runtime.FeatureCountsAndLists),
+			gs: GameServer{
+				Spec: GameServerSpec{
+					Container: "testing",
+					Counters:  map[string]CounterStatus{"rooms": {Count: 11, Capacity: 10}, "sessions": {Capacity: -1}},
+					Lists:     map[string]ListStatus{"tokens": {Capacity: 1, Values: []string{"one", "two"}}},
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+			},
+			isValid: false,
+			causesExpected: []metav1.StatusCause{
+				{Type: metav1.CauseTypeFieldValueInvalid, Message: "Count must be between 0 and Capacity", Field: "counters.rooms.count"},
+				{Type: metav1.CauseTypeFieldValueInvalid, Message: "Capacity must be greater than or equal to 0", Field: "counters.sessions.capacity"},
+				{Type: metav1.CauseTypeFieldValueInvalid, Message: "Count must be between 0 and Capacity", Field: "counters.sessions.count"},
+				{Type: metav1.CauseTypeFieldValueInvalid, Message: "Number of Values must be less than or equal to Capacity", Field: "lists.tokens.values"},
+			},
+		},
+		{
+			description: "SDKTrace is disabled, SdkServer Trace field specified",
+			feature:     fmt.Sprintf("%s=false", runtime.FeatureSDKTrace),
+			gs: GameServer{
+				Spec: GameServerSpec{
+					Container: "testing",
+					SdkServer: SdkServer{Trace: true},
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+			},
+			isValid: false,
+			causesExpected: []metav1.StatusCause{
+				{Type: metav1.CauseTypeFieldValueNotSupported, Message: "Value cannot be set unless feature flag SDKTrace is enabled", Field: "sdkServer.trace"},
+			},
+		},
+		{
+			description: "SDKTrace is enabled, SdkServer Trace field specified",
+			feature:     fmt.Sprintf("%s=true", runtime.FeatureSDKTrace),
+			gs: GameServer{
+				Spec: GameServerSpec{
+					Container: "testing",
+					SdkServer: SdkServer{Trace: true},
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "testing", Image: "testing/image"}}}}},
+			},
+			isValid:        true,
+			causesExpected: []metav1.StatusCause{},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1469,6 +1584,15 @@ func TestGameServerIsDeletable(t *testing.T) {
 	assert.True(t, gs.IsDeletable())
 }
 
+func TestGameServerIsDraining(t *testing.T) {
+	gs := &GameServer{Status: GameServerStatus{State: GameServerStateReady}}
+	assert.False(t, gs.IsDraining())
+
+	now := metav1.Now()
+	gs.Status.DrainDeadline = &now
+	assert.True(t, gs.IsDraining())
+}
+
 func TestGameServerIsBeforeReady(t *testing.T) {
 	fixtures := []struct {
 		state    GameServerState
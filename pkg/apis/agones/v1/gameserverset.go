@@ -80,6 +80,16 @@ type GameServerSetStatus struct {
 	// Players is the current total player capacity and count for this GameServerSet
 	// +optional
 	Players *AggregatedPlayerStatus `json:"players,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:CountsAndLists]
+	// Counters are the total count and capacity of each named Counter for this GameServerSet
+	// +optional
+	Counters map[string]AggregatedCounterStatus `json:"counters,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:CountsAndLists]
+	// Lists are the total number of values and capacity of each named List for this GameServerSet
+	// +optional
+	Lists map[string]AggregatedListStatus `json:"lists,omitempty"`
 }
 
 // ValidateUpdate validates when updates occur. The argument
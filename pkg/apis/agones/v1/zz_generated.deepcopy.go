@@ -24,6 +24,38 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AggregatedCounterStatus) DeepCopyInto(out *AggregatedCounterStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AggregatedCounterStatus.
+func (in *AggregatedCounterStatus) DeepCopy() *AggregatedCounterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AggregatedCounterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AggregatedListStatus) DeepCopyInto(out *AggregatedListStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AggregatedListStatus.
+func (in *AggregatedListStatus) DeepCopy() *AggregatedListStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AggregatedListStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AggregatedPlayerStatus) DeepCopyInto(out *AggregatedPlayerStatus) {
 	*out = *in
@@ -40,6 +72,22 @@ func (in *AggregatedPlayerStatus) DeepCopy() *AggregatedPlayerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CounterStatus) DeepCopyInto(out *CounterStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CounterStatus.
+func (in *CounterStatus) DeepCopy() *CounterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CounterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Fleet) DeepCopyInto(out *Fleet) {
 	*out = *in
@@ -127,6 +175,20 @@ func (in *FleetStatus) DeepCopyInto(out *FleetStatus) {
 		*out = new(AggregatedPlayerStatus)
 		**out = **in
 	}
+	if in.Counters != nil {
+		in, out := &in.Counters, &out.Counters
+		*out = make(map[string]AggregatedCounterStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Lists != nil {
+		in, out := &in.Lists, &out.Lists
+		*out = make(map[string]AggregatedListStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -308,6 +370,20 @@ func (in *GameServerSetStatus) DeepCopyInto(out *GameServerSetStatus) {
 		*out = new(AggregatedPlayerStatus)
 		**out = **in
 	}
+	if in.Counters != nil {
+		in, out := &in.Counters, &out.Counters
+		*out = make(map[string]AggregatedCounterStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Lists != nil {
+		in, out := &in.Lists, &out.Lists
+		*out = make(map[string]AggregatedListStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -339,6 +415,20 @@ func (in *GameServerSpec) DeepCopyInto(out *GameServerSpec) {
 		*out = new(PlayersSpec)
 		**out = **in
 	}
+	if in.Counters != nil {
+		in, out := &in.Counters, &out.Counters
+		*out = make(map[string]CounterStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Lists != nil {
+		in, out := &in.Lists, &out.Lists
+		*out = make(map[string]ListStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -369,6 +459,24 @@ func (in *GameServerStatus) DeepCopyInto(out *GameServerStatus) {
 		*out = new(PlayerStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Counters != nil {
+		in, out := &in.Counters, &out.Counters
+		*out = make(map[string]CounterStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Lists != nil {
+		in, out := &in.Lists, &out.Lists
+		*out = make(map[string]ListStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.DrainDeadline != nil {
+		in, out := &in.DrainDeadline, &out.DrainDeadline
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -432,6 +540,27 @@ func (in *Health) DeepCopy() *Health {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListStatus) DeepCopyInto(out *ListStatus) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListStatus.
+func (in *ListStatus) DeepCopy() *ListStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ListStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlayerStatus) DeepCopyInto(out *PlayerStatus) {
 	*out = *in
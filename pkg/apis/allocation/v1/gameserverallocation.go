@@ -15,13 +15,20 @@
 package v1
 
 import (
+	"crypto/x509"
 	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 
 	"agones.dev/agones/pkg/apis"
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
-	"github.com/pkg/errors"
+	"agones.dev/agones/pkg/util/runtime"
+	admregv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
@@ -32,11 +39,41 @@ const (
 	// GameServerAllocationContention when the allocation is unsuccessful
 	// because of contention
 	GameServerAllocationContention GameServerAllocationState = "Contention"
+
+	// GameServerPriorityCounter is a Priority Type that sorts by the available capacity of a Counter
+	GameServerPriorityCounter PriorityType = "Counter"
+	// GameServerPriorityList is a Priority Type that sorts by the available capacity of a List
+	GameServerPriorityList PriorityType = "List"
+	// GameServerPriorityLabel is a Priority Type that sorts by the value of a label
+	GameServerPriorityLabel PriorityType = "Label"
+	// GameServerPriorityAnnotation is a Priority Type that sorts by the value of an annotation
+	GameServerPriorityAnnotation PriorityType = "Annotation"
+	// GameServerPriorityPlayers is a Priority Type that sorts by the available player capacity
+	GameServerPriorityPlayers PriorityType = "Players"
+
+	// GameServerPriorityAscending is a Priority Order that sorts the smallest value first
+	GameServerPriorityAscending PriorityOrder = "Ascending"
+	// GameServerPriorityDescending is a Priority Order that sorts the largest value first
+	GameServerPriorityDescending PriorityOrder = "Descending"
+
+	// GameServerPriorityString is a Priority Value Type that compares label or annotation values as strings
+	GameServerPriorityString PriorityValueType = "String"
+	// GameServerPriorityInt is a Priority Value Type that compares label or annotation values as integers
+	GameServerPriorityInt PriorityValueType = "Int"
 )
 
 // GameServerAllocationState is the Allocation state
 type GameServerAllocationState string
 
+// PriorityType is the type of GameServer value that a Priority sorts by
+type PriorityType string
+
+// PriorityOrder is the sort order of a Priority
+type PriorityOrder string
+
+// PriorityValueType is how the label or annotation values of a Priority are compared
+type PriorityValueType string
+
 // +genclient
 // +genclient:onlyVerbs=create
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -66,26 +103,198 @@ type GameServerAllocationSpec struct {
 	// Otherwise, allocation will happen locally.
 	MultiClusterSetting MultiClusterSetting `json:"multiClusterSetting,omitempty"`
 
-	// Required The required allocation. Defaults to all GameServers.
-	Required metav1.LabelSelector `json:"required,omitempty"`
+	// Required The required allocation. Defaults to all Ready GameServers.
+	Required GameServerSelector `json:"required,omitempty"`
 
 	// Preferred ordered list of preferred allocations out of the `required` set.
 	// If the first selector is not matched,
 	// the selection attempts the second selector, and so on.
-	Preferred []metav1.LabelSelector `json:"preferred,omitempty"`
+	Preferred []GameServerSelector `json:"preferred,omitempty"`
 
 	// Scheduling strategy. Defaults to "Packed".
 	Scheduling apis.SchedulingStrategy `json:"scheduling"`
 
+	// (Alpha, CountsAndLists or AllocationPriorities feature flag) Priorities configuration alters the order in
+	// which GameServers are searched for matches to the configured `required` and `preferred` selectors.
+	// Priorities are processed in the order they are listed, with each subsequent Priority breaking ties of
+	// the previous one. The Scheduling strategy is only used to break ties of the last Priority.
+	// For "Packed" scheduling, remaining ties are broken by the node packing order, and for "Distributed"
+	// scheduling, remaining ties are broken randomly.
+	// +optional
+	Priorities []Priority `json:"priorities,omitempty"`
+
 	// MetaPatch is optional custom metadata that is added to the game server at allocation
 	// You can use this to tell the server necessary session data
 	MetaPatch MetaPatch `json:"metadata,omitempty"`
+
+	// (Alpha, AllocationWebhook feature flag) Webhook is called with each GameServer that is about to be
+	// allocated, and can veto the GameServer, or add labels and annotations to it.
+	// +optional
+	Webhook *AllocationWebhook `json:"webhook,omitempty"`
+}
+
+// GameServerSelector contains all the filter options for selecting
+// a GameServer for allocation.
+type GameServerSelector struct {
+	// See: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/
+	metav1.LabelSelector `json:",inline"`
+
+	// (Alpha, StateAllocationFilter feature flag) GameServerState specifies which State is the filter to be used
+	// when attempting to retrieve a GameServer via Allocation. Defaults to "Ready". The only other option is
+	// "Allocated", which can be used in conjunction with the Players selector to retrieve an already Allocated
+	// GameServer that still has room for more players.
+	// +optional
+	GameServerState *agonesv1.GameServerState `json:"gameServerState,omitempty"`
+
+	// (Alpha, PlayerTracking feature flag) Players provides a filter on the available player capacity
+	// (Status.Players.Capacity - Status.Players.Count - Status.Players.Reserved) when retrieving a GameServer
+	// through Allocation. The MinAvailable player slots are reserved on the allocated GameServer until players connect.
+	// Defaults to no limits.
+	// +optional
+	Players *PlayerSelector `json:"players,omitempty"`
+
+	// (Alpha, CountsAndLists feature flag) Counters provides filters on the count and available capacity
+	// of the named Counters of a GameServer when retrieving a GameServer through Allocation.
+	// Defaults to no limits.
+	// +optional
+	Counters map[string]CounterSelector `json:"counters,omitempty"`
+
+	// (Alpha, CountsAndLists feature flag) Lists provides filters on the values and available capacity
+	// of the named Lists of a GameServer when retrieving a GameServer through Allocation.
+	// Defaults to no limits.
+	// +optional
+	Lists map[string]ListSelector `json:"lists,omitempty"`
+}
+
+// PlayerSelector is the filter options for a GameServer based on the count and/or available capacity.
+type PlayerSelector struct {
+	// MinAvailable is the minimum number of free player slots the GameServer must have, so that
+	// this allocation can be sure to fit that many players.
+	MinAvailable int64 `json:"minAvailable,omitempty"`
+	// MaxAvailable is the maximum number of free player slots the GameServer can have.
+	// Defaults to no maximum.
+	MaxAvailable int64 `json:"maxAvailable,omitempty"`
+}
+
+// CounterSelector is the filter options for a GameServer based on the count and/or available capacity
+// of a named Counter.
+type CounterSelector struct {
+	// MinCount is the minimum count the Counter must have.
+	MinCount int64 `json:"minCount,omitempty"`
+	// MaxCount is the maximum count the Counter can have. Defaults to no maximum.
+	MaxCount int64 `json:"maxCount,omitempty"`
+	// MinAvailable is the minimum available capacity (Capacity - Count) the Counter must have.
+	MinAvailable int64 `json:"minAvailable,omitempty"`
+	// MaxAvailable is the maximum available capacity (Capacity - Count) the Counter can have.
+	// Defaults to no maximum.
+	MaxAvailable int64 `json:"maxAvailable,omitempty"`
+}
+
+// ListSelector is the filter options for a GameServer based on the values and/or available capacity
+// of a named List.
+type ListSelector struct {
+	// ContainsValue is a value that must be present in the List. Defaults to no value.
+	ContainsValue string `json:"containsValue,omitempty"`
+	// MinAvailable is the minimum available capacity (Capacity - number of Values) the List must have.
+	MinAvailable int64 `json:"minAvailable,omitempty"`
+	// MaxAvailable is the maximum available capacity (Capacity - number of Values) the List can have.
+	// Defaults to no maximum.
+	MaxAvailable int64 `json:"maxAvailable,omitempty"`
+}
+
+// Priority is a sort order for the GameServers that are searched during Allocation.
+type Priority struct {
+	// Type is the type of GameServer value to sort by, one of "Counter", "List" (CountsAndLists feature flag),
+	// "Label", "Annotation" or "Players" (AllocationPriorities feature flag).
+	Type PriorityType `json:"type"`
+	// Key is the name of the Counter, List, label or annotation to sort by. Not used by the "Players" Type.
+	// +optional
+	Key string `json:"key,omitempty"`
+	// Order is the sort order of the value, either "Ascending" or "Descending". Defaults to "Descending".
+	// Counters, Lists and Players are sorted by their available capacity, so by default GameServers with
+	// the most available capacity are searched first. GameServers that do not have the value are always searched last.
+	// +optional
+	Order PriorityOrder `json:"order,omitempty"`
+	// ValueType is how the values of a "Label" or "Annotation" Type are compared, either "String" or "Int".
+	// Defaults to "String". With "Int", values that are not integers are treated as missing.
+	// +optional
+	ValueType PriorityValueType `json:"valueType,omitempty"`
 }
 
 // MultiClusterSetting specifies settings for multi-cluster allocation.
 type MultiClusterSetting struct {
 	Enabled        bool                 `json:"enabled,omitempty"`
 	PolicySelector metav1.LabelSelector `json:"policySelector,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:AllocationLatency]
+	// Latencies are the latencies measured by the player to clusters or regions, for example with the Agones
+	// ping service. If set, the clusters of the policies are tried in order of latency, rather than of priority.
+	// +optional
+	Latencies []LatencyMeasurement `json:"latencies,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:AllocationLatency]
+	// MaxLatencyMillis is the maximum acceptable latency, in milliseconds. If set, the clusters with a higher
+	// latency, or without a latency measurement, are not allocated from.
+	// +optional
+	MaxLatencyMillis int32 `json:"maxLatencyMillis,omitempty"`
+}
+
+// LatencyMeasurement is a latency measured by the player to a cluster or region
+type LatencyMeasurement struct {
+	// Name is the name of the cluster, as in the clusterName of the connectionInfo of the
+	// GameServerAllocationPolicies, or the name of the region, as in their multicluster.agones.dev/region label
+	Name string `json:"name"`
+	// LatencyMillis is the measured round-trip latency, in milliseconds
+	LatencyMillis int32 `json:"latencyMillis"`
+}
+
+// Validate returns if the MultiClusterSetting is valid
+func (s *MultiClusterSetting) Validate(field string) ([]metav1.StatusCause, bool) {
+	var causes []metav1.StatusCause
+
+	if !runtime.FeatureEnabled(runtime.FeatureAllocationLatency) {
+		if s.Latencies != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   field + ".latencies",
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureAllocationLatency),
+			})
+		}
+		if s.MaxLatencyMillis != 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   field + ".maxLatencyMillis",
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureAllocationLatency),
+			})
+		}
+		return causes, len(causes) == 0
+	}
+
+	for i, l := range s.Latencies {
+		if l.Name == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Field:   fmt.Sprintf("%s.latencies[%d].name", field, i),
+				Message: "Name is required",
+			})
+		}
+		if l.LatencyMillis < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("%s.latencies[%d].latencyMillis", field, i),
+				Message: "Value must be greater than or equal to 0",
+			})
+		}
+	}
+	if s.MaxLatencyMillis < 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   field + ".maxLatencyMillis",
+			Message: "Value must be greater than or equal to 0",
+		})
+	}
+
+	return causes, len(causes) == 0
 }
 
 // MetaPatch is the metadata used to patch the GameServer metadata on allocation
@@ -94,21 +303,504 @@ type MetaPatch struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
-// PreferredSelectors converts all the preferred label selectors into an array of
-// labels.Selectors. This is useful as they all have `Match()` functions!
-func (gsas *GameServerAllocationSpec) PreferredSelectors() ([]labels.Selector, error) {
-	list := make([]labels.Selector, len(gsas.Preferred))
+// AllocationWebhook is a webhook that is called with a GameServerAllocationReview for each GameServer
+// that is about to be allocated.
+type AllocationWebhook struct {
+	// WebhookClientConfig contains the URL or Service of the webhook, and its optional CA Bundle
+	admregv1.WebhookClientConfig `json:",inline"`
 
-	var err error
-	for i, p := range gsas.Preferred {
-		p := p
-		list[i], err = metav1.LabelSelectorAsSelector(&p)
-		if err != nil {
-			break
+	// TimeoutSeconds is how long to wait for the webhook to respond. Must be between 1 and 30 seconds.
+	// Defaults to 5 seconds.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailurePolicy defines what happens when the webhook cannot be called, times out or returns an error.
+	// "Fail" (default) fails the allocation of the GameServer, "Ignore" allocates it without the webhook.
+	// +optional
+	FailurePolicy *admregv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+}
+
+// GameServerAllocationReviewRequest defines the request to the allocation webhook endpoint
+type GameServerAllocationReviewRequest struct {
+	// UID is an identifier for the individual request/response, which allows us to distinguish requests that
+	// are otherwise identical, such as retries. It is suitable for correlating log entries.
+	UID types.UID `json:"uid"`
+	// Namespace is the namespace of the GameServerAllocation
+	Namespace string `json:"namespace"`
+	// MetaPatch is the metadata of the GameServerAllocation that will be added to the GameServer
+	MetaPatch MetaPatch `json:"metadata,omitempty"`
+	// GameServer is the GameServer that is about to be allocated
+	GameServer *agonesv1.GameServer `json:"gameServer"`
+}
+
+// GameServerAllocationReviewResponse defines the response of the allocation webhook endpoint
+type GameServerAllocationReviewResponse struct {
+	// UID is an identifier for the individual request/response.
+	// This should be copied over from the corresponding GameServerAllocationReviewRequest.
+	UID types.UID `json:"uid"`
+	// Allowed is set to false to veto the allocation of the GameServer, in which case
+	// another GameServer is searched for.
+	Allowed bool `json:"allowed"`
+	// Reason is an optional explanation of why the GameServer was vetoed
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// MetaPatch is optional custom metadata that is added to the GameServer at allocation, along with
+	// the GameServerAllocation's MetaPatch. Its values take precedence.
+	// +optional
+	MetaPatch MetaPatch `json:"metadata,omitempty"`
+}
+
+// GameServerAllocationReview is passed to the allocation webhook with a populated Request value,
+// and then returned with a populated Response.
+type GameServerAllocationReview struct {
+	Request  *GameServerAllocationReviewRequest  `json:"request"`
+	Response *GameServerAllocationReviewResponse `json:"response"`
+}
+
+// Matches checks to see if a GameServer matches a given GameServerSelector's criteria.
+// A GameServerSelector with an invalid LabelSelector matches nothing, see Validate().
+func (s *GameServerSelector) Matches(gs *agonesv1.GameServer) bool {
+	// state is the cheapest check, and rules out most of the GameServers, so do it first.
+	state := agonesv1.GameServerStateReady
+	if s.GameServerState != nil {
+		state = *s.GameServerState
+	}
+	if gs.Status.State != state {
+		return false
+	}
+
+	if !s.matchesPlayerCapacity(gs) {
+		return false
+	}
+
+	if !s.matchesCountersAndLists(gs) {
+		return false
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&s.LabelSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(gs.ObjectMeta.Labels))
+}
+
+// matchesPlayerCapacity returns true if there is no player selector, or the available
+// player capacity of the GameServer is within the bounds of the PlayerSelector.
+// Player slots reserved by previous allocations are not available.
+func (s *GameServerSelector) matchesPlayerCapacity(gs *agonesv1.GameServer) bool {
+	if s.Players == nil {
+		return true
+	}
+	if gs.Status.Players == nil {
+		return false
+	}
+
+	available := gs.Status.Players.Capacity - gs.Status.Players.Count - gs.Status.Players.Reserved
+	if available < s.Players.MinAvailable {
+		return false
+	}
+	if s.Players.MaxAvailable > 0 && available > s.Players.MaxAvailable {
+		return false
+	}
+	return true
+}
+
+// matchesCountersAndLists returns true if every Counter and List selector is satisfied by
+// the GameServer's Counters and Lists
+func (s *GameServerSelector) matchesCountersAndLists(gs *agonesv1.GameServer) bool {
+	for name, sel := range s.Counters {
+		c, ok := gs.Status.Counters[name]
+		if !ok {
+			return false
+		}
+		if c.Count < sel.MinCount || (sel.MaxCount > 0 && c.Count > sel.MaxCount) {
+			return false
+		}
+		available := c.Capacity - c.Count
+		if available < sel.MinAvailable || (sel.MaxAvailable > 0 && available > sel.MaxAvailable) {
+			return false
+		}
+	}
+
+	for name, sel := range s.Lists {
+		l, ok := gs.Status.Lists[name]
+		if !ok {
+			return false
+		}
+		if sel.ContainsValue != "" && !containsValue(l.Values, sel.ContainsValue) {
+			return false
+		}
+		available := l.Capacity - int64(len(l.Values))
+		if available < sel.MinAvailable || (sel.MaxAvailable > 0 && available > sel.MaxAvailable) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsValue returns true if value is in values
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate validates that the selection fields have been populated correctly.
+// field is the path to this GameServerSelector, and is used as the prefix of the cause fields.
+func (s *GameServerSelector) Validate(field string) ([]metav1.StatusCause, bool) {
+	var causes []metav1.StatusCause
+
+	if _, err := metav1.LabelSelectorAsSelector(&s.LabelSelector); err != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   field,
+			Message: fmt.Sprintf("Error converting label selector: %s", err),
+		})
+	}
+
+	if s.GameServerState != nil {
+		if !runtime.FeatureEnabled(runtime.FeatureStateAllocationFilter) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   fmt.Sprintf("%s.gameServerState", field),
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureStateAllocationFilter),
+			})
+		} else if *s.GameServerState != agonesv1.GameServerStateReady && *s.GameServerState != agonesv1.GameServerStateAllocated {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("%s.gameServerState", field),
+				Message: fmt.Sprintf("Invalid value: %s, value must be either Ready or Allocated", *s.GameServerState),
+			})
+		}
+	}
+
+	if s.Players != nil {
+		if !runtime.FeatureEnabled(runtime.FeaturePlayerTracking) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   fmt.Sprintf("%s.players", field),
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeaturePlayerTracking),
+			})
+		} else {
+			if s.Players.MinAvailable < 0 || s.Players.MaxAvailable < 0 {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Field:   fmt.Sprintf("%s.players", field),
+					Message: "minAvailable and maxAvailable must not be negative",
+				})
+			}
+			if s.Players.MaxAvailable > 0 && s.Players.MinAvailable > s.Players.MaxAvailable {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Field:   fmt.Sprintf("%s.players.minAvailable", field),
+					Message: "minAvailable cannot be greater than maxAvailable",
+				})
+			}
+		}
+	}
+
+	if s.Counters != nil || s.Lists != nil {
+		if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+			if s.Counters != nil {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Field:   fmt.Sprintf("%s.counters", field),
+					Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureCountsAndLists),
+				})
+			}
+			if s.Lists != nil {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Field:   fmt.Sprintf("%s.lists", field),
+					Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureCountsAndLists),
+				})
+			}
+		} else {
+			causes = append(causes, s.validateCountersAndLists(field)...)
+		}
+	}
+
+	return causes, len(causes) == 0
+}
+
+// validateCountersAndLists validates that the Counter and List selectors are not negative,
+// and that their minimums are not greater than their maximums
+func (s *GameServerSelector) validateCountersAndLists(field string) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	names := make([]string, 0, len(s.Counters))
+	for name := range s.Counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c := s.Counters[name]
+		f := fmt.Sprintf("%s.counters.%s", field, name)
+		if c.MinCount < 0 || c.MaxCount < 0 || c.MinAvailable < 0 || c.MaxAvailable < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   f,
+				Message: "minCount, maxCount, minAvailable and maxAvailable must not be negative",
+			})
+		}
+		if c.MaxCount > 0 && c.MinCount > c.MaxCount {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   f + ".minCount",
+				Message: "minCount cannot be greater than maxCount",
+			})
+		}
+		if c.MaxAvailable > 0 && c.MinAvailable > c.MaxAvailable {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   f + ".minAvailable",
+				Message: "minAvailable cannot be greater than maxAvailable",
+			})
+		}
+	}
+
+	names = make([]string, 0, len(s.Lists))
+	for name := range s.Lists {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		l := s.Lists[name]
+		f := fmt.Sprintf("%s.lists.%s", field, name)
+		if l.MinAvailable < 0 || l.MaxAvailable < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   f,
+				Message: "minAvailable and maxAvailable must not be negative",
+			})
+		}
+		if l.MaxAvailable > 0 && l.MinAvailable > l.MaxAvailable {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   f + ".minAvailable",
+				Message: "minAvailable cannot be greater than maxAvailable",
+			})
+		}
+	}
+
+	return causes
+}
+
+// Compare compares the values of this Priority on two GameServers. It returns a negative number
+// if gs1 should be searched before gs2, a positive number if gs2 should be searched before gs1,
+// and zero if they are equal for this Priority.
+func (p *Priority) Compare(gs1, gs2 *agonesv1.GameServer) int {
+	var result int
+	if p.isString() {
+		v1, ok1 := p.stringValue(gs1)
+		v2, ok2 := p.stringValue(gs2)
+		if !ok1 || !ok2 {
+			return compareMissing(ok1, ok2)
 		}
+		result = strings.Compare(v1, v2)
+	} else {
+		v1, ok1 := p.intValue(gs1)
+		v2, ok2 := p.intValue(gs2)
+		if !ok1 || !ok2 {
+			return compareMissing(ok1, ok2)
+		}
+		switch {
+		case v1 < v2:
+			result = -1
+		case v1 > v2:
+			result = 1
+		}
+	}
+
+	if p.Order != GameServerPriorityAscending {
+		result = -result
+	}
+	return result
+}
+
+// compareMissing compares two GameServers of which at least one is missing the value,
+// as GameServers without the value always go last
+func compareMissing(ok1, ok2 bool) int {
+	switch {
+	case !ok1 && !ok2:
+		return 0
+	case !ok1:
+		return 1
 	}
+	return -1
+}
+
+// isString returns true if this Priority compares its values as strings
+func (p *Priority) isString() bool {
+	return (p.Type == GameServerPriorityLabel || p.Type == GameServerPriorityAnnotation) && p.ValueType != GameServerPriorityInt
+}
 
-	return list, errors.WithStack(err)
+// stringValue returns the label or annotation value this Priority sorts by,
+// and false if the GameServer does not have that label or annotation
+func (p *Priority) stringValue(gs *agonesv1.GameServer) (string, bool) {
+	switch p.Type {
+	case GameServerPriorityLabel:
+		v, ok := gs.ObjectMeta.Labels[p.Key]
+		return v, ok
+	case GameServerPriorityAnnotation:
+		v, ok := gs.ObjectMeta.Annotations[p.Key]
+		return v, ok
+	}
+	return "", false
+}
+
+// intValue returns the available capacity of the Counter, List or Players, or the integer value of the
+// label or annotation this Priority sorts by, and false if the GameServer does not have that value
+func (p *Priority) intValue(gs *agonesv1.GameServer) (int64, bool) {
+	switch p.Type {
+	case GameServerPriorityCounter:
+		c, ok := gs.Status.Counters[p.Key]
+		return c.Capacity - c.Count, ok
+	case GameServerPriorityList:
+		l, ok := gs.Status.Lists[p.Key]
+		return l.Capacity - int64(len(l.Values)), ok
+	case GameServerPriorityPlayers:
+		if gs.Status.Players == nil {
+			return 0, false
+		}
+		return gs.Status.Players.Capacity - gs.Status.Players.Count - gs.Status.Players.Reserved, true
+	case GameServerPriorityLabel, GameServerPriorityAnnotation:
+		s, ok := p.stringValue(gs)
+		if !ok {
+			return 0, false
+		}
+		v, err := strconv.ParseInt(s, 10, 64)
+		return v, err == nil
+	}
+	return 0, false
+}
+
+// Validate validates that the Priority fields have been populated correctly,
+// and that the feature flags its Type depends on are enabled.
+// field is the path to this Priority, and is used as the prefix of the cause fields.
+func (p *Priority) Validate(field string) ([]metav1.StatusCause, bool) {
+	var causes []metav1.StatusCause
+
+	var features []runtime.Feature
+	switch p.Type {
+	case GameServerPriorityCounter, GameServerPriorityList:
+		features = []runtime.Feature{runtime.FeatureCountsAndLists}
+	case GameServerPriorityLabel, GameServerPriorityAnnotation:
+		features = []runtime.Feature{runtime.FeatureAllocationPriorities}
+	case GameServerPriorityPlayers:
+		features = []runtime.Feature{runtime.FeatureAllocationPriorities, runtime.FeaturePlayerTracking}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   fmt.Sprintf("%s.type", field),
+			Message: fmt.Sprintf("Invalid value: %s, value must be one of Counter, List, Label, Annotation or Players", p.Type),
+		})
+	}
+	for _, f := range features {
+		if !runtime.FeatureEnabled(f) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   fmt.Sprintf("%s.type", field),
+				Message: fmt.Sprintf("Value %s cannot be set unless feature flag %s is enabled", p.Type, f),
+			})
+		}
+	}
+
+	if p.Key == "" && p.Type != GameServerPriorityPlayers {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Field:   fmt.Sprintf("%s.key", field),
+			Message: "Key is required",
+		})
+	}
+	if p.Order != "" && p.Order != GameServerPriorityAscending && p.Order != GameServerPriorityDescending {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   fmt.Sprintf("%s.order", field),
+			Message: fmt.Sprintf("Invalid value: %s, value must be either Ascending or Descending", p.Order),
+		})
+	}
+	if p.ValueType != "" {
+		if p.Type != GameServerPriorityLabel && p.Type != GameServerPriorityAnnotation {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   fmt.Sprintf("%s.valueType", field),
+				Message: "ValueType can only be set for the Label or Annotation types",
+			})
+		} else if p.ValueType != GameServerPriorityString && p.ValueType != GameServerPriorityInt {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("%s.valueType", field),
+				Message: fmt.Sprintf("Invalid value: %s, value must be either String or Int", p.ValueType),
+			})
+		}
+	}
+
+	return causes, len(causes) == 0
+}
+
+// Validate validates that the AllocationWebhook fields have been populated correctly.
+// field is the path to this AllocationWebhook, and is used as the prefix of the cause fields.
+func (w *AllocationWebhook) Validate(field string) ([]metav1.StatusCause, bool) {
+	var causes []metav1.StatusCause
+
+	switch {
+	case w.Service == nil && w.URL == nil:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Field:   fmt.Sprintf("%s.url", field),
+			Message: "either url or service should be provided",
+		})
+	case w.Service != nil && w.URL != nil:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   fmt.Sprintf("%s.url", field),
+			Message: "service and url cannot be used simultaneously",
+		})
+	case w.URL != nil:
+		if _, err := url.ParseRequestURI(*w.URL); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("%s.url", field),
+				Message: "url is not valid",
+			})
+		}
+	case w.Service.Name == "":
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Field:   fmt.Sprintf("%s.service.name", field),
+			Message: "service name is required",
+		})
+	}
+	if w.CABundle != nil {
+		if ok := x509.NewCertPool().AppendCertsFromPEM(w.CABundle); !ok {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   fmt.Sprintf("%s.caBundle", field),
+				Message: "CA Bundle is not valid",
+			})
+		}
+	}
+	if w.TimeoutSeconds != nil && (*w.TimeoutSeconds < 1 || *w.TimeoutSeconds > 30) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   fmt.Sprintf("%s.timeoutSeconds", field),
+			Message: fmt.Sprintf("Invalid value: %d, value must be between 1 and 30", *w.TimeoutSeconds),
+		})
+	}
+	if w.FailurePolicy != nil && *w.FailurePolicy != admregv1.Fail && *w.FailurePolicy != admregv1.Ignore {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   fmt.Sprintf("%s.failurePolicy", field),
+			Message: fmt.Sprintf("Invalid value: %s, value must be either Fail or Ignore", *w.FailurePolicy),
+		})
+	}
+
+	return causes, len(causes) == 0
 }
 
 // GameServerAllocationStatus is the status for an GameServerAllocation resource
@@ -119,6 +811,22 @@ type GameServerAllocationStatus struct {
 	Ports          []agonesv1.GameServerStatusPort `json:"ports,omitempty"`
 	Address        string                          `json:"address,omitempty"`
 	NodeName       string                          `json:"nodeName,omitempty"`
+	// Metadata is the labels and annotations of the allocated GameServer,
+	// including the ones added through MetaPatch
+	// +optional
+	Metadata *GameServerMetadata `json:"metadata,omitempty"`
+	// (Alpha, PlayerTracking feature flag) Players is the player status of the allocated GameServer
+	// +optional
+	Players *agonesv1.PlayerStatus `json:"players,omitempty"`
+	// AllocationTimestamp is the time at which the GameServer was allocated
+	// +optional
+	AllocationTimestamp *metav1.Time `json:"allocationTimestamp,omitempty"`
+}
+
+// GameServerMetadata is the metadata of an allocated GameServer
+type GameServerMetadata struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // ApplyDefaults applies the default values to this GameServerAllocation
@@ -126,6 +834,34 @@ func (gsa *GameServerAllocation) ApplyDefaults() {
 	if gsa.Spec.Scheduling == "" {
 		gsa.Spec.Scheduling = apis.Packed
 	}
+
+	if w := gsa.Spec.Webhook; w != nil && runtime.FeatureEnabled(runtime.FeatureAllocationWebhook) {
+		if w.TimeoutSeconds == nil {
+			timeout := int32(5)
+			w.TimeoutSeconds = &timeout
+		}
+		if w.FailurePolicy == nil {
+			policy := admregv1.Fail
+			w.FailurePolicy = &policy
+		}
+	}
+}
+
+// PlayerSlotsToReserve returns the number of player slots that allocating the GameServer reserves,
+// which is the MinAvailable of the first Preferred selector the GameServer matches, or else of the Required selector.
+// It should be called before the GameServer is moved to Allocated.
+func (gsa *GameServerAllocation) PlayerSlotsToReserve(gs *agonesv1.GameServer) int64 {
+	sel := &gsa.Spec.Required
+	for i := range gsa.Spec.Preferred {
+		if gsa.Spec.Preferred[i].Matches(gs) {
+			sel = &gsa.Spec.Preferred[i]
+			break
+		}
+	}
+	if sel.Players == nil {
+		return 0
+	}
+	return sel.Players.MinAvailable
 }
 
 // Validate validation for the GameServerAllocation
@@ -144,5 +880,46 @@ func (gsa *GameServerAllocation) Validate() ([]metav1.StatusCause, bool) {
 			Message: fmt.Sprintf("Invalid value: %s, value must be either Packed or Distributed", gsa.Spec.Scheduling)})
 	}
 
+	if c, ok := gsa.Spec.Required.Validate("spec.required"); !ok {
+		causes = append(causes, c...)
+	}
+	for i := range gsa.Spec.Preferred {
+		if c, ok := gsa.Spec.Preferred[i].Validate(fmt.Sprintf("spec.preferred[%d]", i)); !ok {
+			causes = append(causes, c...)
+		}
+	}
+
+	if c, ok := gsa.Spec.MultiClusterSetting.Validate("spec.multiClusterSetting"); !ok {
+		causes = append(causes, c...)
+	}
+
+	if gsa.Spec.Priorities != nil {
+		if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) && !runtime.FeatureEnabled(runtime.FeatureAllocationPriorities) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   "spec.priorities",
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s or %s is enabled", runtime.FeatureCountsAndLists, runtime.FeatureAllocationPriorities),
+			})
+		} else {
+			for i := range gsa.Spec.Priorities {
+				if c, ok := gsa.Spec.Priorities[i].Validate(fmt.Sprintf("spec.priorities[%d]", i)); !ok {
+					causes = append(causes, c...)
+				}
+			}
+		}
+	}
+
+	if gsa.Spec.Webhook != nil {
+		if !runtime.FeatureEnabled(runtime.FeatureAllocationWebhook) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   "spec.webhook",
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureAllocationWebhook),
+			})
+		} else if c, ok := gsa.Spec.Webhook.Validate("spec.webhook"); !ok {
+			causes = append(causes, c...)
+		}
+	}
+
 	return causes, len(causes) == 0
 }
@@ -15,13 +15,15 @@
 package v1
 
 import (
+	"fmt"
 	"testing"
 
 	"agones.dev/agones/pkg/apis"
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
+	"agones.dev/agones/pkg/util/runtime"
 	"github.com/stretchr/testify/assert"
+	admregv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 )
 
 func TestGameServerAllocationApplyDefaults(t *testing.T) {
@@ -37,33 +39,501 @@ func TestGameServerAllocationApplyDefaults(t *testing.T) {
 	assert.Equal(t, apis.Distributed, gsa.Spec.Scheduling)
 }
 
-func TestGameServerAllocationSpecPreferredSelectors(t *testing.T) {
+func TestGameServerSelectorMatches(t *testing.T) {
 	t.Parallel()
 
-	gsas := &GameServerAllocationSpec{
-		Preferred: []metav1.LabelSelector{
-			{MatchLabels: map[string]string{"check": "blue"}},
-			{MatchLabels: map[string]string{"check": "red"}},
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+	assert.NoError(t, runtime.ParseFeatures(fmt.Sprintf("%s=true&%s=true", runtime.FeaturePlayerTracking, runtime.FeatureStateAllocationFilter)))
+
+	allocated := agonesv1.GameServerStateAllocated
+
+	fixtures := map[string]struct {
+		selector GameServerSelector
+		gs       *agonesv1.GameServer
+		matches  bool
+	}{
+		"no labels, ready": {
+			selector: GameServerSelector{},
+			gs:       &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady}},
+			matches:  true,
+		},
+		"no labels, allocated": {
+			selector: GameServerSelector{},
+			gs:       &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateAllocated}},
+			matches:  false,
+		},
+		"label match": {
+			selector: GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"check": "blue"}}},
+			gs: &agonesv1.GameServer{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"check": "blue"}},
+				Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady}},
+			matches: true,
+		},
+		"label mismatch": {
+			selector: GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"check": "blue"}}},
+			gs: &agonesv1.GameServer{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"check": "red"}},
+				Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady}},
+			matches: false,
+		},
+		"allocated state": {
+			selector: GameServerSelector{GameServerState: &allocated},
+			gs:       &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateAllocated}},
+			matches:  true,
+		},
+		"allocated state, ready gameserver": {
+			selector: GameServerSelector{GameServerState: &allocated},
+			gs:       &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady}},
+			matches:  false,
+		},
+		"players, enough room": {
+			selector: GameServerSelector{GameServerState: &allocated, Players: &PlayerSelector{MinAvailable: 5}},
+			gs: &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateAllocated,
+				Players: &agonesv1.PlayerStatus{Count: 5, Capacity: 10}}},
+			matches: true,
+		},
+		"players, not enough room": {
+			selector: GameServerSelector{GameServerState: &allocated, Players: &PlayerSelector{MinAvailable: 6}},
+			gs: &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateAllocated,
+				Players: &agonesv1.PlayerStatus{Count: 5, Capacity: 10}}},
+			matches: false,
+		},
+		"players, room taken by reserved slots": {
+			selector: GameServerSelector{GameServerState: &allocated, Players: &PlayerSelector{MinAvailable: 5}},
+			gs: &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateAllocated,
+				Players: &agonesv1.PlayerStatus{Count: 2, Capacity: 10, Reserved: 4}}},
+			matches: false,
+		},
+		"players, too much room": {
+			selector: GameServerSelector{Players: &PlayerSelector{MinAvailable: 1, MaxAvailable: 4}},
+			gs: &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady,
+				Players: &agonesv1.PlayerStatus{Count: 5, Capacity: 10}}},
+			matches: false,
+		},
+		"players, no player status": {
+			selector: GameServerSelector{Players: &PlayerSelector{MinAvailable: 1}},
+			gs:       &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady}},
+			matches:  false,
+		},
+		"counters, within bounds": {
+			selector: GameServerSelector{Counters: map[string]CounterSelector{"rooms": {MinCount: 1, MaxCount: 5, MinAvailable: 2}}},
+			gs: &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady,
+				Counters: map[string]agonesv1.CounterStatus{"rooms": {Count: 3, Capacity: 5}}}},
+			matches: true,
+		},
+		"counters, count too high": {
+			selector: GameServerSelector{Counters: map[string]CounterSelector{"rooms": {MaxCount: 2}}},
+			gs: &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady,
+				Counters: map[string]agonesv1.CounterStatus{"rooms": {Count: 3, Capacity: 5}}}},
+			matches: false,
+		},
+		"counters, not enough available": {
+			selector: GameServerSelector{Counters: map[string]CounterSelector{"rooms": {MinAvailable: 3}}},
+			gs: &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady,
+				Counters: map[string]agonesv1.CounterStatus{"rooms": {Count: 3, Capacity: 5}}}},
+			matches: false,
+		},
+		"counters, missing counter": {
+			selector: GameServerSelector{Counters: map[string]CounterSelector{"rooms": {}}},
+			gs:       &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady}},
+			matches:  false,
+		},
+		"lists, contains value": {
+			selector: GameServerSelector{Lists: map[string]ListSelector{"tokens": {ContainsValue: "b", MinAvailable: 1}}},
+			gs: &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady,
+				Lists: map[string]agonesv1.ListStatus{"tokens": {Capacity: 3, Values: []string{"a", "b"}}}}},
+			matches: true,
+		},
+		"lists, does not contain value": {
+			selector: GameServerSelector{Lists: map[string]ListSelector{"tokens": {ContainsValue: "c"}}},
+			gs: &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady,
+				Lists: map[string]agonesv1.ListStatus{"tokens": {Capacity: 3, Values: []string{"a", "b"}}}}},
+			matches: false,
+		},
+		"lists, too much available": {
+			selector: GameServerSelector{Lists: map[string]ListSelector{"tokens": {MaxAvailable: 1}}},
+			gs: &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady,
+				Lists: map[string]agonesv1.ListStatus{"tokens": {Capacity: 3}}}},
+			matches: false,
+		},
+	}
+
+	for k, v := range fixtures {
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, v.matches, v.selector.Matches(v.gs))
+		})
+	}
+}
+
+func TestGameServerAllocationPlayerSlotsToReserve(t *testing.T) {
+	t.Parallel()
+
+	allocated := agonesv1.GameServerStateAllocated
+	gsa := &GameServerAllocation{Spec: GameServerAllocationSpec{
+		Preferred: []GameServerSelector{
+			{GameServerState: &allocated, Players: &PlayerSelector{MinAvailable: 4}},
+			{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"mode": "ffa"}}},
+		},
+		Required: GameServerSelector{Players: &PlayerSelector{MinAvailable: 2}},
+	}}
+
+	gs := &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateAllocated,
+		Players: &agonesv1.PlayerStatus{Capacity: 10}}}
+	assert.Equal(t, int64(4), gsa.PlayerSlotsToReserve(gs))
+
+	gs = &agonesv1.GameServer{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"mode": "ffa"}},
+		Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady, Players: &agonesv1.PlayerStatus{Capacity: 10}}}
+	assert.Equal(t, int64(0), gsa.PlayerSlotsToReserve(gs))
+
+	gs.ObjectMeta.Labels = nil
+	assert.Equal(t, int64(2), gsa.PlayerSlotsToReserve(gs))
+}
+
+func TestGameServerSelectorValidate(t *testing.T) {
+	t.Parallel()
+
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	allocated := agonesv1.GameServerStateAllocated
+	shutdown := agonesv1.GameServerStateShutdown
+
+	assert.NoError(t, runtime.ParseFeatures(""))
+	s := &GameServerSelector{GameServerState: &allocated, Players: &PlayerSelector{MinAvailable: 1}}
+	causes, ok := s.Validate("spec.required")
+	assert.False(t, ok)
+	if assert.Len(t, causes, 2) {
+		assert.Equal(t, "spec.required.gameServerState", causes[0].Field)
+		assert.Equal(t, "spec.required.players", causes[1].Field)
+	}
+
+	assert.NoError(t, runtime.ParseFeatures(fmt.Sprintf("%s=true&%s=true", runtime.FeaturePlayerTracking, runtime.FeatureStateAllocationFilter)))
+	causes, ok = s.Validate("spec.required")
+	assert.True(t, ok)
+	assert.Empty(t, causes)
+
+	s = &GameServerSelector{GameServerState: &shutdown, Players: &PlayerSelector{MinAvailable: 10, MaxAvailable: 5}}
+	causes, ok = s.Validate("spec.preferred[0]")
+	assert.False(t, ok)
+	if assert.Len(t, causes, 2) {
+		assert.Equal(t, "spec.preferred[0].gameServerState", causes[0].Field)
+		assert.Equal(t, "spec.preferred[0].players.minAvailable", causes[1].Field)
+	}
+
+	s = &GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"$$$": "nope"}}}
+	causes, ok = s.Validate("spec.required")
+	assert.False(t, ok)
+	if assert.Len(t, causes, 1) {
+		assert.Equal(t, "spec.required", causes[0].Field)
+	}
+}
+
+func TestGameServerSelectorValidateCountersAndLists(t *testing.T) {
+	t.Parallel()
+
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	assert.NoError(t, runtime.ParseFeatures(""))
+	s := &GameServerSelector{
+		Counters: map[string]CounterSelector{"rooms": {MinAvailable: 1}},
+		Lists:    map[string]ListSelector{"tokens": {ContainsValue: "a"}},
+	}
+	causes, ok := s.Validate("spec.required")
+	assert.False(t, ok)
+	if assert.Len(t, causes, 2) {
+		assert.Equal(t, "spec.required.counters", causes[0].Field)
+		assert.Equal(t, "spec.required.lists", causes[1].Field)
+	}
+
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureCountsAndLists)+"=true"))
+	causes, ok = s.Validate("spec.required")
+	assert.True(t, ok)
+	assert.Empty(t, causes)
+
+	s = &GameServerSelector{
+		Counters: map[string]CounterSelector{"rooms": {MinCount: 5, MaxCount: 2}, "sessions": {MinAvailable: -1}},
+		Lists:    map[string]ListSelector{"tokens": {MinAvailable: 3, MaxAvailable: 1}},
+	}
+	causes, ok = s.Validate("spec.required")
+	assert.False(t, ok)
+	if assert.Len(t, causes, 3) {
+		assert.Equal(t, "spec.required.counters.rooms.minCount", causes[0].Field)
+		assert.Equal(t, "spec.required.counters.sessions", causes[1].Field)
+		assert.Equal(t, "spec.required.lists.tokens.minAvailable", causes[2].Field)
+	}
+}
+
+func TestPriorityCompare(t *testing.T) {
+	t.Parallel()
+
+	gs1 := &agonesv1.GameServer{Status: agonesv1.GameServerStatus{
+		Counters: map[string]agonesv1.CounterStatus{"rooms": {Count: 1, Capacity: 10}},
+		Lists:    map[string]agonesv1.ListStatus{"tokens": {Capacity: 2, Values: []string{"a"}}},
+	}}
+	gs2 := &agonesv1.GameServer{Status: agonesv1.GameServerStatus{
+		Counters: map[string]agonesv1.CounterStatus{"rooms": {Count: 5, Capacity: 10}},
+		Lists:    map[string]agonesv1.ListStatus{"tokens": {Capacity: 5}},
+	}}
+	gs3 := &agonesv1.GameServer{}
+	gs4 := &agonesv1.GameServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{"build": "b", "tier": "10"},
+			Annotations: map[string]string{"tier": "9"},
+		},
+		Status: agonesv1.GameServerStatus{Players: &agonesv1.PlayerStatus{Count: 1, Capacity: 10}},
+	}
+	gs5 := &agonesv1.GameServer{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{"build": "a", "tier": "9"},
+			Annotations: map[string]string{"tier": "nope"},
+		},
+		Status: agonesv1.GameServerStatus{Players: &agonesv1.PlayerStatus{Count: 5, Capacity: 10}},
+	}
+
+	fixtures := map[string]struct {
+		priority Priority
+		gs1      *agonesv1.GameServer
+		gs2      *agonesv1.GameServer
+		expected int
+	}{
+		"counter, default descending": {
+			priority: Priority{Type: GameServerPriorityCounter, Key: "rooms"},
+			gs1:      gs1, gs2: gs2, expected: -1,
+		},
+		"counter, ascending": {
+			priority: Priority{Type: GameServerPriorityCounter, Key: "rooms", Order: GameServerPriorityAscending},
+			gs1:      gs1, gs2: gs2, expected: 1,
+		},
+		"counter, equal": {
+			priority: Priority{Type: GameServerPriorityCounter, Key: "rooms"},
+			gs1:      gs1, gs2: gs1, expected: 0,
+		},
+		"list, descending": {
+			priority: Priority{Type: GameServerPriorityList, Key: "tokens", Order: GameServerPriorityDescending},
+			gs1:      gs1, gs2: gs2, expected: 1,
+		},
+		"missing value goes last, ascending": {
+			priority: Priority{Type: GameServerPriorityList, Key: "tokens", Order: GameServerPriorityAscending},
+			gs1:      gs3, gs2: gs2, expected: 1,
+		},
+		"missing value goes last, descending": {
+			priority: Priority{Type: GameServerPriorityCounter, Key: "rooms"},
+			gs1:      gs1, gs2: gs3, expected: -1,
+		},
+		"label, default descending": {
+			priority: Priority{Type: GameServerPriorityLabel, Key: "build"},
+			gs1:      gs4, gs2: gs5, expected: -1,
+		},
+		"label, ascending": {
+			priority: Priority{Type: GameServerPriorityLabel, Key: "build", Order: GameServerPriorityAscending},
+			gs1:      gs4, gs2: gs5, expected: 1,
 		},
+		"label, as string": {
+			priority: Priority{Type: GameServerPriorityLabel, Key: "tier", ValueType: GameServerPriorityString},
+			gs1:      gs4, gs2: gs5, expected: 1,
+		},
+		"label, as int": {
+			priority: Priority{Type: GameServerPriorityLabel, Key: "tier", ValueType: GameServerPriorityInt},
+			gs1:      gs4, gs2: gs5, expected: -1,
+		},
+		"missing label goes last": {
+			priority: Priority{Type: GameServerPriorityLabel, Key: "build", Order: GameServerPriorityAscending},
+			gs1:      gs3, gs2: gs5, expected: 1,
+		},
+		"annotation, as string": {
+			priority: Priority{Type: GameServerPriorityAnnotation, Key: "tier"},
+			gs1:      gs4, gs2: gs5, expected: 1,
+		},
+		"annotation, not an int goes last": {
+			priority: Priority{Type: GameServerPriorityAnnotation, Key: "tier", Order: GameServerPriorityAscending, ValueType: GameServerPriorityInt},
+			gs1:      gs5, gs2: gs4, expected: 1,
+		},
+		"players, default descending": {
+			priority: Priority{Type: GameServerPriorityPlayers},
+			gs1:      gs4, gs2: gs5, expected: -1,
+		},
+		"players, ascending": {
+			priority: Priority{Type: GameServerPriorityPlayers, Order: GameServerPriorityAscending},
+			gs1:      gs4, gs2: gs5, expected: 1,
+		},
+		"players, missing goes last": {
+			priority: Priority{Type: GameServerPriorityPlayers},
+			gs1:      gs3, gs2: gs5, expected: 1,
+		},
+	}
+
+	for k, v := range fixtures {
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, v.expected, v.priority.Compare(v.gs1, v.gs2))
+		})
+	}
+}
+
+func TestGameServerAllocationValidatePriorities(t *testing.T) {
+	t.Parallel()
+
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	gsa := &GameServerAllocation{Spec: GameServerAllocationSpec{
+		Priorities: []Priority{{Type: GameServerPriorityCounter, Key: "rooms"}},
+	}}
+	gsa.ApplyDefaults()
+
+	assert.NoError(t, runtime.ParseFeatures(""))
+	causes, ok := gsa.Validate()
+	assert.False(t, ok)
+	if assert.Len(t, causes, 1) {
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+		assert.Equal(t, "spec.priorities", causes[0].Field)
 	}
 
-	selectors, err := gsas.PreferredSelectors()
-	assert.Nil(t, err)
-	assert.Len(t, selectors, 2)
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureCountsAndLists)+"=true"))
+	causes, ok = gsa.Validate()
+	assert.True(t, ok)
+	assert.Empty(t, causes)
+
+	gsa.Spec.Priorities = append(gsa.Spec.Priorities, Priority{Type: "Nope", Order: "Sideways"})
+	causes, ok = gsa.Validate()
+	assert.False(t, ok)
+	if assert.Len(t, causes, 3) {
+		assert.Equal(t, "spec.priorities[1].type", causes[0].Field)
+		assert.Equal(t, "spec.priorities[1].key", causes[1].Field)
+		assert.Equal(t, "spec.priorities[1].order", causes[2].Field)
+	}
+
+	gsa.Spec.Priorities = []Priority{
+		{Type: GameServerPriorityLabel, Key: "build"},
+		{Type: GameServerPriorityPlayers},
+	}
+	causes, ok = gsa.Validate()
+	assert.False(t, ok)
+	if assert.Len(t, causes, 3) {
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+		assert.Equal(t, "spec.priorities[0].type", causes[0].Field)
+		assert.Equal(t, "spec.priorities[1].type", causes[1].Field)
+		assert.Equal(t, "spec.priorities[1].type", causes[2].Field)
+	}
 
-	gs := &agonesv1.GameServer{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}}}
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureAllocationPriorities)+"=true&"+string(runtime.FeaturePlayerTracking)+"=true"))
+	causes, ok = gsa.Validate()
+	assert.True(t, ok)
+	assert.Empty(t, causes)
 
-	for _, s := range selectors {
-		assert.False(t, s.Matches(labels.Set(gs.ObjectMeta.Labels)))
+	gsa.Spec.Priorities = []Priority{
+		{Type: GameServerPriorityAnnotation, Key: "tier", ValueType: GameServerPriorityInt},
+		{Type: GameServerPriorityAnnotation, Key: "tier", ValueType: "Float"},
+		{Type: GameServerPriorityPlayers, ValueType: GameServerPriorityInt},
+		{Type: GameServerPriorityCounter, Key: "rooms"},
 	}
+	causes, ok = gsa.Validate()
+	assert.False(t, ok)
+	if assert.Len(t, causes, 3) {
+		assert.Equal(t, metav1.CauseTypeFieldValueInvalid, causes[0].Type)
+		assert.Equal(t, "spec.priorities[1].valueType", causes[0].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[1].Type)
+		assert.Equal(t, "spec.priorities[2].valueType", causes[1].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[2].Type)
+		assert.Equal(t, "spec.priorities[3].type", causes[2].Field)
+	}
+}
 
-	gs.ObjectMeta.Labels["check"] = "blue"
-	assert.True(t, selectors[0].Matches(labels.Set(gs.ObjectMeta.Labels)))
-	assert.False(t, selectors[1].Matches(labels.Set(gs.ObjectMeta.Labels)))
+func TestGameServerAllocationValidateWebhook(t *testing.T) {
+	t.Parallel()
 
-	gs.ObjectMeta.Labels["check"] = "red"
-	assert.False(t, selectors[0].Matches(labels.Set(gs.ObjectMeta.Labels)))
-	assert.True(t, selectors[1].Matches(labels.Set(gs.ObjectMeta.Labels)))
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	u := "http://localhost:8000/allocate"
+	gsa := &GameServerAllocation{Spec: GameServerAllocationSpec{
+		Webhook: &AllocationWebhook{WebhookClientConfig: admregv1.WebhookClientConfig{URL: &u}},
+	}}
+
+	assert.NoError(t, runtime.ParseFeatures(""))
+	gsa.ApplyDefaults()
+	assert.Nil(t, gsa.Spec.Webhook.TimeoutSeconds)
+	causes, ok := gsa.Validate()
+	assert.False(t, ok)
+	if assert.Len(t, causes, 1) {
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+		assert.Equal(t, "spec.webhook", causes[0].Field)
+	}
+
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureAllocationWebhook)+"=true"))
+	gsa.ApplyDefaults()
+	assert.Equal(t, int32(5), *gsa.Spec.Webhook.TimeoutSeconds)
+	assert.Equal(t, admregv1.Fail, *gsa.Spec.Webhook.FailurePolicy)
+	causes, ok = gsa.Validate()
+	assert.True(t, ok)
+	assert.Empty(t, causes)
+
+	timeout := int32(31)
+	policy := admregv1.FailurePolicyType("Sometimes")
+	gsa.Spec.Webhook = &AllocationWebhook{
+		WebhookClientConfig: admregv1.WebhookClientConfig{
+			URL:      &u,
+			Service:  &admregv1.ServiceReference{Name: "hook"},
+			CABundle: []byte("nope"),
+		},
+		TimeoutSeconds: &timeout,
+		FailurePolicy:  &policy,
+	}
+	causes, ok = gsa.Validate()
+	assert.False(t, ok)
+	if assert.Len(t, causes, 4) {
+		assert.Equal(t, "spec.webhook.url", causes[0].Field)
+		assert.Equal(t, "spec.webhook.caBundle", causes[1].Field)
+		assert.Equal(t, "spec.webhook.timeoutSeconds", causes[2].Field)
+		assert.Equal(t, "spec.webhook.failurePolicy", causes[3].Field)
+	}
+
+	gsa.Spec.Webhook = &AllocationWebhook{WebhookClientConfig: admregv1.WebhookClientConfig{Service: &admregv1.ServiceReference{}}}
+	causes, ok = gsa.Validate()
+	assert.False(t, ok)
+	if assert.Len(t, causes, 1) {
+		assert.Equal(t, "spec.webhook.service.name", causes[0].Field)
+	}
+}
+
+func TestGameServerAllocationValidateLatencies(t *testing.T) {
+	t.Parallel()
+
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	gsa := &GameServerAllocation{Spec: GameServerAllocationSpec{
+		Scheduling: apis.Packed,
+		MultiClusterSetting: MultiClusterSetting{
+			Enabled:          true,
+			Latencies:        []LatencyMeasurement{{Name: "europe-west1", LatencyMillis: 30}},
+			MaxLatencyMillis: 100,
+		},
+	}}
+
+	assert.NoError(t, runtime.ParseFeatures(""))
+	causes, ok := gsa.Validate()
+	assert.False(t, ok)
+	if assert.Len(t, causes, 2) {
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+		assert.Equal(t, "spec.multiClusterSetting.latencies", causes[0].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[1].Type)
+		assert.Equal(t, "spec.multiClusterSetting.maxLatencyMillis", causes[1].Field)
+	}
+
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureAllocationLatency)+"=true"))
+	causes, ok = gsa.Validate()
+	assert.True(t, ok)
+	assert.Empty(t, causes)
+
+	gsa.Spec.MultiClusterSetting.Latencies = []LatencyMeasurement{{Name: "europe-west1", LatencyMillis: 30}, {LatencyMillis: -1}}
+	gsa.Spec.MultiClusterSetting.MaxLatencyMillis = -1
+	causes, ok = gsa.Validate()
+	assert.False(t, ok)
+	if assert.Len(t, causes, 3) {
+		assert.Equal(t, "spec.multiClusterSetting.latencies[1].name", causes[0].Field)
+		assert.Equal(t, "spec.multiClusterSetting.latencies[1].latencyMillis", causes[1].Field)
+		assert.Equal(t, "spec.multiClusterSetting.maxLatencyMillis", causes[2].Field)
+	}
 }
 
 func TestGameServerAllocationValidate(t *testing.T) {
@@ -22,10 +22,53 @@ package v1
 
 import (
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllocationWebhook) DeepCopyInto(out *AllocationWebhook) {
+	*out = *in
+	in.WebhookClientConfig.DeepCopyInto(&out.WebhookClientConfig)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(admissionregistrationv1.FailurePolicyType)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllocationWebhook.
+func (in *AllocationWebhook) DeepCopy() *AllocationWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(AllocationWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CounterSelector) DeepCopyInto(out *CounterSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CounterSelector.
+func (in *CounterSelector) DeepCopy() *CounterSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(CounterSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GameServerAllocation) DeepCopyInto(out *GameServerAllocation) {
 	*out = *in
@@ -87,6 +130,71 @@ func (in *GameServerAllocationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerAllocationReview) DeepCopyInto(out *GameServerAllocationReview) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(GameServerAllocationReviewRequest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(GameServerAllocationReviewResponse)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerAllocationReview.
+func (in *GameServerAllocationReview) DeepCopy() *GameServerAllocationReview {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerAllocationReview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerAllocationReviewRequest) DeepCopyInto(out *GameServerAllocationReviewRequest) {
+	*out = *in
+	in.MetaPatch.DeepCopyInto(&out.MetaPatch)
+	if in.GameServer != nil {
+		in, out := &in.GameServer, &out.GameServer
+		*out = new(agonesv1.GameServer)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerAllocationReviewRequest.
+func (in *GameServerAllocationReviewRequest) DeepCopy() *GameServerAllocationReviewRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerAllocationReviewRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerAllocationReviewResponse) DeepCopyInto(out *GameServerAllocationReviewResponse) {
+	*out = *in
+	in.MetaPatch.DeepCopyInto(&out.MetaPatch)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerAllocationReviewResponse.
+func (in *GameServerAllocationReviewResponse) DeepCopy() *GameServerAllocationReviewResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerAllocationReviewResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GameServerAllocationSpec) DeepCopyInto(out *GameServerAllocationSpec) {
 	*out = *in
@@ -94,12 +202,22 @@ func (in *GameServerAllocationSpec) DeepCopyInto(out *GameServerAllocationSpec)
 	in.Required.DeepCopyInto(&out.Required)
 	if in.Preferred != nil {
 		in, out := &in.Preferred, &out.Preferred
-		*out = make([]metav1.LabelSelector, len(*in))
+		*out = make([]GameServerSelector, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Priorities != nil {
+		in, out := &in.Priorities, &out.Priorities
+		*out = make([]Priority, len(*in))
+		copy(*out, *in)
+	}
 	in.MetaPatch.DeepCopyInto(&out.MetaPatch)
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(AllocationWebhook)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -121,6 +239,20 @@ func (in *GameServerAllocationStatus) DeepCopyInto(out *GameServerAllocationStat
 		*out = make([]agonesv1.GameServerStatusPort, len(*in))
 		copy(*out, *in)
 	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(GameServerMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Players != nil {
+		in, out := &in.Players, &out.Players
+		*out = new(agonesv1.PlayerStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllocationTimestamp != nil {
+		in, out := &in.AllocationTimestamp, &out.AllocationTimestamp
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -134,6 +266,109 @@ func (in *GameServerAllocationStatus) DeepCopy() *GameServerAllocationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerMetadata) DeepCopyInto(out *GameServerMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerMetadata.
+func (in *GameServerMetadata) DeepCopy() *GameServerMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerSelector) DeepCopyInto(out *GameServerSelector) {
+	*out = *in
+	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
+	if in.GameServerState != nil {
+		in, out := &in.GameServerState, &out.GameServerState
+		*out = new(agonesv1.GameServerState)
+		**out = **in
+	}
+	if in.Players != nil {
+		in, out := &in.Players, &out.Players
+		*out = new(PlayerSelector)
+		**out = **in
+	}
+	if in.Counters != nil {
+		in, out := &in.Counters, &out.Counters
+		*out = make(map[string]CounterSelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Lists != nil {
+		in, out := &in.Lists, &out.Lists
+		*out = make(map[string]ListSelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerSelector.
+func (in *GameServerSelector) DeepCopy() *GameServerSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatencyMeasurement) DeepCopyInto(out *LatencyMeasurement) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatencyMeasurement.
+func (in *LatencyMeasurement) DeepCopy() *LatencyMeasurement {
+	if in == nil {
+		return nil
+	}
+	out := new(LatencyMeasurement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListSelector) DeepCopyInto(out *ListSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListSelector.
+func (in *ListSelector) DeepCopy() *ListSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ListSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetaPatch) DeepCopyInto(out *MetaPatch) {
 	*out = *in
@@ -168,6 +403,11 @@ func (in *MetaPatch) DeepCopy() *MetaPatch {
 func (in *MultiClusterSetting) DeepCopyInto(out *MultiClusterSetting) {
 	*out = *in
 	in.PolicySelector.DeepCopyInto(&out.PolicySelector)
+	if in.Latencies != nil {
+		in, out := &in.Latencies, &out.Latencies
+		*out = make([]LatencyMeasurement, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -180,3 +420,35 @@ func (in *MultiClusterSetting) DeepCopy() *MultiClusterSetting {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlayerSelector) DeepCopyInto(out *PlayerSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlayerSelector.
+func (in *PlayerSelector) DeepCopy() *PlayerSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(PlayerSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Priority) DeepCopyInto(out *Priority) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Priority.
+func (in *Priority) DeepCopy() *Priority {
+	if in == nil {
+		return nil
+	}
+	out := new(Priority)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -16,11 +16,16 @@ package v1
 
 import (
 	"crypto/x509"
+	"fmt"
 	"net/url"
+	"time"
 
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
+	"agones.dev/agones/pkg/util/cron"
+	"agones.dev/agones/pkg/util/runtime"
 	admregv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -49,12 +54,160 @@ type FleetAutoscalerList struct {
 
 // FleetAutoscalerSpec is the spec for a Fleet Scaler
 type FleetAutoscalerSpec struct {
-	FleetName string `json:"fleetName"`
+	// FleetName is the name of the Fleet scaled by the autoscaler. Required, unless FleetSelector is set.
+	// +optional
+	FleetName string `json:"fleetName,omitempty"`
 
 	// Autoscaling policy
 	Policy FleetAutoscalerPolicy `json:"policy"`
+
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscalerBehavior]
+	// Behavior limits how fast the autoscaler scales the fleet up and down.
+	// If not set, the desired replicas computed by the policy are applied straight away.
+	// +optional
+	Behavior *FleetAutoscalerBehavior `json:"behavior,omitempty"`
+
+	// [Stage:Alpha]
+	// [FeatureFlag:CustomFasSyncInterval]
+	// Sync defines when the autoscaler computes the desired replicas of the fleet.
+	// If not set, it is synced along with all the other FleetAutoscalers, every 30 seconds.
+	// +optional
+	Sync *FleetAutoscalerSync `json:"sync,omitempty"`
+
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscalerDryRun]
+	// Mode of the autoscaler, either Scale or DryRun. In DryRun mode, the autoscaler computes
+	// and publishes the desired replicas of the fleet, but does not scale it.
+	// Defaults to Scale.
+	// +optional
+	Mode FleetAutoscalerMode `json:"mode,omitempty"`
+
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscalerBudget]
+	// FleetSelector selects the Fleets in the namespace of the autoscaler that it scales, instead of FleetName.
+	// The policy computes the desired replicas of each of the selected Fleets, which then share the Budget, if set.
+	// +optional
+	FleetSelector *metav1.LabelSelector `json:"fleetSelector,omitempty"`
+
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscalerBudget]
+	// Budget limits the total replicas of the Fleets selected by FleetSelector, and divides them between these Fleets.
+	// If not set, each of the selected Fleets is scaled to the desired replicas computed by the policy.
+	// +optional
+	Budget *FleetAutoscalerBudget `json:"budget,omitempty"`
+}
+
+// FleetAutoscalerBudget is the total replicas shared by the Fleets selected by a FleetAutoscaler.
+// The replicas that are Allocated or Reserved in each Fleet are always kept. The rest of the budget goes to
+// the Fleets with the highest priority first, and is divided between Fleets of the same priority
+// in proportion to how many more replicas the policy wants for them.
+type FleetAutoscalerBudget struct {
+	// MaxReplicas is the maximum total amount of replicas that the selected Fleets may have
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Priorities of the selected Fleets. Fleets that are not listed have a priority of 0.
+	// +optional
+	Priorities []FleetPriority `json:"priorities,omitempty"`
+}
+
+// FleetPriority is the priority of a Fleet in the budget of a FleetAutoscaler
+type FleetPriority struct {
+	// FleetName is the name of the Fleet
+	FleetName string `json:"fleetName"`
+
+	// Priority of the Fleet. The Fleets with the highest priority get their replicas first.
+	Priority int32 `json:"priority"`
 }
 
+// FleetAutoscalerMode is the mode of a FleetAutoscaler
+type FleetAutoscalerMode string
+
+const (
+	// ScaleFleetAutoscalerMode scales the fleet to the desired replicas
+	ScaleFleetAutoscalerMode FleetAutoscalerMode = "Scale"
+	// DryRunFleetAutoscalerMode only records the desired replicas in the status of the autoscaler,
+	// without scaling the fleet
+	DryRunFleetAutoscalerMode FleetAutoscalerMode = "DryRun"
+)
+
+// FleetAutoscalerSync describes when to sync a FleetAutoscaler
+type FleetAutoscalerSync struct {
+	// Type of autoscaling sync.
+	Type FleetAutoscalerSyncType `json:"type"`
+
+	// FixedInterval config params. Present only if FleetAutoscalerSyncType = FixedInterval.
+	// +optional
+	FixedInterval FixedIntervalSync `json:"fixedInterval"`
+
+	// OnAllocation, if set, also syncs the autoscaler as soon as the allocated replicas of the fleet change.
+	// +optional
+	OnAllocation *OnAllocationSync `json:"onAllocation,omitempty"`
+}
+
+// FleetAutoscalerSyncType is the sync strategy for a given Fleet
+type FleetAutoscalerSyncType string
+
+const (
+	// FixedIntervalSyncType is a simple fixed interval based strategy for trigger autoscaling
+	FixedIntervalSyncType FleetAutoscalerSyncType = "FixedInterval"
+)
+
+// FixedIntervalSync controls the desired behavior of the fixed interval based sync.
+type FixedIntervalSync struct {
+	// Seconds defines how often the autoscaler syncs, in seconds. Must be bigger than 0.
+	Seconds int32 `json:"seconds"`
+}
+
+// OnAllocationSync controls syncing a FleetAutoscaler when the allocated replicas of its fleet change
+type OnAllocationSync struct {
+	// DebounceMilliseconds is how long the autoscaler waits after a change to the allocated replicas before
+	// it syncs, so that it syncs once for a burst of allocations. Must be between 0 and 10000.
+	// +optional
+	DebounceMilliseconds int32 `json:"debounceMilliseconds,omitempty"`
+}
+
+// FleetAutoscalerBehavior limits how fast a FleetAutoscaler scales its fleet, to avoid
+// churning GameServers when the demand goes up and down in short bursts
+type FleetAutoscalerBehavior struct {
+	// ScaleUp limits how many replicas may be added to the fleet within a period.
+	// +optional
+	ScaleUp *ScalingRateLimit `json:"scaleUp,omitempty"`
+
+	// ScaleDown limits how many replicas may be removed from the fleet within a period.
+	// +optional
+	ScaleDown *ScalingRateLimit `json:"scaleDown,omitempty"`
+
+	// ScaleDownStabilizationWindowSeconds is how far back the autoscaler looks when scaling down.
+	// The fleet is only scaled down to the highest desired replicas computed by the policy during the window.
+	// Must be between 0 and 3600. If zero, the fleet is scaled down straight away.
+	// +optional
+	ScaleDownStabilizationWindowSeconds int32 `json:"scaleDownStabilizationWindowSeconds,omitempty"`
+}
+
+// ScalingRateLimit is the maximum change to the replicas of a fleet within a period
+type ScalingRateLimit struct {
+	// MaxChange is the maximum number of replicas added or removed within the period.
+	// Value can be an absolute number (ex: 5) or a percentage of the replicas of the fleet
+	// at the start of the period (ex: 50%). Must be bigger than 0.
+	MaxChange intstr.IntOrString `json:"maxChange"`
+
+	// PeriodSeconds is the length of the period. Must be between 1 and 1800.
+	PeriodSeconds int32 `json:"periodSeconds"`
+}
+
+// FleetAutoscalerBehaviorLimit is the part of the behavior of a FleetAutoscaler that limited its desired replicas
+type FleetAutoscalerBehaviorLimit string
+
+const (
+	// ScaleUpRateLimit means the scale up was limited by the ScaleUp rate limit
+	ScaleUpRateLimit FleetAutoscalerBehaviorLimit = "ScaleUpRateLimit"
+	// ScaleDownRateLimit means the scale down was limited by the ScaleDown rate limit
+	ScaleDownRateLimit FleetAutoscalerBehaviorLimit = "ScaleDownRateLimit"
+	// ScaleDownStabilization means the scale down was limited by the scale down stabilization window
+	ScaleDownStabilization FleetAutoscalerBehaviorLimit = "ScaleDownStabilization"
+)
+
 // FleetAutoscalerPolicy describes how to scale a fleet
 type FleetAutoscalerPolicy struct {
 	// Type of autoscaling policy.
@@ -66,6 +219,31 @@ type FleetAutoscalerPolicy struct {
 	// Webhook policy config params. Present only if FleetAutoscalerPolicyType = Webhook.
 	// +optional
 	Webhook *WebhookPolicy `json:"webhook,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:ScheduledAutoscaler]
+	// Schedule policy config params. Present only if FleetAutoscalerPolicyType = Schedule.
+	// +optional
+	Schedule *SchedulePolicy `json:"schedule,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:ChainedAutoscaler]
+	// Chain policy config params. Present only if FleetAutoscalerPolicyType = Chain.
+	// +optional
+	Chain *ChainPolicy `json:"chain,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:PlayerBufferAutoscaler]
+	// PlayerBuffer policy config params. Present only if FleetAutoscalerPolicyType = PlayerBuffer.
+	// +optional
+	PlayerBuffer *PlayerBufferPolicy `json:"playerBuffer,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:PredictiveAutoscaler]
+	// Predictive policy config params. Present only if FleetAutoscalerPolicyType = Predictive.
+	// +optional
+	Predictive *PredictivePolicy `json:"predictive,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscalerWebhookTransport]
+	// WebhookTransport configures how the webhook is called. Used only if FleetAutoscalerPolicyType = Webhook.
+	// +optional
+	WebhookTransport *WebhookTransport `json:"webhookTransport,omitempty"`
 }
 
 // FleetAutoscalerPolicyType is the policy for autoscaling
@@ -79,6 +257,52 @@ const (
 	// WebhookPolicyType is a simple webhook strategy used for horizontal fleet scaling
 	// GameServers
 	WebhookPolicyType FleetAutoscalerPolicyType = "Webhook"
+	// SchedulePolicyType is a buffering strategy for Ready GameServers, that applies
+	// a different buffer policy during scheduled time windows
+	SchedulePolicyType FleetAutoscalerPolicyType = "Schedule"
+	// ChainPolicyType is a strategy that combines the desired replicas of
+	// an ordered list of other policies
+	ChainPolicyType FleetAutoscalerPolicyType = "Chain"
+	// PlayerBufferPolicyType is a buffering strategy for free player slots
+	// across the GameServers of the fleet
+	PlayerBufferPolicyType FleetAutoscalerPolicyType = "PlayerBuffer"
+	// PredictivePolicyType is a buffering strategy for Ready GameServers, that sizes
+	// the buffer by forecasting the allocations of the fleet from their history
+	PredictivePolicyType FleetAutoscalerPolicyType = "Predictive"
+)
+
+// PredictiveModel is the model used by a Predictive policy to forecast allocations
+type PredictiveModel string
+
+const (
+	// ExponentialSmoothingPredictiveModel forecasts allocations with simple exponential smoothing,
+	// which follows the recent allocation rate
+	ExponentialSmoothingPredictiveModel PredictiveModel = "ExponentialSmoothing"
+	// HoltWintersPredictiveModel forecasts allocations with additive Holt-Winters smoothing,
+	// which also follows the trend and the seasonality of the allocation rate
+	HoltWintersPredictiveModel PredictiveModel = "HoltWinters"
+)
+
+// WebhookProtocol is the protocol used to call the webhook of a Webhook policy
+type WebhookProtocol string
+
+const (
+	// HTTPWebhookProtocol posts a FleetAutoscaleReview as JSON to the webhook
+	HTTPWebhookProtocol WebhookProtocol = "HTTP"
+	// GRPCWebhookProtocol calls the Autoscale method of the AutoscalerService gRPC service of the webhook
+	GRPCWebhookProtocol WebhookProtocol = "GRPC"
+)
+
+// ChainCombineRule is how the desired replicas of the policies of a Chain policy are combined
+type ChainCombineRule string
+
+const (
+	// FirstMatchCombineRule uses the first policy of the chain that computes the desired replicas without an error
+	FirstMatchCombineRule ChainCombineRule = "FirstMatch"
+	// MaxCombineRule uses the policy of the chain with the highest desired replicas
+	MaxCombineRule ChainCombineRule = "Max"
+	// MinCombineRule uses the policy of the chain with the lowest desired replicas
+	MinCombineRule ChainCombineRule = "Min"
 )
 
 // BufferPolicy controls the desired behavior of the buffer policy.
@@ -105,11 +329,167 @@ type BufferPolicy struct {
 	BufferSize intstr.IntOrString `json:"bufferSize"`
 }
 
+// PlayerBufferPolicy controls the desired behavior of the player buffer policy.
+// It sizes the fleet by the free player slots of its GameServers, rather than by its Ready GameServers,
+// using the initial player capacity of the GameServer template of the fleet to convert
+// player slots into replicas.
+type PlayerBufferPolicy struct {
+	// MaxReplicas is the maximum amount of replicas that the fleet may have.
+	// It must be bigger than MinReplicas
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// MinReplicas is the minimum amount of replicas that the fleet must have
+	// If zero, it is ignored.
+	// If non zero, it must be smaller than MaxReplicas
+	MinReplicas int32 `json:"minReplicas"`
+
+	// BufferSize defines how many free player slots the autoscaler tries to have across the fleet all the time
+	// Value can be an absolute number (ex: 50) or a percentage of the player capacity of the fleet (ex: 15%)
+	// Example: when this is set to 20%, the autoscaler will make sure that 20%
+	//   of the player capacity of the fleet is free. When this is set to 50,
+	//   the autoscaler will make sure that there are 50 free player slots
+	// Must be bigger than 0
+	BufferSize intstr.IntOrString `json:"bufferSize"`
+}
+
+// PredictivePolicy controls the desired behavior of the predictive policy.
+// The controller counts the allocations of each fleet per minute, over the last 48 hours,
+// and the policy forecasts the allocations of the fleet over the next LeadTimeSeconds from them.
+// The fleet is then sized to its allocated replicas, plus the forecast allocations and the SafetyMargin.
+type PredictivePolicy struct {
+	// MaxReplicas is the maximum amount of replicas that the fleet may have.
+	// It must be bigger than MinReplicas
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// MinReplicas is the minimum amount of replicas that the fleet must have
+	// If zero, it is ignored.
+	// If non zero, it must be smaller than MaxReplicas
+	MinReplicas int32 `json:"minReplicas"`
+
+	// LeadTimeSeconds is how far ahead the allocations are forecast, which should be at least
+	// how long it takes for a new GameServer of the fleet to be Ready. From 1 to 3600.
+	LeadTimeSeconds int32 `json:"leadTimeSeconds"`
+
+	// SafetyMargin is how many Ready GameServers are kept on top of the forecast allocations.
+	// Value can be an absolute number (ex: 5) or a percentage of the forecast allocations (ex: 20%)
+	// +optional
+	SafetyMargin intstr.IntOrString `json:"safetyMargin,omitempty"`
+
+	// Model is the model used to forecast the allocations, either ExponentialSmoothing or HoltWinters.
+	// Defaults to ExponentialSmoothing.
+	// +optional
+	Model PredictiveModel `json:"model,omitempty"`
+
+	// LevelSmoothingPercent is how much the latest allocation rate weighs in the forecast, from 1 to 100.
+	// Defaults to 50.
+	// +optional
+	LevelSmoothingPercent int32 `json:"levelSmoothingPercent,omitempty"`
+
+	// TrendSmoothingPercent is how much the latest trend of the allocation rate weighs in the forecast
+	// of the HoltWinters model, from 1 to 100. Defaults to 10.
+	// +optional
+	TrendSmoothingPercent int32 `json:"trendSmoothingPercent,omitempty"`
+
+	// SeasonalSmoothingPercent is how much the latest season weighs in the seasonality of the forecast
+	// of the HoltWinters model, from 1 to 100. Defaults to 10.
+	// +optional
+	SeasonalSmoothingPercent int32 `json:"seasonalSmoothingPercent,omitempty"`
+
+	// SeasonLengthMinutes is the length of the season of the allocation rate for the HoltWinters model,
+	// from 2 to 1440 (a day). Required for the HoltWinters model. Until two seasons of allocations
+	// have been counted, the allocations are forecast with exponential smoothing.
+	// +optional
+	SeasonLengthMinutes int32 `json:"seasonLengthMinutes,omitempty"`
+}
+
 // WebhookPolicy controls the desired behavior of the webhook policy.
 // It contains the description of the webhook autoscaler service
 // used to form url which is accessible inside the cluster
 type WebhookPolicy admregv1.WebhookClientConfig
 
+// WebhookTransport controls how the webhook of a Webhook policy is called
+type WebhookTransport struct {
+	// Protocol is the protocol used to call the webhook. One of HTTP or GRPC. Defaults to HTTP.
+	// +optional
+	Protocol WebhookProtocol `json:"protocol,omitempty"`
+
+	// ClientCertSecret is the name of a Secret in the namespace of the FleetAutoscaler, with the client
+	// certificate and key ("tls.crt" and "tls.key") to authenticate to the webhook with mutual TLS.
+	// If the Secret has a "ca.crt", it is used as well as the CABundle of the webhook to validate its certificate.
+	// +optional
+	ClientCertSecret string `json:"clientCertSecret,omitempty"`
+
+	// TimeoutSeconds is the timeout of the call to the webhook, retries included, from 1 to 60 seconds. Defaults to 15.
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Retries is how many times a failed call to the webhook is retried, from 0 to 5, as long as the timeout
+	// has not passed. Defaults to 0.
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+
+	// RetryBackoffMilliseconds is how long to wait before the first retry, which doubles for each of the
+	// following retries, from 1 to 10000 milliseconds. Defaults to 100.
+	// +optional
+	RetryBackoffMilliseconds int32 `json:"retryBackoffMilliseconds,omitempty"`
+}
+
+// SchedulePolicy controls the desired behavior of the schedule policy.
+// It applies the buffer policy of the active time window, or the default buffer policy
+// when there is no active time window.
+type SchedulePolicy struct {
+	// Default is the buffer policy that applies when none of the windows are active
+	Default BufferPolicy `json:"default"`
+
+	// Windows are the recurring time windows during which a different buffer policy applies.
+	// If several windows are active at the same time, the first one in the list applies.
+	// +optional
+	Windows []ScheduleWindow `json:"windows,omitempty"`
+}
+
+// ScheduleWindow is a recurring time window, with the buffer policy that applies during it
+type ScheduleWindow struct {
+	// Name of the window, which is shown in the status of the FleetAutoscaler while the window is active
+	Name string `json:"name"`
+
+	// Start is when the window starts, in cron syntax. For example "0 18 * * FRI" starts the window
+	// at 18:00 every Friday.
+	Start string `json:"start"`
+
+	// Duration is how long the window lasts after each start, for example "4h"
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone of Start, for example "America/New_York". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Buffer is the buffer policy that applies while the window is active
+	Buffer BufferPolicy `json:"buffer"`
+}
+
+// ChainPolicy controls the desired behavior of the chain policy.
+// The policies that fail to compute the desired replicas, such as a webhook that is unreachable,
+// are skipped, and the chain only fails if all of its policies fail.
+type ChainPolicy struct {
+	// Combine is how the desired replicas of the policies are combined. One of FirstMatch, Max or Min.
+	// Defaults to FirstMatch.
+	// +optional
+	Combine ChainCombineRule `json:"combine,omitempty"`
+
+	// Policies are the policies of the chain, in order. They may not be Chain policies themselves.
+	Policies []ChainEntry `json:"policies"`
+}
+
+// ChainEntry is a single policy of a Chain policy
+type ChainEntry struct {
+	// ID of the policy in the chain, which is shown in the status of the FleetAutoscaler
+	// when it produced the desired replicas
+	ID string `json:"id"`
+
+	// Policy is the autoscaling policy
+	FleetAutoscalerPolicy `json:",inline"`
+}
+
 // FleetAutoscalerStatus defines the current status of a FleetAutoscaler
 type FleetAutoscalerStatus struct {
 	// CurrentReplicas is the current number of gameserver replicas
@@ -130,6 +510,65 @@ type FleetAutoscalerStatus struct {
 	// ScalingLimited indicates that the calculated scale would be above or below the range
 	// defined by MinReplicas and MaxReplicas, and has thus been capped.
 	ScalingLimited bool `json:"scalingLimited"`
+
+	// [Stage:Alpha]
+	// [FeatureFlag:ScheduledAutoscaler]
+	// ActiveScheduleWindow is the name of the window of the Schedule policy that applied
+	// when the autoscaler last calculated the desired replicas, if any
+	// +optional
+	ActiveScheduleWindow string `json:"activeScheduleWindow,omitempty"`
+
+	// [Stage:Alpha]
+	// [FeatureFlag:ChainedAutoscaler]
+	// ActiveChainEntry is the ID of the policy of the Chain policy that produced the desired replicas
+	// when the autoscaler last calculated them, if any
+	// +optional
+	ActiveChainEntry string `json:"activeChainEntry,omitempty"`
+
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscalerBehavior]
+	// RecommendedReplicas is the number of replicas computed by the policy, before the behavior
+	// of the autoscaler was applied to get DesiredReplicas
+	// +optional
+	RecommendedReplicas int32 `json:"recommendedReplicas,omitempty"`
+
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscalerBehavior]
+	// BehaviorLimit is the part of the behavior of the autoscaler that made DesiredReplicas
+	// differ from RecommendedReplicas, if any
+	// +optional
+	BehaviorLimit FleetAutoscalerBehaviorLimit `json:"behaviorLimit,omitempty"`
+
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscalerDryRun]
+	// DryRun indicates that DesiredReplicas is only a recommendation, which was not applied to the fleet
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscalerBudget]
+	// Fleets is the status of each of the Fleets selected by FleetSelector, when the autoscaler last calculated
+	// their desired replicas. CurrentReplicas and DesiredReplicas are then the totals of these Fleets.
+	// +optional
+	Fleets []FleetAutoscalerFleetStatus `json:"fleets,omitempty"`
+}
+
+// FleetAutoscalerFleetStatus is the status of one of the Fleets selected by a FleetAutoscaler
+type FleetAutoscalerFleetStatus struct {
+	// FleetName is the name of the Fleet
+	FleetName string `json:"fleetName"`
+
+	// CurrentReplicas is the current number of gameserver replicas of the Fleet
+	CurrentReplicas int32 `json:"currentReplicas"`
+
+	// DesiredReplicas is the desired number of gameserver replicas of the Fleet, within its share of the budget
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// DemandReplicas is the number of replicas computed by the policy for the Fleet, before the budget was divided
+	DemandReplicas int32 `json:"demandReplicas"`
+
+	// ScalingLimited indicates that DesiredReplicas was capped, by the policy or by the budget
+	ScalingLimited bool `json:"scalingLimited"`
 }
 
 // FleetAutoscaleRequest defines the request to webhook autoscaler endpoint
@@ -145,6 +584,42 @@ type FleetAutoscaleRequest struct {
 	Namespace string `json:"namespace"`
 	// The Fleet's status values
 	Status agonesv1.FleetStatus `json:"status"`
+
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscaleRequestDetails]
+	// Labels are the labels of the Fleet
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscaleRequestDetails]
+	// Annotations are the annotations of the Fleet
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscaleRequestDetails]
+	// AutoscalerSpec is the spec of the FleetAutoscaler, with the bounds of its policy and behavior
+	// +optional
+	AutoscalerSpec *FleetAutoscalerSpec `json:"autoscalerSpec,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscaleRequestDetails]
+	// GameServerStates is the number of GameServers of the Fleet in each state, including the states
+	// that are not counted in the status of the Fleet, such as Scheduled, Starting or Unhealthy
+	// +optional
+	GameServerStates map[agonesv1.GameServerState]int32 `json:"gameServerStates,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:FleetAutoscaleRequestDetails]
+	// AllocationRate is the number of GameServers of the Fleet that were recently allocated
+	// +optional
+	AllocationRate *FleetAllocationRate `json:"allocationRate,omitempty"`
+}
+
+// FleetAllocationRate is the number of GameServers of a Fleet that were recently allocated,
+// as observed by the controller since it started
+type FleetAllocationRate struct {
+	// LastMinute is the number of GameServers allocated within the last minute
+	LastMinute int32 `json:"lastMinute"`
+	// LastFiveMinutes is the number of GameServers allocated within the last five minutes
+	LastFiveMinutes int32 `json:"lastFiveMinutes"`
 }
 
 // FleetAutoscaleResponse defines the response of webhook autoscaler endpoint
@@ -167,16 +642,461 @@ type FleetAutoscaleReview struct {
 
 // Validate validates the FleetAutoscaler scaling settings
 func (fas *FleetAutoscaler) Validate(causes []metav1.StatusCause) []metav1.StatusCause {
-	switch fas.Spec.Policy.Type {
+	causes = fas.Spec.Policy.ValidatePolicy(causes)
+	causes = fas.validateFleets(causes)
+	if fas.Spec.Behavior != nil {
+		if !runtime.FeatureEnabled(runtime.FeatureFleetAutoscalerBehavior) {
+			return append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   "behavior",
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureFleetAutoscalerBehavior),
+			})
+		}
+		causes = fas.Spec.Behavior.ValidateBehavior(causes)
+	}
+	if fas.Spec.Sync != nil {
+		if !runtime.FeatureEnabled(runtime.FeatureCustomFasSyncInterval) {
+			return append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   "sync",
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureCustomFasSyncInterval),
+			})
+		}
+		causes = fas.Spec.Sync.ValidateSync(causes)
+	}
+	if fas.Spec.Mode != "" {
+		if !runtime.FeatureEnabled(runtime.FeatureFleetAutoscalerDryRun) {
+			return append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   "mode",
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureFleetAutoscalerDryRun),
+			})
+		}
+		if fas.Spec.Mode != ScaleFleetAutoscalerMode && fas.Spec.Mode != DryRunFleetAutoscalerMode {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   "mode",
+				Message: fmt.Sprintf("mode must be %s or %s", ScaleFleetAutoscalerMode, DryRunFleetAutoscalerMode),
+			})
+		}
+	}
+	return causes
+}
+
+// validateFleets validates that the FleetAutoscaler scales either the Fleet with FleetName,
+// or the Fleets selected by FleetSelector, and its Budget
+func (fas *FleetAutoscaler) validateFleets(causes []metav1.StatusCause) []metav1.StatusCause {
+	if fas.Spec.FleetSelector == nil && fas.Spec.Budget == nil {
+		if fas.Spec.FleetName == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Field:   "fleetName",
+				Message: "fleetName is required",
+			})
+		}
+		return causes
+	}
+	if !runtime.FeatureEnabled(runtime.FeatureFleetAutoscalerBudget) {
+		field := "fleetSelector"
+		if fas.Spec.FleetSelector == nil {
+			field = "budget"
+		}
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Field:   field,
+			Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureFleetAutoscalerBudget),
+		})
+	}
+	if fas.Spec.FleetSelector == nil {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "budget",
+			Message: "budget can only be set with fleetSelector",
+		})
+	}
+	if fas.Spec.FleetName != "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "fleetName",
+			Message: "fleetName cannot be set with fleetSelector",
+		})
+	}
+	if _, err := metav1.LabelSelectorAsSelector(fas.Spec.FleetSelector); err != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "fleetSelector",
+			Message: fmt.Sprintf("fleetSelector is invalid: %s", err),
+		})
+	}
+	if fas.Spec.Budget != nil {
+		causes = fas.Spec.Budget.ValidateBudget(causes)
+	}
+	return causes
+}
+
+// ValidateBudget validates the FleetAutoscaler budget settings
+func (b *FleetAutoscalerBudget) ValidateBudget(causes []metav1.StatusCause) []metav1.StatusCause {
+	if b.MaxReplicas < 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "budget.maxReplicas",
+			Message: "maxReplicas must be bigger than 0",
+		})
+	}
+	names := map[string]bool{}
+	for _, p := range b.Priorities {
+		if p.FleetName == "" || names[p.FleetName] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "budget.priorities",
+				Message: "the fleetName of each priority must be set and unique",
+			})
+			break
+		}
+		names[p.FleetName] = true
+	}
+	return causes
+}
+
+// Priority returns the priority of the Fleet with the given name in the budget
+func (b *FleetAutoscalerBudget) Priority(fleetName string) int32 {
+	for _, p := range b.Priorities {
+		if p.FleetName == fleetName {
+			return p.Priority
+		}
+	}
+	return 0
+}
+
+// ScalesFleet returns true if the FleetAutoscaler scales the given Fleet
+func (fas *FleetAutoscaler) ScalesFleet(f *agonesv1.Fleet) bool {
+	if f.ObjectMeta.Namespace != fas.ObjectMeta.Namespace {
+		return false
+	}
+	if fas.HasFleetSelector() {
+		selector, err := metav1.LabelSelectorAsSelector(fas.Spec.FleetSelector)
+		return err == nil && selector.Matches(labels.Set(f.ObjectMeta.Labels))
+	}
+	return fas.Spec.FleetName == f.ObjectMeta.Name
+}
+
+// HasFleetSelector returns true if the FleetAutoscaler scales the Fleets selected by its FleetSelector
+func (fas *FleetAutoscaler) HasFleetSelector() bool {
+	return fas.Spec.FleetSelector != nil && runtime.FeatureEnabled(runtime.FeatureFleetAutoscalerBudget)
+}
+
+// IsDryRun returns true if the FleetAutoscaler only records its desired replicas, without scaling its fleet
+func (fas *FleetAutoscaler) IsDryRun() bool {
+	return fas.Spec.Mode == DryRunFleetAutoscalerMode && runtime.FeatureEnabled(runtime.FeatureFleetAutoscalerDryRun)
+}
+
+// ValidateSync validates the FleetAutoscaler sync settings
+func (s *FleetAutoscalerSync) ValidateSync(causes []metav1.StatusCause) []metav1.StatusCause {
+	switch s.Type {
+	case FixedIntervalSyncType:
+		if s.FixedInterval.Seconds <= 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "seconds",
+				Message: "seconds should be bigger than 0",
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Field:   "type",
+			Message: fmt.Sprintf("sync type must be %s", FixedIntervalSyncType),
+		})
+	}
+	if s.OnAllocation != nil && (s.OnAllocation.DebounceMilliseconds < 0 || s.OnAllocation.DebounceMilliseconds > 10000) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "onAllocation.debounceMilliseconds",
+			Message: "debounceMilliseconds must be between 0 and 10000",
+		})
+	}
+	return causes
+}
+
+// ValidateBehavior validates the FleetAutoscaler behavior settings
+func (b *FleetAutoscalerBehavior) ValidateBehavior(causes []metav1.StatusCause) []metav1.StatusCause {
+	causes = b.ScaleUp.validateScalingRateLimit("scaleUp", causes)
+	causes = b.ScaleDown.validateScalingRateLimit("scaleDown", causes)
+	if b.ScaleDownStabilizationWindowSeconds < 0 || b.ScaleDownStabilizationWindowSeconds > 3600 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "scaleDownStabilizationWindowSeconds",
+			Message: "scaleDownStabilizationWindowSeconds must be between 0 and 3600",
+		})
+	}
+	return causes
+}
+
+// validateScalingRateLimit validates a scaling rate limit of the FleetAutoscaler behavior, if it is set
+func (l *ScalingRateLimit) validateScalingRateLimit(field string, causes []metav1.StatusCause) []metav1.StatusCause {
+	if l == nil {
+		return causes
+	}
+	if l.MaxChange.Type == intstr.Int {
+		if l.MaxChange.IntValue() <= 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   field + ".maxChange",
+				Message: "maxChange must be bigger than 0",
+			})
+		}
+	} else {
+		r, err := intstr.GetValueFromIntOrPercent(&l.MaxChange, 100, true)
+		if err != nil || r < 1 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   field + ".maxChange",
+				Message: "maxChange does not have a valid percentage value (bigger than 0%)",
+			})
+		}
+	}
+	if l.PeriodSeconds < 1 || l.PeriodSeconds > 1800 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   field + ".periodSeconds",
+			Message: "periodSeconds must be between 1 and 1800",
+		})
+	}
+	return causes
+}
+
+// ValidatePolicy validates the settings of the FleetAutoscaler policy of its type
+func (p *FleetAutoscalerPolicy) ValidatePolicy(causes []metav1.StatusCause) []metav1.StatusCause {
+	if p.WebhookTransport != nil {
+		if !runtime.FeatureEnabled(runtime.FeatureFleetAutoscalerWebhookTransport) {
+			return append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   "webhookTransport",
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureFleetAutoscalerWebhookTransport),
+			})
+		}
+		causes = p.WebhookTransport.ValidateWebhookTransport(causes)
+	}
+
+	switch p.Type {
 	case BufferPolicyType:
-		causes = fas.Spec.Policy.Buffer.ValidateBufferPolicy(causes)
+		causes = p.Buffer.ValidateBufferPolicy(causes)
 
 	case WebhookPolicyType:
-		causes = fas.Spec.Policy.Webhook.ValidateWebhookPolicy(causes)
+		causes = p.Webhook.ValidateWebhookPolicy(causes)
+
+	case SchedulePolicyType:
+		if !runtime.FeatureEnabled(runtime.FeatureScheduledAutoscaler) {
+			return append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   "type",
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureScheduledAutoscaler),
+			})
+		}
+		causes = p.Schedule.ValidateSchedulePolicy(causes)
+
+	case ChainPolicyType:
+		if !runtime.FeatureEnabled(runtime.FeatureChainedAutoscaler) {
+			return append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   "type",
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeatureChainedAutoscaler),
+			})
+		}
+		causes = p.Chain.ValidateChainPolicy(causes)
+
+	case PlayerBufferPolicyType:
+		if !runtime.FeatureEnabled(runtime.FeaturePlayerBufferAutoscaler) || !runtime.FeatureEnabled(runtime.FeaturePlayerTracking) {
+			return append(causes, metav1.StatusCause{
+				Type:  metav1.CauseTypeFieldValueNotSupported,
+				Field: "type",
+				Message: fmt.Sprintf("Value cannot be set unless feature flags %s and %s are enabled",
+					runtime.FeaturePlayerBufferAutoscaler, runtime.FeaturePlayerTracking),
+			})
+		}
+		causes = p.PlayerBuffer.ValidatePlayerBufferPolicy(causes)
+
+	case PredictivePolicyType:
+		if !runtime.FeatureEnabled(runtime.FeaturePredictiveAutoscaler) {
+			return append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   "type",
+				Message: fmt.Sprintf("Value cannot be set unless feature flag %s is enabled", runtime.FeaturePredictiveAutoscaler),
+			})
+		}
+		causes = p.Predictive.ValidatePredictivePolicy(causes)
+	}
+	return causes
+}
+
+// ValidateWebhookTransport validates the FleetAutoscaler webhook transport settings
+func (t *WebhookTransport) ValidateWebhookTransport(causes []metav1.StatusCause) []metav1.StatusCause {
+	switch t.Protocol {
+	case "", HTTPWebhookProtocol, GRPCWebhookProtocol:
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Field:   "webhookTransport.protocol",
+			Message: fmt.Sprintf("protocol must be one of %s or %s", HTTPWebhookProtocol, GRPCWebhookProtocol),
+		})
+	}
+	if t.TimeoutSeconds < 0 || t.TimeoutSeconds > 60 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "webhookTransport.timeoutSeconds",
+			Message: "timeoutSeconds must be between 1 and 60",
+		})
+	}
+	if t.Retries < 0 || t.Retries > 5 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "webhookTransport.retries",
+			Message: "retries must be between 0 and 5",
+		})
+	}
+	if t.RetryBackoffMilliseconds < 0 || t.RetryBackoffMilliseconds > 10000 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "webhookTransport.retryBackoffMilliseconds",
+			Message: "retryBackoffMilliseconds must be between 1 and 10000",
+		})
+	}
+	return causes
+}
+
+// ValidateChainPolicy validates the FleetAutoscaler Chain policy settings
+func (c *ChainPolicy) ValidateChainPolicy(causes []metav1.StatusCause) []metav1.StatusCause {
+	if c == nil {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "chain",
+			Message: "Chain policy config params are missing",
+		})
+	}
+	switch c.Combine {
+	case "", FirstMatchCombineRule, MaxCombineRule, MinCombineRule:
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Field:   "combine",
+			Message: fmt.Sprintf("combine must be one of %s, %s or %s", FirstMatchCombineRule, MaxCombineRule, MinCombineRule),
+		})
+	}
+	if len(c.Policies) == 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Field:   "policies",
+			Message: "at least one policy is required",
+		})
+	}
+
+	ids := map[string]bool{}
+	for i := range c.Policies {
+		entry := &c.Policies[i]
+		field := fmt.Sprintf("policies[%d]", i)
+		if entry.ID == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Field:   field + ".id",
+				Message: "id is required",
+			})
+		} else if ids[entry.ID] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Field:   field + ".id",
+				Message: fmt.Sprintf("id %s is used by more than one policy", entry.ID),
+			})
+		}
+		ids[entry.ID] = true
+		if entry.Type == ChainPolicyType {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Field:   field + ".type",
+				Message: "policies of a chain cannot be chains themselves",
+			})
+			continue
+		}
+		causes = entry.ValidatePolicy(causes)
+	}
+	return causes
+}
+
+// ValidateSchedulePolicy validates the FleetAutoscaler Schedule policy settings
+func (s *SchedulePolicy) ValidateSchedulePolicy(causes []metav1.StatusCause) []metav1.StatusCause {
+	if s == nil {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "schedule",
+			Message: "Schedule policy config params are missing",
+		})
+	}
+	causes = s.Default.ValidateBufferPolicy(causes)
+
+	names := map[string]bool{}
+	for i := range s.Windows {
+		w := &s.Windows[i]
+		field := fmt.Sprintf("windows[%d]", i)
+		if w.Name == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Field:   field + ".name",
+				Message: "name is required",
+			})
+		} else if names[w.Name] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Field:   field + ".name",
+				Message: fmt.Sprintf("name %s is used by more than one window", w.Name),
+			})
+		}
+		names[w.Name] = true
+		if _, err := cron.Parse(w.Start); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   field + ".start",
+				Message: err.Error(),
+			})
+		}
+		if w.Duration.Duration <= 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   field + ".duration",
+				Message: "duration must be bigger than 0",
+			})
+		}
+		if _, err := time.LoadLocation(w.TimeZone); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   field + ".timeZone",
+				Message: fmt.Sprintf("timeZone %s is not a valid time zone", w.TimeZone),
+			})
+		}
+		causes = w.Buffer.ValidateBufferPolicy(causes)
 	}
 	return causes
 }
 
+// ActiveWindow returns the first window of the policy that is active at the given time, or nil if there is none
+func (s *SchedulePolicy) ActiveWindow(now time.Time) *ScheduleWindow {
+	for i := range s.Windows {
+		w := &s.Windows[i]
+		schedule, err := cron.Parse(w.Start)
+		if err != nil {
+			continue
+		}
+		loc, err := time.LoadLocation(w.TimeZone)
+		if err != nil {
+			continue
+		}
+		// the window is active if it started within the last Duration
+		start := schedule.Next(now.In(loc).Add(-w.Duration.Duration))
+		if !start.IsZero() && !start.After(now) {
+			return w
+		}
+	}
+	return nil
+}
+
 // ValidateWebhookPolicy validates the FleetAutoscaler Webhook policy settings
 func (w *WebhookPolicy) ValidateWebhookPolicy(causes []metav1.StatusCause) []metav1.StatusCause {
 	if w == nil {
@@ -233,6 +1153,126 @@ func (w *WebhookPolicy) ValidateWebhookPolicy(causes []metav1.StatusCause) []met
 	return causes
 }
 
+// ValidatePlayerBufferPolicy validates the FleetAutoscaler PlayerBuffer policy settings
+func (b *PlayerBufferPolicy) ValidatePlayerBufferPolicy(causes []metav1.StatusCause) []metav1.StatusCause {
+	if b == nil {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "playerBuffer",
+			Message: "PlayerBuffer policy config params are missing",
+		})
+	}
+	if b.MinReplicas > b.MaxReplicas {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "minReplicas",
+			Message: "minReplicas is bigger than maxReplicas",
+		})
+	}
+	if b.BufferSize.Type == intstr.Int {
+		if b.BufferSize.IntValue() <= 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "bufferSize",
+				Message: "bufferSize must be bigger than 0",
+			})
+		}
+	} else {
+		r, err := intstr.GetValueFromIntOrPercent(&b.BufferSize, 100, true)
+		if err != nil || r < 1 || r > 99 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "bufferSize",
+				Message: "bufferSize does not have a valid percentage value (1%-99%)",
+			})
+		}
+		// a percentage of no player capacity is no free player slots,
+		// so an empty fleet would never scale up
+		if b.MinReplicas < 1 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "minReplicas",
+				Message: "minReplicas should be above 0 when used with percentage value bufferSize",
+			})
+		}
+	}
+	return causes
+}
+
+// ValidatePredictivePolicy validates the FleetAutoscaler Predictive policy settings
+func (pp *PredictivePolicy) ValidatePredictivePolicy(causes []metav1.StatusCause) []metav1.StatusCause {
+	if pp == nil {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "predictive",
+			Message: "Predictive policy config params are missing",
+		})
+	}
+	if pp.MinReplicas > pp.MaxReplicas {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "minReplicas",
+			Message: "minReplicas is bigger than maxReplicas",
+		})
+	}
+	if pp.LeadTimeSeconds < 1 || pp.LeadTimeSeconds > 3600 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Field:   "leadTimeSeconds",
+			Message: "leadTimeSeconds must be between 1 and 3600",
+		})
+	}
+	if pp.SafetyMargin.Type == intstr.Int {
+		if pp.SafetyMargin.IntValue() < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "safetyMargin",
+				Message: "safetyMargin must not be negative",
+			})
+		}
+	} else {
+		r, err := intstr.GetValueFromIntOrPercent(&pp.SafetyMargin, 100, true)
+		if err != nil || r < 0 || r > 1000 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "safetyMargin",
+				Message: "safetyMargin does not have a valid percentage value (0%-1000%)",
+			})
+		}
+	}
+	for field, percent := range map[string]int32{
+		"levelSmoothingPercent":    pp.LevelSmoothingPercent,
+		"trendSmoothingPercent":    pp.TrendSmoothingPercent,
+		"seasonalSmoothingPercent": pp.SeasonalSmoothingPercent,
+	} {
+		if percent < 0 || percent > 100 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   field,
+				Message: fmt.Sprintf("%s must be between 1 and 100", field),
+			})
+		}
+	}
+	switch pp.Model {
+	case "", ExponentialSmoothingPredictiveModel:
+	case HoltWintersPredictiveModel:
+		if pp.SeasonLengthMinutes < 2 || pp.SeasonLengthMinutes > 1440 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Field:   "seasonLengthMinutes",
+				Message: "seasonLengthMinutes must be between 2 and 1440 for the HoltWinters model",
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Field:   "model",
+			Message: fmt.Sprintf("model must be one of %s or %s", ExponentialSmoothingPredictiveModel, HoltWintersPredictiveModel),
+		})
+	}
+	return causes
+}
+
 // ValidateBufferPolicy validates the FleetAutoscaler Buffer policy settings
 func (b *BufferPolicy) ValidateBufferPolicy(causes []metav1.StatusCause) []metav1.StatusCause {
 	if b == nil {
@@ -16,7 +16,10 @@ package v1
 
 import (
 	"testing"
+	"time"
 
+	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
+	"agones.dev/agones/pkg/util/runtime"
 	"github.com/stretchr/testify/assert"
 	admregv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -205,6 +208,557 @@ func TestFleetAutoscalerWebhookValidateUpdate(t *testing.T) {
 
 }
 
+func TestFleetAutoscalerScheduleValidateUpdate(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	t.Run("feature flag disabled", func(t *testing.T) {
+		assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureScheduledAutoscaler)+"=false"))
+		fas := scheduleFixture()
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "type", causes[0].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+	})
+
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureScheduledAutoscaler)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	t.Run("good schedule", func(t *testing.T) {
+		fas := scheduleFixture()
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 0)
+	})
+
+	t.Run("missing schedule", func(t *testing.T) {
+		fas := scheduleFixture()
+		fas.Spec.Policy.Schedule = nil
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "schedule", causes[0].Field)
+	})
+
+	t.Run("bad default buffer", func(t *testing.T) {
+		fas := scheduleFixture()
+		fas.Spec.Policy.Schedule.Default.BufferSize = intstr.FromInt(0)
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "bufferSize", causes[0].Field)
+	})
+
+	t.Run("bad windows", func(t *testing.T) {
+		fas := scheduleFixture()
+		w := fas.Spec.Policy.Schedule.Windows[0]
+		w.Start = "0 25 * * *"
+		w.Duration.Duration = 0
+		w.TimeZone = "Mars/Olympus_Mons"
+		w.Buffer.MinReplicas = 30
+		fas.Spec.Policy.Schedule.Windows = append(fas.Spec.Policy.Schedule.Windows, w, ScheduleWindow{Start: "@daily", Duration: w.Duration, Buffer: w.Buffer})
+		fas.Spec.Policy.Schedule.Windows[2].Duration.Duration = time.Hour
+		fas.Spec.Policy.Schedule.Windows[2].Buffer.MinReplicas = 0
+		causes := fas.Validate(nil)
+
+		fields := []string{}
+		for _, cause := range causes {
+			fields = append(fields, cause.Field)
+		}
+		assert.Equal(t, []string{"windows[1].name", "windows[1].start", "windows[1].duration", "windows[1].timeZone", "minReplicas", "windows[2].name"}, fields)
+	})
+}
+
+func TestFleetAutoscalerChainValidateUpdate(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	t.Run("feature flag disabled", func(t *testing.T) {
+		assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureChainedAutoscaler)+"=false"))
+		fas := chainFixture()
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "type", causes[0].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+	})
+
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureChainedAutoscaler)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	t.Run("good chain", func(t *testing.T) {
+		fas := chainFixture()
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 0)
+	})
+
+	t.Run("missing chain", func(t *testing.T) {
+		fas := chainFixture()
+		fas.Spec.Policy.Chain = nil
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "chain", causes[0].Field)
+	})
+
+	t.Run("no policies", func(t *testing.T) {
+		fas := chainFixture()
+		fas.Spec.Policy.Chain.Combine = "Average"
+		fas.Spec.Policy.Chain.Policies = nil
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 2)
+		assert.Equal(t, "combine", causes[0].Field)
+		assert.Equal(t, "policies", causes[1].Field)
+	})
+
+	t.Run("bad policies", func(t *testing.T) {
+		fas := chainFixture()
+		chain := fas.Spec.Policy.Chain
+		chain.Policies[1].ID = chain.Policies[0].ID
+		chain.Policies[1].Webhook.Service = nil
+		chain.Policies = append(chain.Policies, ChainEntry{FleetAutoscalerPolicy: FleetAutoscalerPolicy{Type: ChainPolicyType, Chain: &ChainPolicy{}}})
+		causes := fas.Validate(nil)
+
+		fields := []string{}
+		for _, cause := range causes {
+			fields = append(fields, cause.Field)
+		}
+		assert.Equal(t, []string{"policies[1].id", "url", "policies[2].id", "policies[2].type"}, fields)
+	})
+}
+
+func TestFleetAutoscalerBehaviorValidateUpdate(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	behavior := func() *FleetAutoscalerBehavior {
+		return &FleetAutoscalerBehavior{
+			ScaleUp:                             &ScalingRateLimit{MaxChange: intstr.FromInt(10), PeriodSeconds: 60},
+			ScaleDown:                           &ScalingRateLimit{MaxChange: intstr.FromString("20%"), PeriodSeconds: 60},
+			ScaleDownStabilizationWindowSeconds: 300,
+		}
+	}
+
+	t.Run("feature flag disabled", func(t *testing.T) {
+		assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureFleetAutoscalerBehavior)+"=false"))
+		fas := defaultFixture()
+		fas.Spec.Behavior = behavior()
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "behavior", causes[0].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+	})
+
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureFleetAutoscalerBehavior)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	t.Run("good behavior", func(t *testing.T) {
+		fas := defaultFixture()
+		fas.Spec.Behavior = behavior()
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 0)
+	})
+
+	t.Run("bad behavior", func(t *testing.T) {
+		fas := defaultFixture()
+		fas.Spec.Behavior = behavior()
+		fas.Spec.Behavior.ScaleUp.MaxChange = intstr.FromInt(0)
+		fas.Spec.Behavior.ScaleUp.PeriodSeconds = 0
+		fas.Spec.Behavior.ScaleDown.MaxChange = intstr.FromString("0%")
+		fas.Spec.Behavior.ScaleDownStabilizationWindowSeconds = 3601
+		causes := fas.Validate(nil)
+
+		fields := []string{}
+		for _, cause := range causes {
+			fields = append(fields, cause.Field)
+		}
+		assert.Equal(t, []string{"scaleUp.maxChange", "scaleUp.periodSeconds", "scaleDown.maxChange", "scaleDownStabilizationWindowSeconds"}, fields)
+	})
+}
+
+func TestFleetAutoscalerSyncValidateUpdate(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	sync := func() *FleetAutoscalerSync {
+		return &FleetAutoscalerSync{
+			Type:          FixedIntervalSyncType,
+			FixedInterval: FixedIntervalSync{Seconds: 10},
+			OnAllocation:  &OnAllocationSync{DebounceMilliseconds: 100},
+		}
+	}
+
+	t.Run("feature flag disabled", func(t *testing.T) {
+		assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureCustomFasSyncInterval)+"=false"))
+		fas := defaultFixture()
+		fas.Spec.Sync = sync()
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "sync", causes[0].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+	})
+
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureCustomFasSyncInterval)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	t.Run("good sync", func(t *testing.T) {
+		fas := defaultFixture()
+		fas.Spec.Sync = sync()
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 0)
+	})
+
+	t.Run("bad seconds", func(t *testing.T) {
+		fas := defaultFixture()
+		fas.Spec.Sync = sync()
+		fas.Spec.Sync.FixedInterval.Seconds = 0
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "seconds", causes[0].Field)
+	})
+
+	t.Run("bad type and debounce", func(t *testing.T) {
+		fas := defaultFixture()
+		fas.Spec.Sync = sync()
+		fas.Spec.Sync.Type = "Cron"
+		fas.Spec.Sync.OnAllocation.DebounceMilliseconds = 10001
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 2)
+		assert.Equal(t, "type", causes[0].Field)
+		assert.Equal(t, "onAllocation.debounceMilliseconds", causes[1].Field)
+	})
+}
+
+func TestFleetAutoscalerModeValidateUpdate(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	t.Run("feature flag disabled", func(t *testing.T) {
+		assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureFleetAutoscalerDryRun)+"=false"))
+		fas := defaultFixture()
+		fas.Spec.Mode = DryRunFleetAutoscalerMode
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "mode", causes[0].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+		assert.False(t, fas.IsDryRun())
+	})
+
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureFleetAutoscalerDryRun)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	t.Run("good modes", func(t *testing.T) {
+		fas := defaultFixture()
+		fas.Spec.Mode = ScaleFleetAutoscalerMode
+		assert.Len(t, fas.Validate(nil), 0)
+		assert.False(t, fas.IsDryRun())
+
+		fas.Spec.Mode = DryRunFleetAutoscalerMode
+		assert.Len(t, fas.Validate(nil), 0)
+		assert.True(t, fas.IsDryRun())
+	})
+
+	t.Run("bad mode", func(t *testing.T) {
+		fas := defaultFixture()
+		fas.Spec.Mode = "Shadow"
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "mode", causes[0].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+	})
+}
+
+func TestFleetAutoscalerPlayerBufferValidateUpdate(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	t.Run("feature flags disabled", func(t *testing.T) {
+		for _, features := range []string{"PlayerTracking=true&PlayerBufferAutoscaler=false", "PlayerTracking=false&PlayerBufferAutoscaler=true"} {
+			assert.NoError(t, runtime.ParseFeatures(features))
+			fas := playerBufferFixture()
+			causes := fas.Validate(nil)
+
+			assert.Len(t, causes, 1, features)
+			assert.Equal(t, "type", causes[0].Field)
+			assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+		}
+	})
+
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeaturePlayerTracking)+"=true&"+string(runtime.FeaturePlayerBufferAutoscaler)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	t.Run("good player buffer", func(t *testing.T) {
+		fas := playerBufferFixture()
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 0)
+	})
+
+	t.Run("missing player buffer", func(t *testing.T) {
+		fas := playerBufferFixture()
+		fas.Spec.Policy.PlayerBuffer = nil
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "playerBuffer", causes[0].Field)
+	})
+
+	t.Run("bad min and buffer size", func(t *testing.T) {
+		fas := playerBufferFixture()
+		fas.Spec.Policy.PlayerBuffer.MinReplicas = 20
+		fas.Spec.Policy.PlayerBuffer.BufferSize = intstr.FromInt(0)
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 2)
+		assert.Equal(t, "minReplicas", causes[0].Field)
+		assert.Equal(t, "bufferSize", causes[1].Field)
+	})
+
+	t.Run("bufferSize is bigger than maxReplicas", func(t *testing.T) {
+		fas := playerBufferFixture()
+		fas.Spec.Policy.PlayerBuffer.BufferSize = intstr.FromInt(500)
+		causes := fas.Validate(nil)
+
+		// player slots are not replicas
+		assert.Len(t, causes, 0)
+	})
+
+	t.Run("bad percentage", func(t *testing.T) {
+		fas := playerBufferFixture()
+		fas.Spec.Policy.PlayerBuffer.BufferSize = intstr.FromString("100%")
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 2)
+		assert.Equal(t, "bufferSize", causes[0].Field)
+		assert.Equal(t, "minReplicas", causes[1].Field)
+
+		fas.Spec.Policy.PlayerBuffer.BufferSize = intstr.FromString("20%")
+		fas.Spec.Policy.PlayerBuffer.MinReplicas = 1
+		causes = fas.Validate(nil)
+		assert.Len(t, causes, 0)
+	})
+}
+
+func TestFleetAutoscalerPredictiveValidateUpdate(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	t.Run("feature flag disabled", func(t *testing.T) {
+		assert.NoError(t, runtime.ParseFeatures(string(runtime.FeaturePredictiveAutoscaler)+"=false"))
+		fas := predictiveFixture()
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "type", causes[0].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+	})
+
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeaturePredictiveAutoscaler)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	t.Run("good predictive", func(t *testing.T) {
+		fas := predictiveFixture()
+		assert.Len(t, fas.Validate(nil), 0)
+
+		fas.Spec.Policy.Predictive.Model = HoltWintersPredictiveModel
+		fas.Spec.Policy.Predictive.SeasonLengthMinutes = 1440
+		fas.Spec.Policy.Predictive.SafetyMargin = intstr.FromString("20%")
+		fas.Spec.Policy.Predictive.LevelSmoothingPercent = 30
+		assert.Len(t, fas.Validate(nil), 0)
+	})
+
+	t.Run("missing predictive", func(t *testing.T) {
+		fas := predictiveFixture()
+		fas.Spec.Policy.Predictive = nil
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "predictive", causes[0].Field)
+	})
+
+	t.Run("bad predictive", func(t *testing.T) {
+		fas := predictiveFixture()
+		fas.Spec.Policy.Predictive.MinReplicas = 20
+		fas.Spec.Policy.Predictive.LeadTimeSeconds = 0
+		fas.Spec.Policy.Predictive.SafetyMargin = intstr.FromInt(-1)
+		fas.Spec.Policy.Predictive.TrendSmoothingPercent = 101
+		causes := fas.Validate(nil)
+
+		fields := []string{}
+		for _, cause := range causes {
+			fields = append(fields, cause.Field)
+		}
+		assert.ElementsMatch(t, []string{"minReplicas", "leadTimeSeconds", "safetyMargin", "trendSmoothingPercent"}, fields)
+	})
+
+	t.Run("bad model", func(t *testing.T) {
+		fas := predictiveFixture()
+		fas.Spec.Policy.Predictive.Model = HoltWintersPredictiveModel
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "seasonLengthMinutes", causes[0].Field)
+
+		fas.Spec.Policy.Predictive.Model = "Arima"
+		causes = fas.Validate(nil)
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "model", causes[0].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+	})
+}
+
+func TestFleetAutoscalerBudgetValidateUpdate(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	selectorFixture := func() *FleetAutoscaler {
+		fas := defaultFixture()
+		fas.Spec.FleetName = ""
+		fas.Spec.FleetSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"game": "ctf"}}
+		fas.Spec.Budget = &FleetAutoscalerBudget{
+			MaxReplicas: 100,
+			Priorities:  []FleetPriority{{FleetName: "ctf-1", Priority: 1}},
+		}
+		return fas
+	}
+
+	t.Run("feature flag disabled", func(t *testing.T) {
+		assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureFleetAutoscalerBudget)+"=false"))
+		fas := selectorFixture()
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "fleetSelector", causes[0].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+		assert.False(t, fas.HasFleetSelector())
+	})
+
+	t.Run("missing fleet name", func(t *testing.T) {
+		fas := defaultFixture()
+		fas.Spec.FleetName = ""
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "fleetName", causes[0].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueRequired, causes[0].Type)
+	})
+
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureFleetAutoscalerBudget)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	t.Run("good fleet selector", func(t *testing.T) {
+		fas := selectorFixture()
+		assert.Len(t, fas.Validate(nil), 0)
+		assert.True(t, fas.HasFleetSelector())
+		assert.Equal(t, int32(1), fas.Spec.Budget.Priority("ctf-1"))
+		assert.Equal(t, int32(0), fas.Spec.Budget.Priority("ctf-2"))
+
+		fas.Spec.Budget = nil
+		assert.Len(t, fas.Validate(nil), 0)
+	})
+
+	t.Run("bad fleet selector", func(t *testing.T) {
+		fas := selectorFixture()
+		fas.Spec.FleetName = "ctf-1"
+		fas.Spec.FleetSelector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "game", Operator: "Bad"}}
+		fas.Spec.Budget.MaxReplicas = 0
+		fas.Spec.Budget.Priorities = append(fas.Spec.Budget.Priorities, FleetPriority{FleetName: "ctf-1"})
+		causes := fas.Validate(nil)
+
+		fields := []string{}
+		for _, cause := range causes {
+			fields = append(fields, cause.Field)
+		}
+		assert.Equal(t, []string{"fleetName", "fleetSelector", "budget.maxReplicas", "budget.priorities"}, fields)
+	})
+
+	t.Run("budget without fleet selector", func(t *testing.T) {
+		fas := selectorFixture()
+		fas.Spec.FleetName = "ctf-1"
+		fas.Spec.FleetSelector = nil
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "budget", causes[0].Field)
+	})
+
+	t.Run("scales fleet", func(t *testing.T) {
+		fas := selectorFixture()
+		f := &agonesv1.Fleet{ObjectMeta: metav1.ObjectMeta{Name: "ctf-1", Labels: map[string]string{"game": "ctf"}}}
+		assert.True(t, fas.ScalesFleet(f))
+		f.ObjectMeta.Labels["game"] = "deathmatch"
+		assert.False(t, fas.ScalesFleet(f))
+
+		fas = defaultFixture()
+		f.ObjectMeta.Name = fas.Spec.FleetName
+		assert.True(t, fas.ScalesFleet(f))
+		f.ObjectMeta.Namespace = "other"
+		assert.False(t, fas.ScalesFleet(f))
+	})
+}
+
+func TestFleetAutoscalerWebhookTransportValidateUpdate(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	t.Run("feature flag disabled", func(t *testing.T) {
+		assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureFleetAutoscalerWebhookTransport)+"=false"))
+		fas := webhookFixture()
+		fas.Spec.Policy.WebhookTransport = &WebhookTransport{Retries: 1}
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 1)
+		assert.Equal(t, "webhookTransport", causes[0].Field)
+		assert.Equal(t, metav1.CauseTypeFieldValueNotSupported, causes[0].Type)
+	})
+
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureFleetAutoscalerWebhookTransport)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	t.Run("good transport", func(t *testing.T) {
+		fas := webhookFixture()
+		fas.Spec.Policy.WebhookTransport = &WebhookTransport{
+			Protocol:                 GRPCWebhookProtocol,
+			ClientCertSecret:         "client-cert",
+			TimeoutSeconds:           5,
+			Retries:                  3,
+			RetryBackoffMilliseconds: 200,
+		}
+		causes := fas.Validate(nil)
+
+		assert.Len(t, causes, 0)
+	})
+
+	t.Run("bad transport", func(t *testing.T) {
+		fas := webhookFixture()
+		fas.Spec.Policy.WebhookTransport = &WebhookTransport{
+			Protocol:                 "UDP",
+			TimeoutSeconds:           61,
+			Retries:                  6,
+			RetryBackoffMilliseconds: -1,
+		}
+		causes := fas.Validate(nil)
+
+		fields := []string{}
+		for _, cause := range causes {
+			fields = append(fields, cause.Field)
+		}
+		assert.Equal(t, []string{"webhookTransport.protocol", "webhookTransport.timeoutSeconds",
+			"webhookTransport.retries", "webhookTransport.retryBackoffMilliseconds"}, fields)
+	})
+}
+
 func defaultFixture() *FleetAutoscaler {
 	return customFixture(BufferPolicyType)
 }
@@ -213,6 +767,22 @@ func webhookFixture() *FleetAutoscaler {
 	return customFixture(WebhookPolicyType)
 }
 
+func scheduleFixture() *FleetAutoscaler {
+	return customFixture(SchedulePolicyType)
+}
+
+func chainFixture() *FleetAutoscaler {
+	return customFixture(ChainPolicyType)
+}
+
+func playerBufferFixture() *FleetAutoscaler {
+	return customFixture(PlayerBufferPolicyType)
+}
+
+func predictiveFixture() *FleetAutoscaler {
+	return customFixture(PredictivePolicyType)
+}
+
 func customFixture(t FleetAutoscalerPolicyType) *FleetAutoscaler {
 	res := &FleetAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -240,6 +810,50 @@ func customFixture(t FleetAutoscalerPolicyType) *FleetAutoscaler {
 				Path:      &url,
 			},
 		}
+	case SchedulePolicyType:
+		res.Spec.Policy.Type = SchedulePolicyType
+		res.Spec.Policy.Schedule = &SchedulePolicy{
+			Default: *res.Spec.Policy.Buffer,
+			Windows: []ScheduleWindow{{
+				Name:     "evening",
+				Start:    "0 18 * * MON-FRI",
+				Duration: metav1.Duration{Duration: 4 * time.Hour},
+				TimeZone: "Europe/Paris",
+				Buffer: BufferPolicy{
+					BufferSize:  intstr.FromInt(8),
+					MaxReplicas: 20,
+				},
+			}},
+		}
+		res.Spec.Policy.Buffer = nil
+	case PlayerBufferPolicyType:
+		res.Spec.Policy = FleetAutoscalerPolicy{
+			Type: PlayerBufferPolicyType,
+			PlayerBuffer: &PlayerBufferPolicy{
+				BufferSize:  intstr.FromInt(50),
+				MaxReplicas: 10,
+			},
+		}
+	case PredictivePolicyType:
+		res.Spec.Policy = FleetAutoscalerPolicy{
+			Type: PredictivePolicyType,
+			Predictive: &PredictivePolicy{
+				MaxReplicas:     10,
+				LeadTimeSeconds: 90,
+				SafetyMargin:    intstr.FromInt(2),
+			},
+		}
+	case ChainPolicyType:
+		res.Spec.Policy = FleetAutoscalerPolicy{
+			Type: ChainPolicyType,
+			Chain: &ChainPolicy{
+				Combine: MaxCombineRule,
+				Policies: []ChainEntry{
+					{ID: "buffer", FleetAutoscalerPolicy: customFixture(BufferPolicyType).Spec.Policy},
+					{ID: "webhook", FleetAutoscalerPolicy: customFixture(WebhookPolicyType).Spec.Policy},
+				},
+			},
+		}
 	}
 	return res
 }
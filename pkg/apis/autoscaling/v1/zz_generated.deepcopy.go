@@ -21,7 +21,9 @@
 package v1
 
 import (
+	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -42,10 +44,113 @@ func (in *BufferPolicy) DeepCopy() *BufferPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChainEntry) DeepCopyInto(out *ChainEntry) {
+	*out = *in
+	in.FleetAutoscalerPolicy.DeepCopyInto(&out.FleetAutoscalerPolicy)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChainEntry.
+func (in *ChainEntry) DeepCopy() *ChainEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ChainEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChainPolicy) DeepCopyInto(out *ChainPolicy) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]ChainEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChainPolicy.
+func (in *ChainPolicy) DeepCopy() *ChainPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ChainPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FixedIntervalSync) DeepCopyInto(out *FixedIntervalSync) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FixedIntervalSync.
+func (in *FixedIntervalSync) DeepCopy() *FixedIntervalSync {
+	if in == nil {
+		return nil
+	}
+	out := new(FixedIntervalSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAllocationRate) DeepCopyInto(out *FleetAllocationRate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAllocationRate.
+func (in *FleetAllocationRate) DeepCopy() *FleetAllocationRate {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAllocationRate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FleetAutoscaleRequest) DeepCopyInto(out *FleetAutoscaleRequest) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AutoscalerSpec != nil {
+		in, out := &in.AutoscalerSpec, &out.AutoscalerSpec
+		*out = new(FleetAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GameServerStates != nil {
+		in, out := &in.GameServerStates, &out.GameServerStates
+		*out = make(map[agonesv1.GameServerState]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AllocationRate != nil {
+		in, out := &in.AllocationRate, &out.AllocationRate
+		*out = new(FleetAllocationRate)
+		**out = **in
+	}
 	return
 }
 
@@ -129,6 +234,69 @@ func (in *FleetAutoscaler) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAutoscalerBehavior) DeepCopyInto(out *FleetAutoscalerBehavior) {
+	*out = *in
+	if in.ScaleUp != nil {
+		in, out := &in.ScaleUp, &out.ScaleUp
+		*out = new(ScalingRateLimit)
+		**out = **in
+	}
+	if in.ScaleDown != nil {
+		in, out := &in.ScaleDown, &out.ScaleDown
+		*out = new(ScalingRateLimit)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAutoscalerBehavior.
+func (in *FleetAutoscalerBehavior) DeepCopy() *FleetAutoscalerBehavior {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAutoscalerBehavior)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAutoscalerBudget) DeepCopyInto(out *FleetAutoscalerBudget) {
+	*out = *in
+	if in.Priorities != nil {
+		in, out := &in.Priorities, &out.Priorities
+		*out = make([]FleetPriority, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAutoscalerBudget.
+func (in *FleetAutoscalerBudget) DeepCopy() *FleetAutoscalerBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAutoscalerBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAutoscalerFleetStatus) DeepCopyInto(out *FleetAutoscalerFleetStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAutoscalerFleetStatus.
+func (in *FleetAutoscalerFleetStatus) DeepCopy() *FleetAutoscalerFleetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAutoscalerFleetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FleetAutoscalerList) DeepCopyInto(out *FleetAutoscalerList) {
 	*out = *in
@@ -175,6 +343,31 @@ func (in *FleetAutoscalerPolicy) DeepCopyInto(out *FleetAutoscalerPolicy) {
 		*out = new(WebhookPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(SchedulePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Chain != nil {
+		in, out := &in.Chain, &out.Chain
+		*out = new(ChainPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PlayerBuffer != nil {
+		in, out := &in.PlayerBuffer, &out.PlayerBuffer
+		*out = new(PlayerBufferPolicy)
+		**out = **in
+	}
+	if in.Predictive != nil {
+		in, out := &in.Predictive, &out.Predictive
+		*out = new(PredictivePolicy)
+		**out = **in
+	}
+	if in.WebhookTransport != nil {
+		in, out := &in.WebhookTransport, &out.WebhookTransport
+		*out = new(WebhookTransport)
+		**out = **in
+	}
 	return
 }
 
@@ -192,6 +385,26 @@ func (in *FleetAutoscalerPolicy) DeepCopy() *FleetAutoscalerPolicy {
 func (in *FleetAutoscalerSpec) DeepCopyInto(out *FleetAutoscalerSpec) {
 	*out = *in
 	in.Policy.DeepCopyInto(&out.Policy)
+	if in.Behavior != nil {
+		in, out := &in.Behavior, &out.Behavior
+		*out = new(FleetAutoscalerBehavior)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(FleetAutoscalerSync)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FleetSelector != nil {
+		in, out := &in.FleetSelector, &out.FleetSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(FleetAutoscalerBudget)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -212,6 +425,11 @@ func (in *FleetAutoscalerStatus) DeepCopyInto(out *FleetAutoscalerStatus) {
 		in, out := &in.LastScaleTime, &out.LastScaleTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Fleets != nil {
+		in, out := &in.Fleets, &out.Fleets
+		*out = make([]FleetAutoscalerFleetStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -225,6 +443,151 @@ func (in *FleetAutoscalerStatus) DeepCopy() *FleetAutoscalerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAutoscalerSync) DeepCopyInto(out *FleetAutoscalerSync) {
+	*out = *in
+	out.FixedInterval = in.FixedInterval
+	if in.OnAllocation != nil {
+		in, out := &in.OnAllocation, &out.OnAllocation
+		*out = new(OnAllocationSync)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAutoscalerSync.
+func (in *FleetAutoscalerSync) DeepCopy() *FleetAutoscalerSync {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAutoscalerSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetPriority) DeepCopyInto(out *FleetPriority) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetPriority.
+func (in *FleetPriority) DeepCopy() *FleetPriority {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetPriority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnAllocationSync) DeepCopyInto(out *OnAllocationSync) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnAllocationSync.
+func (in *OnAllocationSync) DeepCopy() *OnAllocationSync {
+	if in == nil {
+		return nil
+	}
+	out := new(OnAllocationSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlayerBufferPolicy) DeepCopyInto(out *PlayerBufferPolicy) {
+	*out = *in
+	out.BufferSize = in.BufferSize
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlayerBufferPolicy.
+func (in *PlayerBufferPolicy) DeepCopy() *PlayerBufferPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PlayerBufferPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PredictivePolicy) DeepCopyInto(out *PredictivePolicy) {
+	*out = *in
+	out.SafetyMargin = in.SafetyMargin
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredictivePolicy.
+func (in *PredictivePolicy) DeepCopy() *PredictivePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PredictivePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingRateLimit) DeepCopyInto(out *ScalingRateLimit) {
+	*out = *in
+	out.MaxChange = in.MaxChange
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingRateLimit.
+func (in *ScalingRateLimit) DeepCopy() *ScalingRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulePolicy) DeepCopyInto(out *SchedulePolicy) {
+	*out = *in
+	out.Default = in.Default
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]ScheduleWindow, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulePolicy.
+func (in *SchedulePolicy) DeepCopy() *SchedulePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleWindow) DeepCopyInto(out *ScheduleWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	out.Buffer = in.Buffer
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleWindow.
+func (in *ScheduleWindow) DeepCopy() *ScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookPolicy) DeepCopyInto(out *WebhookPolicy) {
 	*out = *in
@@ -255,3 +618,19 @@ func (in *WebhookPolicy) DeepCopy() *WebhookPolicy {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookTransport) DeepCopyInto(out *WebhookTransport) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTransport.
+func (in *WebhookTransport) DeepCopy() *WebhookTransport {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookTransport)
+	in.DeepCopyInto(out)
+	return out
+}
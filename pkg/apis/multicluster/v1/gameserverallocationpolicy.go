@@ -18,9 +18,16 @@ import (
 	"math/rand"
 	"sort"
 
+	"agones.dev/agones/pkg/apis/multicluster"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// RegionLabel is the label of a GameServerAllocationPolicy with the region of its cluster,
+	// which latencies measured by players to the region are matched against
+	RegionLabel = multicluster.GroupName + "/region"
+)
+
 // GameServerAllocationPolicySpec defines the desired state of GameServerAllocationPolicy
 type GameServerAllocationPolicySpec struct {
 	// +kubebuilder:validation:Minimum=0
@@ -46,6 +53,90 @@ type ClusterConnectionInfo struct {
 	ServerCA []byte `json:"serverCa,omitempty"`
 }
 
+// AllocationEndpointState is the health state of an allocation endpoint
+type AllocationEndpointState string
+
+const (
+	// AllocationEndpointHealthy is an allocation endpoint that allocation requests are sent to
+	AllocationEndpointHealthy AllocationEndpointState = "Healthy"
+	// AllocationEndpointEjected is an allocation endpoint that has failed too many times in a row, and is
+	// skipped by allocation requests until its ejection time has passed, or it passes a health probe
+	AllocationEndpointEjected AllocationEndpointState = "Ejected"
+	// AllocationEndpointRecovering is an allocation endpoint whose ejection time has passed, and that is
+	// ejected again on its first failure
+	AllocationEndpointRecovering AllocationEndpointState = "Recovering"
+)
+
+// GameServerAllocationPolicyStatus is the observed state of a GameServerAllocationPolicy
+type GameServerAllocationPolicyStatus struct {
+	// [Stage:Alpha]
+	// [FeatureFlag:AllocationEndpointHealth]
+	// [FeatureFlag:AllocationCapacityWeighting]
+	// Allocators is the state of the policy as observed by each allocator, that is each pod of the allocator
+	// service and of the controller, as each of them tracks the health of the endpoints and the capacity
+	// of the cluster from its own allocations
+	// +optional
+	Allocators []AllocatorPolicyStatus `json:"allocators,omitempty"`
+}
+
+// AllocatorPolicyStatus is the state of a GameServerAllocationPolicy as observed by a single allocator
+type AllocatorPolicyStatus struct {
+	// Name is the name of the pod of the allocator
+	Name string `json:"name"`
+	// LastUpdateTime is the last time the allocator updated its state, which it does only when the state changes.
+	// The state of allocators that have not updated it for a while is removed when another allocator updates its own,
+	// and added back by the allocator if it is still running
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+	// [Stage:Alpha]
+	// [FeatureFlag:AllocationEndpointHealth]
+	// Endpoints is the health of each of the AllocationEndpoints of the ConnectionInfo
+	// +optional
+	Endpoints []AllocationEndpointStatus `json:"endpoints,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:AllocationCapacityWeighting]
+	// Capacity is the capacity of the cluster to allocate GameServers, as observed from the outcome of allocations
+	// +optional
+	Capacity *ClusterCapacityStatus `json:"capacity,omitempty"`
+}
+
+// ClusterCapacityStatus is the observed capacity of a cluster to allocate GameServers
+type ClusterCapacityStatus struct {
+	// AvailableCapacity is the percentage of the recent allocations in the cluster that found a GameServer to
+	// allocate, from 0 to 100, or 100 if there are no recent allocations. It is a relative weight between the
+	// clusters, rather than a number of GameServers.
+	AvailableCapacity int32 `json:"availableCapacity"`
+	// ObservedAllocations is the number of recent allocations in the cluster that the AvailableCapacity is derived from
+	ObservedAllocations int32 `json:"observedAllocations"`
+	// EffectiveWeight is the Weight of the policy scaled by the AvailableCapacity, which is used to select between
+	// the clusters of the same Priority
+	EffectiveWeight int `json:"effectiveWeight"`
+	// LastError is the error of the last allocation in the cluster that failed
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// LastExhaustedTime is the last time an allocation found no GameServer to allocate in the cluster
+	// +optional
+	LastExhaustedTime *metav1.Time `json:"lastExhaustedTime,omitempty"`
+}
+
+// AllocationEndpointStatus is the observed health of an allocation endpoint
+type AllocationEndpointStatus struct {
+	// Endpoint is the allocation endpoint, as it is in the AllocationEndpoints of the ConnectionInfo
+	Endpoint string `json:"endpoint"`
+	// State is the health state of the allocation endpoint
+	State AllocationEndpointState `json:"state"`
+	// ConsecutiveFailures is the number of allocation requests or health probes in a row that have failed
+	ConsecutiveFailures int32 `json:"consecutiveFailures"`
+	// LastError is the error of the last failed allocation request or health probe
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// LastTransitionTime is the last time the State changed
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// EjectedUntil is the time until which an Ejected allocation endpoint is skipped
+	// +optional
+	EjectedUntil *metav1.Time `json:"ejectedUntil,omitempty"`
+}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -55,7 +146,8 @@ type GameServerAllocationPolicy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec GameServerAllocationPolicySpec `json:"spec,omitempty"`
+	Spec   GameServerAllocationPolicySpec   `json:"spec,omitempty"`
+	Status GameServerAllocationPolicyStatus `json:"status,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
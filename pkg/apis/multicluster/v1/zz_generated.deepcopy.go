@@ -24,6 +24,79 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllocationEndpointStatus) DeepCopyInto(out *AllocationEndpointStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EjectedUntil != nil {
+		in, out := &in.EjectedUntil, &out.EjectedUntil
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllocationEndpointStatus.
+func (in *AllocationEndpointStatus) DeepCopy() *AllocationEndpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AllocationEndpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllocatorPolicyStatus) DeepCopyInto(out *AllocatorPolicyStatus) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]AllocationEndpointStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(ClusterCapacityStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllocatorPolicyStatus.
+func (in *AllocatorPolicyStatus) DeepCopy() *AllocatorPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AllocatorPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCapacityStatus) DeepCopyInto(out *ClusterCapacityStatus) {
+	*out = *in
+	if in.LastExhaustedTime != nil {
+		in, out := &in.LastExhaustedTime, &out.LastExhaustedTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCapacityStatus.
+func (in *ClusterCapacityStatus) DeepCopy() *ClusterCapacityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCapacityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterConnectionInfo) DeepCopyInto(out *ClusterConnectionInfo) {
 	*out = *in
@@ -115,6 +188,7 @@ func (in *GameServerAllocationPolicy) DeepCopyInto(out *GameServerAllocationPoli
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -185,3 +259,26 @@ func (in *GameServerAllocationPolicySpec) DeepCopy() *GameServerAllocationPolicy
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameServerAllocationPolicyStatus) DeepCopyInto(out *GameServerAllocationPolicyStatus) {
+	*out = *in
+	if in.Allocators != nil {
+		in, out := &in.Allocators, &out.Allocators
+		*out = make([]AllocatorPolicyStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameServerAllocationPolicyStatus.
+func (in *GameServerAllocationPolicyStatus) DeepCopy() *GameServerAllocationPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GameServerAllocationPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
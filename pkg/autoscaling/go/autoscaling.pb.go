@@ -0,0 +1,512 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This code was autogenerated. Do not edit directly.
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: proto/autoscaling/autoscaling.proto
+
+package autoscaling
+
+import (
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/golang/protobuf/proto"
+
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// FleetAutoscaleRequest is the request sent to the webhook on each sync of the FleetAutoscaler
+type FleetAutoscaleRequest struct {
+	// The unique ID of the request, which is sent back in the response
+	Uid string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// The name of the Fleet that is scaled
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The k8s namespace of the Fleet that is scaled
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// The current status of the Fleet
+	Status *FleetStatus `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// The labels of the Fleet.
+	// Alpha, FleetAutoscaleRequestDetails feature flag
+	Labels map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The annotations of the Fleet.
+	// Alpha, FleetAutoscaleRequestDetails feature flag
+	Annotations map[string]string `protobuf:"bytes,6,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The number of GameServers of the Fleet in each state, by state.
+	// Alpha, FleetAutoscaleRequestDetails feature flag
+	GameServerStates map[string]int32 `protobuf:"bytes,7,rep,name=gameServerStates,proto3" json:"gameServerStates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// The number of GameServers of the Fleet that were recently allocated.
+	// Alpha, FleetAutoscaleRequestDetails feature flag
+	AllocationRate *AllocationRate `protobuf:"bytes,8,opt,name=allocationRate,proto3" json:"allocationRate,omitempty"`
+	// The spec of the FleetAutoscaler in JSON, with the same fields as the FleetAutoscaler resource.
+	// Alpha, FleetAutoscaleRequestDetails feature flag
+	AutoscalerSpec       string   `protobuf:"bytes,9,opt,name=autoscalerSpec,proto3" json:"autoscalerSpec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FleetAutoscaleRequest) Reset()         { *m = FleetAutoscaleRequest{} }
+func (m *FleetAutoscaleRequest) String() string { return proto.CompactTextString(m) }
+func (*FleetAutoscaleRequest) ProtoMessage()    {}
+func (*FleetAutoscaleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_autoscaling_a76cc189ca4ac081, []int{0}
+}
+func (m *FleetAutoscaleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FleetAutoscaleRequest.Unmarshal(m, b)
+}
+func (m *FleetAutoscaleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FleetAutoscaleRequest.Marshal(b, m, deterministic)
+}
+func (dst *FleetAutoscaleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FleetAutoscaleRequest.Merge(dst, src)
+}
+func (m *FleetAutoscaleRequest) XXX_Size() int {
+	return xxx_messageInfo_FleetAutoscaleRequest.Size(m)
+}
+func (m *FleetAutoscaleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FleetAutoscaleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FleetAutoscaleRequest proto.InternalMessageInfo
+
+func (m *FleetAutoscaleRequest) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+func (m *FleetAutoscaleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FleetAutoscaleRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *FleetAutoscaleRequest) GetStatus() *FleetStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *FleetAutoscaleRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *FleetAutoscaleRequest) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+func (m *FleetAutoscaleRequest) GetGameServerStates() map[string]int32 {
+	if m != nil {
+		return m.GameServerStates
+	}
+	return nil
+}
+
+func (m *FleetAutoscaleRequest) GetAllocationRate() *AllocationRate {
+	if m != nil {
+		return m.AllocationRate
+	}
+	return nil
+}
+
+func (m *FleetAutoscaleRequest) GetAutoscalerSpec() string {
+	if m != nil {
+		return m.AutoscalerSpec
+	}
+	return ""
+}
+
+// AllocationRate is the number of GameServers of a Fleet that were recently allocated
+type AllocationRate struct {
+	// The number of GameServers allocated within the last minute
+	LastMinute int32 `protobuf:"varint,1,opt,name=lastMinute,proto3" json:"lastMinute,omitempty"`
+	// The number of GameServers allocated within the last five minutes
+	LastFiveMinutes      int32    `protobuf:"varint,2,opt,name=lastFiveMinutes,proto3" json:"lastFiveMinutes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AllocationRate) Reset()         { *m = AllocationRate{} }
+func (m *AllocationRate) String() string { return proto.CompactTextString(m) }
+func (*AllocationRate) ProtoMessage()    {}
+func (*AllocationRate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_autoscaling_a76cc189ca4ac081, []int{1}
+}
+func (m *AllocationRate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AllocationRate.Unmarshal(m, b)
+}
+func (m *AllocationRate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AllocationRate.Marshal(b, m, deterministic)
+}
+func (dst *AllocationRate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllocationRate.Merge(dst, src)
+}
+func (m *AllocationRate) XXX_Size() int {
+	return xxx_messageInfo_AllocationRate.Size(m)
+}
+func (m *AllocationRate) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllocationRate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllocationRate proto.InternalMessageInfo
+
+func (m *AllocationRate) GetLastMinute() int32 {
+	if m != nil {
+		return m.LastMinute
+	}
+	return 0
+}
+
+func (m *AllocationRate) GetLastFiveMinutes() int32 {
+	if m != nil {
+		return m.LastFiveMinutes
+	}
+	return 0
+}
+
+// FleetStatus is the current status of a Fleet
+type FleetStatus struct {
+	// The total number of GameServers in the Fleet
+	Replicas int32 `protobuf:"varint,1,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	// The number of Ready GameServers in the Fleet
+	ReadyReplicas int32 `protobuf:"varint,2,opt,name=readyReplicas,proto3" json:"readyReplicas,omitempty"`
+	// The number of Reserved GameServers in the Fleet
+	ReservedReplicas int32 `protobuf:"varint,3,opt,name=reservedReplicas,proto3" json:"reservedReplicas,omitempty"`
+	// The number of Allocated GameServers in the Fleet
+	AllocatedReplicas int32 `protobuf:"varint,4,opt,name=allocatedReplicas,proto3" json:"allocatedReplicas,omitempty"`
+	// The total player capacity and count of the Fleet, if player tracking is enabled
+	Players              *PlayerStatus `protobuf:"bytes,5,opt,name=players,proto3" json:"players,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *FleetStatus) Reset()         { *m = FleetStatus{} }
+func (m *FleetStatus) String() string { return proto.CompactTextString(m) }
+func (*FleetStatus) ProtoMessage()    {}
+func (*FleetStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_autoscaling_a76cc189ca4ac081, []int{2}
+}
+func (m *FleetStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FleetStatus.Unmarshal(m, b)
+}
+func (m *FleetStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FleetStatus.Marshal(b, m, deterministic)
+}
+func (dst *FleetStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FleetStatus.Merge(dst, src)
+}
+func (m *FleetStatus) XXX_Size() int {
+	return xxx_messageInfo_FleetStatus.Size(m)
+}
+func (m *FleetStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_FleetStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FleetStatus proto.InternalMessageInfo
+
+func (m *FleetStatus) GetReplicas() int32 {
+	if m != nil {
+		return m.Replicas
+	}
+	return 0
+}
+
+func (m *FleetStatus) GetReadyReplicas() int32 {
+	if m != nil {
+		return m.ReadyReplicas
+	}
+	return 0
+}
+
+func (m *FleetStatus) GetReservedReplicas() int32 {
+	if m != nil {
+		return m.ReservedReplicas
+	}
+	return 0
+}
+
+func (m *FleetStatus) GetAllocatedReplicas() int32 {
+	if m != nil {
+		return m.AllocatedReplicas
+	}
+	return 0
+}
+
+func (m *FleetStatus) GetPlayers() *PlayerStatus {
+	if m != nil {
+		return m.Players
+	}
+	return nil
+}
+
+// PlayerStatus is the total player capacity and count of a Fleet
+type PlayerStatus struct {
+	Count                int64    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Capacity             int64    `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PlayerStatus) Reset()         { *m = PlayerStatus{} }
+func (m *PlayerStatus) String() string { return proto.CompactTextString(m) }
+func (*PlayerStatus) ProtoMessage()    {}
+func (*PlayerStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_autoscaling_a76cc189ca4ac081, []int{3}
+}
+func (m *PlayerStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerStatus.Unmarshal(m, b)
+}
+func (m *PlayerStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerStatus.Marshal(b, m, deterministic)
+}
+func (dst *PlayerStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerStatus.Merge(dst, src)
+}
+func (m *PlayerStatus) XXX_Size() int {
+	return xxx_messageInfo_PlayerStatus.Size(m)
+}
+func (m *PlayerStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerStatus proto.InternalMessageInfo
+
+func (m *PlayerStatus) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *PlayerStatus) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+// FleetAutoscaleResponse is the response of the webhook with the desired replicas of the Fleet
+type FleetAutoscaleResponse struct {
+	// The unique ID of the request
+	Uid string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// Set to true to scale the Fleet to replicas. If false, the Fleet is not scaled.
+	Scale bool `protobuf:"varint,2,opt,name=scale,proto3" json:"scale,omitempty"`
+	// The desired number of GameServers in the Fleet
+	Replicas             int32    `protobuf:"varint,3,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FleetAutoscaleResponse) Reset()         { *m = FleetAutoscaleResponse{} }
+func (m *FleetAutoscaleResponse) String() string { return proto.CompactTextString(m) }
+func (*FleetAutoscaleResponse) ProtoMessage()    {}
+func (*FleetAutoscaleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_autoscaling_a76cc189ca4ac081, []int{4}
+}
+func (m *FleetAutoscaleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FleetAutoscaleResponse.Unmarshal(m, b)
+}
+func (m *FleetAutoscaleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FleetAutoscaleResponse.Marshal(b, m, deterministic)
+}
+func (dst *FleetAutoscaleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FleetAutoscaleResponse.Merge(dst, src)
+}
+func (m *FleetAutoscaleResponse) XXX_Size() int {
+	return xxx_messageInfo_FleetAutoscaleResponse.Size(m)
+}
+func (m *FleetAutoscaleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FleetAutoscaleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FleetAutoscaleResponse proto.InternalMessageInfo
+
+func (m *FleetAutoscaleResponse) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+func (m *FleetAutoscaleResponse) GetScale() bool {
+	if m != nil {
+		return m.Scale
+	}
+	return false
+}
+
+func (m *FleetAutoscaleResponse) GetReplicas() int32 {
+	if m != nil {
+		return m.Replicas
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*FleetAutoscaleRequest)(nil), "autoscaling.FleetAutoscaleRequest")
+	proto.RegisterMapType((map[string]string)(nil), "autoscaling.FleetAutoscaleRequest.AnnotationsEntry")
+	proto.RegisterMapType((map[string]int32)(nil), "autoscaling.FleetAutoscaleRequest.GameServerStatesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "autoscaling.FleetAutoscaleRequest.LabelsEntry")
+	proto.RegisterType((*AllocationRate)(nil), "autoscaling.AllocationRate")
+	proto.RegisterType((*FleetStatus)(nil), "autoscaling.FleetStatus")
+	proto.RegisterType((*PlayerStatus)(nil), "autoscaling.PlayerStatus")
+	proto.RegisterType((*FleetAutoscaleResponse)(nil), "autoscaling.FleetAutoscaleResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AutoscalerServiceClient is the client API for AutoscalerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AutoscalerServiceClient interface {
+	Autoscale(ctx context.Context, in *FleetAutoscaleRequest, opts ...grpc.CallOption) (*FleetAutoscaleResponse, error)
+}
+
+type autoscalerServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAutoscalerServiceClient(cc *grpc.ClientConn) AutoscalerServiceClient {
+	return &autoscalerServiceClient{cc}
+}
+
+func (c *autoscalerServiceClient) Autoscale(ctx context.Context, in *FleetAutoscaleRequest, opts ...grpc.CallOption) (*FleetAutoscaleResponse, error) {
+	out := new(FleetAutoscaleResponse)
+	err := c.cc.Invoke(ctx, "/autoscaling.AutoscalerService/Autoscale", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AutoscalerServiceServer is the server API for AutoscalerService service.
+type AutoscalerServiceServer interface {
+	Autoscale(context.Context, *FleetAutoscaleRequest) (*FleetAutoscaleResponse, error)
+}
+
+func RegisterAutoscalerServiceServer(s *grpc.Server, srv AutoscalerServiceServer) {
+	s.RegisterService(&_AutoscalerService_serviceDesc, srv)
+}
+
+func _AutoscalerService_Autoscale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FleetAutoscaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AutoscalerServiceServer).Autoscale(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/autoscaling.AutoscalerService/Autoscale",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AutoscalerServiceServer).Autoscale(ctx, req.(*FleetAutoscaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AutoscalerService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "autoscaling.AutoscalerService",
+	HandlerType: (*AutoscalerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Autoscale",
+			Handler:    _AutoscalerService_Autoscale_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/autoscaling/autoscaling.proto",
+}
+
+func init() {
+	proto.RegisterFile("proto/autoscaling/autoscaling.proto", fileDescriptor_autoscaling_a76cc189ca4ac081)
+}
+
+var fileDescriptor_autoscaling_a76cc189ca4ac081 = []byte{
+	// 539 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xc1, 0x6e, 0xd3, 0x40,
+	0x10, 0x25, 0x75, 0x9d, 0x26, 0x63, 0x08, 0xe9, 0xa8, 0x45, 0x26, 0x20, 0x14, 0xb9, 0x08, 0x45,
+	0x08, 0x05, 0x94, 0x5c, 0x0a, 0x07, 0x44, 0x54, 0x11, 0x2e, 0x20, 0xa1, 0x8d, 0xe0, 0x80, 0xb8,
+	0x6c, 0x9d, 0x51, 0x65, 0xc5, 0xb5, 0x8d, 0x77, 0x1d, 0xc9, 0x5f, 0xc7, 0x9f, 0xf0, 0x2d, 0xc8,
+	0x63, 0x27, 0x59, 0x3b, 0x15, 0x2d, 0x27, 0xef, 0xbc, 0x79, 0xf3, 0x76, 0xf7, 0xcd, 0xac, 0xe1,
+	0x2c, 0x49, 0x63, 0x1d, 0xbf, 0x96, 0x99, 0x8e, 0x95, 0x2f, 0xc3, 0x20, 0xba, 0x32, 0xd7, 0x63,
+	0xce, 0xa2, 0x63, 0x40, 0xde, 0x6f, 0x1b, 0x4e, 0xe7, 0x21, 0x91, 0x9e, 0x55, 0x20, 0x09, 0xfa,
+	0x95, 0x91, 0xd2, 0xd8, 0x07, 0x2b, 0x0b, 0x96, 0x6e, 0x6b, 0xd8, 0x1a, 0x75, 0x45, 0xb1, 0x44,
+	0x84, 0xc3, 0x48, 0x5e, 0x93, 0x7b, 0xc0, 0x10, 0xaf, 0xf1, 0x29, 0x74, 0x8b, 0xaf, 0x4a, 0xa4,
+	0x4f, 0xae, 0xc5, 0x89, 0x1d, 0x80, 0x6f, 0xa0, 0xad, 0xb4, 0xd4, 0x99, 0x72, 0x0f, 0x87, 0xad,
+	0x91, 0x33, 0x71, 0xc7, 0xe6, 0x71, 0x78, 0xdf, 0x05, 0xe7, 0x45, 0xc5, 0xc3, 0x39, 0xb4, 0x43,
+	0x79, 0x49, 0xa1, 0x72, 0xed, 0xa1, 0x35, 0x72, 0x26, 0xe3, 0xfd, 0x8a, 0xe6, 0x49, 0xc7, 0x9f,
+	0xb9, 0xe0, 0x63, 0xa4, 0xd3, 0x5c, 0x54, 0xd5, 0xf8, 0x0d, 0x1c, 0x19, 0x45, 0xb1, 0x96, 0x3a,
+	0x88, 0x23, 0xe5, 0xb6, 0x59, 0x6c, 0x7a, 0x07, 0xb1, 0xd9, 0xae, 0xaa, 0x54, 0x34, 0x75, 0x70,
+	0x09, 0xfd, 0x2b, 0x79, 0x4d, 0x0b, 0x4a, 0xd7, 0x94, 0x16, 0x47, 0x27, 0xe5, 0x1e, 0xb1, 0xf6,
+	0xf9, 0x1d, 0xb4, 0x3f, 0x35, 0x4a, 0xcb, 0x0d, 0xf6, 0x14, 0xf1, 0x02, 0x7a, 0x32, 0x0c, 0x63,
+	0x9f, 0x37, 0x15, 0x52, 0x93, 0xdb, 0x61, 0xfb, 0x9e, 0xd4, 0xf6, 0x98, 0xd5, 0x28, 0xa2, 0x51,
+	0x82, 0x2f, 0xa0, 0xb7, 0x61, 0x53, 0xba, 0x48, 0xc8, 0x77, 0xbb, 0xdc, 0x9e, 0x06, 0x3a, 0x78,
+	0x0b, 0x8e, 0x61, 0x60, 0xd1, 0xf6, 0x15, 0xe5, 0x9b, 0xb6, 0xaf, 0x28, 0xc7, 0x13, 0xb0, 0xd7,
+	0x32, 0xcc, 0x36, 0x7d, 0x2f, 0x83, 0x77, 0x07, 0xe7, 0xad, 0xc1, 0x7b, 0xe8, 0x37, 0xed, 0xfa,
+	0xaf, 0xfa, 0x0b, 0x38, 0xbd, 0xd1, 0x92, 0xdb, 0x44, 0x6c, 0x43, 0xc4, 0xfb, 0x01, 0xbd, 0xba,
+	0x13, 0xf8, 0x0c, 0x20, 0x94, 0x4a, 0x7f, 0x09, 0xa2, 0x4c, 0x13, 0x8b, 0xd8, 0xc2, 0x40, 0x70,
+	0x04, 0x0f, 0x8b, 0x68, 0x1e, 0xac, 0xa9, 0x44, 0x54, 0xa5, 0xda, 0x84, 0xbd, 0x3f, 0x2d, 0x70,
+	0x8c, 0x29, 0xc5, 0x01, 0x74, 0x52, 0x4a, 0xc2, 0xc0, 0x97, 0xaa, 0xd2, 0xdd, 0xc6, 0xf8, 0x1c,
+	0x1e, 0xa4, 0x24, 0x97, 0xb9, 0xd8, 0x10, 0x4a, 0xcd, 0x3a, 0x88, 0x2f, 0xa1, 0x9f, 0x92, 0x2a,
+	0x2e, 0xbc, 0xdc, 0x12, 0x2d, 0x26, 0xee, 0xe1, 0xf8, 0x0a, 0x8e, 0xab, 0x9e, 0x1a, 0xe4, 0x43,
+	0x26, 0xef, 0x27, 0x70, 0x0a, 0x47, 0x49, 0x28, 0x73, 0x4a, 0x8b, 0xa7, 0x53, 0x4c, 0xcb, 0xe3,
+	0xda, 0xb4, 0x7c, 0xe5, 0x5c, 0xf5, 0xda, 0x36, 0x4c, 0xef, 0x03, 0xdc, 0x37, 0x13, 0x85, 0xcd,
+	0x7e, 0x9c, 0x45, 0x9a, 0x6f, 0x67, 0x89, 0x32, 0x28, 0xae, 0xed, 0xcb, 0x44, 0xfa, 0x81, 0xce,
+	0xf9, 0x56, 0x96, 0xd8, 0xc6, 0xde, 0x4f, 0x78, 0xd4, 0x1c, 0x76, 0x95, 0xc4, 0x91, 0xa2, 0x1b,
+	0x7e, 0x20, 0x27, 0x60, 0x33, 0x85, 0x45, 0x3a, 0xa2, 0x0c, 0x6a, 0xa6, 0x5a, 0x75, 0x53, 0x27,
+	0x2b, 0x38, 0x9e, 0xed, 0xc6, 0x95, 0xd2, 0x75, 0xe0, 0x13, 0x7e, 0x87, 0xee, 0x16, 0x44, 0xef,
+	0xf6, 0x77, 0x37, 0x38, 0xfb, 0x27, 0xa7, 0x3c, 0xae, 0x77, 0xef, 0xb2, 0xcd, 0xff, 0xc7, 0xe9,
+	0xdf, 0x01, 0x00, 0x2e, 0xe6, 0xac, 0x49, 0x46, 0x05, 0x00, 0x00,
+}
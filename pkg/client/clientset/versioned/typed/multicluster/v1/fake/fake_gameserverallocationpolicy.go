@@ -100,6 +100,18 @@ func (c *FakeGameServerAllocationPolicies) Update(gameServerAllocationPolicy *mu
 	return obj.(*multiclusterv1.GameServerAllocationPolicy), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeGameServerAllocationPolicies) UpdateStatus(gameServerAllocationPolicy *multiclusterv1.GameServerAllocationPolicy) (*multiclusterv1.GameServerAllocationPolicy, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(gameserverallocationpoliciesResource, "status", c.ns, gameServerAllocationPolicy), &multiclusterv1.GameServerAllocationPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*multiclusterv1.GameServerAllocationPolicy), err
+}
+
 // Delete takes name of the gameServerAllocationPolicy and deletes it. Returns an error if one occurs.
 func (c *FakeGameServerAllocationPolicies) Delete(name string, options *v1.DeleteOptions) error {
 	_, err := c.Fake.
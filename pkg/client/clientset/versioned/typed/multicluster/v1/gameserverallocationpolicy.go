@@ -39,6 +39,7 @@ type GameServerAllocationPoliciesGetter interface {
 type GameServerAllocationPolicyInterface interface {
 	Create(*v1.GameServerAllocationPolicy) (*v1.GameServerAllocationPolicy, error)
 	Update(*v1.GameServerAllocationPolicy) (*v1.GameServerAllocationPolicy, error)
+	UpdateStatus(*v1.GameServerAllocationPolicy) (*v1.GameServerAllocationPolicy, error)
 	Delete(name string, options *metav1.DeleteOptions) error
 	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
 	Get(name string, options metav1.GetOptions) (*v1.GameServerAllocationPolicy, error)
@@ -132,6 +133,22 @@ func (c *gameServerAllocationPolicies) Update(gameServerAllocationPolicy *v1.Gam
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *gameServerAllocationPolicies) UpdateStatus(gameServerAllocationPolicy *v1.GameServerAllocationPolicy) (result *v1.GameServerAllocationPolicy, err error) {
+	result = &v1.GameServerAllocationPolicy{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("gameserverallocationpolicies").
+		Name(gameServerAllocationPolicy.Name).
+		SubResource("status").
+		Body(gameServerAllocationPolicy).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the gameServerAllocationPolicy and deletes it. Returns an error if one occurs.
 func (c *gameServerAllocationPolicies) Delete(name string, options *metav1.DeleteOptions) error {
 	return c.client.Delete().
@@ -0,0 +1,84 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetautoscalers
+
+import (
+	"sync"
+	"time"
+
+	autoscalingv1 "agones.dev/agones/pkg/apis/autoscaling/v1"
+)
+
+const (
+	// allocationHistoryWindow is how long the allocations of a fleet are kept to compute its allocation rate
+	allocationHistoryWindow = 5 * time.Minute
+)
+
+// allocationHistories keeps the times at which the GameServers of each Fleet were recently allocated, by Fleet key
+type allocationHistories struct {
+	mu        sync.Mutex
+	histories map[string][]time.Time
+}
+
+// newAllocationHistories returns an empty allocationHistories
+func newAllocationHistories() *allocationHistories {
+	return &allocationHistories{histories: map[string][]time.Time{}}
+}
+
+// record records that a GameServer of the Fleet with the given key was allocated
+func (a *allocationHistories) record(key string, now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.histories[key] = append(pruneAllocations(a.histories[key], now), now)
+}
+
+// rate returns the number of GameServers of the Fleet with the given key that were recently allocated
+func (a *allocationHistories) rate(key string, now time.Time) *autoscalingv1.FleetAllocationRate {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	h := pruneAllocations(a.histories[key], now)
+	if len(h) == 0 {
+		delete(a.histories, key)
+	} else {
+		a.histories[key] = h
+	}
+
+	result := &autoscalingv1.FleetAllocationRate{LastFiveMinutes: int32(len(h))}
+	lastMinute := now.Add(-time.Minute)
+	for _, t := range h {
+		if t.After(lastMinute) {
+			result.LastMinute++
+		}
+	}
+	return result
+}
+
+// forget removes the history of the Fleet with the given key
+func (a *allocationHistories) forget(key string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.histories, key)
+}
+
+// pruneAllocations removes the allocations that are older than allocationHistoryWindow, which are sorted by time
+func pruneAllocations(h []time.Time, now time.Time) []time.Time {
+	start := now.Add(-allocationHistoryWindow)
+	i := 0
+	for i < len(h) && !h[i].After(start) {
+		i++
+	}
+	return h[i:]
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetautoscalers
+
+import (
+	"testing"
+	"time"
+
+	autoscalingv1 "agones.dev/agones/pkg/apis/autoscaling/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocationHistoriesRate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	key := "default/fleet-1"
+	a := newAllocationHistories()
+
+	assert.Equal(t, &autoscalingv1.FleetAllocationRate{}, a.rate(key, start))
+
+	a.record(key, start)
+	a.record(key, start.Add(2*time.Minute))
+	a.record(key, start.Add(4*time.Minute))
+	a.record(key, start.Add(4*time.Minute+30*time.Second))
+	a.record("default/fleet-2", start)
+
+	assert.Equal(t, &autoscalingv1.FleetAllocationRate{LastMinute: 2, LastFiveMinutes: 4}, a.rate(key, start.Add(4*time.Minute+30*time.Second)))
+	assert.Equal(t, &autoscalingv1.FleetAllocationRate{LastMinute: 0, LastFiveMinutes: 3}, a.rate(key, start.Add(6*time.Minute)))
+	assert.Equal(t, &autoscalingv1.FleetAllocationRate{}, a.rate(key, start.Add(10*time.Minute)))
+	assert.NotContains(t, a.histories, key)
+
+	a.forget("default/fleet-2")
+	assert.Empty(t, a.histories)
+}
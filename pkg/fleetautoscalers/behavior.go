@@ -0,0 +1,197 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetautoscalers
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	autoscalingv1 "agones.dev/agones/pkg/apis/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// scaleHistory is the recent history of the recommendations and scale events of a FleetAutoscaler,
+// which is needed to apply its behavior
+type scaleHistory struct {
+	recommendations []recommendation
+	scaleEvents     []scaleEvent
+}
+
+// recommendation is a number of replicas computed by the policy of a FleetAutoscaler
+type recommendation struct {
+	time     time.Time
+	replicas int32
+}
+
+// scaleEvent is a change to the replicas of a fleet by its FleetAutoscaler
+type scaleEvent struct {
+	time   time.Time
+	change int32
+}
+
+// scaleHistories keeps the scaleHistory of each FleetAutoscaler, by key
+type scaleHistories struct {
+	mu        sync.Mutex
+	histories map[string]*scaleHistory
+}
+
+// newScaleHistories returns an empty scaleHistories
+func newScaleHistories() *scaleHistories {
+	return &scaleHistories{histories: map[string]*scaleHistory{}}
+}
+
+// applyBehavior records the recommended replicas of the FleetAutoscaler with the given key, and returns the
+// desired replicas once its behavior is applied, with the part of the behavior that limited them, if any.
+func (s *scaleHistories) applyBehavior(key string, b *autoscalingv1.FleetAutoscalerBehavior, currentReplicas, recommendedReplicas int32, now time.Time) (int32, autoscalingv1.FleetAutoscalerBehaviorLimit) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.histories[key]
+	if !ok {
+		h = &scaleHistory{}
+		s.histories[key] = h
+	}
+	h.prune(b, now)
+	h.recommendations = append(h.recommendations, recommendation{time: now, replicas: recommendedReplicas})
+
+	desired := recommendedReplicas
+	var limit autoscalingv1.FleetAutoscalerBehaviorLimit
+
+	if desired < currentReplicas && b.ScaleDownStabilizationWindowSeconds > 0 {
+		// scale down no further than the highest recommendation within the window
+		stabilized := desired
+		windowStart := now.Add(-time.Duration(b.ScaleDownStabilizationWindowSeconds) * time.Second)
+		for _, r := range h.recommendations {
+			if r.time.After(windowStart) && r.replicas > stabilized {
+				stabilized = r.replicas
+			}
+		}
+		if stabilized > currentReplicas {
+			stabilized = currentReplicas
+		}
+		if stabilized != desired {
+			desired, limit = stabilized, autoscalingv1.ScaleDownStabilization
+		}
+	}
+
+	switch {
+	case desired > currentReplicas && b.ScaleUp != nil:
+		// the replicas at the start of the period, before the scale ups within it
+		periodStart := currentReplicas - h.changeSince(now, b.ScaleUp.PeriodSeconds, true)
+		max := periodStart + maxChange(b.ScaleUp.MaxChange, periodStart)
+		if max < currentReplicas {
+			max = currentReplicas
+		}
+		if desired > max {
+			desired, limit = max, autoscalingv1.ScaleUpRateLimit
+		}
+	case desired < currentReplicas && b.ScaleDown != nil:
+		// the replicas at the start of the period, before the scale downs within it
+		periodStart := currentReplicas - h.changeSince(now, b.ScaleDown.PeriodSeconds, false)
+		min := periodStart - maxChange(b.ScaleDown.MaxChange, periodStart)
+		if min > currentReplicas {
+			min = currentReplicas
+		}
+		if desired < min {
+			desired, limit = min, autoscalingv1.ScaleDownRateLimit
+		}
+	}
+
+	return desired, limit
+}
+
+// recordScale records that the FleetAutoscaler with the given key scaled its fleet
+func (s *scaleHistories) recordScale(key string, from, to int32, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.histories[key]
+	if !ok {
+		h = &scaleHistory{}
+		s.histories[key] = h
+	}
+	h.scaleEvents = append(h.scaleEvents, scaleEvent{time: now, change: to - from})
+}
+
+// forget removes the history of the FleetAutoscaler with the given key, along with the histories
+// of the fleets it scales by selector, which are kept by key + "/" + fleet name
+func (s *scaleHistories) forget(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.histories, key)
+	for k := range s.histories {
+		if strings.HasPrefix(k, key+"/") {
+			delete(s.histories, k)
+		}
+	}
+}
+
+// changeSince returns the sum of the scale ups, or of the scale downs, within the last periodSeconds
+func (h *scaleHistory) changeSince(now time.Time, periodSeconds int32, up bool) int32 {
+	start := now.Add(-time.Duration(periodSeconds) * time.Second)
+	var result int32
+	for _, e := range h.scaleEvents {
+		if e.time.After(start) && (e.change > 0) == up {
+			result += e.change
+		}
+	}
+	return result
+}
+
+// prune removes the recommendations and scale events that are too old to matter to the behavior
+func (h *scaleHistory) prune(b *autoscalingv1.FleetAutoscalerBehavior, now time.Time) {
+	keep := b.ScaleDownStabilizationWindowSeconds
+	for _, l := range []*autoscalingv1.ScalingRateLimit{b.ScaleUp, b.ScaleDown} {
+		if l != nil && l.PeriodSeconds > keep {
+			keep = l.PeriodSeconds
+		}
+	}
+	start := now.Add(-time.Duration(keep) * time.Second)
+
+	recommendations := h.recommendations[:0]
+	for _, r := range h.recommendations {
+		if r.time.After(start) {
+			recommendations = append(recommendations, r)
+		}
+	}
+	h.recommendations = recommendations
+
+	scaleEvents := h.scaleEvents[:0]
+	for _, e := range h.scaleEvents {
+		if e.time.After(start) {
+			scaleEvents = append(scaleEvents, e)
+		}
+	}
+	h.scaleEvents = scaleEvents
+}
+
+// maxChange returns the maximum number of replicas of a rate limit, relative to the given replicas.
+// Percentages are rounded up, so that a fleet can always scale by at least one replica.
+func maxChange(value intstr.IntOrString, replicas int32) int32 {
+	if value.Type == intstr.Int {
+		return int32(value.IntValue())
+	}
+	percent, err := intstr.GetValueFromIntOrPercent(&value, 100, true)
+	if err != nil {
+		return 0
+	}
+	result := int32(math.Ceil(float64(replicas) * float64(percent) / 100))
+	if result < 1 {
+		return 1
+	}
+	return result
+}
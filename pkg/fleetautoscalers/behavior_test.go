@@ -0,0 +1,117 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetautoscalers
+
+import (
+	"testing"
+	"time"
+
+	autoscalingv1 "agones.dev/agones/pkg/apis/autoscaling/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestScaleHistoriesApplyBehavior(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	key := "default/fas-1"
+
+	t.Run("scale up rate limit", func(t *testing.T) {
+		s := newScaleHistories()
+		b := &autoscalingv1.FleetAutoscalerBehavior{
+			ScaleUp: &autoscalingv1.ScalingRateLimit{MaxChange: intstr.FromInt(10), PeriodSeconds: 60},
+		}
+
+		desired, limit := s.applyBehavior(key, b, 20, 50, start)
+		assert.Equal(t, int32(30), desired)
+		assert.Equal(t, autoscalingv1.ScaleUpRateLimit, limit)
+		s.recordScale(key, 20, desired, start)
+
+		// the limit is used up for the period
+		desired, limit = s.applyBehavior(key, b, 30, 50, start.Add(30*time.Second))
+		assert.Equal(t, int32(30), desired)
+		assert.Equal(t, autoscalingv1.ScaleUpRateLimit, limit)
+
+		// until the period is over
+		desired, limit = s.applyBehavior(key, b, 30, 35, start.Add(time.Minute))
+		assert.Equal(t, int32(35), desired)
+		assert.Equal(t, autoscalingv1.FleetAutoscalerBehaviorLimit(""), limit)
+
+		// scale downs are not limited
+		desired, limit = s.applyBehavior(key, b, 35, 5, start.Add(time.Minute))
+		assert.Equal(t, int32(5), desired)
+		assert.Equal(t, autoscalingv1.FleetAutoscalerBehaviorLimit(""), limit)
+	})
+
+	t.Run("scale down percentage rate limit", func(t *testing.T) {
+		s := newScaleHistories()
+		b := &autoscalingv1.FleetAutoscalerBehavior{
+			ScaleDown: &autoscalingv1.ScalingRateLimit{MaxChange: intstr.FromString("50%"), PeriodSeconds: 60},
+		}
+
+		desired, limit := s.applyBehavior(key, b, 40, 5, start)
+		assert.Equal(t, int32(20), desired)
+		assert.Equal(t, autoscalingv1.ScaleDownRateLimit, limit)
+		s.recordScale(key, 40, desired, start)
+
+		// the percentage is of the replicas at the start of the period
+		desired, limit = s.applyBehavior(key, b, 20, 5, start.Add(30*time.Second))
+		assert.Equal(t, int32(20), desired)
+		assert.Equal(t, autoscalingv1.ScaleDownRateLimit, limit)
+
+		desired, limit = s.applyBehavior(key, b, 20, 5, start.Add(time.Minute))
+		assert.Equal(t, int32(10), desired)
+		assert.Equal(t, autoscalingv1.ScaleDownRateLimit, limit)
+
+		// percentages are rounded up
+		desired, limit = s.applyBehavior(key, b, 1, 0, start.Add(time.Hour))
+		assert.Equal(t, int32(0), desired)
+		assert.Equal(t, autoscalingv1.FleetAutoscalerBehaviorLimit(""), limit)
+	})
+
+	t.Run("scale down stabilization window", func(t *testing.T) {
+		s := newScaleHistories()
+		b := &autoscalingv1.FleetAutoscalerBehavior{ScaleDownStabilizationWindowSeconds: 300}
+
+		desired, limit := s.applyBehavior(key, b, 10, 30, start)
+		assert.Equal(t, int32(30), desired)
+		assert.Equal(t, autoscalingv1.FleetAutoscalerBehaviorLimit(""), limit)
+
+		desired, limit = s.applyBehavior(key, b, 30, 25, start.Add(time.Minute))
+		assert.Equal(t, int32(30), desired)
+		assert.Equal(t, autoscalingv1.ScaleDownStabilization, limit)
+
+		desired, limit = s.applyBehavior(key, b, 30, 10, start.Add(5*time.Minute))
+		assert.Equal(t, int32(25), desired)
+		assert.Equal(t, autoscalingv1.ScaleDownStabilization, limit)
+
+		desired, limit = s.applyBehavior(key, b, 25, 10, start.Add(6*time.Minute))
+		assert.Equal(t, int32(10), desired)
+		assert.Equal(t, autoscalingv1.FleetAutoscalerBehaviorLimit(""), limit)
+	})
+
+	t.Run("forget", func(t *testing.T) {
+		s := newScaleHistories()
+		s.recordScale(key, 1, 2, start)
+		s.recordScale(key+"/fleet-1", 1, 2, start)
+		s.recordScale(key+"/fleet-2", 1, 2, start)
+		s.recordScale(key+"-other", 1, 2, start)
+		assert.Len(t, s.histories, 4)
+		s.forget(key)
+		assert.Len(t, s.histories, 1)
+		assert.Contains(t, s.histories, key+"-other")
+	})
+}
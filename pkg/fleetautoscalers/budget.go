@@ -0,0 +1,113 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetautoscalers
+
+import (
+	"sort"
+
+	autoscalingv1 "agones.dev/agones/pkg/apis/autoscaling/v1"
+)
+
+// fleetDemand is what one of the Fleets selected by a FleetAutoscaler needs from its budget
+type fleetDemand struct {
+	// replicas is the desired replicas of the Fleet computed by the policy
+	replicas int32
+	// kept is the replicas of the Fleet that can't be scaled down, as they are Allocated or Reserved
+	kept int32
+	// priority is the priority of the Fleet in the budget
+	priority int32
+}
+
+// divideBudget divides the replicas of the budget between the demands of the Fleets, and returns the
+// desired replicas of each Fleet, in the same order. The replicas that are kept by each Fleet are always given,
+// even if they exceed the budget. The rest of the budget goes to the Fleets with the highest priority first,
+// and is divided between Fleets of the same priority in proportion to how many more replicas they need.
+func divideBudget(b *autoscalingv1.FleetAutoscalerBudget, demands []fleetDemand) []int32 {
+	result := make([]int32, len(demands))
+	if b == nil {
+		for i, d := range demands {
+			result[i] = d.replicas
+		}
+		return result
+	}
+
+	remaining := int64(b.MaxReplicas)
+	for i, d := range demands {
+		result[i] = min32(d.replicas, d.kept)
+		remaining -= int64(result[i])
+	}
+
+	// group the Fleets by priority, highest first
+	byPriority := map[int32][]int{}
+	var priorities []int32
+	for i, d := range demands {
+		if _, ok := byPriority[d.priority]; !ok {
+			priorities = append(priorities, d.priority)
+		}
+		byPriority[d.priority] = append(byPriority[d.priority], i)
+	}
+	sort.Slice(priorities, func(i, j int) bool { return priorities[i] > priorities[j] })
+
+	for _, p := range priorities {
+		if remaining <= 0 {
+			break
+		}
+		group := byPriority[p]
+		var needed int64
+		for _, i := range group {
+			needed += int64(demands[i].replicas - result[i])
+		}
+		if needed <= remaining {
+			for _, i := range group {
+				result[i] = demands[i].replicas
+			}
+			remaining -= needed
+			continue
+		}
+
+		// divide the rest of the budget in proportion to the needs of the Fleets, rounding down,
+		// and then give the replicas left by the rounding to the Fleets with the biggest remainders
+		shares := make([]int64, len(group))
+		remainders := make([]int64, len(group))
+		var given int64
+		for j, i := range group {
+			need := int64(demands[i].replicas - result[i])
+			shares[j] = remaining * need / needed
+			remainders[j] = remaining * need % needed
+			given += shares[j]
+		}
+		order := make([]int, len(group))
+		for j := range order {
+			order[j] = j
+		}
+		sort.SliceStable(order, func(x, y int) bool { return remainders[order[x]] > remainders[order[y]] })
+		for _, j := range order[:remaining-given] {
+			shares[j]++
+		}
+		for j, i := range group {
+			result[i] += int32(shares[j])
+		}
+		remaining = 0
+	}
+
+	return result
+}
+
+func min32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
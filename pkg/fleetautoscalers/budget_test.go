@@ -0,0 +1,88 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetautoscalers
+
+import (
+	"testing"
+
+	autoscalingv1 "agones.dev/agones/pkg/apis/autoscaling/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDivideBudget(t *testing.T) {
+	t.Parallel()
+
+	var testCases = []struct {
+		description string
+		budget      *autoscalingv1.FleetAutoscalerBudget
+		demands     []fleetDemand
+		expected    []int32
+	}{
+		{
+			description: "No budget",
+			demands:     []fleetDemand{{replicas: 50}, {replicas: 70}},
+			expected:    []int32{50, 70},
+		},
+		{
+			description: "Within the budget",
+			budget:      &autoscalingv1.FleetAutoscalerBudget{MaxReplicas: 100},
+			demands:     []fleetDemand{{replicas: 30, kept: 10}, {replicas: 70, kept: 20}},
+			expected:    []int32{30, 70},
+		},
+		{
+			description: "Divided in proportion to the demands",
+			budget:      &autoscalingv1.FleetAutoscalerBudget{MaxReplicas: 100},
+			demands:     []fleetDemand{{replicas: 60, kept: 10}, {replicas: 120, kept: 20}},
+			// 70 replicas left after the kept replicas, for needs of 50 and 100
+			expected: []int32{33, 67},
+		},
+		{
+			description: "Highest priority first",
+			budget:      &autoscalingv1.FleetAutoscalerBudget{MaxReplicas: 100},
+			demands:     []fleetDemand{{replicas: 60, kept: 10}, {replicas: 120, kept: 20, priority: 1}},
+			expected:    []int32{10, 90},
+		},
+		{
+			description: "Lower priority gets the rest",
+			budget:      &autoscalingv1.FleetAutoscalerBudget{MaxReplicas: 100},
+			demands:     []fleetDemand{{replicas: 60, kept: 10}, {replicas: 30, kept: 20, priority: 1}},
+			expected:    []int32{60, 30},
+		},
+		{
+			description: "Kept replicas over the budget",
+			budget:      &autoscalingv1.FleetAutoscalerBudget{MaxReplicas: 20},
+			demands:     []fleetDemand{{replicas: 30, kept: 15}, {replicas: 30, kept: 15, priority: 1}},
+			expected:    []int32{15, 15},
+		},
+		{
+			description: "Demand below the kept replicas",
+			budget:      &autoscalingv1.FleetAutoscalerBudget{MaxReplicas: 20},
+			demands:     []fleetDemand{{replicas: 5, kept: 10}, {replicas: 30}},
+			expected:    []int32{5, 15},
+		},
+		{
+			description: "Rounding remainders",
+			budget:      &autoscalingv1.FleetAutoscalerBudget{MaxReplicas: 10},
+			demands:     []fleetDemand{{replicas: 10}, {replicas: 10}, {replicas: 10}},
+			expected:    []int32{4, 3, 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, divideBudget(tc.budget, tc.demands))
+		})
+	}
+}
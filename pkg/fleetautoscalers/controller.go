@@ -17,6 +17,7 @@ package fleetautoscalers
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
@@ -43,9 +44,13 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corev1lister "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 )
@@ -60,8 +65,28 @@ type Controller struct {
 	fleetAutoscalerGetter typedautoscalingv1.FleetAutoscalersGetter
 	fleetAutoscalerLister listerautoscalingv1.FleetAutoscalerLister
 	fleetAutoscalerSynced cache.InformerSynced
+	gameServerLister      listeragonesv1.GameServerLister
+	gameServerSynced      cache.InformerSynced
+	secretLister          corev1lister.SecretLister
+	secretSynced          cache.InformerSynced
 	workerqueue           *workerqueue.WorkerQueue
 	recorder              record.EventRecorder
+	clock                 clock.Clock
+	scaleHistories        *scaleHistories
+	allocationHistories   *allocationHistories
+	allocationCounts      *allocationCounts
+	webhookConns          *webhookConns
+}
+
+// scaleDetails are the details of how the desired replicas of a FleetAutoscaler were computed,
+// which are shown in its status
+type scaleDetails struct {
+	activeScheduleWindow string
+	activeChainEntry     string
+	recommendedReplicas  int32
+	behaviorLimit        autoscalingv1.FleetAutoscalerBehaviorLimit
+	dryRun               bool
+	fleets               []autoscalingv1.FleetAutoscalerFleetStatus
 }
 
 // NewController returns a controller for a FleetAutoscaler
@@ -69,12 +94,15 @@ func NewController(
 	wh *webhooks.WebHook,
 	health healthcheck.Handler,
 	kubeClient kubernetes.Interface,
+	kubeInformerFactory informers.SharedInformerFactory,
 	extClient extclientset.Interface,
 	agonesClient versioned.Interface,
 	agonesInformerFactory externalversions.SharedInformerFactory) *Controller {
 
 	autoscaler := agonesInformerFactory.Autoscaling().V1().FleetAutoscalers()
 	fleetInformer := agonesInformerFactory.Agones().V1().Fleets()
+	gameServerInformer := agonesInformerFactory.Agones().V1().GameServers()
+	secretInformer := kubeInformerFactory.Core().V1().Secrets()
 	c := &Controller{
 		crdGetter:             extClient.ApiextensionsV1().CustomResourceDefinitions(),
 		fleetGetter:           agonesClient.AgonesV1(),
@@ -83,6 +111,15 @@ func NewController(
 		fleetAutoscalerGetter: agonesClient.AutoscalingV1(),
 		fleetAutoscalerLister: autoscaler.Lister(),
 		fleetAutoscalerSynced: autoscaler.Informer().HasSynced,
+		gameServerLister:      gameServerInformer.Lister(),
+		gameServerSynced:      gameServerInformer.Informer().HasSynced,
+		secretLister:          secretInformer.Lister(),
+		secretSynced:          secretInformer.Informer().HasSynced,
+		clock:                 clock.RealClock{},
+		scaleHistories:        newScaleHistories(),
+		allocationHistories:   newAllocationHistories(),
+		allocationCounts:      newAllocationCounts(),
+		webhookConns:          newWebhookConns(),
 	}
 	c.baseLogger = runtime.NewLoggerWithType(c)
 	c.workerqueue = workerqueue.NewWorkerQueueWithRateLimiter(c.syncFleetAutoscaler, c.baseLogger, logfields.FleetAutoscalerKey, autoscaling.GroupName+".FleetAutoscalerController", workerqueue.FastRateLimiter(3*time.Second))
@@ -99,9 +136,60 @@ func NewController(
 
 	autoscaler.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: c.workerqueue.Enqueue,
-		UpdateFunc: func(_, newObj interface{}) {
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// FleetAutoscalers with their own sync interval are only synced on changes to their spec, and not on
+			// the periodic resyncs of the informer, nor on the updates of their status by their own syncs
+			newFas := newObj.(*autoscalingv1.FleetAutoscaler)
+			if _, ok := syncInterval(newFas); ok && oldObj.(*autoscalingv1.FleetAutoscaler).ObjectMeta.Generation == newFas.ObjectMeta.Generation {
+				return
+			}
 			c.workerqueue.Enqueue(newObj)
 		},
+		DeleteFunc: func(obj interface{}) {
+			if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+				c.scaleHistories.forget(key)
+				c.webhookConns.forget(key)
+			}
+		},
+	})
+
+	fleetInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldFleet := oldObj.(*agonesv1.Fleet)
+			newFleet := newObj.(*agonesv1.Fleet)
+			if oldFleet.Status.AllocatedReplicas != newFleet.Status.AllocatedReplicas {
+				c.enqueueOnAllocation(newFleet)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+				c.allocationHistories.forget(key)
+				c.allocationCounts.forget(key)
+			}
+		},
+	})
+
+	gameServerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			details := runtime.FeatureEnabled(runtime.FeatureFleetAutoscaleRequestDetails)
+			predictive := runtime.FeatureEnabled(runtime.FeaturePredictiveAutoscaler)
+			if !details && !predictive {
+				return
+			}
+			oldGs := oldObj.(*agonesv1.GameServer)
+			newGs := newObj.(*agonesv1.GameServer)
+			fleetName, ok := newGs.ObjectMeta.Labels[agonesv1.FleetNameLabel]
+			if ok && oldGs.Status.State != agonesv1.GameServerStateAllocated && newGs.Status.State == agonesv1.GameServerStateAllocated {
+				key := newGs.ObjectMeta.Namespace + "/" + fleetName
+				now := c.clock.Now()
+				if details {
+					c.allocationHistories.record(key, now)
+				}
+				if predictive {
+					c.allocationCounts.record(key, now)
+				}
+			}
+		},
 	})
 
 	return c
@@ -116,7 +204,7 @@ func (c *Controller) Run(workers int, stop <-chan struct{}) error {
 	}
 
 	c.baseLogger.Debug("Wait for cache sync")
-	if !cache.WaitForCacheSync(stop, c.fleetSynced, c.fleetAutoscalerSynced) {
+	if !cache.WaitForCacheSync(stop, c.fleetSynced, c.fleetAutoscalerSynced, c.gameServerSynced, c.secretSynced) {
 		return errors.New("failed to wait for caches to sync")
 	}
 
@@ -168,9 +256,37 @@ func (c *Controller) validationHandler(review admissionv1.AdmissionReview) (admi
 	return review, nil
 }
 
+// syncInterval returns the interval between the syncs of the FleetAutoscaler, if it has its own
+func syncInterval(fas *autoscalingv1.FleetAutoscaler) (time.Duration, bool) {
+	if fas.Spec.Sync == nil || fas.Spec.Sync.Type != autoscalingv1.FixedIntervalSyncType ||
+		!runtime.FeatureEnabled(runtime.FeatureCustomFasSyncInterval) {
+		return 0, false
+	}
+	return time.Duration(fas.Spec.Sync.FixedInterval.Seconds) * time.Second, true
+}
+
+// enqueueOnAllocation enqueues the FleetAutoscalers of the fleet that sync when its allocated replicas change,
+// after their debounce period
+func (c *Controller) enqueueOnAllocation(f *agonesv1.Fleet) {
+	if !runtime.FeatureEnabled(runtime.FeatureCustomFasSyncInterval) {
+		return
+	}
+	list, err := c.fleetAutoscalerLister.FleetAutoscalers(f.ObjectMeta.Namespace).List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(c.baseLogger.WithField("fleet", f.ObjectMeta.Name), errors.Wrap(err, "error listing FleetAutoscalers"))
+		return
+	}
+	for _, fas := range list {
+		if !fas.ScalesFleet(f) || fas.Spec.Sync == nil || fas.Spec.Sync.OnAllocation == nil {
+			continue
+		}
+		c.workerqueue.EnqueueAfter(fas, time.Duration(fas.Spec.Sync.OnAllocation.DebounceMilliseconds)*time.Millisecond)
+	}
+}
+
 // syncFleetAutoscaler scales the attached fleet and
 // synchronizes the FleetAutoscaler CRD
-func (c *Controller) syncFleetAutoscaler(key string) error {
+func (c *Controller) syncFleetAutoscaler(key string) (err error) {
 	c.loggerForFleetAutoscalerKey(key).Debug("Synchronising")
 
 	// Convert the namespace/name string into a distinct namespace and name
@@ -190,6 +306,19 @@ func (c *Controller) syncFleetAutoscaler(key string) error {
 		return errors.Wrapf(err, "error retrieving FleetAutoscaler %s from namespace %s", name, namespace)
 	}
 
+	if interval, ok := syncInterval(fas); ok {
+		// failed syncs are already retried by the workerqueue, so the next sync is only scheduled after a successful one
+		defer func() {
+			if err == nil {
+				c.workerqueue.EnqueueAfter(fas, interval)
+			}
+		}()
+	}
+
+	if fas.HasFleetSelector() {
+		return c.syncFleetAutoscalerFleets(key, fas)
+	}
+
 	// Retrieve the fleet by spec name
 	fleet, err := c.fleetLister.Fleets(namespace).Get(fas.Spec.FleetName)
 	if err != nil {
@@ -210,8 +339,9 @@ func (c *Controller) syncFleetAutoscaler(key string) error {
 		return err
 	}
 
+	now := c.clock.Now()
 	currentReplicas := fleet.Status.Replicas
-	desiredReplicas, scalingLimited, err := computeDesiredFleetSize(fas, fleet)
+	desiredReplicas, scalingLimited, chainEntry, err := computeDesiredFleetSize(fas, fleet, now, c.webhookClient(fas, fleet, now), c.allocationCounts)
 	if err != nil {
 		c.recorder.Eventf(fas, corev1.EventTypeWarning, "FleetAutoscaler",
 			"Error calculating desired fleet size on FleetAutoscaler %s. Error: %s", fas.ObjectMeta.Name, err.Error())
@@ -222,12 +352,161 @@ func (c *Controller) syncFleetAutoscaler(key string) error {
 		return errors.Wrapf(err, "error calculating autoscaling fleet: %s", fleet.ObjectMeta.Name)
 	}
 
+	details := scaleDetails{
+		activeScheduleWindow: activeScheduleWindow(fas, chainEntry, now),
+		activeChainEntry:     chainEntry,
+	}
+	behavior := fas.Spec.Behavior != nil && runtime.FeatureEnabled(runtime.FeatureFleetAutoscalerBehavior)
+	if behavior {
+		details.recommendedReplicas = desiredReplicas
+		desiredReplicas, details.behaviorLimit = c.scaleHistories.applyBehavior(key, fas.Spec.Behavior, fleet.Spec.Replicas, desiredReplicas, now)
+	}
+
+	// In dry run mode, only publish the desired replicas, without scaling the fleet
+	if fas.IsDryRun() {
+		details.dryRun = true
+		if desiredReplicas != fleet.Spec.Replicas && desiredReplicas != fas.Status.DesiredReplicas {
+			c.recorder.Eventf(fas, corev1.EventTypeNormal, "DryRunAutoScalingFleet",
+				"Would scale fleet %s from %d to %d", fleet.ObjectMeta.Name, fleet.Spec.Replicas, desiredReplicas)
+		}
+		return c.updateStatus(fas, currentReplicas, desiredReplicas, false, scalingLimited, details)
+	}
+
 	// Scale the fleet to the new size
 	if err = c.scaleFleet(fas, fleet, desiredReplicas); err != nil {
 		return errors.Wrapf(err, "error autoscaling fleet %s to %d replicas", fas.Spec.FleetName, desiredReplicas)
 	}
+	if behavior && desiredReplicas != fleet.Spec.Replicas {
+		c.scaleHistories.recordScale(key, fleet.Spec.Replicas, desiredReplicas, now)
+	}
+
+	return c.updateStatus(fas, currentReplicas, desiredReplicas, desiredReplicas != fleet.Spec.Replicas, scalingLimited, details)
+}
+
+// syncFleetAutoscalerFleets scales the fleets selected by the fleet selector of the FleetAutoscaler,
+// within its budget, and synchronizes the FleetAutoscaler CRD
+func (c *Controller) syncFleetAutoscalerFleets(key string, fas *autoscalingv1.FleetAutoscaler) error {
+	selector, err := metav1.LabelSelectorAsSelector(fas.Spec.FleetSelector)
+	if err != nil {
+		// don't return an error, as we don't want this retried
+		runtime.HandleError(c.loggerForFleetAutoscaler(fas), errors.Wrap(err, "invalid fleet selector"))
+		return c.updateStatusUnableToScale(fas)
+	}
+	fleets, err := c.fleetLister.Fleets(fas.ObjectMeta.Namespace).List(selector)
+	if err != nil {
+		return errors.Wrapf(err, "error listing fleets for FleetAutoscaler %s", fas.ObjectMeta.Name)
+	}
+	if len(fleets) == 0 {
+		c.loggerForFleetAutoscaler(fas).Debug("Could not find any fleet for autoscaler. Skipping.")
+		c.recorder.Eventf(fas, corev1.EventTypeWarning, "FailedGetFleet", "no fleet matches the fleet selector")
+		return c.updateStatusUnableToScale(fas)
+	}
+	sort.Slice(fleets, func(i, j int) bool { return fleets[i].ObjectMeta.Name < fleets[j].ObjectMeta.Name })
+
+	now := c.clock.Now()
+	behavior := fas.Spec.Behavior != nil && runtime.FeatureEnabled(runtime.FeatureFleetAutoscalerBehavior)
+	demands := make([]fleetDemand, len(fleets))
+	limits := make([]bool, len(fleets))
+	for i, f := range fleets {
+		replicas, limited, _, err := computeDesiredFleetSize(fas, f, now, c.webhookClient(fas, f, now), c.allocationCounts)
+		if err != nil {
+			c.recorder.Eventf(fas, corev1.EventTypeWarning, "FleetAutoscaler",
+				"Error calculating desired size of fleet %s on FleetAutoscaler %s. Error: %s", f.ObjectMeta.Name, fas.ObjectMeta.Name, err.Error())
+
+			if err := c.updateStatusUnableToScale(fas); err != nil {
+				return err
+			}
+			return errors.Wrapf(err, "error calculating autoscaling fleet: %s", f.ObjectMeta.Name)
+		}
+		if behavior {
+			replicas, _ = c.scaleHistories.applyBehavior(key+"/"+f.ObjectMeta.Name, fas.Spec.Behavior, f.Spec.Replicas, replicas, now)
+		}
+		demands[i] = fleetDemand{replicas: replicas, kept: f.Status.AllocatedReplicas + f.Status.ReservedReplicas}
+		if fas.Spec.Budget != nil {
+			demands[i].priority = fas.Spec.Budget.Priority(f.ObjectMeta.Name)
+		}
+		limits[i] = limited
+	}
+	desired := divideBudget(fas.Spec.Budget, demands)
+
+	details := scaleDetails{dryRun: fas.IsDryRun()}
+	var currentReplicas, desiredReplicas int32
+	scaled, scalingLimited := false, false
+	for i, f := range fleets {
+		fleetStatus := autoscalingv1.FleetAutoscalerFleetStatus{
+			FleetName:       f.ObjectMeta.Name,
+			CurrentReplicas: f.Status.Replicas,
+			DesiredReplicas: desired[i],
+			DemandReplicas:  demands[i].replicas,
+			ScalingLimited:  limits[i] || desired[i] < demands[i].replicas,
+		}
+		details.fleets = append(details.fleets, fleetStatus)
+		currentReplicas += fleetStatus.CurrentReplicas
+		desiredReplicas += fleetStatus.DesiredReplicas
+		scalingLimited = scalingLimited || fleetStatus.ScalingLimited
+
+		if desired[i] == f.Spec.Replicas {
+			continue
+		}
+		if details.dryRun {
+			if previous, ok := lastFleetStatus(fas, f.ObjectMeta.Name); !ok || previous.DesiredReplicas != desired[i] {
+				c.recorder.Eventf(fas, corev1.EventTypeNormal, "DryRunAutoScalingFleet",
+					"Would scale fleet %s from %d to %d", f.ObjectMeta.Name, f.Spec.Replicas, desired[i])
+			}
+			continue
+		}
+		if err := c.scaleFleet(fas, f, desired[i]); err != nil {
+			return errors.Wrapf(err, "error autoscaling fleet %s to %d replicas", f.ObjectMeta.Name, desired[i])
+		}
+		if behavior {
+			c.scaleHistories.recordScale(key+"/"+f.ObjectMeta.Name, f.Spec.Replicas, desired[i], now)
+		}
+		scaled = true
+	}
 
-	return c.updateStatus(fas, currentReplicas, desiredReplicas, desiredReplicas != fleet.Spec.Replicas, scalingLimited)
+	return c.updateStatus(fas, currentReplicas, desiredReplicas, scaled, scalingLimited, details)
+}
+
+// lastFleetStatus returns the last status of the fleet with the given name, among the fleets selected by the FleetAutoscaler
+func lastFleetStatus(fas *autoscalingv1.FleetAutoscaler, name string) (autoscalingv1.FleetAutoscalerFleetStatus, bool) {
+	for _, s := range fas.Status.Fleets {
+		if s.FleetName == name {
+			return s, true
+		}
+	}
+	return autoscalingv1.FleetAutoscalerFleetStatus{}, false
+}
+
+// webhookClient returns what the Webhook policies of the FleetAutoscaler need to call their webhook for the given fleet
+func (c *Controller) webhookClient(fas *autoscalingv1.FleetAutoscaler, f *agonesv1.Fleet, now time.Time) *webhookClient {
+	wc := &webhookClient{secretLister: c.secretLister}
+	if key, err := cache.MetaNamespaceKeyFunc(fas); err == nil {
+		wc.conns, wc.key = c.webhookConns, key
+	}
+	if runtime.FeatureEnabled(runtime.FeatureFleetAutoscaleRequestDetails) {
+		wc.addRequestDetails = func(req *autoscalingv1.FleetAutoscaleRequest) {
+			c.addRequestDetails(req, fas, f, now)
+		}
+	}
+	return wc
+}
+
+// addRequestDetails adds the details of the fleet and of its autoscaler to a request to the webhook of the autoscaler
+func (c *Controller) addRequestDetails(req *autoscalingv1.FleetAutoscaleRequest, fas *autoscalingv1.FleetAutoscaler, f *agonesv1.Fleet, now time.Time) {
+	req.Labels = f.ObjectMeta.Labels
+	req.Annotations = f.ObjectMeta.Annotations
+	req.AutoscalerSpec = fas.Spec.DeepCopy()
+	req.AllocationRate = c.allocationHistories.rate(f.ObjectMeta.Namespace+"/"+f.ObjectMeta.Name, now)
+
+	list, err := c.gameServerLister.GameServers(f.ObjectMeta.Namespace).List(labels.SelectorFromSet(labels.Set{agonesv1.FleetNameLabel: f.ObjectMeta.Name}))
+	if err != nil {
+		runtime.HandleError(c.loggerForFleetAutoscaler(fas), errors.Wrapf(err, "could not list the game servers of fleet %s", f.ObjectMeta.Name))
+		return
+	}
+	req.GameServerStates = map[agonesv1.GameServerState]int32{}
+	for _, gs := range list {
+		req.GameServerStates[gs.Status.State]++
+	}
 }
 
 // scaleFleet scales the fleet of the autoscaler to a new number of replicas
@@ -250,20 +529,30 @@ func (c *Controller) scaleFleet(fas *autoscalingv1.FleetAutoscaler, f *agonesv1.
 }
 
 // updateStatus updates the status of the given FleetAutoscaler
-func (c *Controller) updateStatus(fas *autoscalingv1.FleetAutoscaler, currentReplicas int32, desiredReplicas int32, scaled bool, scalingLimited bool) error {
+func (c *Controller) updateStatus(fas *autoscalingv1.FleetAutoscaler, currentReplicas int32, desiredReplicas int32, scaled bool, scalingLimited bool, details scaleDetails) error {
 	fasCopy := fas.DeepCopy()
 	fasCopy.Status.AbleToScale = true
 	fasCopy.Status.ScalingLimited = scalingLimited
 	fasCopy.Status.CurrentReplicas = currentReplicas
 	fasCopy.Status.DesiredReplicas = desiredReplicas
+	fasCopy.Status.ActiveScheduleWindow = details.activeScheduleWindow
+	fasCopy.Status.ActiveChainEntry = details.activeChainEntry
+	fasCopy.Status.RecommendedReplicas = details.recommendedReplicas
+	fasCopy.Status.BehaviorLimit = details.behaviorLimit
+	fasCopy.Status.DryRun = details.dryRun
+	fasCopy.Status.Fleets = details.fleets
 	if scaled {
-		now := metav1.NewTime(time.Now())
+		now := metav1.NewTime(c.clock.Now())
 		fasCopy.Status.LastScaleTime = &now
 	}
 
 	if !apiequality.Semantic.DeepEqual(fas.Status, fasCopy.Status) {
 		if scalingLimited {
-			c.recorder.Eventf(fas, corev1.EventTypeWarning, "ScalingLimited", "Scaling fleet %s was limited to maximum size of %d", fas.Spec.FleetName, desiredReplicas)
+			if details.fleets != nil {
+				c.recorder.Eventf(fas, corev1.EventTypeWarning, "ScalingLimited", "Scaling the selected fleets was limited to a total size of %d", desiredReplicas)
+			} else {
+				c.recorder.Eventf(fas, corev1.EventTypeWarning, "ScalingLimited", "Scaling fleet %s was limited to maximum size of %d", fas.Spec.FleetName, desiredReplicas)
+			}
 		}
 
 		_, err := c.fleetAutoscalerGetter.FleetAutoscalers(fas.ObjectMeta.Namespace).UpdateStatus(fasCopy)
@@ -282,6 +571,10 @@ func (c *Controller) updateStatusUnableToScale(fas *autoscalingv1.FleetAutoscale
 	fasCopy.Status.ScalingLimited = false
 	fasCopy.Status.CurrentReplicas = 0
 	fasCopy.Status.DesiredReplicas = 0
+	fasCopy.Status.ActiveScheduleWindow = ""
+	fasCopy.Status.ActiveChainEntry = ""
+	fasCopy.Status.RecommendedReplicas = 0
+	fasCopy.Status.BehaviorLimit = ""
 
 	if !apiequality.Semantic.DeepEqual(fas.Status, fasCopy.Status) {
 		_, err := c.fleetAutoscalerGetter.FleetAutoscalers(fas.ObjectMeta.Namespace).UpdateStatus(fasCopy)
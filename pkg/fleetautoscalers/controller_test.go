@@ -20,11 +20,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
 	autoscalingv1 "agones.dev/agones/pkg/apis/autoscaling/v1"
 	agtesting "agones.dev/agones/pkg/testing"
+	"agones.dev/agones/pkg/util/logfields"
+	utilruntime "agones.dev/agones/pkg/util/runtime"
 	"agones.dev/agones/pkg/util/webhooks"
+	"agones.dev/agones/pkg/util/workerqueue"
 	"github.com/heptiolabs/healthcheck"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -32,7 +36,9 @@ import (
 	admregv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/watch"
 	k8stesting "k8s.io/client-go/testing"
 )
 
@@ -414,7 +420,7 @@ func TestControllerSyncFleetAutoscaler(t *testing.T) {
 
 		err := c.syncFleetAutoscaler("default/fas-1")
 		if assert.NotNil(t, err) {
-			assert.Equal(t, "error calculating autoscaling fleet: fleet-1: wrong policy type, should be one of: Buffer, Webhook", err.Error())
+			assert.Equal(t, "error calculating autoscaling fleet: fleet-1: wrong policy type, should be one of: Buffer, Webhook, Schedule, Chain, PlayerBuffer, Predictive", err.Error())
 		}
 	})
 
@@ -481,6 +487,514 @@ func TestControllerSyncFleetAutoscaler(t *testing.T) {
 	})
 }
 
+func TestControllerSyncFleetAutoscalerSchedule(t *testing.T) {
+	utilruntime.FeatureTestMutex.Lock()
+	defer utilruntime.FeatureTestMutex.Unlock()
+	assert.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureScheduledAutoscaler)+"=true"))
+	defer utilruntime.ParseFeatures("") // nolint: errcheck
+
+	c, m := newFakeController()
+	fc := clock.NewFakeClock(time.Date(2020, 10, 2, 19, 0, 0, 0, time.UTC))
+	c.clock = fc
+	fas, f := defaultFixtures()
+	fas.Spec.Policy = autoscalingv1.FleetAutoscalerPolicy{
+		Type: autoscalingv1.SchedulePolicyType,
+		Schedule: &autoscalingv1.SchedulePolicy{
+			Default: autoscalingv1.BufferPolicy{BufferSize: intstr.FromInt(5), MaxReplicas: 100},
+			Windows: []autoscalingv1.ScheduleWindow{{
+				Name:     "evening",
+				Start:    "0 18 * * *",
+				Duration: metav1.Duration{Duration: 2 * time.Hour},
+				Buffer:   autoscalingv1.BufferPolicy{BufferSize: intstr.FromInt(20), MaxReplicas: 100},
+			}},
+		},
+	}
+	f.Spec.Replicas = 5
+	f.Status.Replicas = 5
+	f.Status.AllocatedReplicas = 5
+	f.Status.ReadyReplicas = 0
+
+	var updatedFas *autoscalingv1.FleetAutoscaler
+	var updatedReplicas int32
+	m.AgonesClient.AddReactor("list", "fleetautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &autoscalingv1.FleetAutoscalerList{Items: []autoscalingv1.FleetAutoscaler{*fas}}, nil
+	})
+	m.AgonesClient.AddReactor("update", "fleetautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		updatedFas = action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.FleetAutoscaler)
+		return true, updatedFas, nil
+	})
+	m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &agonesv1.FleetList{Items: []agonesv1.Fleet{*f}}, nil
+	})
+	m.AgonesClient.AddReactor("update", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		f := action.(k8stesting.UpdateAction).GetObject().(*agonesv1.Fleet)
+		updatedReplicas = f.Spec.Replicas
+		return true, f, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, c.fleetSynced, c.fleetAutoscalerSynced)
+	defer cancel()
+
+	err := c.syncFleetAutoscaler("default/fas-1")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(25), updatedReplicas)
+	if assert.NotNil(t, updatedFas) {
+		assert.Equal(t, "evening", updatedFas.Status.ActiveScheduleWindow)
+		assert.Equal(t, int32(25), updatedFas.Status.DesiredReplicas)
+	}
+
+	// outside of the window, the default buffer policy applies
+	fc.Step(2 * time.Hour)
+	updatedFas = nil
+	err = c.syncFleetAutoscaler("default/fas-1")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(10), updatedReplicas)
+	if assert.NotNil(t, updatedFas) {
+		assert.Equal(t, "", updatedFas.Status.ActiveScheduleWindow)
+	}
+}
+
+func TestControllerSyncFleetAutoscalerChain(t *testing.T) {
+	utilruntime.FeatureTestMutex.Lock()
+	defer utilruntime.FeatureTestMutex.Unlock()
+	assert.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureScheduledAutoscaler)+"=true&"+string(utilruntime.FeatureChainedAutoscaler)+"=true"))
+	defer utilruntime.ParseFeatures("") // nolint: errcheck
+
+	c, m := newFakeController()
+	c.clock = clock.NewFakeClock(time.Date(2020, 10, 2, 19, 0, 0, 0, time.UTC))
+	fas, f := defaultFixtures()
+	wrongServerURL := "http://127.0.0.1:1"
+	fas.Spec.Policy = autoscalingv1.FleetAutoscalerPolicy{
+		Type: autoscalingv1.ChainPolicyType,
+		Chain: &autoscalingv1.ChainPolicy{
+			Combine: autoscalingv1.FirstMatchCombineRule,
+			Policies: []autoscalingv1.ChainEntry{
+				{
+					ID: "webhook",
+					FleetAutoscalerPolicy: autoscalingv1.FleetAutoscalerPolicy{
+						Type:    autoscalingv1.WebhookPolicyType,
+						Webhook: &autoscalingv1.WebhookPolicy{URL: &wrongServerURL},
+					},
+				},
+				{
+					ID: "schedule",
+					FleetAutoscalerPolicy: autoscalingv1.FleetAutoscalerPolicy{
+						Type: autoscalingv1.SchedulePolicyType,
+						Schedule: &autoscalingv1.SchedulePolicy{
+							Default: autoscalingv1.BufferPolicy{BufferSize: intstr.FromInt(5), MaxReplicas: 100},
+							Windows: []autoscalingv1.ScheduleWindow{{
+								Name:     "evening",
+								Start:    "0 18 * * *",
+								Duration: metav1.Duration{Duration: 2 * time.Hour},
+								Buffer:   autoscalingv1.BufferPolicy{BufferSize: intstr.FromInt(20), MaxReplicas: 100},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+	f.Spec.Replicas = 5
+	f.Status.Replicas = 5
+	f.Status.AllocatedReplicas = 5
+	f.Status.ReadyReplicas = 0
+
+	var updatedFas *autoscalingv1.FleetAutoscaler
+	var updatedReplicas int32
+	m.AgonesClient.AddReactor("list", "fleetautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &autoscalingv1.FleetAutoscalerList{Items: []autoscalingv1.FleetAutoscaler{*fas}}, nil
+	})
+	m.AgonesClient.AddReactor("update", "fleetautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		updatedFas = action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.FleetAutoscaler)
+		return true, updatedFas, nil
+	})
+	m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &agonesv1.FleetList{Items: []agonesv1.Fleet{*f}}, nil
+	})
+	m.AgonesClient.AddReactor("update", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		f := action.(k8stesting.UpdateAction).GetObject().(*agonesv1.Fleet)
+		updatedReplicas = f.Spec.Replicas
+		return true, f, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, c.fleetSynced, c.fleetAutoscalerSynced)
+	defer cancel()
+
+	err := c.syncFleetAutoscaler("default/fas-1")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(25), updatedReplicas)
+	if assert.NotNil(t, updatedFas) {
+		assert.Equal(t, "schedule", updatedFas.Status.ActiveChainEntry)
+		assert.Equal(t, "evening", updatedFas.Status.ActiveScheduleWindow)
+	}
+}
+
+func TestControllerSyncFleetAutoscalerBehavior(t *testing.T) {
+	utilruntime.FeatureTestMutex.Lock()
+	defer utilruntime.FeatureTestMutex.Unlock()
+	assert.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureFleetAutoscalerBehavior)+"=true"))
+	defer utilruntime.ParseFeatures("") // nolint: errcheck
+
+	c, m := newFakeController()
+	fc := clock.NewFakeClock(time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC))
+	c.clock = fc
+	fas, f := defaultFixtures()
+	fas.Spec.Policy.Buffer.BufferSize = intstr.FromInt(20)
+	fas.Spec.Behavior = &autoscalingv1.FleetAutoscalerBehavior{
+		ScaleUp: &autoscalingv1.ScalingRateLimit{MaxChange: intstr.FromInt(4), PeriodSeconds: 60},
+	}
+	f.Spec.Replicas = 5
+	f.Status.Replicas = 5
+	f.Status.AllocatedReplicas = 5
+	f.Status.ReadyReplicas = 0
+
+	var updatedFas *autoscalingv1.FleetAutoscaler
+	var updatedReplicas []int32
+	m.AgonesClient.AddReactor("list", "fleetautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &autoscalingv1.FleetAutoscalerList{Items: []autoscalingv1.FleetAutoscaler{*fas}}, nil
+	})
+	m.AgonesClient.AddReactor("update", "fleetautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		updatedFas = action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.FleetAutoscaler)
+		return true, updatedFas, nil
+	})
+	m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &agonesv1.FleetList{Items: []agonesv1.Fleet{*f}}, nil
+	})
+	m.AgonesClient.AddReactor("update", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		f := action.(k8stesting.UpdateAction).GetObject().(*agonesv1.Fleet)
+		updatedReplicas = append(updatedReplicas, f.Spec.Replicas)
+		return true, f, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, c.fleetSynced, c.fleetAutoscalerSynced)
+	defer cancel()
+
+	err := c.syncFleetAutoscaler("default/fas-1")
+	assert.Nil(t, err)
+	assert.Equal(t, []int32{9}, updatedReplicas)
+	if assert.NotNil(t, updatedFas) {
+		assert.Equal(t, int32(9), updatedFas.Status.DesiredReplicas)
+		assert.Equal(t, int32(25), updatedFas.Status.RecommendedReplicas)
+		assert.Equal(t, autoscalingv1.ScaleUpRateLimit, updatedFas.Status.BehaviorLimit)
+	}
+
+	// the fleet lister still has the fleet at 5 replicas, but the scale up is already recorded
+	fc.Step(30 * time.Second)
+	err = c.syncFleetAutoscaler("default/fas-1")
+	assert.Nil(t, err)
+	assert.Equal(t, []int32{9}, updatedReplicas)
+}
+
+func TestControllerSyncFleetAutoscalerDryRun(t *testing.T) {
+	utilruntime.FeatureTestMutex.Lock()
+	defer utilruntime.FeatureTestMutex.Unlock()
+	assert.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureFleetAutoscalerDryRun)+"=true"))
+	defer utilruntime.ParseFeatures("") // nolint: errcheck
+
+	c, m := newFakeController()
+	fas, f := defaultFixtures()
+	fas.Spec.Policy.Buffer.BufferSize = intstr.FromInt(7)
+	fas.Spec.Mode = autoscalingv1.DryRunFleetAutoscalerMode
+	f.Spec.Replicas = 5
+	f.Status.Replicas = 5
+	f.Status.AllocatedReplicas = 5
+	f.Status.ReadyReplicas = 0
+
+	fleetUpdated := false
+	var updatedFas *autoscalingv1.FleetAutoscaler
+	m.AgonesClient.AddReactor("list", "fleetautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &autoscalingv1.FleetAutoscalerList{Items: []autoscalingv1.FleetAutoscaler{*fas}}, nil
+	})
+	m.AgonesClient.AddReactor("update", "fleetautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		updatedFas = action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.FleetAutoscaler)
+		return true, updatedFas, nil
+	})
+	m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &agonesv1.FleetList{Items: []agonesv1.Fleet{*f}}, nil
+	})
+	m.AgonesClient.AddReactor("update", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		fleetUpdated = true
+		return true, action.(k8stesting.UpdateAction).GetObject(), nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, c.fleetSynced, c.fleetAutoscalerSynced)
+	defer cancel()
+
+	err := c.syncFleetAutoscaler("default/fas-1")
+	assert.Nil(t, err)
+	assert.False(t, fleetUpdated, "the fleet should not be scaled in dry run mode")
+	agtesting.AssertEventContains(t, m.FakeRecorder.Events, "DryRunAutoScalingFleet")
+	agtesting.AssertNoEvent(t, m.FakeRecorder.Events)
+	if assert.NotNil(t, updatedFas) {
+		assert.Equal(t, int32(12), updatedFas.Status.DesiredReplicas)
+		assert.Equal(t, int32(5), updatedFas.Status.CurrentReplicas)
+		assert.True(t, updatedFas.Status.DryRun)
+		assert.Nil(t, updatedFas.Status.LastScaleTime)
+	}
+
+	// the mode is ignored when the feature is disabled
+	assert.NoError(t, utilruntime.ParseFeatures(""))
+	fleetUpdated = false
+	err = c.syncFleetAutoscaler("default/fas-1")
+	assert.Nil(t, err)
+	assert.True(t, fleetUpdated)
+}
+
+func TestControllerSyncFleetAutoscalerFleets(t *testing.T) {
+	utilruntime.FeatureTestMutex.Lock()
+	defer utilruntime.FeatureTestMutex.Unlock()
+	assert.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureFleetAutoscalerBudget)+"=true"))
+	defer utilruntime.ParseFeatures("") // nolint: errcheck
+
+	c, m := newFakeController()
+	fas, f1 := defaultFixtures()
+	fas.Spec.FleetName = ""
+	fas.Spec.FleetSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"game": "ctf"}}
+	fas.Spec.Policy.Buffer.BufferSize = intstr.FromInt(10)
+	fas.Spec.Budget = &autoscalingv1.FleetAutoscalerBudget{
+		MaxReplicas: 40,
+		Priorities:  []autoscalingv1.FleetPriority{{FleetName: "fleet-2", Priority: 1}},
+	}
+	f1.ObjectMeta.Labels = map[string]string{"game": "ctf"}
+	f1.Spec.Replicas = 25
+	f1.Status = agonesv1.FleetStatus{Replicas: 25, ReadyReplicas: 5, AllocatedReplicas: 20}
+	f2 := f1.DeepCopy()
+	f2.ObjectMeta.Name = "fleet-2"
+	f2.Spec.Replicas = 15
+	f2.Status = agonesv1.FleetStatus{Replicas: 15, ReadyReplicas: 5, AllocatedReplicas: 10}
+	f3 := f1.DeepCopy()
+	f3.ObjectMeta.Name = "fleet-3"
+	f3.ObjectMeta.Labels = map[string]string{"game": "deathmatch"}
+
+	var updatedFas *autoscalingv1.FleetAutoscaler
+	updatedReplicas := map[string]int32{}
+	m.AgonesClient.AddReactor("list", "fleetautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &autoscalingv1.FleetAutoscalerList{Items: []autoscalingv1.FleetAutoscaler{*fas}}, nil
+	})
+	m.AgonesClient.AddReactor("update", "fleetautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		updatedFas = action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.FleetAutoscaler)
+		return true, updatedFas, nil
+	})
+	m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &agonesv1.FleetList{Items: []agonesv1.Fleet{*f1, *f2, *f3}}, nil
+	})
+	m.AgonesClient.AddReactor("update", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		f := action.(k8stesting.UpdateAction).GetObject().(*agonesv1.Fleet)
+		updatedReplicas[f.ObjectMeta.Name] = f.Spec.Replicas
+		return true, f, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, c.fleetSynced, c.fleetAutoscalerSynced)
+	defer cancel()
+
+	// the budget keeps the allocated replicas of each fleet, and the rest goes to fleet-2 first
+	err := c.syncFleetAutoscaler("default/fas-1")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int32{"fleet-1": 20, "fleet-2": 20}, updatedReplicas)
+	if assert.NotNil(t, updatedFas) {
+		assert.Equal(t, int32(40), updatedFas.Status.CurrentReplicas)
+		assert.Equal(t, int32(40), updatedFas.Status.DesiredReplicas)
+		assert.True(t, updatedFas.Status.ScalingLimited)
+		assert.Equal(t, []autoscalingv1.FleetAutoscalerFleetStatus{
+			{FleetName: "fleet-1", CurrentReplicas: 25, DesiredReplicas: 20, DemandReplicas: 30, ScalingLimited: true},
+			{FleetName: "fleet-2", CurrentReplicas: 15, DesiredReplicas: 20, DemandReplicas: 20},
+		}, updatedFas.Status.Fleets)
+	}
+	agtesting.AssertEventContains(t, m.FakeRecorder.Events, "AutoScalingFleet")
+	agtesting.AssertEventContains(t, m.FakeRecorder.Events, "AutoScalingFleet")
+	agtesting.AssertEventContains(t, m.FakeRecorder.Events, "ScalingLimited")
+}
+
+func TestControllerSyncInterval(t *testing.T) {
+	utilruntime.FeatureTestMutex.Lock()
+	defer utilruntime.FeatureTestMutex.Unlock()
+
+	fas, _ := defaultFixtures()
+	_, ok := syncInterval(fas)
+	assert.False(t, ok)
+
+	fas.Spec.Sync = &autoscalingv1.FleetAutoscalerSync{
+		Type:          autoscalingv1.FixedIntervalSyncType,
+		FixedInterval: autoscalingv1.FixedIntervalSync{Seconds: 5},
+	}
+	_, ok = syncInterval(fas)
+	assert.False(t, ok, "the feature flag is disabled")
+
+	assert.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureCustomFasSyncInterval)+"=true"))
+	defer utilruntime.ParseFeatures("") // nolint: errcheck
+	interval, ok := syncInterval(fas)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Second, interval)
+}
+
+func TestControllerSyncIntervalIsNotExceeded(t *testing.T) {
+	utilruntime.FeatureTestMutex.Lock()
+	defer utilruntime.FeatureTestMutex.Unlock()
+	assert.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureCustomFasSyncInterval)+"=true"))
+	defer utilruntime.ParseFeatures("") // nolint: errcheck
+
+	c, m := newFakeController()
+	fas, f := defaultFixtures()
+	fas.ObjectMeta.Generation = 1
+	fas.ObjectMeta.ResourceVersion = "1"
+	fas.Spec.Sync = &autoscalingv1.FleetAutoscalerSync{
+		Type:          autoscalingv1.FixedIntervalSyncType,
+		FixedInterval: autoscalingv1.FixedIntervalSync{Seconds: 1},
+	}
+	interval := time.Second
+
+	fasWatch := watch.NewFakeWithChanSize(10, false)
+	m.AgonesClient.AddWatchReactor("fleetautoscalers", k8stesting.DefaultWatchReactor(fasWatch, nil))
+	m.AgonesClient.AddReactor("list", "fleetautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &autoscalingv1.FleetAutoscalerList{Items: []autoscalingv1.FleetAutoscaler{*fas}}, nil
+	})
+	m.AgonesClient.AddReactor("list", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &agonesv1.FleetList{Items: []agonesv1.Fleet{*f}}, nil
+	})
+	m.AgonesClient.AddReactor("update", "fleets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, action.(k8stesting.UpdateAction).GetObject(), nil
+	})
+	// the first status update fails, and each of the others is seen by the informer as a status-only update
+	statusUpdates := 0
+	m.AgonesClient.AddReactor("update", "fleetautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		statusUpdates++
+		if statusUpdates == 1 {
+			return true, nil, errors.New("update failed")
+		}
+		updated := action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.FleetAutoscaler).DeepCopy()
+		updated.ObjectMeta.ResourceVersion = fmt.Sprint(statusUpdates)
+		fasWatch.Modify(updated)
+		return true, updated, nil
+	})
+
+	type sync struct {
+		start time.Time
+		err   error
+	}
+	syncs := make(chan sync, 100)
+	c.workerqueue = workerqueue.NewWorkerQueueWithRateLimiter(func(key string) error {
+		start := time.Now()
+		err := c.syncFleetAutoscaler(key)
+		syncs <- sync{start: start, err: err}
+		return err
+	}, c.baseLogger, logfields.FleetAutoscalerKey, "test", workerqueue.FastRateLimiter(3*time.Second))
+
+	stop, cancel := agtesting.StartInformers(m, c.fleetSynced, c.fleetAutoscalerSynced)
+	defer cancel()
+	go c.workerqueue.Run(1, stop)
+	c.workerqueue.Enqueue(fas)
+
+	time.Sleep(3*interval + 500*time.Millisecond)
+	cancel()
+
+	var previous *sync
+	count := 0
+	for len(syncs) > 0 {
+		s := <-syncs
+		count++
+		if previous != nil && previous.err == nil {
+			assert.True(t, s.start.Sub(previous.start) >= interval,
+				"sync %d came %v after a successful sync, before the interval", count, s.start.Sub(previous.start))
+		}
+		previous = &s
+	}
+	// the failed sync, its retry, and up to three syncs on the interval
+	assert.True(t, count >= 3 && count <= 5, "unexpected number of syncs: %d", count)
+}
+
+func TestControllerEnqueueOnAllocation(t *testing.T) {
+	utilruntime.FeatureTestMutex.Lock()
+	defer utilruntime.FeatureTestMutex.Unlock()
+	assert.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureCustomFasSyncInterval)+"=true"))
+	defer utilruntime.ParseFeatures("") // nolint: errcheck
+
+	c, m := newFakeController()
+	fas, f := defaultFixtures()
+	fas.Spec.Sync = &autoscalingv1.FleetAutoscalerSync{
+		Type:          autoscalingv1.FixedIntervalSyncType,
+		FixedInterval: autoscalingv1.FixedIntervalSync{Seconds: 300},
+		OnAllocation:  &autoscalingv1.OnAllocationSync{DebounceMilliseconds: 10},
+	}
+	noOnAllocation := fas.DeepCopy()
+	noOnAllocation.ObjectMeta.Name = "fas-2"
+	noOnAllocation.Spec.Sync.OnAllocation = nil
+	otherFleet := fas.DeepCopy()
+	otherFleet.ObjectMeta.Name = "fas-3"
+	otherFleet.Spec.FleetName = "other"
+
+	m.AgonesClient.AddReactor("list", "fleetautoscalers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &autoscalingv1.FleetAutoscalerList{Items: []autoscalingv1.FleetAutoscaler{*fas, *noOnAllocation, *otherFleet}}, nil
+	})
+
+	keys := make(chan string, 10)
+	c.workerqueue = workerqueue.NewWorkerQueue(func(key string) error {
+		keys <- key
+		return nil
+	}, c.baseLogger, logfields.FleetAutoscalerKey, "test")
+
+	stop, cancel := agtesting.StartInformers(m, c.fleetAutoscalerSynced)
+	defer cancel()
+	go c.workerqueue.Run(1, stop)
+
+	c.enqueueOnAllocation(f)
+	select {
+	case key := <-keys:
+		assert.Equal(t, "default/fas-1", key)
+	case <-time.After(5 * time.Second):
+		assert.FailNow(t, "the FleetAutoscaler should have been enqueued")
+	}
+	select {
+	case key := <-keys:
+		assert.FailNow(t, "only one FleetAutoscaler should have been enqueued", key)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestControllerAddRequestDetails(t *testing.T) {
+	t.Parallel()
+
+	c, m := newFakeController()
+	fas, f := defaultFixtures()
+	f.ObjectMeta.Labels = map[string]string{"mode": "deathmatch"}
+	f.ObjectMeta.Annotations = map[string]string{"region": "eu"}
+
+	gameServer := func(name, fleetName string, state agonesv1.GameServerState) agonesv1.GameServer {
+		return agonesv1.GameServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: f.ObjectMeta.Namespace, Labels: map[string]string{agonesv1.FleetNameLabel: fleetName}},
+			Status:     agonesv1.GameServerStatus{State: state},
+		}
+	}
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &agonesv1.GameServerList{Items: []agonesv1.GameServer{
+			gameServer("gs1", f.ObjectMeta.Name, agonesv1.GameServerStateReady),
+			gameServer("gs2", f.ObjectMeta.Name, agonesv1.GameServerStateStarting),
+			gameServer("gs3", f.ObjectMeta.Name, agonesv1.GameServerStateAllocated),
+			gameServer("gs4", f.ObjectMeta.Name, agonesv1.GameServerStateAllocated),
+			gameServer("gs5", "other", agonesv1.GameServerStateUnhealthy),
+		}}, nil
+	})
+
+	_, cancel := agtesting.StartInformers(m, c.gameServerSynced)
+	defer cancel()
+
+	now := time.Now()
+	c.allocationHistories.record("default/fleet-1", now.Add(-2*time.Minute))
+	c.allocationHistories.record("default/fleet-1", now.Add(-10*time.Second))
+
+	req := &autoscalingv1.FleetAutoscaleRequest{Name: f.ObjectMeta.Name, Namespace: f.ObjectMeta.Namespace}
+	c.addRequestDetails(req, fas, f, now)
+
+	assert.Equal(t, f.ObjectMeta.Labels, req.Labels)
+	assert.Equal(t, f.ObjectMeta.Annotations, req.Annotations)
+	assert.Equal(t, &fas.Spec, req.AutoscalerSpec)
+	assert.Equal(t, &autoscalingv1.FleetAllocationRate{LastMinute: 1, LastFiveMinutes: 2}, req.AllocationRate)
+	assert.Equal(t, map[agonesv1.GameServerState]int32{
+		agonesv1.GameServerStateReady:     1,
+		agonesv1.GameServerStateStarting:  1,
+		agonesv1.GameServerStateAllocated: 2,
+	}, req.GameServerStates)
+}
+
 func TestControllerScaleFleet(t *testing.T) {
 	t.Parallel()
 
@@ -563,7 +1077,7 @@ func TestControllerUpdateStatus(t *testing.T) {
 		_, cancel := agtesting.StartInformers(m, c.fleetAutoscalerSynced)
 		defer cancel()
 
-		err := c.updateStatus(fas, 10, 20, true, false)
+		err := c.updateStatus(fas, 10, 20, true, false, scaleDetails{})
 		assert.Nil(t, err)
 		assert.True(t, fasUpdated)
 		agtesting.AssertNoEvent(t, m.FakeRecorder.Events)
@@ -587,7 +1101,7 @@ func TestControllerUpdateStatus(t *testing.T) {
 		_, cancel := agtesting.StartInformers(m, c.fleetAutoscalerSynced)
 		defer cancel()
 
-		err := c.updateStatus(fas, fas.Status.CurrentReplicas, fas.Status.DesiredReplicas, false, fas.Status.ScalingLimited)
+		err := c.updateStatus(fas, fas.Status.CurrentReplicas, fas.Status.DesiredReplicas, false, fas.Status.ScalingLimited, scaleDetails{})
 		assert.Nil(t, err)
 		agtesting.AssertNoEvent(t, m.FakeRecorder.Events)
 	})
@@ -603,7 +1117,7 @@ func TestControllerUpdateStatus(t *testing.T) {
 		_, cancel := agtesting.StartInformers(m, c.fleetAutoscalerSynced)
 		defer cancel()
 
-		err := c.updateStatus(fas, fas.Status.CurrentReplicas, fas.Status.DesiredReplicas, false, fas.Status.ScalingLimited)
+		err := c.updateStatus(fas, fas.Status.CurrentReplicas, fas.Status.DesiredReplicas, false, fas.Status.ScalingLimited, scaleDetails{})
 		if assert.NotNil(t, err) {
 			assert.Equal(t, "error updating status for fleetautoscaler fas-1: random-err", err.Error())
 		}
@@ -614,7 +1128,7 @@ func TestControllerUpdateStatus(t *testing.T) {
 		c, m := newFakeController()
 		fas, _ := defaultFixtures()
 
-		err := c.updateStatus(fas, 10, 20, true, true)
+		err := c.updateStatus(fas, 10, 20, true, true, scaleDetails{})
 		assert.Nil(t, err)
 		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "ScalingLimited")
 	})
@@ -752,7 +1266,7 @@ func defaultWebhookFixtures() (*autoscalingv1.FleetAutoscaler, *agonesv1.Fleet)
 func newFakeController() (*Controller, agtesting.Mocks) {
 	m := agtesting.NewMocks()
 	wh := webhooks.NewWebHook(http.NewServeMux())
-	c := NewController(wh, healthcheck.NewHandler(), m.KubeClient, m.ExtClient, m.AgonesClient, m.AgonesInformerFactory)
+	c := NewController(wh, healthcheck.NewHandler(), m.KubeClient, m.KubeInformerFactory, m.ExtClient, m.AgonesClient, m.AgonesInformerFactory)
 	c.recorder = m.FakeRecorder
 	return c, m
 }
@@ -17,6 +17,7 @@
 package fleetautoscalers
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -30,28 +31,108 @@ import (
 
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
 	autoscalingv1 "agones.dev/agones/pkg/apis/autoscaling/v1"
+	pb "agones.dev/agones/pkg/autoscaling/go"
+	"agones.dev/agones/pkg/util/runtime"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	corev1lister "k8s.io/client-go/listers/core/v1"
 )
 
-var client = http.Client{
-	Timeout: 15 * time.Second,
+const (
+	// defaultWebhookTimeout is the timeout of the calls to a webhook, retries included, unless its transport sets one
+	defaultWebhookTimeout = 15 * time.Second
+	// defaultWebhookRetryBackoff is how long to wait before the first retry of a failed call to a webhook,
+	// unless its transport sets it
+	defaultWebhookRetryBackoff = 100 * time.Millisecond
+
+	secretClientCertName = "tls.crt"
+	secretClientKeyName  = "tls.key"
+	secretCACertName     = "ca.crt"
+)
+
+// webhookClient is what the Webhook policies of a FleetAutoscaler need from the controller to call their webhook
+type webhookClient struct {
+	// secretLister gets the client certificates of the webhooks
+	secretLister corev1lister.SecretLister
+	// conns keeps the connections to the webhooks between syncs, by key, if set
+	conns *webhookConns
+	// key is the key of the connection to the webhook in conns
+	key string
+	// addRequestDetails adds the details of the fleet and of the FleetAutoscaler to the requests, if set
+	addRequestDetails func(req *autoscalingv1.FleetAutoscaleRequest)
+}
+
+// forChainEntry returns the webhookClient for the policy of a chain with the given ID,
+// which keeps its own connection to its webhook
+func (wc *webhookClient) forChainEntry(id string) *webhookClient {
+	if wc == nil {
+		return nil
+	}
+	result := *wc
+	result.key = wc.key + "/" + id
+	return &result
 }
 
-// computeDesiredFleetSize computes the new desired size of the given fleet
-func computeDesiredFleetSize(fas *autoscalingv1.FleetAutoscaler, f *agonesv1.Fleet) (int32, bool, error) {
-	switch fas.Spec.Policy.Type {
+// computeDesiredFleetSize computes the new desired size of the given fleet.
+// For Chain policies, it also returns the ID of the policy of the chain that produced it.
+func computeDesiredFleetSize(fas *autoscalingv1.FleetAutoscaler, f *agonesv1.Fleet, now time.Time, wc *webhookClient, ac *allocationCounts) (int32, bool, string, error) {
+	if fas.Spec.Policy.Type == autoscalingv1.ChainPolicyType && runtime.FeatureEnabled(runtime.FeatureChainedAutoscaler) {
+		return applyChainPolicy(fas.Spec.Policy.Chain, f, now, wc, ac)
+	}
+	replicas, limited, err := applyPolicy(&fas.Spec.Policy, f, now, wc, ac)
+	return replicas, limited, "", err
+}
+
+// applyPolicy computes the new desired size of the given fleet with a policy that is not a Chain policy
+func applyPolicy(p *autoscalingv1.FleetAutoscalerPolicy, f *agonesv1.Fleet, now time.Time, wc *webhookClient, ac *allocationCounts) (int32, bool, error) {
+	switch p.Type {
 	case autoscalingv1.BufferPolicyType:
-		return applyBufferPolicy(fas.Spec.Policy.Buffer, f)
+		return applyBufferPolicy(p.Buffer, f)
 	case autoscalingv1.WebhookPolicyType:
-		return applyWebhookPolicy(fas.Spec.Policy.Webhook, f)
+		return applyWebhookPolicy(p.Webhook, p.WebhookTransport, f, wc)
+	case autoscalingv1.SchedulePolicyType:
+		if runtime.FeatureEnabled(runtime.FeatureScheduledAutoscaler) {
+			return applySchedulePolicy(p.Schedule, f, now)
+		}
+	case autoscalingv1.PlayerBufferPolicyType:
+		if runtime.FeatureEnabled(runtime.FeaturePlayerBufferAutoscaler) && runtime.FeatureEnabled(runtime.FeaturePlayerTracking) {
+			return applyPlayerBufferPolicy(p.PlayerBuffer, f)
+		}
+	case autoscalingv1.PredictivePolicyType:
+		if runtime.FeatureEnabled(runtime.FeaturePredictiveAutoscaler) {
+			return applyPredictivePolicy(p.Predictive, f, now, ac)
+		}
 	}
 
-	return 0, false, errors.New("wrong policy type, should be one of: Buffer, Webhook")
+	return 0, false, errors.New("wrong policy type, should be one of: Buffer, Webhook, Schedule, Chain, PlayerBuffer, Predictive")
+}
+
+// activeScheduleWindow returns the name of the active window of the Schedule policy of the autoscaler,
+// or of the Schedule policy of its chain with the given ID, if any
+func activeScheduleWindow(fas *autoscalingv1.FleetAutoscaler, chainEntry string, now time.Time) string {
+	p := &fas.Spec.Policy
+	if chainEntry != "" && p.Chain != nil {
+		for i := range p.Chain.Policies {
+			if p.Chain.Policies[i].ID == chainEntry {
+				p = &p.Chain.Policies[i].FleetAutoscalerPolicy
+				break
+			}
+		}
+	}
+	if p.Type != autoscalingv1.SchedulePolicyType || p.Schedule == nil ||
+		!runtime.FeatureEnabled(runtime.FeatureScheduledAutoscaler) {
+		return ""
+	}
+	if w := p.Schedule.ActiveWindow(now); w != nil {
+		return w.Name
+	}
+	return ""
 }
 
-// buildURLFromWebhookPolicy - build URL for Webhook and set CARoot for client Transport
+// buildURLFromWebhookPolicy - build URL for Webhook
 func buildURLFromWebhookPolicy(w *autoscalingv1.WebhookPolicy) (u *url.URL, err error) {
 	if w.URL != nil && w.Service != nil {
 		return nil, errors.New("service and URL cannot be used simultaneously")
@@ -60,10 +141,6 @@ func buildURLFromWebhookPolicy(w *autoscalingv1.WebhookPolicy) (u *url.URL, err
 	scheme := "http"
 	if w.CABundle != nil {
 		scheme = "https"
-
-		if err := setCABundle(w.CABundle); err != nil {
-			return nil, err
-		}
 	}
 
 	if w.URL != nil {
@@ -108,22 +185,58 @@ func createURL(scheme, name, namespace, path string, port *int32) *url.URL {
 	}
 }
 
-func setCABundle(caBundle []byte) error {
-	// We can have multiple fleetautoscalers with different CABundles defined,
-	// so we switch client.Transport before each POST request
-	rootCAs := x509.NewCertPool()
-	if ok := rootCAs.AppendCertsFromPEM(caBundle); !ok {
-		return errors.New("no certs were appended from caBundle")
+// webhookTLSConfig returns the TLS configuration to call the webhook with, built from its CABundle
+// and from the client certificate Secret of its transport, or nil if the webhook is called without TLS,
+// along with the resource version of the Secret.
+// Each webhook gets its own configuration, as we can have multiple fleetautoscalers with different
+// CABundles and client certificates defined.
+func webhookTLSConfig(w *autoscalingv1.WebhookPolicy, t *autoscalingv1.WebhookTransport, namespace string, secretLister corev1lister.SecretLister) (*tls.Config, string, error) {
+	if w.CABundle == nil && t.ClientCertSecret == "" {
+		return nil, "", nil
 	}
-	client.Transport = &http.Transport{
-		TLSClientConfig: &tls.Config{
-			RootCAs: rootCAs,
-		},
+
+	tlsConfig := &tls.Config{}
+	secretVersion := ""
+	if w.CABundle != nil {
+		rootCAs := x509.NewCertPool()
+		if ok := rootCAs.AppendCertsFromPEM(w.CABundle); !ok {
+			return nil, "", errors.New("no certs were appended from caBundle")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if t.ClientCertSecret != "" {
+		if secretLister == nil {
+			return nil, "", errors.New("client certificate secrets are not available")
+		}
+		secret, err := secretLister.Secrets(namespace).Get(t.ClientCertSecret)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "could not get client certificate secret %s", t.ClientCertSecret)
+		}
+		cert, err := tls.X509KeyPair(secret.Data[secretClientCertName], secret.Data[secretClientKeyName])
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "invalid client certificate in secret %s", t.ClientCertSecret)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+		secretVersion = secret.ObjectMeta.ResourceVersion
+
+		if caCert := secret.Data[secretCACertName]; len(caCert) != 0 {
+			if tlsConfig.RootCAs == nil {
+				tlsConfig.RootCAs = x509.NewCertPool()
+			}
+			if ok := tlsConfig.RootCAs.AppendCertsFromPEM(caCert); !ok {
+				return nil, "", errors.Errorf("no certs were appended from %s of secret %s", secretCACertName, t.ClientCertSecret)
+			}
+		}
 	}
-	return nil
+
+	return tlsConfig, secretVersion, nil
 }
 
-func applyWebhookPolicy(w *autoscalingv1.WebhookPolicy, f *agonesv1.Fleet) (replicas int32, limited bool, err error) {
+// applyWebhookPolicy calls the webhook with the current status of the fleet, and returns the replicas it responds with.
+// The call is made with the protocol, client certificate, timeout and retries of the given transport, if any.
+// The retries are made within the timeout, so that a failing webhook does not hold up the sync for longer.
+func applyWebhookPolicy(w *autoscalingv1.WebhookPolicy, t *autoscalingv1.WebhookTransport, f *agonesv1.Fleet, wc *webhookClient) (replicas int32, limited bool, err error) {
 	if w == nil {
 		return 0, false, errors.New("webhookPolicy parameter must not be nil")
 	}
@@ -132,34 +245,124 @@ func applyWebhookPolicy(w *autoscalingv1.WebhookPolicy, f *agonesv1.Fleet) (repl
 		return 0, false, errors.New("fleet parameter must not be nil")
 	}
 
+	if t == nil || !runtime.FeatureEnabled(runtime.FeatureFleetAutoscalerWebhookTransport) {
+		t = &autoscalingv1.WebhookTransport{}
+	}
+
 	u, err := buildURLFromWebhookPolicy(w)
 	if err != nil {
 		return 0, false, err
 	}
 
-	faReq := autoscalingv1.FleetAutoscaleReview{
-		Request: &autoscalingv1.FleetAutoscaleRequest{
-			UID:       uuid.NewUUID(),
-			Name:      f.Name,
-			Namespace: f.Namespace,
-			Status:    f.Status,
-		},
-		Response: nil,
+	var secretLister corev1lister.SecretLister
+	if wc != nil {
+		secretLister = wc.secretLister
+	}
+	tlsConfig, secretVersion, err := webhookTLSConfig(w, t, f.ObjectMeta.Namespace, secretLister)
+	if err != nil {
+		return 0, false, err
+	}
+	if w.Service != nil && tlsConfig != nil {
+		u.Scheme = "https"
+	}
+
+	timeout := defaultWebhookTimeout
+	if t.TimeoutSeconds > 0 {
+		timeout = time.Duration(t.TimeoutSeconds) * time.Second
+	}
+	backoff := defaultWebhookRetryBackoff
+	if t.RetryBackoffMilliseconds > 0 {
+		backoff = time.Duration(t.RetryBackoffMilliseconds) * time.Millisecond
+	}
+
+	faReq := &autoscalingv1.FleetAutoscaleRequest{
+		UID:       uuid.NewUUID(),
+		Name:      f.Name,
+		Namespace: f.Namespace,
+		Status:    f.Status,
+	}
+	if wc != nil && wc.addRequestDetails != nil {
+		wc.addRequestDetails(faReq)
 	}
 
-	b, err := json.Marshal(faReq)
+	version := webhookConnVersion{
+		url:                     u.String(),
+		protocol:                t.Protocol,
+		caBundle:                string(w.CABundle),
+		clientCertSecret:        t.ClientCertSecret,
+		clientCertSecretVersion: secretVersion,
+	}
+	build := func() (*webhookConn, error) {
+		return newWebhookConn(u, version, tlsConfig)
+	}
+	var conn *webhookConn
+	if wc != nil && wc.conns != nil {
+		conn, err = wc.conns.get(wc.key, version, build)
+	} else {
+		conn, err = build()
+		if conn != nil {
+			defer conn.close()
+		}
+	}
 	if err != nil {
 		return 0, false, err
 	}
 
-	res, err := client.Post(
-		u.String(),
-		"application/json",
-		strings.NewReader(string(b)),
-	)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var faResp *autoscalingv1.FleetAutoscaleResponse
+	for attempt := int32(0); ; attempt++ {
+		faResp, err = conn.call(ctx, u, faReq)
+		if err == nil || attempt >= t.Retries || !waitForRetry(ctx, backoff) {
+			break
+		}
+		backoff *= 2
+	}
 	if err != nil {
 		return 0, false, err
 	}
+
+	if faResp.Scale {
+		return faResp.Replicas, false, nil
+	}
+	return f.Status.Replicas, false, nil
+}
+
+// waitForRetry waits for the backoff before the next retry of a call to a webhook. It returns false,
+// without waiting, if the context would be done before.
+func waitForRetry(ctx context.Context, backoff time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= backoff {
+		return false
+	}
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// postWebhook posts the request to the webhook as a FleetAutoscaleReview in JSON, and returns its response
+func postWebhook(ctx context.Context, c *http.Client, u *url.URL, faReq *autoscalingv1.FleetAutoscaleRequest) (faResp *autoscalingv1.FleetAutoscaleResponse, err error) {
+	b, err := json.Marshal(autoscalingv1.FleetAutoscaleReview{
+		Request:  faReq,
+		Response: nil,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader(string(b)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
 	defer func() {
 		if cerr := res.Body.Close(); cerr != nil {
 			if err != nil {
@@ -171,23 +374,117 @@ func applyWebhookPolicy(w *autoscalingv1.WebhookPolicy, f *agonesv1.Fleet) (repl
 	}()
 
 	if res.StatusCode != http.StatusOK {
-		return 0, false, fmt.Errorf("bad status code %d from the server: %s", res.StatusCode, u.String())
+		return nil, fmt.Errorf("bad status code %d from the server: %s", res.StatusCode, u.String())
 	}
 	result, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return 0, false, err
+		return nil, err
 	}
 
-	var faResp autoscalingv1.FleetAutoscaleReview
-	err = json.Unmarshal(result, &faResp)
+	var review autoscalingv1.FleetAutoscaleReview
+	err = json.Unmarshal(result, &review)
 	if err != nil {
-		return 0, false, err
+		return nil, err
+	}
+	if review.Response == nil {
+		return nil, fmt.Errorf("no response from the server: %s", u.String())
 	}
 
-	if faResp.Response.Scale {
-		return faResp.Response.Replicas, false, nil
+	return review.Response, nil
+}
+
+// callGRPCWebhook calls the Autoscale method of the AutoscalerService of the webhook, and returns its response
+func callGRPCWebhook(ctx context.Context, conn *grpc.ClientConn, faReq *autoscalingv1.FleetAutoscaleRequest) (*autoscalingv1.FleetAutoscaleResponse, error) {
+	req := &pb.FleetAutoscaleRequest{
+		Uid:       string(faReq.UID),
+		Name:      faReq.Name,
+		Namespace: faReq.Namespace,
+		Status: &pb.FleetStatus{
+			Replicas:          faReq.Status.Replicas,
+			ReadyReplicas:     faReq.Status.ReadyReplicas,
+			ReservedReplicas:  faReq.Status.ReservedReplicas,
+			AllocatedReplicas: faReq.Status.AllocatedReplicas,
+		},
 	}
-	return f.Status.Replicas, false, nil
+	if faReq.Status.Players != nil {
+		req.Status.Players = &pb.PlayerStatus{Count: faReq.Status.Players.Count, Capacity: faReq.Status.Players.Capacity}
+	}
+	req.Labels = faReq.Labels
+	req.Annotations = faReq.Annotations
+	if len(faReq.GameServerStates) != 0 {
+		req.GameServerStates = map[string]int32{}
+		for state, count := range faReq.GameServerStates {
+			req.GameServerStates[string(state)] = count
+		}
+	}
+	if faReq.AllocationRate != nil {
+		req.AllocationRate = &pb.AllocationRate{LastMinute: faReq.AllocationRate.LastMinute, LastFiveMinutes: faReq.AllocationRate.LastFiveMinutes}
+	}
+	if faReq.AutoscalerSpec != nil {
+		spec, err := json.Marshal(faReq.AutoscalerSpec)
+		if err != nil {
+			return nil, err
+		}
+		req.AutoscalerSpec = string(spec)
+	}
+
+	res, err := pb.NewAutoscalerServiceClient(conn).Autoscale(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &autoscalingv1.FleetAutoscaleResponse{
+		UID:      types.UID(res.Uid),
+		Scale:    res.Scale,
+		Replicas: res.Replicas,
+	}, nil
+}
+
+// applySchedulePolicy applies the buffer policy of the window that is active at the given time,
+// or the default buffer policy if there is none
+func applySchedulePolicy(s *autoscalingv1.SchedulePolicy, f *agonesv1.Fleet, now time.Time) (int32, bool, error) {
+	if s == nil {
+		return 0, false, errors.New("schedulePolicy parameter must not be nil")
+	}
+
+	if w := s.ActiveWindow(now); w != nil {
+		return applyBufferPolicy(&w.Buffer, f)
+	}
+	return applyBufferPolicy(&s.Default, f)
+}
+
+// applyChainPolicy combines the desired sizes of the given fleet computed by each policy of the chain,
+// skipping the policies that fail, and returns the ID of the policy that produced the result
+func applyChainPolicy(c *autoscalingv1.ChainPolicy, f *agonesv1.Fleet, now time.Time, wc *webhookClient, ac *allocationCounts) (replicas int32, limited bool, id string, err error) {
+	if c == nil {
+		return 0, false, "", errors.New("chainPolicy parameter must not be nil")
+	}
+
+	var failures []string
+	found := false
+	for i := range c.Policies {
+		entry := &c.Policies[i]
+		if entry.Type == autoscalingv1.ChainPolicyType {
+			failures = append(failures, fmt.Sprintf("%s: policies of a chain cannot be chains themselves", entry.ID))
+			continue
+		}
+		r, l, err := applyPolicy(&entry.FleetAutoscalerPolicy, f, now, wc.forChainEntry(entry.ID), ac)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", entry.ID, err))
+			continue
+		}
+		if !found || (c.Combine == autoscalingv1.MaxCombineRule && r > replicas) || (c.Combine == autoscalingv1.MinCombineRule && r < replicas) {
+			replicas, limited, id = r, l, entry.ID
+		}
+		found = true
+		if c.Combine != autoscalingv1.MaxCombineRule && c.Combine != autoscalingv1.MinCombineRule {
+			break
+		}
+	}
+
+	if !found {
+		return 0, false, "", errors.Errorf("all the policies of the chain failed: %s", strings.Join(failures, "; "))
+	}
+	return replicas, limited, id, nil
 }
 
 func applyBufferPolicy(b *autoscalingv1.BufferPolicy, f *agonesv1.Fleet) (int32, bool, error) {
@@ -224,3 +521,92 @@ func applyBufferPolicy(b *autoscalingv1.BufferPolicy, f *agonesv1.Fleet) (int32,
 
 	return replicas, limited, nil
 }
+
+// applyPlayerBufferPolicy computes the replicas needed to keep the buffer of free player slots across the fleet,
+// assuming each replica that is added or removed has the initial player capacity of the fleet template
+func applyPlayerBufferPolicy(b *autoscalingv1.PlayerBufferPolicy, f *agonesv1.Fleet) (int32, bool, error) {
+	if b == nil {
+		return 0, false, errors.New("playerBufferPolicy parameter must not be nil")
+	}
+	if f.Spec.Template.Spec.Players == nil || f.Spec.Template.Spec.Players.InitialCapacity <= 0 {
+		return 0, false, errors.Errorf("fleet %s must have a player initial capacity for the %s policy", f.ObjectMeta.Name, autoscalingv1.PlayerBufferPolicyType)
+	}
+	capacityPerReplica := float64(f.Spec.Template.Spec.Players.InitialCapacity)
+
+	var count, capacity int64
+	if f.Status.Players != nil {
+		count, capacity = f.Status.Players.Count, f.Status.Players.Capacity
+	}
+	// the player capacity of the fleet only includes Ready, Reserved and Allocated game servers,
+	// so count the ones that are still starting with the initial capacity
+	if starting := f.Status.Replicas - f.Status.ReadyReplicas - f.Status.ReservedReplicas - f.Status.AllocatedReplicas; starting > 0 {
+		capacity += int64(starting) * f.Spec.Template.Spec.Players.InitialCapacity
+	}
+	free := capacity - count
+
+	var change float64
+	if b.BufferSize.Type == intstr.Int {
+		change = float64(int64(b.BufferSize.IntValue())-free) / capacityPerReplica
+	} else {
+		bufferPercent, err := intstr.GetValueFromIntOrPercent(&b.BufferSize, 100, true)
+		if err != nil {
+			return 0, false, err
+		}
+		// the free slots must be bufferPercent of the future capacity, not of the current one:
+		// (free + change*capacityPerReplica) >= bufferPercent/100 * (capacity + change*capacityPerReplica)
+		change = float64(int64(bufferPercent)*capacity-100*free) / (capacityPerReplica * float64(100-bufferPercent))
+	}
+	// use Math.Ceil to round the result up, so that there are at least as many free slots as the buffer
+	replicas := f.Status.Replicas + int32(math.Ceil(change))
+
+	limited := false
+
+	if replicas < b.MinReplicas {
+		replicas = b.MinReplicas
+		limited = true
+	}
+	if replicas > b.MaxReplicas {
+		replicas = b.MaxReplicas
+		limited = true
+	}
+
+	return replicas, limited, nil
+}
+
+// applyPredictivePolicy computes the replicas needed to keep enough Ready replicas for the allocations
+// that are forecast over the lead time of the policy, plus its safety margin
+func applyPredictivePolicy(p *autoscalingv1.PredictivePolicy, f *agonesv1.Fleet, now time.Time, ac *allocationCounts) (int32, bool, error) {
+	if p == nil {
+		return 0, false, errors.New("predictivePolicy parameter must not be nil")
+	}
+
+	var counts []int32
+	if ac != nil {
+		counts = ac.completed(f.ObjectMeta.Namespace+"/"+f.ObjectMeta.Name, now)
+	}
+	forecast := forecastAllocations(p, counts, time.Duration(p.LeadTimeSeconds)*time.Second)
+
+	margin := float64(p.SafetyMargin.IntValue())
+	if p.SafetyMargin.Type == intstr.String {
+		marginPercent, err := intstr.GetValueFromIntOrPercent(&p.SafetyMargin, 100, true)
+		if err != nil {
+			return 0, false, err
+		}
+		margin = forecast * float64(marginPercent) / 100
+	}
+	// use Math.Ceil to round the result up, so that there are at least as many Ready replicas as forecast allocations
+	replicas := f.Status.AllocatedReplicas + int32(math.Ceil(forecast+margin))
+
+	limited := false
+
+	if replicas < p.MinReplicas {
+		replicas = p.MinReplicas
+		limited = true
+	}
+	if replicas > p.MaxReplicas {
+		replicas = p.MaxReplicas
+		limited = true
+	}
+
+	return replicas, limited, nil
+}
@@ -17,18 +17,39 @@
 package fleetautoscalers
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
 	autoscalingv1 "agones.dev/agones/pkg/apis/autoscaling/v1"
+	pb "agones.dev/agones/pkg/autoscaling/go"
+	utilruntime "agones.dev/agones/pkg/util/runtime"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	admregv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	corev1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
@@ -151,7 +172,7 @@ func TestComputeDesiredFleetSize(t *testing.T) {
 			expected: expected{
 				replicas: 0,
 				limited:  false,
-				err:      "wrong policy type, should be one of: Buffer, Webhook",
+				err:      "wrong policy type, should be one of: Buffer, Webhook, Schedule, Chain, PlayerBuffer, Predictive",
 			},
 		},
 	}
@@ -164,7 +185,7 @@ func TestComputeDesiredFleetSize(t *testing.T) {
 			f.Status.AllocatedReplicas = tc.statusAllocatedReplicas
 			f.Status.ReadyReplicas = tc.statusReadyReplicas
 
-			replicas, limited, err := computeDesiredFleetSize(fas, f)
+			replicas, limited, _, err := computeDesiredFleetSize(fas, f, time.Now(), nil, nil)
 
 			if tc.expected.err != "" && assert.NotNil(t, err) {
 				assert.Equal(t, tc.expected.err, err.Error())
@@ -320,6 +341,440 @@ func TestApplyBufferPolicy(t *testing.T) {
 	}
 }
 
+func TestApplySchedulePolicy(t *testing.T) {
+	t.Parallel()
+
+	_, f := defaultFixtures()
+	f.Status.Replicas = 50
+	f.Status.AllocatedReplicas = 40
+	f.Status.ReadyReplicas = 10
+
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	schedule := &autoscalingv1.SchedulePolicy{
+		Default: autoscalingv1.BufferPolicy{BufferSize: intstr.FromInt(5), MaxReplicas: 100},
+		Windows: []autoscalingv1.ScheduleWindow{
+			{
+				Name:     "friday-evening",
+				Start:    "0 18 * * FRI",
+				Duration: metav1.Duration{Duration: 4 * time.Hour},
+				TimeZone: "America/New_York",
+				Buffer:   autoscalingv1.BufferPolicy{BufferSize: intstr.FromInt(30), MaxReplicas: 60},
+			},
+			{
+				Name:     "evenings",
+				Start:    "0 18 * * *",
+				Duration: metav1.Duration{Duration: 2 * time.Hour},
+				TimeZone: "America/New_York",
+				Buffer:   autoscalingv1.BufferPolicy{BufferSize: intstr.FromInt(15), MaxReplicas: 100},
+			},
+		},
+	}
+
+	var testCases = []struct {
+		description string
+		now         time.Time
+		window      string
+		replicas    int32
+		limited     bool
+	}{
+		{
+			description: "No active window",
+			now:         time.Date(2020, 10, 1, 12, 0, 0, 0, newYork),
+			replicas:    45,
+		},
+		{
+			description: "Active window",
+			now:         time.Date(2020, 10, 1, 19, 0, 0, 0, newYork),
+			window:      "evenings",
+			replicas:    55,
+		},
+		{
+			description: "First of several active windows",
+			now:         time.Date(2020, 10, 2, 19, 0, 0, 0, newYork),
+			window:      "friday-evening",
+			replicas:    60,
+			limited:     true,
+		},
+		{
+			description: "Window in another time zone",
+			now:         time.Date(2020, 10, 3, 0, 30, 0, 0, time.UTC),
+			window:      "friday-evening",
+			replicas:    60,
+			limited:     true,
+		},
+		{
+			description: "Window ended",
+			now:         time.Date(2020, 10, 2, 22, 0, 0, 0, newYork),
+			replicas:    45,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			replicas, limited, err := applySchedulePolicy(schedule, f, tc.now)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.replicas, replicas)
+			assert.Equal(t, tc.limited, limited)
+
+			w := schedule.ActiveWindow(tc.now)
+			if tc.window == "" {
+				assert.Nil(t, w)
+			} else if assert.NotNil(t, w) {
+				assert.Equal(t, tc.window, w.Name)
+			}
+		})
+	}
+
+	_, _, err = applySchedulePolicy(nil, f, time.Now())
+	assert.EqualError(t, err, "schedulePolicy parameter must not be nil")
+}
+
+func TestApplyPlayerBufferPolicy(t *testing.T) {
+	t.Parallel()
+
+	type expected struct {
+		replicas int32
+		limited  bool
+		err      string
+	}
+
+	var testCases = []struct {
+		description       string
+		bufferSize        intstr.IntOrString
+		minReplicas       int32
+		maxReplicas       int32
+		replicas          int32
+		readyReplicas     int32
+		allocatedReplicas int32
+		players           *agonesv1.AggregatedPlayerStatus
+		templatePlayers   *agonesv1.PlayersSpec
+		expected          expected
+	}{
+		{
+			description:       "Enough free player slots",
+			bufferSize:        intstr.FromInt(50),
+			maxReplicas:       100,
+			replicas:          10,
+			readyReplicas:     5,
+			allocatedReplicas: 5,
+			players:           &agonesv1.AggregatedPlayerStatus{Count: 45, Capacity: 100},
+			expected:          expected{replicas: 10},
+		},
+		{
+			description:       "Scale up to the free player slots",
+			bufferSize:        intstr.FromInt(50),
+			maxReplicas:       100,
+			replicas:          10,
+			readyReplicas:     2,
+			allocatedReplicas: 8,
+			players:           &agonesv1.AggregatedPlayerStatus{Count: 80, Capacity: 100},
+			expected:          expected{replicas: 13},
+		},
+		{
+			description:       "Starting game servers count with their initial capacity",
+			bufferSize:        intstr.FromInt(50),
+			maxReplicas:       100,
+			replicas:          13,
+			readyReplicas:     2,
+			allocatedReplicas: 8,
+			players:           &agonesv1.AggregatedPlayerStatus{Count: 80, Capacity: 100},
+			expected:          expected{replicas: 13},
+		},
+		{
+			description:       "Scale down to the free player slots",
+			bufferSize:        intstr.FromInt(25),
+			maxReplicas:       100,
+			replicas:          10,
+			readyReplicas:     8,
+			allocatedReplicas: 2,
+			players:           &agonesv1.AggregatedPlayerStatus{Count: 15, Capacity: 100},
+			expected:          expected{replicas: 4},
+		},
+		{
+			description: "No player status",
+			bufferSize:  intstr.FromInt(25),
+			maxReplicas: 100,
+			expected:    expected{replicas: 3},
+		},
+		{
+			description:       "Percentage of the future player capacity",
+			bufferSize:        intstr.FromString("20%"),
+			minReplicas:       1,
+			maxReplicas:       100,
+			replicas:          10,
+			readyReplicas:     1,
+			allocatedReplicas: 9,
+			players:           &agonesv1.AggregatedPlayerStatus{Count: 90, Capacity: 100},
+			expected:          expected{replicas: 12},
+		},
+		{
+			description:       "Percentage scale down",
+			bufferSize:        intstr.FromString("20%"),
+			minReplicas:       1,
+			maxReplicas:       100,
+			replicas:          10,
+			readyReplicas:     8,
+			allocatedReplicas: 2,
+			players:           &agonesv1.AggregatedPlayerStatus{Count: 10, Capacity: 100},
+			expected:          expected{replicas: 2},
+		},
+		{
+			description:       "Limited by MaxReplicas",
+			bufferSize:        intstr.FromInt(50),
+			maxReplicas:       12,
+			replicas:          10,
+			readyReplicas:     2,
+			allocatedReplicas: 8,
+			players:           &agonesv1.AggregatedPlayerStatus{Count: 80, Capacity: 100},
+			expected:          expected{replicas: 12, limited: true},
+		},
+		{
+			description:   "Limited by MinReplicas",
+			bufferSize:    intstr.FromInt(10),
+			minReplicas:   5,
+			maxReplicas:   100,
+			replicas:      10,
+			readyReplicas: 10,
+			players:       &agonesv1.AggregatedPlayerStatus{Count: 0, Capacity: 100},
+			expected:      expected{replicas: 5, limited: true},
+		},
+		{
+			description:     "No initial player capacity",
+			bufferSize:      intstr.FromInt(10),
+			maxReplicas:     100,
+			templatePlayers: &agonesv1.PlayersSpec{},
+			expected:        expected{err: "fleet fleet-1 must have a player initial capacity for the PlayerBuffer policy"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			_, f := defaultFixtures()
+			f.Spec.Template.Spec.Players = &agonesv1.PlayersSpec{InitialCapacity: 10}
+			if tc.templatePlayers != nil {
+				f.Spec.Template.Spec.Players = tc.templatePlayers
+			}
+			f.Status.Replicas = tc.replicas
+			f.Status.ReadyReplicas = tc.readyReplicas
+			f.Status.ReservedReplicas = 0
+			f.Status.AllocatedReplicas = tc.allocatedReplicas
+			f.Status.Players = tc.players
+
+			b := &autoscalingv1.PlayerBufferPolicy{
+				BufferSize:  tc.bufferSize,
+				MinReplicas: tc.minReplicas,
+				MaxReplicas: tc.maxReplicas,
+			}
+			replicas, limited, err := applyPlayerBufferPolicy(b, f)
+			if tc.expected.err != "" {
+				assert.EqualError(t, err, tc.expected.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected.replicas, replicas)
+			assert.Equal(t, tc.expected.limited, limited)
+		})
+	}
+
+	_, f := defaultFixtures()
+	_, _, err := applyPlayerBufferPolicy(nil, f)
+	assert.EqualError(t, err, "playerBufferPolicy parameter must not be nil")
+}
+
+func TestApplyPredictivePolicy(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	now := start.Add(10 * time.Minute)
+	// 4 allocations per minute over the last 10 minutes
+	ac := newAllocationCounts()
+	for m := 0; m < 10; m++ {
+		for i := 0; i < 4; i++ {
+			ac.record("default/fleet-1", start.Add(time.Duration(m)*time.Minute+time.Duration(i)*time.Second))
+		}
+	}
+
+	type expected struct {
+		replicas int32
+		limited  bool
+	}
+
+	var testCases = []struct {
+		description       string
+		safetyMargin      intstr.IntOrString
+		minReplicas       int32
+		maxReplicas       int32
+		allocatedReplicas int32
+		counts            *allocationCounts
+		expected          expected
+	}{
+		{
+			description:       "Forecast allocations over the lead time",
+			maxReplicas:       100,
+			allocatedReplicas: 8,
+			counts:            ac,
+			expected:          expected{replicas: 14},
+		},
+		{
+			description:       "Safety margin count",
+			safetyMargin:      intstr.FromInt(2),
+			maxReplicas:       100,
+			allocatedReplicas: 8,
+			counts:            ac,
+			expected:          expected{replicas: 16},
+		},
+		{
+			description:       "Safety margin percentage",
+			safetyMargin:      intstr.FromString("50%"),
+			maxReplicas:       100,
+			allocatedReplicas: 8,
+			counts:            ac,
+			expected:          expected{replicas: 17},
+		},
+		{
+			description:       "No allocation history",
+			safetyMargin:      intstr.FromInt(2),
+			maxReplicas:       100,
+			allocatedReplicas: 8,
+			counts:            newAllocationCounts(),
+			expected:          expected{replicas: 10},
+		},
+		{
+			description:       "Limited by MaxReplicas",
+			maxReplicas:       12,
+			allocatedReplicas: 8,
+			counts:            ac,
+			expected:          expected{replicas: 12, limited: true},
+		},
+		{
+			description:       "Limited by MinReplicas",
+			minReplicas:       20,
+			maxReplicas:       100,
+			allocatedReplicas: 8,
+			counts:            ac,
+			expected:          expected{replicas: 20, limited: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			_, f := defaultFixtures()
+			f.Status.AllocatedReplicas = tc.allocatedReplicas
+
+			p := &autoscalingv1.PredictivePolicy{
+				MinReplicas:     tc.minReplicas,
+				MaxReplicas:     tc.maxReplicas,
+				LeadTimeSeconds: 90,
+				SafetyMargin:    tc.safetyMargin,
+			}
+			replicas, limited, err := applyPredictivePolicy(p, f, now, tc.counts)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected.replicas, replicas)
+			assert.Equal(t, tc.expected.limited, limited)
+		})
+	}
+
+	_, f := defaultFixtures()
+	_, _, err := applyPredictivePolicy(nil, f, now, ac)
+	assert.EqualError(t, err, "predictivePolicy parameter must not be nil")
+}
+
+func TestApplyChainPolicy(t *testing.T) {
+	t.Parallel()
+	ts := testServer{}
+	server := httptest.NewServer(ts)
+	defer server.Close()
+
+	_, f := defaultFixtures()
+	f.Status.Replicas = 50
+	f.Status.AllocatedReplicas = 40
+	f.Status.ReadyReplicas = 10
+	wrongServerURL := "http://127.0.0.1:1"
+
+	webhook := autoscalingv1.ChainEntry{
+		ID: "webhook",
+		FleetAutoscalerPolicy: autoscalingv1.FleetAutoscalerPolicy{
+			Type:    autoscalingv1.WebhookPolicyType,
+			Webhook: &autoscalingv1.WebhookPolicy{URL: &(server.URL)},
+		},
+	}
+	unreachable := autoscalingv1.ChainEntry{
+		ID: "unreachable",
+		FleetAutoscalerPolicy: autoscalingv1.FleetAutoscalerPolicy{
+			Type:    autoscalingv1.WebhookPolicyType,
+			Webhook: &autoscalingv1.WebhookPolicy{URL: &wrongServerURL},
+		},
+	}
+	buffer := autoscalingv1.ChainEntry{
+		ID: "buffer",
+		FleetAutoscalerPolicy: autoscalingv1.FleetAutoscalerPolicy{
+			Type:   autoscalingv1.BufferPolicyType,
+			Buffer: &autoscalingv1.BufferPolicy{BufferSize: intstr.FromInt(20), MaxReplicas: 80},
+		},
+	}
+	chain := autoscalingv1.ChainEntry{
+		ID:                    "chain",
+		FleetAutoscalerPolicy: autoscalingv1.FleetAutoscalerPolicy{Type: autoscalingv1.ChainPolicyType},
+	}
+
+	type expected struct {
+		replicas int32
+		id       string
+		err      string
+	}
+
+	var testCases = []struct {
+		description string
+		chain       *autoscalingv1.ChainPolicy
+		expected    expected
+	}{
+		{
+			description: "First match",
+			chain:       &autoscalingv1.ChainPolicy{Policies: []autoscalingv1.ChainEntry{webhook, buffer}},
+			expected:    expected{replicas: 100, id: "webhook"},
+		},
+		{
+			description: "First match, with a fallback",
+			chain:       &autoscalingv1.ChainPolicy{Combine: autoscalingv1.FirstMatchCombineRule, Policies: []autoscalingv1.ChainEntry{unreachable, chain, buffer}},
+			expected:    expected{replicas: 60, id: "buffer"},
+		},
+		{
+			description: "Max",
+			chain:       &autoscalingv1.ChainPolicy{Combine: autoscalingv1.MaxCombineRule, Policies: []autoscalingv1.ChainEntry{buffer, unreachable, webhook}},
+			expected:    expected{replicas: 100, id: "webhook"},
+		},
+		{
+			description: "Min",
+			chain:       &autoscalingv1.ChainPolicy{Combine: autoscalingv1.MinCombineRule, Policies: []autoscalingv1.ChainEntry{webhook, unreachable, buffer}},
+			expected:    expected{replicas: 60, id: "buffer"},
+		},
+		{
+			description: "All policies fail",
+			chain:       &autoscalingv1.ChainPolicy{Combine: autoscalingv1.MaxCombineRule, Policies: []autoscalingv1.ChainEntry{chain}},
+			expected:    expected{err: "all the policies of the chain failed: chain: policies of a chain cannot be chains themselves"},
+		},
+		{
+			description: "No chain policy",
+			expected:    expected{err: "chainPolicy parameter must not be nil"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			replicas, _, id, err := applyChainPolicy(tc.chain, f, time.Now(), nil, nil)
+
+			if tc.expected.err != "" && assert.NotNil(t, err) {
+				assert.Equal(t, tc.expected.err, err.Error())
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.expected.replicas, replicas)
+				assert.Equal(t, tc.expected.id, id)
+			}
+		})
+	}
+}
+
 func TestApplyWebhookPolicy(t *testing.T) {
 	t.Parallel()
 	ts := testServer{}
@@ -541,7 +996,7 @@ func TestApplyWebhookPolicy(t *testing.T) {
 			f.Status.AllocatedReplicas = tc.statusAllocatedReplicas
 			f.Status.ReadyReplicas = tc.statusReadyReplicas
 
-			replicas, limited, err := applyWebhookPolicy(tc.webhookPolicy, f)
+			replicas, limited, err := applyWebhookPolicy(tc.webhookPolicy, nil, f, nil)
 
 			if tc.expected.err != "" && assert.NotNil(t, err) {
 				assert.Equal(t, tc.expected.err, err.Error())
@@ -566,7 +1021,7 @@ func TestApplyWebhookPolicyNilFleet(t *testing.T) {
 		},
 	}
 
-	replicas, limited, err := applyWebhookPolicy(w, nil)
+	replicas, limited, err := applyWebhookPolicy(w, nil, nil, nil)
 
 	if assert.NotNil(t, err) {
 		assert.Equal(t, "fleet parameter must not be nil", err.Error())
@@ -576,6 +1031,160 @@ func TestApplyWebhookPolicyNilFleet(t *testing.T) {
 	assert.Zero(t, replicas)
 }
 
+func TestApplyWebhookPolicyTransport(t *testing.T) {
+	utilruntime.FeatureTestMutex.Lock()
+	defer utilruntime.FeatureTestMutex.Unlock()
+	assert.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureFleetAutoscalerWebhookTransport)+"=true"))
+	defer utilruntime.ParseFeatures("") // nolint: errcheck
+
+	_, f := defaultWebhookFixtures()
+	f.Status.Replicas = 50
+	f.Status.AllocatedReplicas = 40
+	f.Status.ReadyReplicas = 10
+
+	clientCert, clientKey := generateTestCertificate(t)
+	secrets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	err := secrets.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "client-cert", Namespace: f.ObjectMeta.Namespace},
+		Data:       map[string][]byte{"tls.crt": clientCert, "tls.key": clientKey},
+	})
+	require.NoError(t, err)
+	wc := &webhookClient{secretLister: corev1lister.NewSecretLister(secrets)}
+
+	t.Run("mutual TLS", func(t *testing.T) {
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(r.TLS.PeerCertificates) != 1 {
+				http.Error(w, "no client certificate", http.StatusUnauthorized)
+				return
+			}
+			testServer{}.ServeHTTP(w, r)
+		}))
+		server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+		server.StartTLS()
+		defer server.Close()
+
+		w := &autoscalingv1.WebhookPolicy{
+			URL:      &server.URL,
+			CABundle: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+		}
+		transport := &autoscalingv1.WebhookTransport{ClientCertSecret: "client-cert"}
+
+		replicas, limited, err := applyWebhookPolicy(w, transport, f, wc)
+		assert.NoError(t, err)
+		assert.False(t, limited)
+		assert.Equal(t, int32(50*scaleFactor), replicas)
+
+		// without the client certificate, the server refuses the connection
+		_, _, err = applyWebhookPolicy(w, &autoscalingv1.WebhookTransport{}, f, wc)
+		assert.Error(t, err)
+
+		_, _, err = applyWebhookPolicy(w, &autoscalingv1.WebhookTransport{ClientCertSecret: "missing"}, f, wc)
+		assert.EqualError(t, err, "could not get client certificate secret missing: secret \"missing\" not found")
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls < 3 {
+				http.Error(w, "not yet", http.StatusServiceUnavailable)
+				return
+			}
+			testServer{}.ServeHTTP(w, r)
+		}))
+		defer server.Close()
+		w := &autoscalingv1.WebhookPolicy{URL: &server.URL}
+
+		_, _, err := applyWebhookPolicy(w, &autoscalingv1.WebhookTransport{Retries: 1, RetryBackoffMilliseconds: 1}, f, wc)
+		assert.EqualError(t, err, fmt.Sprintf("bad status code %d from the server: %s", http.StatusServiceUnavailable, server.URL))
+		assert.Equal(t, 2, calls)
+
+		calls = 0
+		replicas, _, err := applyWebhookPolicy(w, &autoscalingv1.WebhookTransport{Retries: 2, RetryBackoffMilliseconds: 1}, f, wc)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(50*scaleFactor), replicas)
+		assert.Equal(t, 3, calls)
+
+		// the retries stop once the next one would be past the timeout
+		calls = 0
+		start := time.Now()
+		_, _, err = applyWebhookPolicy(w, &autoscalingv1.WebhookTransport{TimeoutSeconds: 1, Retries: 5, RetryBackoffMilliseconds: 2000}, f, wc)
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+		assert.True(t, time.Since(start) < time.Second)
+	})
+
+	t.Run("connections are reused", func(t *testing.T) {
+		var conns int32
+		server := httptest.NewUnstartedServer(testServer{})
+		server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				atomic.AddInt32(&conns, 1)
+			}
+		}
+		server.Start()
+		defer server.Close()
+		w := &autoscalingv1.WebhookPolicy{URL: &server.URL}
+		cached := &webhookClient{secretLister: wc.secretLister, conns: newWebhookConns(), key: "default/fas"}
+		defer cached.conns.forget(cached.key)
+
+		for i := 0; i < 3; i++ {
+			replicas, _, err := applyWebhookPolicy(w, &autoscalingv1.WebhookTransport{}, f, cached)
+			assert.NoError(t, err)
+			assert.Equal(t, int32(50*scaleFactor), replicas)
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&conns))
+	})
+
+	t.Run("gRPC", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		server := grpc.NewServer()
+		pb.RegisterAutoscalerServiceServer(server, testGRPCServer{})
+		go server.Serve(lis) // nolint: errcheck
+		defer server.Stop()
+
+		u := "grpc://" + lis.Addr().String()
+		w := &autoscalingv1.WebhookPolicy{URL: &u}
+		transport := &autoscalingv1.WebhookTransport{Protocol: autoscalingv1.GRPCWebhookProtocol, TimeoutSeconds: 5}
+
+		replicas, limited, err := applyWebhookPolicy(w, transport, f, wc)
+		assert.NoError(t, err)
+		assert.False(t, limited)
+		assert.Equal(t, int32(50*scaleFactor), replicas)
+	})
+}
+
+// testGRPCServer is the gRPC equivalent of testServer
+type testGRPCServer struct{}
+
+func (testGRPCServer) Autoscale(ctx context.Context, req *pb.FleetAutoscaleRequest) (*pb.FleetAutoscaleResponse, error) {
+	res := &pb.FleetAutoscaleResponse{Uid: req.Uid, Replicas: req.Status.Replicas}
+	if float32(req.Status.AllocatedReplicas)/float32(req.Status.Replicas) > 0.7 {
+		res.Scale = true
+		res.Replicas = req.Status.Replicas * scaleFactor
+	}
+	return res, nil
+}
+
+// generateTestCertificate returns a self signed certificate and its key, in PEM format
+func generateTestCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fleetautoscaler"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
 func TestCreateURL(t *testing.T) {
 	t.Parallel()
 	var nonStandardPort int32 = 8888
@@ -0,0 +1,198 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetautoscalers
+
+import (
+	"sync"
+	"time"
+
+	autoscalingv1 "agones.dev/agones/pkg/apis/autoscaling/v1"
+)
+
+const (
+	// allocationCountInterval is the interval over which the allocations of a fleet are counted to forecast them
+	allocationCountInterval = time.Minute
+	// allocationCountWindow is how long the allocation counts of a fleet are kept to forecast its allocations.
+	// It must hold two seasons of the longest season of the HoltWinters model.
+	allocationCountWindow = 48 * time.Hour
+
+	defaultLevelSmoothingPercent    = 50
+	defaultTrendSmoothingPercent    = 10
+	defaultSeasonalSmoothingPercent = 10
+)
+
+// allocationCount is the number of allocations of a fleet per allocationCountInterval,
+// starting from the interval that starts at start
+type allocationCount struct {
+	start  time.Time
+	counts []int32
+}
+
+// allocationCounts keeps the number of GameServers of each Fleet that were allocated
+// per allocationCountInterval, by Fleet key
+type allocationCounts struct {
+	mu     sync.Mutex
+	counts map[string]*allocationCount
+}
+
+// newAllocationCounts returns an empty allocationCounts
+func newAllocationCounts() *allocationCounts {
+	return &allocationCounts{counts: map[string]*allocationCount{}}
+}
+
+// record records that a GameServer of the Fleet with the given key was allocated
+func (a *allocationCounts) record(key string, now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	c, ok := a.counts[key]
+	if !ok {
+		c = &allocationCount{start: now.Truncate(allocationCountInterval)}
+		a.counts[key] = c
+	}
+	i := c.advance(now)
+	c.counts[i]++
+}
+
+// completed returns the allocations of the Fleet with the given key per allocationCountInterval, oldest first,
+// for the intervals that are over
+func (a *allocationCounts) completed(key string, now time.Time) []int32 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	c, ok := a.counts[key]
+	if !ok {
+		return nil
+	}
+	i := c.advance(now)
+	result := make([]int32, i)
+	copy(result, c.counts[:i])
+	return result
+}
+
+// forget removes the allocation counts of the Fleet with the given key
+func (a *allocationCounts) forget(key string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.counts, key)
+}
+
+// advance adds empty counts up to the interval of now, drops the counts that are older than
+// allocationCountWindow, and returns the index of the count of the interval of now
+func (c *allocationCount) advance(now time.Time) int {
+	i := int(now.Sub(c.start) / allocationCountInterval)
+	if i < 0 {
+		// the clock went backwards, count it with the oldest interval
+		i = 0
+	}
+	for len(c.counts) <= i {
+		c.counts = append(c.counts, 0)
+	}
+	if max := int(allocationCountWindow / allocationCountInterval); i >= max {
+		drop := i - max + 1
+		c.counts = c.counts[drop:]
+		c.start = c.start.Add(time.Duration(drop) * allocationCountInterval)
+		i -= drop
+	}
+	return i
+}
+
+// forecastAllocations forecasts the allocations over the next lead time, from the allocations per
+// allocationCountInterval, oldest first, with the model of the given Predictive policy
+func forecastAllocations(p *autoscalingv1.PredictivePolicy, counts []int32, lead time.Duration) float64 {
+	if len(counts) == 0 {
+		return 0
+	}
+	alpha := smoothingFactor(p.LevelSmoothingPercent, defaultLevelSmoothingPercent)
+
+	var forecast func(h int) float64
+	season := int(p.SeasonLengthMinutes)
+	if p.Model == autoscalingv1.HoltWintersPredictiveModel && season > 1 && len(counts) >= 2*season {
+		beta := smoothingFactor(p.TrendSmoothingPercent, defaultTrendSmoothingPercent)
+		gamma := smoothingFactor(p.SeasonalSmoothingPercent, defaultSeasonalSmoothingPercent)
+		forecast = holtWinters(counts, season, alpha, beta, gamma)
+	} else {
+		level := exponentialSmoothing(counts, alpha)
+		forecast = func(int) float64 { return level }
+	}
+
+	// sum the forecast of each interval of the lead time, with a share of the last one if it is not a whole interval
+	intervals := lead / allocationCountInterval
+	total := 0.0
+	for h := 1; h <= int(intervals); h++ {
+		total += forecast(h)
+	}
+	if rest := lead - intervals*allocationCountInterval; rest > 0 {
+		total += forecast(int(intervals)+1) * float64(rest) / float64(allocationCountInterval)
+	}
+	return total
+}
+
+// smoothingFactor converts a smoothing percentage of a Predictive policy to a factor, applying its default if unset
+func smoothingFactor(percent int32, defaultPercent int32) float64 {
+	if percent <= 0 {
+		percent = defaultPercent
+	}
+	return float64(percent) / 100
+}
+
+// exponentialSmoothing returns the smoothed level of the counts, which is their forecast for any interval ahead
+func exponentialSmoothing(counts []int32, alpha float64) float64 {
+	level := float64(counts[0])
+	for _, y := range counts[1:] {
+		level = alpha*float64(y) + (1-alpha)*level
+	}
+	return level
+}
+
+// holtWinters fits an additive Holt-Winters model with the given season length to the counts,
+// which must hold at least two seasons, and returns the forecast of the counts h intervals ahead.
+// Negative forecasts are returned as 0, since there can't be less than no allocations.
+func holtWinters(counts []int32, season int, alpha, beta, gamma float64) func(h int) float64 {
+	// initialize the level and the trend from the means of the first two seasons,
+	// and the seasonal components from the difference of the first season with its mean
+	var first, second float64
+	for i := 0; i < season; i++ {
+		first += float64(counts[i])
+		second += float64(counts[season+i])
+	}
+	first /= float64(season)
+	second /= float64(season)
+
+	level := first
+	trend := (second - first) / float64(season)
+	seasonal := make([]float64, season)
+	for i := 0; i < season; i++ {
+		seasonal[i] = float64(counts[i]) - first
+	}
+
+	for t := season; t < len(counts); t++ {
+		y := float64(counts[t])
+		s := seasonal[t%season]
+		previous := level
+		level = alpha*(y-s) + (1-alpha)*(level+trend)
+		trend = beta*(level-previous) + (1-beta)*trend
+		seasonal[t%season] = gamma*(y-level) + (1-gamma)*s
+	}
+
+	n := len(counts)
+	return func(h int) float64 {
+		f := level + float64(h)*trend + seasonal[(n+h-1)%season]
+		if f < 0 {
+			return 0
+		}
+		return f
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetautoscalers
+
+import (
+	"testing"
+	"time"
+
+	autoscalingv1 "agones.dev/agones/pkg/apis/autoscaling/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocationCounts(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2020, 10, 1, 12, 0, 30, 0, time.UTC)
+	key := "default/fleet-1"
+	a := newAllocationCounts()
+
+	assert.Empty(t, a.completed(key, start))
+
+	a.record(key, start)
+	a.record(key, start.Add(10*time.Second))
+	a.record(key, start.Add(2*time.Minute))
+	a.record("default/fleet-2", start)
+
+	// the interval that is not over is not returned
+	assert.Equal(t, []int32{2, 0}, a.completed(key, start.Add(2*time.Minute)))
+	assert.Equal(t, []int32{2, 0, 1, 0}, a.completed(key, start.Add(4*time.Minute)))
+
+	// the counts that are older than the window are dropped
+	later := start.Add(allocationCountWindow + 2*time.Minute)
+	a.record(key, later)
+	counts := a.completed(key, later.Add(time.Minute))
+	assert.Len(t, counts, int(allocationCountWindow/allocationCountInterval)-1)
+	assert.Equal(t, int32(1), counts[len(counts)-1])
+	assert.Equal(t, int32(0), counts[0])
+
+	a.forget(key)
+	a.forget("default/fleet-2")
+	assert.Empty(t, a.counts)
+}
+
+func TestForecastAllocations(t *testing.T) {
+	t.Parallel()
+
+	es := &autoscalingv1.PredictivePolicy{}
+	assert.Equal(t, float64(0), forecastAllocations(es, nil, time.Minute))
+	assert.Equal(t, float64(6), forecastAllocations(es, []int32{4, 4, 4}, 90*time.Second))
+	// the latest allocations weigh more
+	assert.Equal(t, float64(5), forecastAllocations(es, []int32{0, 4, 8}, time.Minute))
+	es.LevelSmoothingPercent = 100
+	assert.Equal(t, float64(8), forecastAllocations(es, []int32{0, 4, 8}, time.Minute))
+
+	// a season of 4 minutes, with a peak in its last minute
+	var counts []int32
+	for i := 0; i < 6; i++ {
+		counts = append(counts, 2, 2, 2, 10)
+	}
+	hw := &autoscalingv1.PredictivePolicy{Model: autoscalingv1.HoltWintersPredictiveModel, SeasonLengthMinutes: 4}
+	assert.InDelta(t, float64(2), forecastAllocations(hw, counts, time.Minute), 0.01)
+	assert.InDelta(t, float64(16), forecastAllocations(hw, counts, 4*time.Minute), 0.01)
+	assert.InDelta(t, float64(10), forecastAllocations(hw, counts[:len(counts)-1], time.Minute), 0.01)
+
+	// a growing trend
+	counts = nil
+	for i := int32(0); i < 12; i++ {
+		counts = append(counts, i)
+	}
+	hw.SeasonLengthMinutes = 2
+	assert.InDelta(t, float64(12), forecastAllocations(hw, counts, time.Minute), 0.5)
+
+	// falls back to exponential smoothing without two seasons of allocations
+	hw.SeasonLengthMinutes = 10
+	assert.Equal(t, forecastAllocations(es, counts, time.Minute), forecastAllocations(&autoscalingv1.PredictivePolicy{
+		Model: autoscalingv1.HoltWintersPredictiveModel, SeasonLengthMinutes: 10, LevelSmoothingPercent: 100}, counts, time.Minute))
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetautoscalers
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	autoscalingv1 "agones.dev/agones/pkg/apis/autoscaling/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// webhookConnVersion identifies what a webhookConn is built from, so that it is replaced when any of it changes
+type webhookConnVersion struct {
+	url              string
+	protocol         autoscalingv1.WebhookProtocol
+	caBundle         string
+	clientCertSecret string
+	// clientCertSecretVersion is the resource version of the client certificate Secret
+	clientCertSecretVersion string
+}
+
+// webhookConn is a connection to the webhook of a FleetAutoscaler, over HTTP or gRPC
+type webhookConn struct {
+	version    webhookConnVersion
+	httpClient *http.Client
+	grpcConn   *grpc.ClientConn
+}
+
+// newWebhookConn returns a connection to the webhook at the given URL, with the given TLS configuration, if any.
+// gRPC connections are established in the background, so this does not block.
+func newWebhookConn(u *url.URL, version webhookConnVersion, tlsConfig *tls.Config) (*webhookConn, error) {
+	if version.protocol == autoscalingv1.GRPCWebhookProtocol {
+		opt := grpc.WithInsecure()
+		if tlsConfig != nil {
+			opt = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+		}
+		conn, err := grpc.Dial(u.Host, opt)
+		if err != nil {
+			return nil, err
+		}
+		return &webhookConn{version: version, grpcConn: conn}, nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &webhookConn{version: version, httpClient: &http.Client{Transport: transport}}, nil
+}
+
+// call calls the webhook at the given URL until the context is done, and returns its response
+func (c *webhookConn) call(ctx context.Context, u *url.URL, faReq *autoscalingv1.FleetAutoscaleRequest) (*autoscalingv1.FleetAutoscaleResponse, error) {
+	if c.grpcConn != nil {
+		return callGRPCWebhook(ctx, c.grpcConn, faReq)
+	}
+	return postWebhook(ctx, c.httpClient, u, faReq)
+}
+
+// close closes the connection, and its idle HTTP connections
+func (c *webhookConn) close() {
+	if c.grpcConn != nil {
+		_ = c.grpcConn.Close()
+		return
+	}
+	c.httpClient.CloseIdleConnections()
+}
+
+// webhookConns keeps the connections to the webhooks of the FleetAutoscalers, so that they are reused
+// between syncs rather than established again on each call. They are kept by the key of the FleetAutoscaler,
+// with the ID of the policy of its chain appended for Chain policies.
+type webhookConns struct {
+	mu    sync.Mutex
+	conns map[string]*webhookConn
+}
+
+// newWebhookConns returns an empty webhookConns
+func newWebhookConns() *webhookConns {
+	return &webhookConns{conns: map[string]*webhookConn{}}
+}
+
+// get returns the connection with the given key, or builds it if there is none with the given version yet.
+// A connection with another version is closed and replaced.
+func (w *webhookConns) get(key string, version webhookConnVersion, build func() (*webhookConn, error)) (*webhookConn, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	old, ok := w.conns[key]
+	if ok && old.version == version {
+		return old, nil
+	}
+	conn, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		old.close()
+	}
+	w.conns[key] = conn
+	return conn, nil
+}
+
+// forget closes and removes the connections of the FleetAutoscaler with the given key, along with
+// the connections of the policies of its chain
+func (w *webhookConns) forget(key string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for k, conn := range w.conns {
+		if k == key || strings.HasPrefix(k, key+"/") {
+			conn.close()
+			delete(w.conns, k)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetautoscalers
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookConns(t *testing.T) {
+	t.Parallel()
+
+	u, err := url.Parse("http://localhost:8000/scale")
+	require.NoError(t, err)
+	version := webhookConnVersion{url: u.String(), clientCertSecret: "client-cert", clientCertSecretVersion: "1"}
+	builds := 0
+	build := func(v webhookConnVersion) func() (*webhookConn, error) {
+		return func() (*webhookConn, error) {
+			builds++
+			return newWebhookConn(u, v, nil)
+		}
+	}
+
+	w := newWebhookConns()
+	conn, err := w.get("default/fas", version, build(version))
+	require.NoError(t, err)
+	assert.Equal(t, 1, builds)
+
+	t.Run("same version is reused", func(t *testing.T) {
+		result, err := w.get("default/fas", version, build(version))
+		require.NoError(t, err)
+		assert.Same(t, conn, result)
+		assert.Equal(t, 1, builds)
+	})
+
+	t.Run("new secret version is replaced", func(t *testing.T) {
+		updated := version
+		updated.clientCertSecretVersion = "2"
+		result, err := w.get("default/fas", updated, build(updated))
+		require.NoError(t, err)
+		assert.NotSame(t, conn, result)
+		assert.Equal(t, 2, builds)
+		assert.Len(t, w.conns, 1)
+		conn = result
+	})
+
+	t.Run("build error keeps the previous connection", func(t *testing.T) {
+		_, err := w.get("default/fas", webhookConnVersion{}, func() (*webhookConn, error) {
+			return nil, errors.New("bad webhook")
+		})
+		assert.EqualError(t, err, "bad webhook")
+		assert.Same(t, conn, w.conns["default/fas"])
+	})
+
+	t.Run("forget", func(t *testing.T) {
+		for _, key := range []string{"default/fas/entry", "default/fas-other"} {
+			_, err := w.get(key, version, build(version))
+			require.NoError(t, err)
+		}
+		assert.Len(t, w.conns, 3)
+		w.forget("default/fas")
+		assert.Len(t, w.conns, 1)
+		assert.Contains(t, w.conns, "default/fas-other")
+	})
+}
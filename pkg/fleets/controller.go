@@ -691,6 +691,31 @@ func (c *Controller) updateFleetStatus(fleet *agonesv1.Fleet) error {
 			}
 		}
 	}
+	if runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		fCopy.Status.Counters = nil
+		fCopy.Status.Lists = nil
+		// TODO: integrate this extra loop into the above for loop when CountsAndLists moves to GA
+		for _, gsSet := range list {
+			for name, c := range gsSet.Status.Counters {
+				if fCopy.Status.Counters == nil {
+					fCopy.Status.Counters = map[string]agonesv1.AggregatedCounterStatus{}
+				}
+				a := fCopy.Status.Counters[name]
+				a.Count += c.Count
+				a.Capacity += c.Capacity
+				fCopy.Status.Counters[name] = a
+			}
+			for name, l := range gsSet.Status.Lists {
+				if fCopy.Status.Lists == nil {
+					fCopy.Status.Lists = map[string]agonesv1.AggregatedListStatus{}
+				}
+				a := fCopy.Status.Lists[name]
+				a.Count += l.Count
+				a.Capacity += l.Capacity
+				fCopy.Status.Lists[name] = a
+			}
+		}
+	}
 
 	_, err = c.fleetGetter.Fleets(fCopy.ObjectMeta.Namespace).UpdateStatus(fCopy)
 	return errors.Wrapf(err, "error updating status of fleet %s", fCopy.ObjectMeta.Name)
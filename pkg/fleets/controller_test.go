@@ -642,6 +642,52 @@ func TestControllerUpdateFleetPlayerStatus(t *testing.T) {
 	assert.True(t, updated)
 }
 
+func TestControllerUpdateFleetCountsAndListsStatus(t *testing.T) {
+	t.Parallel()
+
+	utilruntime.FeatureTestMutex.Lock()
+	defer utilruntime.FeatureTestMutex.Unlock()
+
+	require.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureCountsAndLists)+"=true"))
+
+	fleet := defaultFixture()
+	c, m := newFakeController()
+
+	gsSet1 := fleet.GameServerSet()
+	gsSet1.ObjectMeta.Name = "gsSet1"
+	gsSet1.Status.Counters = map[string]agonesv1.AggregatedCounterStatus{"rooms": {Count: 5, Capacity: 10}}
+	gsSet1.Status.Lists = map[string]agonesv1.AggregatedListStatus{"tokens": {Count: 1, Capacity: 4}}
+
+	gsSet2 := fleet.GameServerSet()
+	gsSet2.ObjectMeta.Name = "gsSet2"
+	gsSet2.Status.Counters = map[string]agonesv1.AggregatedCounterStatus{"rooms": {Count: 10, Capacity: 20}}
+
+	m.AgonesClient.AddReactor("list", "gameserversets",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, &agonesv1.GameServerSetList{Items: []agonesv1.GameServerSet{*gsSet1, *gsSet2}}, nil
+		})
+
+	updated := false
+	m.AgonesClient.AddReactor("update", "fleets",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			updated = true
+			ua := action.(k8stesting.UpdateAction)
+			fleet := ua.GetObject().(*agonesv1.Fleet)
+
+			assert.Equal(t, map[string]agonesv1.AggregatedCounterStatus{"rooms": {Count: 15, Capacity: 30}}, fleet.Status.Counters)
+			assert.Equal(t, map[string]agonesv1.AggregatedListStatus{"tokens": {Count: 1, Capacity: 4}}, fleet.Status.Lists)
+
+			return true, fleet, nil
+		})
+
+	_, cancel := agtesting.StartInformers(m, c.fleetSynced, c.gameServerSetSynced)
+	defer cancel()
+
+	err := c.updateFleetStatus(fleet)
+	assert.Nil(t, err)
+	assert.True(t, updated)
+}
+
 func TestControllerFilterGameServerSetByActive(t *testing.T) {
 	t.Parallel()
 
@@ -30,6 +30,7 @@ import (
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
 	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
 	multiclusterv1 "agones.dev/agones/pkg/apis/multicluster/v1"
+	multiclustergetterv1 "agones.dev/agones/pkg/client/clientset/versioned/typed/multicluster/v1"
 	multiclusterinformerv1 "agones.dev/agones/pkg/client/informers/externalversions/multicluster/v1"
 	multiclusterlisterv1 "agones.dev/agones/pkg/client/listers/multicluster/v1"
 	"agones.dev/agones/pkg/util/apiserver"
@@ -46,6 +47,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/wait"
 	informercorev1 "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -64,6 +66,10 @@ var (
 	ErrConflictInGameServerSelection = errors.New("The Gameserver was already allocated")
 	// ErrTotalTimeoutExceeded is used to signal that total retry timeout has been exceeded and no additional retries should be made
 	ErrTotalTimeoutExceeded = status.Errorf(codes.DeadlineExceeded, "remote allocation total timeout exceeded")
+	// ErrAllocationVetoed is returned when the allocation webhook vetoed the candidate GameServer
+	ErrAllocationVetoed = errors.New("The allocation webhook vetoed the GameServer")
+	// ErrNoHealthyAllocationEndpoint is returned when all the allocation endpoints of a remote cluster are ejected
+	ErrNoHealthyAllocationEndpoint = status.Errorf(codes.Unavailable, "all the allocation endpoints of the remote cluster are ejected")
 )
 
 const (
@@ -82,6 +88,8 @@ const (
 	maxBatchQueue         = 100
 	maxBatchBeforeRefresh = 100
 	batchWaitTime         = 500 * time.Millisecond
+	// maxConcurrentReviews is how many allocation webhooks can be called at the same time
+	maxConcurrentReviews = 100
 )
 
 var allocationRetry = wait.Backoff{
@@ -102,6 +110,7 @@ type Allocator struct {
 	baseLogger                   *logrus.Entry
 	allocationPolicyLister       multiclusterlisterv1.GameServerAllocationPolicyLister
 	allocationPolicySynced       cache.InformerSynced
+	allocationPolicyGetter       multiclustergetterv1.GameServerAllocationPoliciesGetter
 	secretLister                 corev1lister.SecretLister
 	secretSynced                 cache.InformerSynced
 	recorder                     record.EventRecorder
@@ -111,11 +120,19 @@ type Allocator struct {
 	remoteAllocationCallback     func(context.Context, string, grpc.DialOption, *pb.AllocationRequest) (*pb.AllocationResponse, error)
 	remoteAllocationTimeout      time.Duration
 	totalRemoteAllocationTimeout time.Duration
+	allocationWebhookCallback    func(*allocationv1.AllocationWebhook, *allocationv1.GameServerAllocationReview) (*allocationv1.GameServerAllocationReviewResponse, error)
+	endpointHealth               *endpointHealth
+	clusterCapacity              *clusterCapacity
+	remoteProbeCallback          func(string, grpc.DialOption) error
+	name                         string
+	clock                        clock.Clock
 }
 
 // request is an async request for allocation
 type request struct {
-	gsa      *allocationv1.GameServerAllocation
+	gsa *allocationv1.GameServerAllocation
+	// vetoed is the set of names of the GameServers the allocation webhook has vetoed for this request
+	vetoed   map[string]bool
 	response chan response
 }
 
@@ -123,22 +140,32 @@ type request struct {
 type response struct {
 	request request
 	gs      *agonesv1.GameServer
-	err     error
+	// metaPatch is the metadata to patch the GameServer with on allocation
+	metaPatch allocationv1.MetaPatch
+	err       error
 }
 
 // NewAllocator creates an instance of Allocator
-func NewAllocator(policyInformer multiclusterinformerv1.GameServerAllocationPolicyInformer, secretInformer informercorev1.SecretInformer,
-	kubeClient kubernetes.Interface, readyGameServerCache *ReadyGameServerCache, remoteAllocationTimeout time.Duration, totalRemoteAllocationTimeout time.Duration) *Allocator {
+func NewAllocator(policyInformer multiclusterinformerv1.GameServerAllocationPolicyInformer, policyGetter multiclustergetterv1.GameServerAllocationPoliciesGetter,
+	secretInformer informercorev1.SecretInformer, kubeClient kubernetes.Interface, readyGameServerCache *ReadyGameServerCache,
+	remoteAllocationTimeout time.Duration, totalRemoteAllocationTimeout time.Duration) *Allocator {
 	ah := &Allocator{
 		pendingRequests:              make(chan request, maxBatchQueue),
 		allocationPolicyLister:       policyInformer.Lister(),
 		allocationPolicySynced:       policyInformer.Informer().HasSynced,
+		allocationPolicyGetter:       policyGetter,
 		secretLister:                 secretInformer.Lister(),
 		secretSynced:                 secretInformer.Informer().HasSynced,
 		readyGameServerCache:         readyGameServerCache,
 		topNGameServerCount:          topNGameServerDefaultCount,
 		remoteAllocationTimeout:      remoteAllocationTimeout,
 		totalRemoteAllocationTimeout: totalRemoteAllocationTimeout,
+		allocationWebhookCallback:    callAllocationWebhook,
+		endpointHealth:               newEndpointHealth(),
+		clusterCapacity:              newClusterCapacity(),
+		remoteProbeCallback:          probeEndpoint(remoteAllocationTimeout),
+		name:                         allocatorName(),
+		clock:                        clock.RealClock{},
 		remoteAllocationCallback: func(ctx context.Context, endpoint string, dialOpts grpc.DialOption, request *pb.AllocationRequest) (*pb.AllocationResponse, error) {
 			conn, err := grpc.Dial(endpoint, dialOpts)
 			if err != nil {
@@ -175,6 +202,10 @@ func (c *Allocator) Start(stop <-chan struct{}) error {
 	// workers and logic for batching allocations
 	go c.ListenAndAllocate(maxBatchQueue, stop)
 
+	if runtime.FeatureEnabled(runtime.FeatureAllocationEndpointHealth) || runtime.FeatureEnabled(runtime.FeatureAllocationCapacityWeighting) {
+		go wait.Until(c.syncPolicyStatus, policyStatusSyncPeriod, stop)
+	}
+
 	return nil
 }
 
@@ -246,22 +277,27 @@ func (c *Allocator) loggerForGameServerAllocation(gsa *allocationv1.GameServerAl
 // allocateFromLocalCluster allocates gameservers from the local cluster.
 func (c *Allocator) allocateFromLocalCluster(gsa *allocationv1.GameServerAllocation, stop <-chan struct{}) (*allocationv1.GameServerAllocation, error) {
 	var gs *agonesv1.GameServer
+	vetoed := map[string]bool{}
 	err := Retry(allocationRetry, func() error {
 		var err error
-		gs, err = c.allocate(gsa, stop)
+		gs, err = c.allocate(gsa, vetoed, stop)
+		if err == ErrAllocationVetoed {
+			// search for another GameServer on the next try
+			vetoed[gs.ObjectMeta.Name] = true
+		}
 		if err != nil {
 			c.loggerForGameServerAllocation(gsa).WithError(err).Warn("failed to allocate. Retrying... ")
 		}
 		return err
 	})
 
-	if err != nil && err != ErrNoGameServerReady && err != ErrConflictInGameServerSelection {
+	if err != nil && err != ErrNoGameServerReady && err != ErrConflictInGameServerSelection && err != ErrAllocationVetoed {
 		c.readyGameServerCache.Resync()
 		return nil, err
 	}
 
 	switch err {
-	case ErrNoGameServerReady:
+	case ErrNoGameServerReady, ErrAllocationVetoed:
 		gsa.Status.State = allocationv1.GameServerAllocationUnAllocated
 	case ErrConflictInGameServerSelection:
 		gsa.Status.State = allocationv1.GameServerAllocationContention
@@ -272,6 +308,11 @@ func (c *Allocator) allocateFromLocalCluster(gsa *allocationv1.GameServerAllocat
 		gsa.Status.Ports = gs.Status.Ports
 		gsa.Status.Address = gs.Status.Address
 		gsa.Status.NodeName = gs.Status.NodeName
+		gsa.Status.Metadata = &allocationv1.GameServerMetadata{
+			Labels:      gs.ObjectMeta.Labels,
+			Annotations: gs.ObjectMeta.Annotations,
+		}
+		gsa.Status.Players = gs.Status.Players
 	}
 
 	c.loggerForGameServerAllocation(gsa).Debug("Game server allocation")
@@ -296,6 +337,16 @@ func (c *Allocator) applyMultiClusterAllocation(gsa *allocationv1.GameServerAllo
 		return nil, errors.New("no multi-cluster allocation policy is specified")
 	}
 
+	if runtime.FeatureEnabled(runtime.FeatureAllocationCapacityWeighting) {
+		policies = c.clusterCapacity.weighted(policies)
+	}
+	if setting := gsa.Spec.MultiClusterSetting; runtime.FeatureEnabled(runtime.FeatureAllocationLatency) && (len(setting.Latencies) > 0 || setting.MaxLatencyMillis > 0) {
+		policies = orderPoliciesByLatency(policies, setting.Latencies, setting.MaxLatencyMillis)
+		if len(policies) == 0 {
+			return nil, errors.Errorf("no multi-cluster allocation policy is within the maximum latency of %dms", setting.MaxLatencyMillis)
+		}
+	}
+
 	it := multiclusterv1.NewConnectionInfoIterator(policies)
 	for {
 		connectionInfo := it.Next()
@@ -319,6 +370,7 @@ func (c *Allocator) applyMultiClusterAllocation(gsa *allocationv1.GameServerAllo
 				c.loggerForGameServerAllocation(gsa).WithField("allocConnInfo", connectionInfo).WithError(err).Error("remote-allocation failed")
 			}
 		}
+		c.recordClusterResult(gsa.ObjectMeta.Namespace, connectionInfo, result, err)
 		if result != nil && result.Status.State == allocationv1.GameServerAllocationAllocated {
 			return result, nil
 		}
@@ -339,29 +391,37 @@ func (c *Allocator) allocateFromRemoteCluster(gsa *allocationv1.GameServerAlloca
 
 	// Forward the game server allocation request to another cluster,
 	// and disable multicluster settings to avoid the target cluster
-	// forward the allocation request again.
+	// forward the allocation request again. The latencies are only used
+	// to order the clusters, so they are not forwarded either.
 	request := converters.ConvertGSAToAllocationRequest(gsa)
 	request.MultiClusterSetting.Enabled = false
+	request.MultiClusterSetting.Latencies = nil
+	request.MultiClusterSetting.MaxLatencyMillis = 0
 	request.Namespace = connectionInfo.Namespace
 
 	ctx, cancel := context.WithTimeout(context.Background(), c.totalRemoteAllocationTimeout)
 	defer cancel() // nolint: errcheck
 	// Retry on remote call failures.
 	err = Retry(remoteAllocationRetry, func() error {
-		for i, ip := range connectionInfo.AllocationEndpoints {
+		// Ejected endpoints are skipped, and are left out again on each retry
+		endpoints := c.availableEndpoints(connectionInfo)
+		if len(endpoints) == 0 {
+			return ErrNoHealthyAllocationEndpoint
+		}
+		for i, endpoint := range endpoints {
 			select {
 			case <-ctx.Done():
 				return ErrTotalTimeoutExceeded
 			default:
 			}
-			endpoint := addPort(ip)
 			c.loggerForGameServerAllocationKey("remote-allocation").WithField("request", request).WithField("endpoint", endpoint).Debug("forwarding allocation request")
 			allocationResponse, err = c.remoteAllocationCallback(ctx, endpoint, dialOpts, request)
+			c.recordEndpointResult(endpoint, err)
 			if err != nil {
 				c.baseLogger.WithError(err).Error("remote allocation failed")
 				// If there are multiple endpoints for the allocator connection and the current one is
 				// failing, try the next endpoint. Otherwise, return the error response.
-				if (i + 1) < len(connectionInfo.AllocationEndpoints) {
+				if (i + 1) < len(endpoints) {
 					// If there is a server error try a different endpoint
 					c.loggerForGameServerAllocationKey("remote-allocation").WithField("request", request).WithError(err).WithField("endpoint", endpoint).Warn("The request failed. Trying next endpoint")
 					continue
@@ -428,12 +488,13 @@ func (c *Allocator) getClientCertificates(namespace, secretName string) (clientC
 	return clientCert, clientKey, caCert, nil
 }
 
-// allocate allocated a GameServer from a given GameServerAllocation
+// allocate allocated a GameServer from a given GameServerAllocation, skipping the vetoed GameServers
 // this sets up allocation through a batch process.
-func (c *Allocator) allocate(gsa *allocationv1.GameServerAllocation, stop <-chan struct{}) (*agonesv1.GameServer, error) {
+// If the allocation webhook vetoes the GameServer, it is returned along with ErrAllocationVetoed.
+func (c *Allocator) allocate(gsa *allocationv1.GameServerAllocation, vetoed map[string]bool, stop <-chan struct{}) (*agonesv1.GameServer, error) {
 	// creates an allocation request. This contains the requested GameServerAllocation, as well as the
 	// channel we expect the return values to come back for this GameServerAllocation
-	req := request{gsa: gsa, response: make(chan response)}
+	req := request{gsa: gsa, vetoed: vetoed, response: make(chan response)}
 
 	// this pushes the request into the batching process
 	c.pendingRequests <- req
@@ -452,6 +513,9 @@ func (c *Allocator) ListenAndAllocate(updateWorkerCount int, stop <-chan struct{
 	// setup workers for allocation updates. Push response values into
 	// this queue for concurrent updating of GameServers to Allocated
 	updateQueue := c.allocationUpdateWorkers(updateWorkerCount, stop)
+	// allocation webhooks can be slow, so they are called with their own concurrency limit,
+	// rather than on the update workers, which are shared by all the allocations
+	reviewSlots := make(chan struct{}, maxConcurrentReviews)
 
 	// Batch processing strategy:
 	// We constantly loop around the below for loop. If nothing is found in c.pendingRequests, we move to
@@ -501,7 +565,7 @@ func (c *Allocator) ListenAndAllocate(updateWorkerCount int, stop <-chan struct{
 				list = c.readyGameServerCache.ListSortedReadyGameServers()
 			}
 
-			gs, index, err := findGameServerForAllocation(req.gsa, list)
+			gs, index, err := findGameServerForRequest(req, list)
 			if err != nil {
 				req.response <- response{request: req, gs: nil, err: err}
 				continue
@@ -515,7 +579,12 @@ func (c *Allocator) ListenAndAllocate(updateWorkerCount int, stop <-chan struct{
 				continue
 			}
 
-			updateQueue <- response{request: req, gs: gs.DeepCopy(), err: nil}
+			res := response{request: req, gs: gs.DeepCopy(), metaPatch: req.gsa.Spec.MetaPatch}
+			if hasAllocationWebhook(req.gsa) {
+				go c.reviewAllocationThenUpdate(res, reviewSlots, updateQueue, stop)
+				continue
+			}
+			updateQueue <- res
 
 		case <-stop:
 			return
@@ -541,13 +610,25 @@ func (c *Allocator) allocationUpdateWorkers(workerCount int, stop <-chan struct{
 			for {
 				select {
 				case res := <-updateQueue:
-					gs, err := c.readyGameServerCache.PatchGameServerMetadata(res.request.gsa.Spec.MetaPatch, res.gs)
+					if slots := res.request.gsa.PlayerSlotsToReserve(res.gs); slots > 0 && res.gs.Status.Players != nil {
+						// reserve the player slots, so later allocations don't also count on them before the players connect
+						res.gs.Status.Players.Reserved += slots
+					}
+
+					gs, err := c.readyGameServerCache.PatchGameServerMetadata(res.metaPatch, res.gs)
 					if err != nil {
 						// since we could not allocate, we should put it back
 						c.readyGameServerCache.AddToReadyGameServer(gs)
 						res.err = errors.Wrap(err, "error updating allocated gameserver")
 					} else {
+						// the GameServerAllocation is waiting on the response, so it is safe to set its status here
+						now := metav1.Now()
+						res.request.gsa.Status.AllocationTimestamp = &now
 						res.gs = gs
+						if runtime.FeatureEnabled(runtime.FeatureStateAllocationFilter) {
+							// Allocated GameServers can be allocated again, so make it available straight away
+							c.readyGameServerCache.AddToReadyGameServer(gs)
+						}
 						c.recorder.Event(res.gs, corev1.EventTypeNormal, string(res.gs.Status.State), "Allocated")
 					}
 
@@ -582,6 +663,8 @@ func Retry(backoff wait.Backoff, fn func() error) error {
 			return true, err
 		case err == ErrTotalTimeoutExceeded:
 			return true, err
+		case err == ErrNoHealthyAllocationEndpoint:
+			return true, err
 		default:
 			lastConflictErr = err
 			return false, nil
@@ -0,0 +1,223 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserverallocations
+
+import (
+	"sync"
+	"time"
+
+	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
+	multiclusterv1 "agones.dev/agones/pkg/apis/multicluster/v1"
+	"agones.dev/agones/pkg/util/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+const (
+	// clusterCapacityWindow is the number of the most recent allocations in a cluster
+	// that its available capacity is derived from
+	clusterCapacityWindow = 20
+	// clusterCapacityMaxAge is how long the outcome of an allocation in a cluster
+	// is used to derive its available capacity
+	clusterCapacityMaxAge = 5 * time.Minute
+)
+
+// clusterCapacity estimates the capacity of the clusters of the multi-cluster allocation policies
+// to allocate GameServers, from the outcome of the allocations in each cluster.
+// The available capacity of a cluster is the percentage of its recent allocations that found a GameServer
+// to allocate, which is only a relative weight between the clusters, rather than a number of GameServers.
+// A cluster without recent allocations has an available capacity of 100.
+type clusterCapacity struct {
+	mu       sync.Mutex
+	clock    clock.Clock
+	window   int
+	maxAge   time.Duration
+	clusters map[string]*clusterCapacityStatus
+}
+
+// clusterCapacityStatus is the observed capacity of a single cluster
+type clusterCapacityStatus struct {
+	// outcomes are the outcomes of the recent allocations in the cluster, oldest first
+	outcomes          []allocationOutcome
+	lastExhaustedTime time.Time
+	lastError         string
+}
+
+// allocationOutcome is the outcome of an allocation in a cluster
+type allocationOutcome struct {
+	time time.Time
+	// exhausted is true if the allocation found no GameServer to allocate in the cluster
+	exhausted bool
+}
+
+// newClusterCapacity returns a clusterCapacity with the default window and max age
+func newClusterCapacity() *clusterCapacity {
+	return &clusterCapacity{
+		clock:    clock.RealClock{},
+		window:   clusterCapacityWindow,
+		maxAge:   clusterCapacityMaxAge,
+		clusters: map[string]*clusterCapacityStatus{},
+	}
+}
+
+// clusterKey returns the key of the cluster of a policy in the given namespace
+func clusterKey(namespace string, connectionInfo *multiclusterv1.ClusterConnectionInfo) string {
+	return namespace + "/" + connectionInfo.ClusterName
+}
+
+// success records a successful allocation in the cluster
+func (c *clusterCapacity) success(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.record(c.status(key), false)
+}
+
+// exhausted records an allocation that found no GameServer to allocate in the cluster
+func (c *clusterCapacity) exhausted(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.status(key)
+	c.record(s, true)
+	s.lastExhaustedTime = c.clock.Now()
+}
+
+// record adds the outcome of an allocation to the recent allocations of the cluster.
+// Must be called with the lock held.
+func (c *clusterCapacity) record(s *clusterCapacityStatus, exhausted bool) {
+	s.outcomes = append(s.outcomes, allocationOutcome{time: c.clock.Now(), exhausted: exhausted})
+	if len(s.outcomes) > c.window {
+		s.outcomes = s.outcomes[len(s.outcomes)-c.window:]
+	}
+}
+
+// failure records an allocation in the cluster that failed with an error
+func (c *clusterCapacity) failure(key string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.status(key).lastError = err.Error()
+}
+
+// weighted returns copies of the policies, with their Weight scaled by the available capacity of their cluster
+func (c *clusterCapacity) weighted(policies []*multiclusterv1.GameServerAllocationPolicy) []*multiclusterv1.GameServerAllocationPolicy {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	result := make([]*multiclusterv1.GameServerAllocationPolicy, 0, len(policies))
+	for _, policy := range policies {
+		policyCopy := policy.DeepCopy()
+		s := c.status(clusterKey(policy.ObjectMeta.Namespace, &policy.Spec.ConnectionInfo))
+		policyCopy.Spec.Weight = effectiveWeight(policy.Spec.Weight, c.availableCapacity(s, now))
+		result = append(result, policyCopy)
+	}
+	return result
+}
+
+// capacityStatus returns the observed capacity of the cluster of the policy
+func (c *clusterCapacity) capacityStatus(policy *multiclusterv1.GameServerAllocationPolicy) *multiclusterv1.ClusterCapacityStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.status(clusterKey(policy.ObjectMeta.Namespace, &policy.Spec.ConnectionInfo))
+	capacity := c.availableCapacity(s, c.clock.Now())
+	result := &multiclusterv1.ClusterCapacityStatus{
+		AvailableCapacity:   capacity,
+		ObservedAllocations: int32(len(s.outcomes)),
+		EffectiveWeight:     effectiveWeight(policy.Spec.Weight, capacity),
+		LastError:           s.lastError,
+	}
+	// status times only have a precision of seconds, so truncate them to avoid needless status updates
+	if !s.lastExhaustedTime.IsZero() {
+		t := metav1.NewTime(s.lastExhaustedTime).Rfc3339Copy()
+		result.LastExhaustedTime = &t
+	}
+	return result
+}
+
+// prune forgets all the clusters that are not in keep
+func (c *clusterCapacity) prune(keep map[string]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.clusters {
+		if !keep[key] {
+			delete(c.clusters, key)
+		}
+	}
+}
+
+// status returns the status of the cluster. Must be called with the lock held.
+func (c *clusterCapacity) status(key string) *clusterCapacityStatus {
+	s, ok := c.clusters[key]
+	if !ok {
+		s = &clusterCapacityStatus{}
+		c.clusters[key] = s
+	}
+	return s
+}
+
+// availableCapacity returns the available capacity of the cluster, from 0 to 100, which is the percentage of its
+// recent allocations that found a GameServer to allocate, and forgets the allocations that are too old.
+// Must be called with the lock held.
+func (c *clusterCapacity) availableCapacity(s *clusterCapacityStatus, now time.Time) int32 {
+	i := 0
+	for i < len(s.outcomes) && now.Sub(s.outcomes[i].time) >= c.maxAge {
+		i++
+	}
+	s.outcomes = s.outcomes[i:]
+	if len(s.outcomes) == 0 {
+		return 100
+	}
+
+	allocated := 0
+	for _, o := range s.outcomes {
+		if !o.exhausted {
+			allocated++
+		}
+	}
+	return int32(100 * allocated / len(s.outcomes))
+}
+
+// effectiveWeight scales the weight by the available capacity. A non zero weight is scaled to at least 1,
+// so that an exhausted cluster is still tried once all the other clusters of the same priority have been
+func effectiveWeight(weight int, capacity int32) int {
+	if weight <= 0 {
+		return weight
+	}
+	result := weight * int(capacity) / 100
+	if result < 1 {
+		return 1
+	}
+	return result
+}
+
+// recordClusterResult records the outcome of an allocation in the cluster of the ClusterConnectionInfo
+func (c *Allocator) recordClusterResult(namespace string, connectionInfo *multiclusterv1.ClusterConnectionInfo, result *allocationv1.GameServerAllocation, err error) {
+	if !runtime.FeatureEnabled(runtime.FeatureAllocationCapacityWeighting) {
+		return
+	}
+	key := clusterKey(namespace, connectionInfo)
+	switch {
+	case err != nil:
+		if st, ok := status.FromError(err); ok && st.Code() == codes.ResourceExhausted {
+			c.clusterCapacity.exhausted(key)
+			return
+		}
+		c.clusterCapacity.failure(key, err)
+	case result != nil && result.Status.State == allocationv1.GameServerAllocationAllocated:
+		c.clusterCapacity.success(key)
+	default:
+		c.clusterCapacity.exhausted(key)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserverallocations
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	pb "agones.dev/agones/pkg/allocation/go"
+	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
+	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
+	multiclusterv1 "agones.dev/agones/pkg/apis/multicluster/v1"
+	agtesting "agones.dev/agones/pkg/testing"
+	"agones.dev/agones/pkg/util/runtime"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestClusterCapacity(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	fc := clock.NewFakeClock(now)
+	c := newClusterCapacity()
+	c.clock = fc
+
+	newPolicy := func(cluster string, weight int) *multiclusterv1.GameServerAllocationPolicy {
+		return &multiclusterv1.GameServerAllocationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: cluster, Namespace: defaultNs},
+			Spec: multiclusterv1.GameServerAllocationPolicySpec{
+				Priority:       1,
+				Weight:         weight,
+				ConnectionInfo: multiclusterv1.ClusterConnectionInfo{ClusterName: cluster},
+			},
+		}
+	}
+	policies := []*multiclusterv1.GameServerAllocationPolicy{newPolicy("a", 200), newPolicy("b", 200), newPolicy("c", 0)}
+	weights := func() []int {
+		var result []int
+		for _, p := range c.weighted(policies) {
+			result = append(result, p.Spec.Weight)
+		}
+		return result
+	}
+
+	assert.Equal(t, []int{200, 200, 0}, weights())
+	assert.Equal(t, &multiclusterv1.ClusterCapacityStatus{AvailableCapacity: 100, EffectiveWeight: 200}, c.capacityStatus(policies[0]))
+
+	a := clusterKey(defaultNs, &policies[0].Spec.ConnectionInfo)
+	b := clusterKey(defaultNs, &policies[1].Spec.ConnectionInfo)
+
+	// exhausted clusters drop to a weight of 1
+	c.exhausted(a)
+	c.exhausted(clusterKey(defaultNs, &policies[2].Spec.ConnectionInfo))
+	assert.Equal(t, []int{1, 200, 0}, weights())
+	assert.Equal(t, 200, policies[0].Spec.Weight, "the policies should not be changed")
+
+	// the capacity is the share of the recent allocations that found a GameServer
+	fc.Step(time.Minute)
+	c.success(a)
+	c.success(a)
+	c.exhausted(a)
+	assert.Equal(t, []int{100, 200, 0}, weights())
+
+	c.failure(a, errors.New("remote allocation failed"))
+	lastExhaustedTime := metav1.NewTime(now.Add(time.Minute))
+	assert.Equal(t, &multiclusterv1.ClusterCapacityStatus{
+		AvailableCapacity:   50,
+		ObservedAllocations: 4,
+		EffectiveWeight:     100,
+		LastError:           "remote allocation failed",
+		LastExhaustedTime:   &lastExhaustedTime,
+	}, c.capacityStatus(policies[0]))
+
+	// old allocations are forgotten
+	fc.Step(clusterCapacityMaxAge - time.Minute)
+	assert.Equal(t, []int{132, 200, 0}, weights())
+	fc.Step(time.Minute)
+	assert.Equal(t, []int{200, 200, 0}, weights())
+
+	// only the most recent allocations count
+	for i := 0; i < clusterCapacityWindow; i++ {
+		c.exhausted(b)
+	}
+	assert.Equal(t, []int{200, 1, 0}, weights())
+	for i := 0; i < clusterCapacityWindow/4; i++ {
+		c.success(b)
+	}
+	assert.Equal(t, []int{200, 50, 0}, weights())
+	assert.Len(t, c.clusters[b].outcomes, clusterCapacityWindow)
+
+	c.prune(map[string]bool{clusterKey(defaultNs, &policies[0].Spec.ConnectionInfo): true})
+	assert.Len(t, c.clusters, 1)
+}
+
+func TestAllocatorCapacityWeighting(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+	require.NoError(t, runtime.ParseFeatures(string(runtime.FeatureAllocationCapacityWeighting)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	const clusterName = "remotecluster"
+	secretName := clusterName + "secret"
+	c, m := newFakeController()
+	fleetName := addReactorForGameServer(&m)
+	m.AgonesClient.AddReactor("list", "gameserverallocationpolicies", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, &multiclusterv1.GameServerAllocationPolicyList{Items: []multiclusterv1.GameServerAllocationPolicy{{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: defaultNs},
+			Spec: multiclusterv1.GameServerAllocationPolicySpec{
+				Priority: 1,
+				Weight:   200,
+				ConnectionInfo: multiclusterv1.ClusterConnectionInfo{
+					AllocationEndpoints: []string{"remote"},
+					ClusterName:         clusterName,
+					SecretName:          secretName,
+					ServerCA:            clientCert,
+				},
+			},
+		}}}, nil
+	})
+	m.KubeClient.AddReactor("list", "secrets",
+		func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+			return true, getTestSecret(secretName, clientCert), nil
+		})
+	var mu sync.Mutex
+	var updated *multiclusterv1.GameServerAllocationPolicy
+	m.AgonesClient.AddReactor("update", "gameserverallocationpolicies", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		updated = action.(k8stesting.UpdateAction).GetObject().(*multiclusterv1.GameServerAllocationPolicy)
+		return true, updated, nil
+	})
+
+	exhausted := true
+	c.allocator.remoteAllocationCallback = func(ctx context.Context, endpoint string, dialOpt grpc.DialOption, request *pb.AllocationRequest) (*pb.AllocationResponse, error) {
+		if exhausted {
+			return nil, status.Error(codes.ResourceExhausted, "there is no available GameServer to allocate")
+		}
+		return &pb.AllocationResponse{GameServerName: "remote"}, nil
+	}
+
+	stop, cancel := agtesting.StartInformers(m, c.allocator.allocationPolicySynced, c.allocator.secretSynced, c.allocator.readyGameServerCache.gameServerSynced)
+	defer cancel()
+	require.NoError(t, c.allocator.readyGameServerCache.syncReadyGSServerCache())
+	require.NoError(t, c.allocator.readyGameServerCache.counter.Run(0, stop))
+
+	gsa := &allocationv1.GameServerAllocation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: defaultNs, Name: "alloc1"},
+		Spec: allocationv1.GameServerAllocationSpec{
+			MultiClusterSetting: allocationv1.MultiClusterSetting{Enabled: true},
+			Required:            allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}}},
+		},
+	}
+
+	_, err := executeAllocation(gsa, c)
+	require.Error(t, err)
+	c.allocator.syncPolicyStatus()
+	mu.Lock()
+	require.NotNil(t, updated)
+	require.Len(t, updated.Status.Allocators, 1)
+	assert.Equal(t, c.allocator.name, updated.Status.Allocators[0].Name)
+	require.NotNil(t, updated.Status.Allocators[0].Capacity)
+	assert.Equal(t, int32(0), updated.Status.Allocators[0].Capacity.AvailableCapacity)
+	assert.Equal(t, 1, updated.Status.Allocators[0].Capacity.EffectiveWeight)
+	assert.NotNil(t, updated.Status.Allocators[0].Capacity.LastExhaustedTime)
+	assert.Nil(t, updated.Status.Allocators[0].Endpoints)
+	mu.Unlock()
+
+	exhausted = false
+	result, err := executeAllocation(gsa, c)
+	require.NoError(t, err)
+	assert.Equal(t, "remote", result.Status.GameServerName)
+	c.allocator.syncPolicyStatus()
+	mu.Lock()
+	assert.Equal(t, int32(50), updated.Status.Allocators[0].Capacity.AvailableCapacity)
+	assert.Equal(t, int32(2), updated.Status.Allocators[0].Capacity.ObservedAllocations)
+	assert.Equal(t, 100, updated.Status.Allocators[0].Capacity.EffectiveWeight)
+	mu.Unlock()
+}
@@ -66,6 +66,7 @@ func NewController(apiServer *apiserver.APIServer,
 		api: apiServer,
 		allocator: NewAllocator(
 			agonesInformerFactory.Multicluster().V1().GameServerAllocationPolicies(),
+			agonesClient.MulticlusterV1(),
 			kubeInformerFactory.Core().V1().Secrets(),
 			kubeClient,
 			NewReadyGameServerCache(agonesInformerFactory.Agones().V1().GameServers(), agonesClient.AgonesV1(), counter, health),
@@ -33,6 +33,7 @@ import (
 	"agones.dev/agones/pkg/gameservers"
 	agtesting "agones.dev/agones/pkg/testing"
 	"agones.dev/agones/pkg/util/apiserver"
+	"agones.dev/agones/pkg/util/runtime"
 	"agones.dev/agones/pkg/util/signals"
 	"github.com/heptiolabs/healthcheck"
 	"github.com/pkg/errors"
@@ -67,7 +68,7 @@ func TestControllerAllocator(t *testing.T) {
 
 		gsa := &allocationv1.GameServerAllocation{
 			Spec: allocationv1.GameServerAllocationSpec{
-				Required: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: f.ObjectMeta.Name}},
+				Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: f.ObjectMeta.Name}}},
 			}}
 
 		c, m := newFakeController()
@@ -111,6 +112,12 @@ func TestControllerAllocator(t *testing.T) {
 
 			assert.Equal(t, gsa.Spec.Required, ret.Spec.Required)
 			assert.True(t, expectedState == ret.Status.State, "Failed: %s vs %s", expectedState, ret.Status.State)
+			if expectedState == allocationv1.GameServerAllocationAllocated {
+				if assert.NotNil(t, ret.Status.Metadata) {
+					assert.Equal(t, f.ObjectMeta.Name, ret.Status.Metadata.Labels[agonesv1.FleetNameLabel])
+				}
+				assert.NotNil(t, ret.Status.AllocationTimestamp)
+			}
 		}
 
 		test(gsa.DeepCopy(), allocationv1.GameServerAllocationAllocated)
@@ -201,12 +208,12 @@ func TestControllerAllocate(t *testing.T) {
 
 	gsa := allocationv1.GameServerAllocation{ObjectMeta: metav1.ObjectMeta{Name: "gsa-1", Namespace: defaultNs},
 		Spec: allocationv1.GameServerAllocationSpec{
-			Required:  metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: f.ObjectMeta.Name}},
+			Required:  allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: f.ObjectMeta.Name}}},
 			MetaPatch: fam,
 		}}
 	gsa.ApplyDefaults()
 
-	gs, err := c.allocator.allocate(&gsa, stop)
+	gs, err := c.allocator.allocate(&gsa, nil, stop)
 	assert.Nil(t, err)
 	assert.Equal(t, agonesv1.GameServerStateAllocated, gs.Status.State)
 	assert.True(t, updated)
@@ -222,24 +229,104 @@ func TestControllerAllocate(t *testing.T) {
 	}
 
 	updated = false
-	gs, err = c.allocator.allocate(&gsa, stop)
+	gs, err = c.allocator.allocate(&gsa, nil, stop)
 	assert.Nil(t, err)
 	assert.Equal(t, agonesv1.GameServerStateAllocated, gs.Status.State)
 	assert.True(t, updated)
 
 	updated = false
-	gs, err = c.allocator.allocate(&gsa, stop)
+	gs, err = c.allocator.allocate(&gsa, nil, stop)
 	assert.Nil(t, err)
 	assert.Equal(t, agonesv1.GameServerStateAllocated, gs.Status.State)
 	assert.True(t, updated)
 
 	updated = false
-	_, err = c.allocator.allocate(&gsa, stop)
+	_, err = c.allocator.allocate(&gsa, nil, stop)
 	assert.NotNil(t, err)
 	assert.Equal(t, ErrNoGameServerReady, err)
 	assert.False(t, updated)
 }
 
+func TestControllerAllocateReservesPlayerSlots(t *testing.T) {
+	t.Parallel()
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeaturePlayerTracking)+"=true&"+string(runtime.FeatureStateAllocationFilter)+"=true"))
+
+	f, gsList := defaultFixtures(1)
+	c, m := newFakeController()
+	// room for one allocation of 4 players, but not two
+	gsList[0].Status.State = agonesv1.GameServerStateAllocated
+	gsList[0].Status.Players = &agonesv1.PlayerStatus{Capacity: 6}
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, &agonesv1.GameServerList{Items: gsList}, nil
+	})
+
+	updates := make(chan *agonesv1.GameServer, 2)
+	gsWatch := watch.NewFake()
+	m.AgonesClient.AddWatchReactor("gameservers", k8stesting.DefaultWatchReactor(gsWatch, nil))
+	m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		ua := action.(k8stesting.UpdateAction)
+		gs := ua.GetObject().(*agonesv1.GameServer)
+		updates <- gs.DeepCopy()
+		gsWatch.Modify(gs)
+		return true, gs, nil
+	})
+
+	stop, cancel := agtesting.StartInformers(m)
+	defer cancel()
+
+	if err := c.Run(1, stop); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	err := wait.PollImmediate(time.Second, 10*time.Second, func() (done bool, err error) {
+		return c.allocator.readyGameServerCache.workerqueue.RunCount() == 1, nil
+	})
+	assert.NoError(t, err)
+
+	allocated := agonesv1.GameServerStateAllocated
+	gsa := allocationv1.GameServerAllocation{ObjectMeta: metav1.ObjectMeta{Name: "gsa-1", Namespace: defaultNs},
+		Spec: allocationv1.GameServerAllocationSpec{
+			Required: allocationv1.GameServerSelector{
+				LabelSelector:   metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: f.ObjectMeta.Name}},
+				GameServerState: &allocated,
+				Players:         &allocationv1.PlayerSelector{MinAvailable: 4},
+			},
+		}}
+	gsa.ApplyDefaults()
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := c.allocator.allocate(gsa.DeepCopy(), nil, stop)
+			errs <- err
+		}()
+	}
+
+	var failed []error
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if assert.Len(t, failed, 1, "only one allocation should fit on the GameServer") {
+		assert.Equal(t, ErrNoGameServerReady, failed[0])
+	}
+
+	// the reserved slots are still taken for allocations that come after
+	_, err = c.allocator.allocate(gsa.DeepCopy(), nil, stop)
+	assert.Equal(t, ErrNoGameServerReady, err)
+
+	select {
+	case gs := <-updates:
+		assert.Equal(t, int64(4), gs.Status.Players.Reserved)
+	default:
+		assert.Fail(t, "GameServer should be updated")
+	}
+	assert.Empty(t, updates, "GameServer should only be allocated once")
+}
+
 func TestControllerAllocatePriority(t *testing.T) {
 	t.Parallel()
 	stop := signals.NewStopChannel()
@@ -281,7 +368,7 @@ func TestControllerAllocatePriority(t *testing.T) {
 
 		gas := &allocationv1.GameServerAllocation{ObjectMeta: metav1.ObjectMeta{Name: "fa-1", Namespace: defaultNs},
 			Spec: allocationv1.GameServerAllocationSpec{
-				Required: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: f.ObjectMeta.Name}},
+				Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: f.ObjectMeta.Name}}},
 			}}
 		gas.ApplyDefaults()
 
@@ -292,27 +379,27 @@ func TestControllerAllocatePriority(t *testing.T) {
 
 	run(t, "packed", func(t *testing.T, c *Controller, gas *allocationv1.GameServerAllocation) {
 		// priority should be node1, then node2
-		gs1, err := c.allocator.allocate(gas, stop)
+		gs1, err := c.allocator.allocate(gas, nil, stop)
 		assert.NoError(t, err)
 		assert.Equal(t, n1, gs1.Status.NodeName)
 
-		gs2, err := c.allocator.allocate(gas, stop)
+		gs2, err := c.allocator.allocate(gas, nil, stop)
 		assert.NoError(t, err)
 		assert.Equal(t, n1, gs2.Status.NodeName)
 		assert.NotEqual(t, gs1.ObjectMeta.Name, gs2.ObjectMeta.Name)
 
-		gs3, err := c.allocator.allocate(gas, stop)
+		gs3, err := c.allocator.allocate(gas, nil, stop)
 		assert.NoError(t, err)
 		assert.Equal(t, n1, gs3.Status.NodeName)
 		assert.NotContains(t, []string{gs1.ObjectMeta.Name, gs2.ObjectMeta.Name}, gs3.ObjectMeta.Name)
 
-		gs4, err := c.allocator.allocate(gas, stop)
+		gs4, err := c.allocator.allocate(gas, nil, stop)
 		assert.NoError(t, err)
 		assert.Equal(t, n2, gs4.Status.NodeName)
 		assert.NotContains(t, []string{gs1.ObjectMeta.Name, gs2.ObjectMeta.Name, gs3.ObjectMeta.Name}, gs4.ObjectMeta.Name)
 
 		// should have none left
-		_, err = c.allocator.allocate(gas, stop)
+		_, err = c.allocator.allocate(gas, nil, stop)
 		assert.Equal(t, err, ErrNoGameServerReady)
 	})
 
@@ -323,23 +410,23 @@ func TestControllerAllocatePriority(t *testing.T) {
 
 		// distributed is randomised, so no set pattern
 
-		gs1, err := c.allocator.allocate(gas, stop)
+		gs1, err := c.allocator.allocate(gas, nil, stop)
 		assert.NoError(t, err)
 
-		gs2, err := c.allocator.allocate(gas, stop)
+		gs2, err := c.allocator.allocate(gas, nil, stop)
 		assert.NoError(t, err)
 		assert.NotEqual(t, gs1.ObjectMeta.Name, gs2.ObjectMeta.Name)
 
-		gs3, err := c.allocator.allocate(gas, stop)
+		gs3, err := c.allocator.allocate(gas, nil, stop)
 		assert.NoError(t, err)
 		assert.NotContains(t, []string{gs1.ObjectMeta.Name, gs2.ObjectMeta.Name}, gs3.ObjectMeta.Name)
 
-		gs4, err := c.allocator.allocate(gas, stop)
+		gs4, err := c.allocator.allocate(gas, nil, stop)
 		assert.NoError(t, err)
 		assert.NotContains(t, []string{gs1.ObjectMeta.Name, gs2.ObjectMeta.Name, gs3.ObjectMeta.Name}, gs4.ObjectMeta.Name)
 
 		// should have none left
-		_, err = c.allocator.allocate(gas, stop)
+		_, err = c.allocator.allocate(gas, nil, stop)
 		assert.Equal(t, err, ErrNoGameServerReady)
 	})
 }
@@ -380,7 +467,7 @@ func TestControllerRunLocalAllocations(t *testing.T) {
 				Namespace: defaultNs,
 			},
 			Spec: allocationv1.GameServerAllocationSpec{
-				Required: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: f.ObjectMeta.Name}},
+				Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: f.ObjectMeta.Name}}},
 			}}
 		gsa.ApplyDefaults()
 
@@ -435,7 +522,7 @@ func TestControllerRunLocalAllocations(t *testing.T) {
 				Namespace: defaultNs,
 			},
 			Spec: allocationv1.GameServerAllocationSpec{
-				Required: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: "thereisnofleet"}},
+				Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: "thereisnofleet"}}},
 			}}
 		gsa.ApplyDefaults()
 
@@ -562,6 +649,26 @@ func TestControllerRunCacheSync(t *testing.T) {
 	assertCacheEntries(0)
 }
 
+func TestIsAllocatable(t *testing.T) {
+	t.Parallel()
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+
+	gs := &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady}}
+	assert.True(t, isAllocatable(gs))
+
+	n := metav1.Now()
+	gs.Status.DrainDeadline = &n
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureSDKDrain)+"=false"))
+	assert.True(t, isAllocatable(gs))
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureSDKDrain)+"=true"))
+	assert.False(t, isAllocatable(gs), "draining GameServers are not allocatable")
+
+	gs.Status.DrainDeadline = nil
+	gs.Status.State = agonesv1.GameServerStateShutdown
+	assert.False(t, isAllocatable(gs))
+}
+
 func TestGetRandomlySelectedGS(t *testing.T) {
 	c, _ := newFakeController()
 	c.allocator.topNGameServerCount = 5
@@ -602,6 +709,7 @@ func TestControllerAllocationUpdateWorkers(t *testing.T) {
 		c, m := newFakeController()
 
 		updated := false
+		var updateTime time.Time
 		gs1 := &agonesv1.GameServer{
 			ObjectMeta: metav1.ObjectMeta{Name: "gs1"},
 		}
@@ -615,6 +723,7 @@ func TestControllerAllocationUpdateWorkers(t *testing.T) {
 
 		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
 			updated = true
+			updateTime = time.Now()
 
 			uo := action.(k8stesting.UpdateAction)
 			gs := uo.GetObject().(*agonesv1.GameServer)
@@ -637,6 +746,10 @@ func TestControllerAllocationUpdateWorkers(t *testing.T) {
 		assert.NoError(t, r.err)
 		assert.Equal(t, gs1.ObjectMeta.Name, r.gs.ObjectMeta.Name)
 		assert.Equal(t, agonesv1.GameServerStateAllocated, r.gs.Status.State)
+		if assert.NotNil(t, r.request.gsa.Status.AllocationTimestamp) {
+			assert.False(t, r.request.gsa.Status.AllocationTimestamp.Time.Before(updateTime),
+				"the allocation timestamp should be from when the GameServer was allocated")
+		}
 
 		agtesting.AssertEventContains(t, m.FakeRecorder.Events, "Allocated")
 
@@ -709,6 +822,7 @@ func TestControllerAllocationUpdateWorkers(t *testing.T) {
 		assert.True(t, updated)
 		assert.Error(t, r.err)
 		assert.Equal(t, gs1, r.gs)
+		assert.Nil(t, r.request.gsa.Status.AllocationTimestamp)
 		agtesting.AssertNoEvent(t, m.FakeRecorder.Events)
 
 		var cached *agonesv1.GameServer
@@ -849,7 +963,7 @@ func TestMultiClusterAllocationFromLocal(t *testing.T) {
 						},
 					},
 				},
-				Required: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}},
+				Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}}},
 			},
 		}
 
@@ -898,7 +1012,7 @@ func TestMultiClusterAllocationFromLocal(t *testing.T) {
 						},
 					},
 				},
-				Required: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}},
+				Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}}},
 			},
 		}
 
@@ -967,7 +1081,7 @@ func TestMultiClusterAllocationFromRemote(t *testing.T) {
 				MultiClusterSetting: allocationv1.MultiClusterSetting{
 					Enabled: true,
 				},
-				Required: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}},
+				Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}}},
 			},
 		}
 
@@ -1071,7 +1185,7 @@ func TestMultiClusterAllocationFromRemote(t *testing.T) {
 				MultiClusterSetting: allocationv1.MultiClusterSetting{
 					Enabled: true,
 				},
-				Required: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}},
+				Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}}},
 			},
 		}
 
@@ -1150,7 +1264,7 @@ func TestMultiClusterAllocationFromRemote(t *testing.T) {
 				MultiClusterSetting: allocationv1.MultiClusterSetting{
 					Enabled: true,
 				},
-				Required: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}},
+				Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}}},
 			},
 		}
 
@@ -1218,7 +1332,7 @@ func TestMultiClusterAllocationFromRemote(t *testing.T) {
 				MultiClusterSetting: allocationv1.MultiClusterSetting{
 					Enabled: true,
 				},
-				Required: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}},
+				Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}}},
 			},
 		}
 
@@ -1292,7 +1406,7 @@ func TestMultiClusterAllocationFromRemote(t *testing.T) {
 				MultiClusterSetting: allocationv1.MultiClusterSetting{
 					Enabled: true,
 				},
-				Required: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}},
+				Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}}},
 			},
 		}
 
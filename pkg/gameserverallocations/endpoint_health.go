@@ -0,0 +1,280 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserverallocations
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	multiclusterv1 "agones.dev/agones/pkg/apis/multicluster/v1"
+	"agones.dev/agones/pkg/util/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+const (
+	// endpointFailureThreshold is the number of failures in a row after which a Healthy allocation endpoint is ejected
+	endpointFailureThreshold = 3
+	// endpointBaseEjectionTime is how long an allocation endpoint is ejected for the first time.
+	// It doubles with each ejection in a row, up to endpointMaxEjectionTime.
+	endpointBaseEjectionTime = 10 * time.Second
+	endpointMaxEjectionTime  = 5 * time.Minute
+)
+
+// endpointHealth is a circuit breaker for each of the remote allocation endpoints.
+// An endpoint that fails endpointFailureThreshold times in a row is ejected, and is skipped by remote allocations
+// until its ejection time has passed, or it passes a health probe. Once its ejection time has passed,
+// a single failure ejects it again, for twice as long.
+type endpointHealth struct {
+	mu               sync.Mutex
+	clock            clock.Clock
+	failureThreshold int32
+	baseEjectionTime time.Duration
+	maxEjectionTime  time.Duration
+	endpoints        map[string]*endpointStatus
+}
+
+// endpointStatus is the circuit breaker state of a single allocation endpoint
+type endpointStatus struct {
+	state               multiclusterv1.AllocationEndpointState
+	consecutiveFailures int32
+	// ejections is the number of times in a row the endpoint has been ejected
+	ejections          int32
+	lastError          string
+	lastTransitionTime time.Time
+	ejectedUntil       time.Time
+}
+
+// newEndpointHealth returns an endpointHealth with the default thresholds
+func newEndpointHealth() *endpointHealth {
+	return &endpointHealth{
+		clock:            clock.RealClock{},
+		failureThreshold: endpointFailureThreshold,
+		baseEjectionTime: endpointBaseEjectionTime,
+		maxEjectionTime:  endpointMaxEjectionTime,
+		endpoints:        map[string]*endpointStatus{},
+	}
+}
+
+// available returns true if allocation requests can be sent to the endpoint
+func (h *endpointHealth) available(endpoint string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.status(endpoint, h.clock.Now()).state != multiclusterv1.AllocationEndpointEjected
+}
+
+// unhealthy returns the endpoints that are either Ejected or Recovering
+func (h *endpointHealth) unhealthy() map[string]bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.clock.Now()
+	result := map[string]bool{}
+	for endpoint := range h.endpoints {
+		if h.status(endpoint, now).state != multiclusterv1.AllocationEndpointHealthy {
+			result[endpoint] = true
+		}
+	}
+	return result
+}
+
+// success records a successful allocation request or health probe to the endpoint,
+// which makes it Healthy again
+func (h *endpointHealth) success(endpoint string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.clock.Now()
+	s := h.status(endpoint, now)
+	s.consecutiveFailures = 0
+	s.ejections = 0
+	if s.state != multiclusterv1.AllocationEndpointHealthy {
+		h.transition(endpoint, s, multiclusterv1.AllocationEndpointHealthy, now)
+	}
+}
+
+// failure records a failed allocation request or health probe to the endpoint, and ejects
+// the endpoint if it is Recovering, or if it is Healthy and has reached the failure threshold
+func (h *endpointHealth) failure(endpoint string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.clock.Now()
+	s := h.status(endpoint, now)
+	s.consecutiveFailures++
+	s.lastError = err.Error()
+
+	if s.state == multiclusterv1.AllocationEndpointRecovering ||
+		(s.state == multiclusterv1.AllocationEndpointHealthy && s.consecutiveFailures >= h.failureThreshold) {
+		ejection := h.baseEjectionTime
+		for i := int32(0); i < s.ejections && ejection < h.maxEjectionTime; i++ {
+			ejection *= 2
+		}
+		if ejection > h.maxEjectionTime {
+			ejection = h.maxEjectionTime
+		}
+		s.ejections++
+		s.ejectedUntil = now.Add(ejection)
+		h.transition(endpoint, s, multiclusterv1.AllocationEndpointEjected, now)
+		recordEndpointEjection(endpoint)
+	}
+}
+
+// endpointStatuses returns the status of each of the allocation endpoints of a ClusterConnectionInfo
+func (h *endpointHealth) endpointStatuses(endpoints []string) []multiclusterv1.AllocationEndpointStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.clock.Now()
+	result := make([]multiclusterv1.AllocationEndpointStatus, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		s := h.status(addPort(endpoint), now)
+		es := multiclusterv1.AllocationEndpointStatus{
+			Endpoint:            endpoint,
+			State:               s.state,
+			ConsecutiveFailures: s.consecutiveFailures,
+			LastError:           s.lastError,
+		}
+		// status times only have a precision of seconds, so truncate them to avoid needless status updates
+		if !s.lastTransitionTime.IsZero() {
+			t := metav1.NewTime(s.lastTransitionTime).Rfc3339Copy()
+			es.LastTransitionTime = &t
+		}
+		if s.state == multiclusterv1.AllocationEndpointEjected {
+			t := metav1.NewTime(s.ejectedUntil).Rfc3339Copy()
+			es.EjectedUntil = &t
+		}
+		result = append(result, es)
+	}
+	return result
+}
+
+// prune forgets all the endpoints that are not in keep
+func (h *endpointHealth) prune(keep map[string]bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for endpoint := range h.endpoints {
+		if !keep[endpoint] {
+			delete(h.endpoints, endpoint)
+		}
+	}
+}
+
+// status returns the status of the endpoint, and moves an Ejected endpoint to Recovering
+// once its ejection time has passed. Must be called with the lock held.
+func (h *endpointHealth) status(endpoint string, now time.Time) *endpointStatus {
+	s, ok := h.endpoints[endpoint]
+	if !ok {
+		s = &endpointStatus{state: multiclusterv1.AllocationEndpointHealthy}
+		h.endpoints[endpoint] = s
+	}
+	if s.state == multiclusterv1.AllocationEndpointEjected && !now.Before(s.ejectedUntil) {
+		h.transition(endpoint, s, multiclusterv1.AllocationEndpointRecovering, now)
+	}
+	return s
+}
+
+// transition moves the endpoint to the given state. Must be called with the lock held.
+func (h *endpointHealth) transition(endpoint string, s *endpointStatus, state multiclusterv1.AllocationEndpointState, now time.Time) {
+	s.state = state
+	s.lastTransitionTime = now
+	recordEndpointHealthy(endpoint, state != multiclusterv1.AllocationEndpointEjected)
+}
+
+// isEndpointFailure returns true if the error of a remote allocation request means the allocation endpoint
+// is unhealthy, rather than, for example, having no Ready GameServers to allocate
+func isEndpointFailure(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return true
+	}
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Unknown, codes.Internal:
+		return true
+	}
+	return false
+}
+
+// availableEndpoints returns the allocation endpoints of the ClusterConnectionInfo, with their port,
+// that allocation requests can be sent to
+func (c *Allocator) availableEndpoints(connectionInfo *multiclusterv1.ClusterConnectionInfo) []string {
+	result := make([]string, 0, len(connectionInfo.AllocationEndpoints))
+	for _, ip := range connectionInfo.AllocationEndpoints {
+		endpoint := addPort(ip)
+		if runtime.FeatureEnabled(runtime.FeatureAllocationEndpointHealth) && !c.endpointHealth.available(endpoint) {
+			continue
+		}
+		result = append(result, endpoint)
+	}
+	return result
+}
+
+// recordEndpointResult records the outcome of a remote allocation request to the endpoint
+func (c *Allocator) recordEndpointResult(endpoint string, err error) {
+	if !runtime.FeatureEnabled(runtime.FeatureAllocationEndpointHealth) {
+		return
+	}
+	if err != nil && isEndpointFailure(err) {
+		c.endpointHealth.failure(endpoint, err)
+		return
+	}
+	c.endpointHealth.success(endpoint)
+}
+
+// probeEndpoints probes each of the allocation endpoints of the policies that is not Healthy, once
+func (c *Allocator) probeEndpoints(policies []*multiclusterv1.GameServerAllocationPolicy) {
+	unhealthy := c.endpointHealth.unhealthy()
+	for _, policy := range policies {
+		if len(unhealthy) == 0 {
+			return
+		}
+		connectionInfo := &policy.Spec.ConnectionInfo
+		var dialOpts grpc.DialOption
+		for _, ip := range connectionInfo.AllocationEndpoints {
+			endpoint := addPort(ip)
+			if !unhealthy[endpoint] {
+				continue
+			}
+			if dialOpts == nil {
+				var err error
+				if dialOpts, err = c.createRemoteClusterDialOption(policy.ObjectMeta.Namespace, connectionInfo); err != nil {
+					c.baseLogger.WithField("policy", policy.ObjectMeta.Name).WithError(err).Warn("could not create the dial options to probe the allocation endpoints")
+					break
+				}
+			}
+			delete(unhealthy, endpoint)
+
+			if err := c.remoteProbeCallback(endpoint, dialOpts); err != nil {
+				c.baseLogger.WithField("endpoint", endpoint).WithError(err).Debug("allocation endpoint probe failed")
+				c.endpointHealth.failure(endpoint, err)
+				continue
+			}
+			c.endpointHealth.success(endpoint)
+		}
+	}
+}
+
+// probeEndpoint checks that a connection, including the TLS handshake, can be made to the allocation endpoint
+func probeEndpoint(timeout time.Duration) func(string, grpc.DialOption) error {
+	return func(endpoint string, dialOpts grpc.DialOption) error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		conn, err := grpc.DialContext(ctx, endpoint, dialOpts, grpc.WithBlock(), grpc.FailOnNonTempDialError(true))
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserverallocations
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	pb "agones.dev/agones/pkg/allocation/go"
+	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
+	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
+	multiclusterv1 "agones.dev/agones/pkg/apis/multicluster/v1"
+	agtesting "agones.dev/agones/pkg/testing"
+	"agones.dev/agones/pkg/util/runtime"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestEndpointHealth(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	fc := clock.NewFakeClock(now)
+	h := newEndpointHealth()
+	h.clock = fc
+	endpoint := "a:443"
+	failure := errors.New("connection refused")
+
+	assert.True(t, h.available(endpoint))
+
+	// ejected on the third failure in a row
+	h.failure(endpoint, failure)
+	h.failure(endpoint, failure)
+	assert.True(t, h.available(endpoint))
+	assert.Empty(t, h.unhealthy())
+	h.failure(endpoint, failure)
+	assert.False(t, h.available(endpoint))
+	assert.Equal(t, map[string]bool{endpoint: true}, h.unhealthy())
+
+	statuses := h.endpointStatuses([]string{"a", "b"})
+	require.Len(t, statuses, 2)
+	ejectedUntil := metav1.NewTime(now.Add(endpointBaseEjectionTime))
+	lastTransitionTime := metav1.NewTime(now)
+	assert.Equal(t, multiclusterv1.AllocationEndpointStatus{
+		Endpoint:            "a",
+		State:               multiclusterv1.AllocationEndpointEjected,
+		ConsecutiveFailures: 3,
+		LastError:           "connection refused",
+		LastTransitionTime:  &lastTransitionTime,
+		EjectedUntil:        &ejectedUntil,
+	}, statuses[0])
+	assert.Equal(t, multiclusterv1.AllocationEndpointStatus{Endpoint: "b", State: multiclusterv1.AllocationEndpointHealthy}, statuses[1])
+
+	// recovering once the ejection time has passed, and ejected for twice as long on the first failure
+	fc.Step(endpointBaseEjectionTime)
+	assert.True(t, h.available(endpoint))
+	assert.Equal(t, multiclusterv1.AllocationEndpointRecovering, h.endpointStatuses([]string{"a"})[0].State)
+	h.failure(endpoint, failure)
+	assert.False(t, h.available(endpoint))
+	fc.Step(endpointBaseEjectionTime)
+	assert.False(t, h.available(endpoint))
+	fc.Step(endpointBaseEjectionTime)
+	assert.True(t, h.available(endpoint))
+
+	// a success makes it healthy again
+	h.success(endpoint)
+	assert.Empty(t, h.unhealthy())
+	statuses = h.endpointStatuses([]string{"a"})
+	assert.Equal(t, multiclusterv1.AllocationEndpointHealthy, statuses[0].State)
+	assert.Equal(t, int32(0), statuses[0].ConsecutiveFailures)
+	assert.Nil(t, statuses[0].EjectedUntil)
+
+	// the ejection time is capped
+	for i := 0; i < 20; i++ {
+		for j := 0; j < endpointFailureThreshold; j++ {
+			h.failure(endpoint, failure)
+		}
+		fc.Step(endpointMaxEjectionTime)
+	}
+	h.failure(endpoint, failure)
+	fc.Step(endpointMaxEjectionTime - time.Second)
+	assert.False(t, h.available(endpoint))
+	fc.Step(time.Second)
+	assert.True(t, h.available(endpoint))
+
+	h.prune(map[string]bool{})
+	assert.Empty(t, h.endpoints)
+}
+
+func TestIsEndpointFailure(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isEndpointFailure(errors.New("connection refused")))
+	assert.True(t, isEndpointFailure(status.Error(codes.Unavailable, "unavailable")))
+	assert.True(t, isEndpointFailure(status.Error(codes.DeadlineExceeded, "timeout")))
+	assert.False(t, isEndpointFailure(status.Error(codes.ResourceExhausted, "no GameServers")))
+	assert.False(t, isEndpointFailure(status.Error(codes.Aborted, "conflict")))
+}
+
+func TestAllocatorEndpointHealth(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+	require.NoError(t, runtime.ParseFeatures(string(runtime.FeatureAllocationEndpointHealth)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	const clusterName = "remotecluster"
+	secretName := clusterName + "secret"
+	policy := multiclusterv1.GameServerAllocationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: defaultNs},
+		Spec: multiclusterv1.GameServerAllocationPolicySpec{
+			Priority: 1,
+			Weight:   200,
+			ConnectionInfo: multiclusterv1.ClusterConnectionInfo{
+				AllocationEndpoints: []string{"bad", "good"},
+				ClusterName:         clusterName,
+				SecretName:          secretName,
+				ServerCA:            clientCert,
+			},
+		},
+	}
+
+	setup := func() (*Controller, agtesting.Mocks) {
+		c, m := newFakeController()
+		m.AgonesClient.AddReactor("list", "gameserverallocationpolicies", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+			return true, &multiclusterv1.GameServerAllocationPolicyList{Items: []multiclusterv1.GameServerAllocationPolicy{policy}}, nil
+		})
+		m.KubeClient.AddReactor("list", "secrets",
+			func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+				return true, getTestSecret(secretName, clientCert), nil
+			})
+		return c, m
+	}
+
+	t.Run("ejected endpoints are skipped", func(t *testing.T) {
+		c, m := setup()
+		fleetName := addReactorForGameServer(&m)
+
+		var calls []string
+		c.allocator.remoteAllocationCallback = func(ctx context.Context, endpoint string, dialOpt grpc.DialOption, request *pb.AllocationRequest) (*pb.AllocationResponse, error) {
+			calls = append(calls, endpoint)
+			if endpoint == "bad:443" {
+				return nil, status.Error(codes.Unavailable, "connection refused")
+			}
+			return &pb.AllocationResponse{GameServerName: "remote"}, nil
+		}
+
+		stop, cancel := agtesting.StartInformers(m, c.allocator.allocationPolicySynced, c.allocator.secretSynced, c.allocator.readyGameServerCache.gameServerSynced)
+		defer cancel()
+		require.NoError(t, c.allocator.readyGameServerCache.syncReadyGSServerCache())
+		require.NoError(t, c.allocator.readyGameServerCache.counter.Run(0, stop))
+
+		gsa := &allocationv1.GameServerAllocation{
+			ObjectMeta: metav1.ObjectMeta{Namespace: defaultNs, Name: "alloc1"},
+			Spec: allocationv1.GameServerAllocationSpec{
+				MultiClusterSetting: allocationv1.MultiClusterSetting{Enabled: true},
+				Required:            allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}}},
+			},
+		}
+
+		for i := 0; i < endpointFailureThreshold+1; i++ {
+			result, err := executeAllocation(gsa, c)
+			require.NoError(t, err)
+			assert.Equal(t, "remote", result.Status.GameServerName)
+		}
+		assert.Equal(t, []string{"bad:443", "good:443", "bad:443", "good:443", "bad:443", "good:443", "good:443"}, calls)
+		assert.False(t, c.allocator.endpointHealth.available("bad:443"))
+		assert.True(t, c.allocator.endpointHealth.available("good:443"))
+	})
+
+	t.Run("unhealthy endpoints are probed and reported in the status", func(t *testing.T) {
+		c, m := setup()
+
+		var mu sync.Mutex
+		var updated *multiclusterv1.GameServerAllocationPolicy
+		m.AgonesClient.AddReactor("update", "gameserverallocationpolicies", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+			ua := action.(k8stesting.UpdateAction)
+			assert.Equal(t, "status", ua.GetSubresource())
+			mu.Lock()
+			defer mu.Unlock()
+			updated = ua.GetObject().(*multiclusterv1.GameServerAllocationPolicy)
+			return true, updated, nil
+		})
+		var probed []string
+		probeErr := errors.New("probe failed")
+		c.allocator.remoteProbeCallback = func(endpoint string, _ grpc.DialOption) error {
+			probed = append(probed, endpoint)
+			return probeErr
+		}
+
+		_, cancel := agtesting.StartInformers(m, c.allocator.allocationPolicySynced, c.allocator.secretSynced)
+		defer cancel()
+
+		for i := 0; i < endpointFailureThreshold; i++ {
+			c.allocator.endpointHealth.failure("bad:443", errors.New("connection refused"))
+		}
+
+		c.allocator.syncPolicyStatus()
+		assert.Equal(t, []string{"bad:443"}, probed)
+		mu.Lock()
+		require.NotNil(t, updated)
+		require.Len(t, updated.Status.Allocators, 1)
+		require.Len(t, updated.Status.Allocators[0].Endpoints, 2)
+		assert.Equal(t, multiclusterv1.AllocationEndpointEjected, updated.Status.Allocators[0].Endpoints[0].State)
+		assert.Equal(t, int32(endpointFailureThreshold+1), updated.Status.Allocators[0].Endpoints[0].ConsecutiveFailures)
+		assert.Equal(t, "probe failed", updated.Status.Allocators[0].Endpoints[0].LastError)
+		assert.Equal(t, multiclusterv1.AllocationEndpointHealthy, updated.Status.Allocators[0].Endpoints[1].State)
+		mu.Unlock()
+
+		probeErr = nil
+		c.allocator.syncPolicyStatus()
+		assert.Equal(t, []string{"bad:443", "bad:443"}, probed)
+		assert.True(t, c.allocator.endpointHealth.available("bad:443"))
+		mu.Lock()
+		assert.Equal(t, multiclusterv1.AllocationEndpointHealthy, updated.Status.Allocators[0].Endpoints[0].State)
+		mu.Unlock()
+	})
+}
@@ -16,13 +16,12 @@ package gameserverallocations
 
 import (
 	"math/rand"
+	"sort"
 
 	"agones.dev/agones/pkg/apis"
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
 	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
 	"github.com/pkg/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 )
 
 // findGameServerForAllocation finds an optimal gameserver, given the
@@ -30,77 +29,83 @@ import (
 // that the gameserver was found at in `list`, in case you want to remove it from the list
 // Packed: will search list from start to finish
 // Distributed: will search in a random order through the list
-// It is assumed that all gameservers passed in, are Ready and not being deleted, and are sorted in Packed priority order
+// If the GameServerAllocation has Priorities, the list is searched in Priority order, and the above
+// order is only used to break ties.
+// It is assumed that all gameservers passed in, are Ready (or Allocated, when the StateAllocationFilter feature
+// is enabled) and not being deleted, and are sorted in Packed priority order
 func findGameServerForAllocation(gsa *allocationv1.GameServerAllocation, list []*agonesv1.GameServer) (*agonesv1.GameServer, int, error) {
 	type result struct {
 		gs    *agonesv1.GameServer
 		index int
 	}
 
-	requiredSelector, err := metav1.LabelSelectorAsSelector(&gsa.Spec.Required)
-	if err != nil {
-		return nil, -1, errors.Wrap(err, "could not convert GameServerAllocation selector")
-	}
-
-	preferredSelector, err := gsa.Spec.PreferredSelectors()
-	if err != nil {
-		return nil, -1, errors.Wrap(err, "could not convert preferred selectors for GameServerAllocation")
-	}
-
 	var required *result
-	preferred := make([]*result, len(preferredSelector))
+	preferred := make([]*result, len(gsa.Spec.Preferred))
 
-	var loop func(list []*agonesv1.GameServer, f func(i int, gs *agonesv1.GameServer))
+	// only the gameservers in the same namespace that match any of the selectors are candidates, so that
+	// the ordering below does not have to go through all the gameservers of the cluster
+	matches := func(gs *agonesv1.GameServer) bool {
+		if gs.ObjectMeta.Namespace != gsa.ObjectMeta.Namespace {
+			return false
+		}
+		if gsa.Spec.Required.Matches(gs) {
+			return true
+		}
+		for _, sel := range gsa.Spec.Preferred {
+			if sel.Matches(gs) {
+				return true
+			}
+		}
+		return false
+	}
+	var indices []int
+	for i, gs := range list {
+		if matches(gs) {
+			indices = append(indices, i)
+		}
+	}
 
 	// packed is forward looping, distributed is random looping
 	switch gsa.Spec.Scheduling {
 	case apis.Packed:
-		loop = func(list []*agonesv1.GameServer, f func(i int, gs *agonesv1.GameServer)) {
-			for i, gs := range list {
-				f(i, gs)
-			}
-		}
 	case apis.Distributed:
-		// randomised looping - make a list of indices, and then randomise them
+		// randomised looping - randomise the list of indices,
 		// as we don't want to change the order of the gameserver slice
-		l := len(list)
-		indices := make([]int, l)
-		for i := 0; i < l; i++ {
-			indices[i] = i
-		}
-		rand.Shuffle(l, func(i, j int) {
+		rand.Shuffle(len(indices), func(i, j int) {
 			indices[i], indices[j] = indices[j], indices[i]
 		})
-
-		loop = func(list []*agonesv1.GameServer, f func(i int, gs *agonesv1.GameServer)) {
-			for _, i := range indices {
-				f(i, list[i])
-			}
-		}
 	default:
 		return nil, -1, errors.Errorf("scheduling strategy of '%s' is not supported", gsa.Spec.Scheduling)
 	}
 
-	loop(list, func(i int, gs *agonesv1.GameServer) {
-		// only search the same namespace
-		if gs.ObjectMeta.Namespace != gsa.ObjectMeta.Namespace {
-			return
-		}
+	// priorities take precedence over the scheduling order, which is then only used to break ties
+	if len(gsa.Spec.Priorities) > 0 {
+		sort.SliceStable(indices, func(i, j int) bool {
+			gs1, gs2 := list[indices[i]], list[indices[j]]
+			for k := range gsa.Spec.Priorities {
+				if c := gsa.Spec.Priorities[k].Compare(gs1, gs2); c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+	}
 
-		set := labels.Set(gs.ObjectMeta.Labels)
+	for _, i := range indices {
+		gs := list[i]
 
 		// first look at preferred
-		for j, sel := range preferredSelector {
-			if preferred[j] == nil && sel.Matches(set) {
+		for j, sel := range gsa.Spec.Preferred {
+			if preferred[j] == nil && sel.Matches(gs) {
 				preferred[j] = &result{gs: gs, index: i}
 			}
 		}
 
 		// then look at required
-		if required == nil && requiredSelector.Matches(set) {
+		if required == nil && gsa.Spec.Required.Matches(gs) {
 			required = &result{gs: gs, index: i}
 		}
-	})
+	}
 
 	for _, r := range preferred {
 		if r != nil {
@@ -114,3 +119,27 @@ func findGameServerForAllocation(gsa *allocationv1.GameServerAllocation, list []
 
 	return required.gs, required.index, nil
 }
+
+// findGameServerForRequest finds an optimal gameserver for the request through findGameServerForAllocation,
+// skipping the gameservers that the allocation webhook has already vetoed for this request.
+// The returned index is that of the gameserver in `list`.
+func findGameServerForRequest(req request, list []*agonesv1.GameServer) (*agonesv1.GameServer, int, error) {
+	if len(req.vetoed) == 0 {
+		return findGameServerForAllocation(req.gsa, list)
+	}
+
+	candidates := make([]*agonesv1.GameServer, 0, len(list))
+	indices := make([]int, 0, len(list))
+	for i, gs := range list {
+		if !req.vetoed[gs.ObjectMeta.Name] {
+			candidates = append(candidates, gs)
+			indices = append(indices, i)
+		}
+	}
+
+	gs, index, err := findGameServerForAllocation(req.gsa, candidates)
+	if err != nil {
+		return nil, 0, err
+	}
+	return gs, indices[index], nil
+}
@@ -37,18 +37,18 @@ func TestFindGameServerForAllocationPacked(t *testing.T) {
 	gsa := &allocationv1.GameServerAllocation{
 		ObjectMeta: metav1.ObjectMeta{Namespace: defaultNs},
 		Spec: allocationv1.GameServerAllocationSpec{
-			Required: metav1.LabelSelector{
+			Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{
 				MatchLabels: labels,
-			},
+			}},
 			Scheduling: apis.Packed,
 		},
 	}
 
 	n := metav1.Now()
 	prefGsa := gsa.DeepCopy()
-	prefGsa.Spec.Preferred = append(prefGsa.Spec.Preferred, metav1.LabelSelector{
+	prefGsa.Spec.Preferred = append(prefGsa.Spec.Preferred, allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{
 		MatchLabels: map[string]string{"preferred": "true"},
-	})
+	}})
 
 	fixtures := map[string]struct {
 		list []agonesv1.GameServer
@@ -183,6 +183,166 @@ func TestFindGameServerForAllocationPacked(t *testing.T) {
 	}
 }
 
+func TestFindGameServerForAllocationAllocatedWithPlayerCapacity(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"role": "gameserver"}
+	allocated := agonesv1.GameServerStateAllocated
+
+	gsa := &allocationv1.GameServerAllocation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: defaultNs},
+		Spec: allocationv1.GameServerAllocationSpec{
+			Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: labels}},
+			Preferred: []allocationv1.GameServerSelector{{
+				LabelSelector:   metav1.LabelSelector{MatchLabels: labels},
+				GameServerState: &allocated,
+				Players:         &allocationv1.PlayerSelector{MinAvailable: 4},
+			}},
+			Scheduling: apis.Packed,
+		},
+	}
+
+	list := []*agonesv1.GameServer{
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs1", Namespace: defaultNs, Labels: labels},
+			Status: agonesv1.GameServerStatus{NodeName: "node1", State: agonesv1.GameServerStateAllocated, Players: &agonesv1.PlayerStatus{Count: 8, Capacity: 10}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs2", Namespace: defaultNs, Labels: labels},
+			Status: agonesv1.GameServerStatus{NodeName: "node1", State: agonesv1.GameServerStateReady, Players: &agonesv1.PlayerStatus{Count: 0, Capacity: 10}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs3", Namespace: defaultNs, Labels: labels},
+			Status: agonesv1.GameServerStatus{NodeName: "node1", State: agonesv1.GameServerStateAllocated, Players: &agonesv1.PlayerStatus{Count: 6, Capacity: 10}}},
+	}
+
+	// gs3 is the only Allocated GameServer with room for 4 more players
+	gs, index, err := findGameServerForAllocation(gsa, list)
+	assert.NoError(t, err)
+	assert.Equal(t, "gs3", gs.ObjectMeta.Name)
+	assert.Equal(t, gs, list[index])
+
+	// once that is gone, fall back to the Ready GameServer from the required selector
+	list = append(list[:index], list[index+1:]...)
+	gs, index, err = findGameServerForAllocation(gsa, list)
+	assert.NoError(t, err)
+	assert.Equal(t, "gs2", gs.ObjectMeta.Name)
+	assert.Equal(t, gs, list[index])
+
+	list = append(list[:index], list[index+1:]...)
+	gs, _, err = findGameServerForAllocation(gsa, list)
+	assert.Equal(t, ErrNoGameServerReady, err)
+	assert.Nil(t, gs)
+}
+
+func TestFindGameServerForAllocationPriorities(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"role": "gameserver"}
+
+	list := []*agonesv1.GameServer{
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs1", Namespace: defaultNs, Labels: labels},
+			Status: agonesv1.GameServerStatus{NodeName: "node1", State: agonesv1.GameServerStateReady,
+				Counters: map[string]agonesv1.CounterStatus{"rooms": {Count: 8, Capacity: 10}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs2", Namespace: defaultNs, Labels: labels},
+			Status: agonesv1.GameServerStatus{NodeName: "node1", State: agonesv1.GameServerStateReady}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs3", Namespace: defaultNs, Labels: labels},
+			Status: agonesv1.GameServerStatus{NodeName: "node1", State: agonesv1.GameServerStateReady,
+				Counters: map[string]agonesv1.CounterStatus{"rooms": {Count: 2, Capacity: 10}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gs4", Namespace: defaultNs, Labels: labels},
+			Status: agonesv1.GameServerStatus{NodeName: "node1", State: agonesv1.GameServerStateReady,
+				Counters: map[string]agonesv1.CounterStatus{"rooms": {Count: 5, Capacity: 10}}}},
+	}
+
+	for _, scheduling := range []apis.SchedulingStrategy{apis.Packed, apis.Distributed} {
+		scheduling := scheduling
+		t.Run(string(scheduling), func(t *testing.T) {
+			gsa := &allocationv1.GameServerAllocation{
+				ObjectMeta: metav1.ObjectMeta{Namespace: defaultNs},
+				Spec: allocationv1.GameServerAllocationSpec{
+					Required:   allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: labels}},
+					Scheduling: scheduling,
+					Priorities: []allocationv1.Priority{{Type: allocationv1.GameServerPriorityCounter, Key: "rooms"}},
+				},
+			}
+
+			// most available capacity first
+			gs, index, err := findGameServerForAllocation(gsa, list)
+			assert.NoError(t, err)
+			assert.Equal(t, "gs3", gs.ObjectMeta.Name)
+			assert.Equal(t, gs, list[index])
+
+			// least available capacity first
+			gsa.Spec.Priorities[0].Order = allocationv1.GameServerPriorityAscending
+			gs, index, err = findGameServerForAllocation(gsa, list)
+			assert.NoError(t, err)
+			assert.Equal(t, "gs1", gs.ObjectMeta.Name)
+			assert.Equal(t, gs, list[index])
+
+			// GameServers without the Counter are searched last
+			l := []*agonesv1.GameServer{list[1], list[3]}
+			gs, index, err = findGameServerForAllocation(gsa, l)
+			assert.NoError(t, err)
+			assert.Equal(t, "gs4", gs.ObjectMeta.Name)
+			assert.Equal(t, gs, l[index])
+
+			// GameServers in other namespaces, or that do not match, are not candidates, whatever their priority
+			other := []*agonesv1.GameServer{
+				{ObjectMeta: metav1.ObjectMeta{Name: "other-ns", Namespace: "other", Labels: labels},
+					Status: agonesv1.GameServerStatus{NodeName: "node1", State: agonesv1.GameServerStateReady,
+						Counters: map[string]agonesv1.CounterStatus{"rooms": {Count: 9, Capacity: 10}}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "no-match", Namespace: defaultNs},
+					Status: agonesv1.GameServerStatus{NodeName: "node1", State: agonesv1.GameServerStateReady,
+						Counters: map[string]agonesv1.CounterStatus{"rooms": {Count: 9, Capacity: 10}}}},
+			}
+			l = append(other, list...)
+			gs, index, err = findGameServerForAllocation(gsa, l)
+			assert.NoError(t, err)
+			assert.Equal(t, "gs1", gs.ObjectMeta.Name)
+			assert.Equal(t, gs, l[index])
+		})
+	}
+}
+
+func TestFindGameServerForAllocationChainedPriorities(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"role": "gameserver"}
+	newGameServer := func(name, build string, players int64) *agonesv1.GameServer {
+		return &agonesv1.GameServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNs,
+				Labels: map[string]string{"role": "gameserver", "build": build}},
+			Status: agonesv1.GameServerStatus{NodeName: "node1", State: agonesv1.GameServerStateReady,
+				Players: &agonesv1.PlayerStatus{Count: players, Capacity: 10}}}
+	}
+	list := []*agonesv1.GameServer{
+		newGameServer("gs1", "9", 1),
+		newGameServer("gs2", "10", 5),
+		newGameServer("gs3", "10", 2),
+		newGameServer("gs4", "8", 0),
+	}
+
+	gsa := &allocationv1.GameServerAllocation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: defaultNs},
+		Spec: allocationv1.GameServerAllocationSpec{
+			Required:   allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: labels}},
+			Scheduling: apis.Packed,
+			Priorities: []allocationv1.Priority{
+				{Type: allocationv1.GameServerPriorityLabel, Key: "build", ValueType: allocationv1.GameServerPriorityInt},
+				{Type: allocationv1.GameServerPriorityPlayers},
+			},
+		},
+	}
+
+	// newest build first, then the emptiest GameServer of that build
+	gs, index, err := findGameServerForAllocation(gsa, list)
+	assert.NoError(t, err)
+	assert.Equal(t, "gs3", gs.ObjectMeta.Name)
+	assert.Equal(t, gs, list[index])
+
+	// as strings, "9" sorts after "10"
+	gsa.Spec.Priorities[0].ValueType = allocationv1.GameServerPriorityString
+	gs, index, err = findGameServerForAllocation(gsa, list)
+	assert.NoError(t, err)
+	assert.Equal(t, "gs1", gs.ObjectMeta.Name)
+	assert.Equal(t, gs, list[index])
+}
+
 func TestFindGameServerForAllocationDistributed(t *testing.T) {
 	t.Parallel()
 
@@ -193,9 +353,9 @@ func TestFindGameServerForAllocationDistributed(t *testing.T) {
 	gsa := &allocationv1.GameServerAllocation{
 		ObjectMeta: metav1.ObjectMeta{Namespace: defaultNs},
 		Spec: allocationv1.GameServerAllocationSpec{
-			Required: metav1.LabelSelector{
+			Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{
 				MatchLabels: labels,
-			},
+			}},
 			Scheduling: apis.Distributed,
 		},
 	}
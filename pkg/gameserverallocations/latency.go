@@ -0,0 +1,93 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserverallocations
+
+import (
+	"sort"
+
+	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
+	multiclusterv1 "agones.dev/agones/pkg/apis/multicluster/v1"
+)
+
+// orderPoliciesByLatency returns copies of the policies, with their Priority replaced by the rank of the latency
+// the player measured to their cluster or region, so the clusters are tried in order of latency, and the clusters
+// with the same latency are selected between by weight.
+// The policies without a latency measurement are ranked after all the others, in order of their Priority,
+// unless maxLatencyMillis is set, in which case they are left out, along with the ones over maxLatencyMillis.
+func orderPoliciesByLatency(policies []*multiclusterv1.GameServerAllocationPolicy, latencies []allocationv1.LatencyMeasurement, maxLatencyMillis int32) []*multiclusterv1.GameServerAllocationPolicy {
+	measured := make(map[string]int32, len(latencies))
+	for _, l := range latencies {
+		if existing, ok := measured[l.Name]; !ok || l.LatencyMillis < existing {
+			measured[l.Name] = l.LatencyMillis
+		}
+	}
+	latencyOf := func(policy *multiclusterv1.GameServerAllocationPolicy) (int32, bool) {
+		if name := policy.Spec.ConnectionInfo.ClusterName; name != "" {
+			if l, ok := measured[name]; ok {
+				return l, true
+			}
+		}
+		if region, ok := policy.ObjectMeta.Labels[multiclusterv1.RegionLabel]; ok {
+			if l, ok := measured[region]; ok {
+				return l, true
+			}
+		}
+		return 0, false
+	}
+
+	var result []*multiclusterv1.GameServerAllocationPolicy
+	var unmeasured []*multiclusterv1.GameServerAllocationPolicy
+	policyLatencies := map[*multiclusterv1.GameServerAllocationPolicy]int32{}
+	var distinct []int32
+	for _, policy := range policies {
+		l, ok := latencyOf(policy)
+		switch {
+		case !ok && maxLatencyMillis > 0:
+			continue
+		case !ok:
+			unmeasured = append(unmeasured, policy.DeepCopy())
+			continue
+		case maxLatencyMillis > 0 && l > maxLatencyMillis:
+			continue
+		}
+		policyCopy := policy.DeepCopy()
+		if _, ok := findLatency(distinct, l); !ok {
+			distinct = append(distinct, l)
+		}
+		policyLatencies[policyCopy] = l
+		result = append(result, policyCopy)
+	}
+
+	sort.Slice(distinct, func(i, j int) bool { return distinct[i] < distinct[j] })
+	for _, policy := range result {
+		rank, _ := findLatency(distinct, policyLatencies[policy])
+		policy.Spec.Priority = int32(rank)
+	}
+	for _, policy := range unmeasured {
+		policy.Spec.Priority += int32(len(distinct))
+	}
+
+	return append(result, unmeasured...)
+}
+
+// findLatency returns the index of the latency in latencies, if it is there
+func findLatency(latencies []int32, latency int32) (int, bool) {
+	for i, l := range latencies {
+		if l == latency {
+			return i, true
+		}
+	}
+	return 0, false
+}
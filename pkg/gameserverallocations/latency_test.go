@@ -0,0 +1,199 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserverallocations
+
+import (
+	"context"
+	"testing"
+
+	pb "agones.dev/agones/pkg/allocation/go"
+	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
+	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
+	multiclusterv1 "agones.dev/agones/pkg/apis/multicluster/v1"
+	agtesting "agones.dev/agones/pkg/testing"
+	"agones.dev/agones/pkg/util/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestOrderPoliciesByLatency(t *testing.T) {
+	t.Parallel()
+
+	newPolicy := func(name, cluster, region string, priority int32) *multiclusterv1.GameServerAllocationPolicy {
+		p := &multiclusterv1.GameServerAllocationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: defaultNs},
+			Spec: multiclusterv1.GameServerAllocationPolicySpec{
+				Priority:       priority,
+				Weight:         100,
+				ConnectionInfo: multiclusterv1.ClusterConnectionInfo{ClusterName: cluster},
+			},
+		}
+		if region != "" {
+			p.ObjectMeta.Labels = map[string]string{multiclusterv1.RegionLabel: region}
+		}
+		return p
+	}
+	policies := []*multiclusterv1.GameServerAllocationPolicy{
+		newPolicy("local", "", "", 1),
+		newPolicy("eu-1", "eu-cluster-1", "europe-west1", 1),
+		newPolicy("eu-2", "eu-cluster-2", "europe-west1", 2),
+		newPolicy("us", "us-cluster", "us-east1", 1),
+		newPolicy("asia", "asia-cluster", "asia-east1", 3),
+	}
+
+	fixtures := map[string]struct {
+		latencies        []allocationv1.LatencyMeasurement
+		maxLatencyMillis int32
+		expected         map[string]int32
+	}{
+		"regions and clusters": {
+			latencies: []allocationv1.LatencyMeasurement{
+				{Name: "us-east1", LatencyMillis: 110},
+				{Name: "europe-west1", LatencyMillis: 30},
+				{Name: "eu-cluster-2", LatencyMillis: 25},
+			},
+			expected: map[string]int32{"eu-2": 0, "eu-1": 1, "us": 2, "local": 4, "asia": 6},
+		},
+		"same latency": {
+			latencies: []allocationv1.LatencyMeasurement{
+				{Name: "europe-west1", LatencyMillis: 30},
+				{Name: "us-east1", LatencyMillis: 30},
+			},
+			expected: map[string]int32{"eu-1": 0, "eu-2": 0, "us": 0, "local": 2, "asia": 4},
+		},
+		"lowest latency of duplicates": {
+			latencies: []allocationv1.LatencyMeasurement{
+				{Name: "us-east1", LatencyMillis: 110},
+				{Name: "us-east1", LatencyMillis: 90},
+				{Name: "europe-west1", LatencyMillis: 100},
+			},
+			expected: map[string]int32{"us": 0, "eu-1": 1, "eu-2": 1, "local": 3, "asia": 5},
+		},
+		"max latency": {
+			latencies: []allocationv1.LatencyMeasurement{
+				{Name: "us-east1", LatencyMillis: 110},
+				{Name: "europe-west1", LatencyMillis: 30},
+			},
+			maxLatencyMillis: 100,
+			expected:         map[string]int32{"eu-1": 0, "eu-2": 0},
+		},
+		"max latency without measurements": {
+			maxLatencyMillis: 100,
+			expected:         map[string]int32{},
+		},
+	}
+
+	for k, v := range fixtures {
+		t.Run(k, func(t *testing.T) {
+			result := orderPoliciesByLatency(policies, v.latencies, v.maxLatencyMillis)
+			priorities := map[string]int32{}
+			for _, p := range result {
+				priorities[p.ObjectMeta.Name] = p.Spec.Priority
+			}
+			assert.Equal(t, v.expected, priorities)
+		})
+	}
+
+	// the policies should not be changed
+	assert.Equal(t, int32(3), policies[4].Spec.Priority)
+}
+
+func TestAllocatorAllocateByLatency(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+	require.NoError(t, runtime.ParseFeatures(string(runtime.FeatureAllocationLatency)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	secretName := "remotesecret"
+	c, m := newFakeController()
+	fleetName := addReactorForGameServer(&m)
+	newPolicy := func(cluster string, priority int32) multiclusterv1.GameServerAllocationPolicy {
+		return multiclusterv1.GameServerAllocationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: cluster, Namespace: defaultNs},
+			Spec: multiclusterv1.GameServerAllocationPolicySpec{
+				Priority: priority,
+				Weight:   100,
+				ConnectionInfo: multiclusterv1.ClusterConnectionInfo{
+					AllocationEndpoints: []string{cluster},
+					ClusterName:         cluster,
+					SecretName:          secretName,
+					ServerCA:            clientCert,
+				},
+			},
+		}
+	}
+	m.AgonesClient.AddReactor("list", "gameserverallocationpolicies", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, &multiclusterv1.GameServerAllocationPolicyList{Items: []multiclusterv1.GameServerAllocationPolicy{
+			newPolicy("near", 2), newPolicy("far", 1),
+		}}, nil
+	})
+	m.KubeClient.AddReactor("list", "secrets",
+		func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+			return true, getTestSecret(secretName, clientCert), nil
+		})
+
+	var calls []string
+	var requests []*pb.AllocationRequest
+	c.allocator.remoteAllocationCallback = func(ctx context.Context, endpoint string, dialOpt grpc.DialOption, request *pb.AllocationRequest) (*pb.AllocationResponse, error) {
+		calls = append(calls, endpoint)
+		requests = append(requests, request)
+		if endpoint == "near:443" {
+			return nil, status.Error(codes.ResourceExhausted, "there is no available GameServer to allocate")
+		}
+		return &pb.AllocationResponse{GameServerName: endpoint}, nil
+	}
+
+	stop, cancel := agtesting.StartInformers(m, c.allocator.allocationPolicySynced, c.allocator.secretSynced, c.allocator.readyGameServerCache.gameServerSynced)
+	defer cancel()
+	require.NoError(t, c.allocator.readyGameServerCache.syncReadyGSServerCache())
+	require.NoError(t, c.allocator.readyGameServerCache.counter.Run(0, stop))
+
+	gsa := &allocationv1.GameServerAllocation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: defaultNs, Name: "alloc1"},
+		Spec: allocationv1.GameServerAllocationSpec{
+			MultiClusterSetting: allocationv1.MultiClusterSetting{
+				Enabled:   true,
+				Latencies: []allocationv1.LatencyMeasurement{{Name: "near", LatencyMillis: 20}, {Name: "far", LatencyMillis: 150}},
+			},
+			Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: fleetName}}},
+		},
+	}
+
+	// the near cluster is tried first, despite its lower priority
+	result, err := executeAllocation(gsa, c)
+	require.NoError(t, err)
+	assert.Equal(t, "far:443", result.Status.GameServerName)
+	assert.Equal(t, []string{"near:443", "far:443"}, calls)
+
+	// the multi-cluster settings, latencies included, are not forwarded to the remote clusters
+	for _, request := range requests {
+		assert.False(t, request.GetMultiClusterSetting().GetEnabled())
+		assert.Empty(t, request.GetMultiClusterSetting().GetLatencies())
+		assert.Zero(t, request.GetMultiClusterSetting().GetMaxLatencyMillis())
+	}
+
+	// the far cluster is over the maximum latency
+	calls = nil
+	gsa.Spec.MultiClusterSetting.MaxLatencyMillis = 100
+	_, err = executeAllocation(gsa, c)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"near:443"}, calls)
+}
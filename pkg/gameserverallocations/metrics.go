@@ -38,8 +38,11 @@ var (
 	keyMultiCluster       = mt.MustTagKey("is_multicluster")
 	keyStatus             = mt.MustTagKey("status")
 	keySchedulingStrategy = mt.MustTagKey("scheduling_strategy")
+	keyEndpoint           = mt.MustTagKey("endpoint")
 
-	gameServerAllocationsLatency = stats.Float64("gameserver_allocations/latency", "The duration of gameserver allocations", "s")
+	gameServerAllocationsLatency          = stats.Float64("gameserver_allocations/latency", "The duration of gameserver allocations", "s")
+	gameServerAllocationsEndpointHealthy  = stats.Int64("gameserver_allocations/endpoint_healthy", "The allocation endpoint is not ejected (0 indicates false, 1 indicates true)", "1")
+	gameServerAllocationsEndpointEjection = stats.Int64("gameserver_allocations/endpoint_ejections", "The number of times an allocation endpoint was ejected", "1")
 )
 
 func init() {
@@ -50,6 +53,20 @@ func init() {
 		Aggregation: view.Distribution(0, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2, 3),
 		TagKeys:     []tag.Key{keyFleetName, keyNodeName, keyClusterName, keyMultiCluster, keyStatus, keySchedulingStrategy},
 	}))
+	runtime.Must(view.Register(&view.View{
+		Name:        "gameserver_allocations_endpoint_healthy",
+		Measure:     gameServerAllocationsEndpointHealthy,
+		Description: "Whether a multi-cluster allocation endpoint is healthy or ejected",
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{keyEndpoint},
+	}))
+	runtime.Must(view.Register(&view.View{
+		Name:        "gameserver_allocations_endpoint_ejections_total",
+		Measure:     gameServerAllocationsEndpointEjection,
+		Description: "The total of multi-cluster allocation endpoint ejections",
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{keyEndpoint},
+	}))
 }
 
 // default set of tags for latency metric
@@ -130,3 +147,19 @@ func (r *metrics) setResponse(o k8sruntime.Object) {
 func (r *metrics) record() {
 	stats.Record(r.ctx, gameServerAllocationsLatency.M(time.Since(r.start).Seconds()))
 }
+
+// recordEndpointHealthy records whether the allocation endpoint is healthy or ejected
+func recordEndpointHealthy(endpoint string, healthy bool) {
+	var value int64
+	if healthy {
+		value = 1
+	}
+	ctx, _ := tag.New(context.Background(), tag.Upsert(keyEndpoint, endpoint))
+	stats.Record(ctx, gameServerAllocationsEndpointHealthy.M(value))
+}
+
+// recordEndpointEjection records an ejection of the allocation endpoint
+func recordEndpointEjection(endpoint string) {
+	ctx, _ := tag.New(context.Background(), tag.Upsert(keyEndpoint, endpoint))
+	stats.Record(ctx, gameServerAllocationsEndpointEjection.M(1))
+}
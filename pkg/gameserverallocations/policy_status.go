@@ -0,0 +1,167 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserverallocations
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	multiclusterv1 "agones.dev/agones/pkg/apis/multicluster/v1"
+	"agones.dev/agones/pkg/util/runtime"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// policyStatusSyncPeriod is how often the allocation endpoints that are not Healthy are probed,
+	// and the status of the GameServerAllocationPolicies is updated, if the state of the allocator has changed
+	policyStatusSyncPeriod = 10 * time.Second
+	// policyStatusExpiry is how long the state of an allocator is kept in the status of the GameServerAllocationPolicies
+	// without being updated. It is then removed the next time another allocator updates the status, and added back
+	// by its allocator on its next sync if it is still running.
+	policyStatusExpiry = 30 * time.Minute
+	// capacityStatusStep is how much the available capacity of a cluster has to change by,
+	// for it to be updated in the status of the GameServerAllocationPolicies
+	capacityStatusStep = 10
+)
+
+// syncPolicyStatus probes the allocation endpoints that are not Healthy, and updates the state of this allocator
+// in the status of the GameServerAllocationPolicies, with the health of their allocation endpoints and the capacity
+// of their cluster. Every allocator only updates its own state, which is kept separate from the state of the others,
+// and only when it has changed.
+func (c *Allocator) syncPolicyStatus() {
+	policies, err := c.allocationPolicyLister.List(labels.Everything())
+	if err != nil {
+		c.baseLogger.WithError(err).Error("could not list allocation policies")
+		return
+	}
+
+	endpointHealth := runtime.FeatureEnabled(runtime.FeatureAllocationEndpointHealth)
+	capacityWeighting := runtime.FeatureEnabled(runtime.FeatureAllocationCapacityWeighting)
+	if endpointHealth {
+		c.probeEndpoints(policies)
+	}
+
+	now := c.clock.Now()
+	endpoints := map[string]bool{}
+	clusters := map[string]bool{}
+	for _, policy := range policies {
+		observed := multiclusterv1.AllocatorPolicyStatus{Name: c.name}
+		if endpointHealth && len(policy.Spec.ConnectionInfo.AllocationEndpoints) > 0 {
+			for _, ip := range policy.Spec.ConnectionInfo.AllocationEndpoints {
+				endpoints[addPort(ip)] = true
+			}
+			observed.Endpoints = c.endpointHealth.endpointStatuses(policy.Spec.ConnectionInfo.AllocationEndpoints)
+		}
+		if capacityWeighting {
+			clusters[clusterKey(policy.ObjectMeta.Namespace, &policy.Spec.ConnectionInfo)] = true
+			observed.Capacity = c.clusterCapacity.capacityStatus(policy)
+		}
+
+		if err := c.updatePolicyStatus(policy, observed, now); err != nil {
+			c.baseLogger.WithField("policy", policy.ObjectMeta.Name).WithError(err).Warn("could not update the status of the allocation policy")
+		}
+	}
+
+	c.endpointHealth.prune(endpoints)
+	c.clusterCapacity.prune(clusters)
+}
+
+// updatePolicyStatus updates the state observed by this allocator in the status of the policy, if it has changed.
+// On conflicts with the updates of the other allocators, the latest status is merged with again.
+func (c *Allocator) updatePolicyStatus(policy *multiclusterv1.GameServerAllocationPolicy, observed multiclusterv1.AllocatorPolicyStatus, now time.Time) error {
+	policies := c.allocationPolicyGetter.GameServerAllocationPolicies(policy.ObjectMeta.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		policyStatus, changed := mergeAllocatorPolicyStatus(policy.Status, observed, now)
+		if !changed {
+			return nil
+		}
+		policyCopy := policy.DeepCopy()
+		policyCopy.Status = policyStatus
+		_, err := policies.UpdateStatus(policyCopy)
+		if k8serrors.IsConflict(err) {
+			latest, getErr := policies.Get(policy.ObjectMeta.Name, metav1.GetOptions{})
+			if getErr != nil {
+				return getErr
+			}
+			policy = latest
+		}
+		return err
+	})
+}
+
+// mergeAllocatorPolicyStatus returns the policy status with the state observed by this allocator, and whether
+// it has changed. The state of the other allocators is kept as is, unless it has expired.
+func mergeAllocatorPolicyStatus(status multiclusterv1.GameServerAllocationPolicyStatus, observed multiclusterv1.AllocatorPolicyStatus,
+	now time.Time) (multiclusterv1.GameServerAllocationPolicyStatus, bool) {
+	for _, a := range status.Allocators {
+		if a.Name == observed.Name && !allocatorPolicyStatusChanged(a, observed) {
+			return status, false
+		}
+	}
+
+	result := multiclusterv1.GameServerAllocationPolicyStatus{}
+	for _, a := range status.Allocators {
+		if a.Name != observed.Name && now.Sub(a.LastUpdateTime.Time) < policyStatusExpiry {
+			result.Allocators = append(result.Allocators, a)
+		}
+	}
+	// status times only have a precision of seconds
+	observed.LastUpdateTime = metav1.NewTime(now).Rfc3339Copy()
+	result.Allocators = append(result.Allocators, observed)
+	sort.Slice(result.Allocators, func(i, j int) bool {
+		return result.Allocators[i].Name < result.Allocators[j].Name
+	})
+	return result, true
+}
+
+// allocatorPolicyStatusChanged returns whether the state observed by an allocator has changed enough since it was
+// last updated in the status: when the health state of an allocation endpoint has changed, or when the available
+// capacity of the cluster has changed by at least capacityStatusStep. The failures, errors and times are
+// updated along with these changes.
+func allocatorPolicyStatusChanged(last, observed multiclusterv1.AllocatorPolicyStatus) bool {
+	if len(last.Endpoints) != len(observed.Endpoints) {
+		return true
+	}
+	for i := range observed.Endpoints {
+		if last.Endpoints[i].Endpoint != observed.Endpoints[i].Endpoint || last.Endpoints[i].State != observed.Endpoints[i].State {
+			return true
+		}
+	}
+
+	if (last.Capacity == nil) != (observed.Capacity == nil) {
+		return true
+	}
+	if observed.Capacity != nil {
+		change := observed.Capacity.AvailableCapacity - last.Capacity.AvailableCapacity
+		if change >= capacityStatusStep || change <= -capacityStatusStep {
+			return true
+		}
+	}
+	return false
+}
+
+// allocatorName returns the name of the pod the allocator runs in, which identifies its state
+// in the status of the GameServerAllocationPolicies
+func allocatorName() string {
+	if name := os.Getenv("POD_NAME"); name != "" {
+		return name
+	}
+	name, _ := os.Hostname() // nolint: errcheck
+	return name
+}
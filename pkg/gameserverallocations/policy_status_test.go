@@ -0,0 +1,165 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserverallocations
+
+import (
+	"testing"
+	"time"
+
+	multiclusterv1 "agones.dev/agones/pkg/apis/multicluster/v1"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestMergeAllocatorPolicyStatus(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) metav1.Time {
+		return metav1.NewTime(now.Add(-d))
+	}
+	healthy := []multiclusterv1.AllocationEndpointStatus{{Endpoint: "a", State: multiclusterv1.AllocationEndpointHealthy}}
+	ejected := []multiclusterv1.AllocationEndpointStatus{{Endpoint: "a", State: multiclusterv1.AllocationEndpointEjected, ConsecutiveFailures: 3}}
+
+	status := multiclusterv1.GameServerAllocationPolicyStatus{Allocators: []multiclusterv1.AllocatorPolicyStatus{
+		{Name: "controller", LastUpdateTime: at(time.Minute), Endpoints: ejected},
+		{Name: "allocator-1", LastUpdateTime: at(10 * time.Second), Endpoints: healthy},
+		{Name: "allocator-gone", LastUpdateTime: at(policyStatusExpiry), Endpoints: healthy},
+	}}
+
+	t.Run("unchanged state is not updated", func(t *testing.T) {
+		observed := multiclusterv1.AllocatorPolicyStatus{Name: "controller", Endpoints: []multiclusterv1.AllocationEndpointStatus{
+			{Endpoint: "a", State: multiclusterv1.AllocationEndpointEjected, ConsecutiveFailures: 4},
+		}}
+		result, changed := mergeAllocatorPolicyStatus(status, observed, now)
+		assert.False(t, changed)
+		assert.Equal(t, status, result)
+	})
+
+	t.Run("changed state is updated, and expired allocators are removed", func(t *testing.T) {
+		result, changed := mergeAllocatorPolicyStatus(status, multiclusterv1.AllocatorPolicyStatus{Name: "allocator-1", Endpoints: ejected}, now)
+		assert.True(t, changed)
+		require.Len(t, result.Allocators, 2, "the expired allocator should be removed")
+		assert.Equal(t, "allocator-1", result.Allocators[0].Name)
+		assert.Equal(t, ejected, result.Allocators[0].Endpoints)
+		assert.Equal(t, metav1.NewTime(now), result.Allocators[0].LastUpdateTime)
+		assert.Equal(t, status.Allocators[0], result.Allocators[1])
+		assert.Equal(t, healthy, status.Allocators[1].Endpoints, "the original status should not change")
+	})
+
+	t.Run("new allocator is added", func(t *testing.T) {
+		result, changed := mergeAllocatorPolicyStatus(status, multiclusterv1.AllocatorPolicyStatus{Name: "allocator-2", Endpoints: healthy}, now)
+		assert.True(t, changed)
+		require.Len(t, result.Allocators, 3)
+		assert.Equal(t, []string{"allocator-1", "allocator-2", "controller"},
+			[]string{result.Allocators[0].Name, result.Allocators[1].Name, result.Allocators[2].Name})
+	})
+}
+
+func TestAllocatorPolicyStatusChanged(t *testing.T) {
+	t.Parallel()
+
+	healthy := []multiclusterv1.AllocationEndpointStatus{{Endpoint: "a", State: multiclusterv1.AllocationEndpointHealthy}}
+	capacity := func(available int32) *multiclusterv1.ClusterCapacityStatus {
+		return &multiclusterv1.ClusterCapacityStatus{AvailableCapacity: available}
+	}
+	last := multiclusterv1.AllocatorPolicyStatus{Name: "allocator-1", Endpoints: healthy, Capacity: capacity(50)}
+
+	fixtures := map[string]struct {
+		observed multiclusterv1.AllocatorPolicyStatus
+		expected bool
+	}{
+		"same": {
+			observed: multiclusterv1.AllocatorPolicyStatus{Endpoints: healthy, Capacity: capacity(50)},
+			expected: false,
+		},
+		"failures only": {
+			observed: multiclusterv1.AllocatorPolicyStatus{Endpoints: []multiclusterv1.AllocationEndpointStatus{
+				{Endpoint: "a", State: multiclusterv1.AllocationEndpointHealthy, ConsecutiveFailures: 1, LastError: "timeout"},
+			}, Capacity: capacity(50)},
+			expected: false,
+		},
+		"endpoint state": {
+			observed: multiclusterv1.AllocatorPolicyStatus{Endpoints: []multiclusterv1.AllocationEndpointStatus{
+				{Endpoint: "a", State: multiclusterv1.AllocationEndpointEjected},
+			}, Capacity: capacity(50)},
+			expected: true,
+		},
+		"new endpoint": {
+			observed: multiclusterv1.AllocatorPolicyStatus{Endpoints: append(healthy,
+				multiclusterv1.AllocationEndpointStatus{Endpoint: "b", State: multiclusterv1.AllocationEndpointHealthy}), Capacity: capacity(50)},
+			expected: true,
+		},
+		"capacity below the step": {
+			observed: multiclusterv1.AllocatorPolicyStatus{Endpoints: healthy, Capacity: capacity(50 - capacityStatusStep + 1)},
+			expected: false,
+		},
+		"capacity by the step": {
+			observed: multiclusterv1.AllocatorPolicyStatus{Endpoints: healthy, Capacity: capacity(50 + capacityStatusStep)},
+			expected: true,
+		},
+		"capacity removed": {
+			observed: multiclusterv1.AllocatorPolicyStatus{Endpoints: healthy},
+			expected: true,
+		},
+	}
+
+	for k, v := range fixtures {
+		t.Run(k, func(t *testing.T) {
+			assert.Equal(t, v.expected, allocatorPolicyStatusChanged(last, v.observed))
+		})
+	}
+}
+
+func TestAllocatorUpdatePolicyStatusRetriesOnConflict(t *testing.T) {
+	t.Parallel()
+	c, m := newFakeController()
+
+	now := time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC)
+	policy := &multiclusterv1.GameServerAllocationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: defaultNs, ResourceVersion: "1"}}
+	latest := policy.DeepCopy()
+	latest.ObjectMeta.ResourceVersion = "2"
+	latest.Status.Allocators = []multiclusterv1.AllocatorPolicyStatus{{Name: "other", LastUpdateTime: metav1.NewTime(now)}}
+
+	var updates []*multiclusterv1.GameServerAllocationPolicy
+	m.AgonesClient.AddReactor("update", "gameserverallocationpolicies", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		ua := action.(k8stesting.UpdateAction)
+		assert.Equal(t, "status", ua.GetSubresource())
+		p := ua.GetObject().(*multiclusterv1.GameServerAllocationPolicy)
+		updates = append(updates, p)
+		if p.ObjectMeta.ResourceVersion != latest.ObjectMeta.ResourceVersion {
+			return true, nil, k8serrors.NewConflict(schema.GroupResource{Resource: "gameserverallocationpolicies"}, p.ObjectMeta.Name, errors.New("conflict"))
+		}
+		return true, p, nil
+	})
+	m.AgonesClient.AddReactor("get", "gameserverallocationpolicies", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, latest, nil
+	})
+
+	observed := multiclusterv1.AllocatorPolicyStatus{Name: "allocator-1", Endpoints: []multiclusterv1.AllocationEndpointStatus{
+		{Endpoint: "a", State: multiclusterv1.AllocationEndpointEjected},
+	}}
+	require.NoError(t, c.allocator.updatePolicyStatus(policy, observed, now))
+	require.Len(t, updates, 2)
+	require.Len(t, updates[1].Status.Allocators, 2, "the state of the other allocator should be kept")
+	assert.Equal(t, "allocator-1", updates[1].Status.Allocators[0].Name)
+	assert.Equal(t, "other", updates[1].Status.Allocators[1].Name)
+}
@@ -56,7 +56,7 @@ func NewReadyGameServerCache(informer informerv1.GameServerInformer, gameServerG
 
 	informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		UpdateFunc: func(oldObj, newObj interface{}) {
-			// only interested in if the old / new state was/is Ready
+			// only interested in if the old / new state was/is allocatable
 			oldGs := oldObj.(*agonesv1.GameServer)
 			newGs := newObj.(*agonesv1.GameServer)
 			key, ok := c.getKey(newGs)
@@ -65,8 +65,8 @@ func NewReadyGameServerCache(informer informerv1.GameServerInformer, gameServerG
 			}
 			if newGs.IsBeingDeleted() {
 				c.readyGameServers.Delete(key)
-			} else if oldGs.Status.State == agonesv1.GameServerStateReady || newGs.Status.State == agonesv1.GameServerStateReady {
-				if newGs.Status.State == agonesv1.GameServerStateReady {
+			} else if isAllocatable(oldGs) || isAllocatable(newGs) {
+				if isAllocatable(newGs) {
 					c.readyGameServers.Store(key, newGs)
 				} else {
 					c.readyGameServers.Delete(key)
@@ -156,6 +156,7 @@ func (c *ReadyGameServerCache) getReadyGameServers() []*agonesv1.GameServer {
 }
 
 // ListSortedReadyGameServers returns a list of the cache ready gameservers
+// (and Allocated gameservers, if the StateAllocationFilter feature is enabled)
 // sorted by most allocated to least
 func (c *ReadyGameServerCache) ListSortedReadyGameServers() []*agonesv1.GameServer {
 	list := c.getReadyGameServers()
@@ -271,12 +272,12 @@ func (c *ReadyGameServerCache) syncReadyGSServerCache() error {
 	// refresh the cache of possible allocatable GameServers
 	for key, gs := range currGameservers {
 		if gsCache, ok := c.readyGameServers.Load(key); ok {
-			if !(gs.DeletionTimestamp.IsZero() && gs.Status.State == agonesv1.GameServerStateReady) {
+			if !(gs.DeletionTimestamp.IsZero() && isAllocatable(gs)) {
 				c.readyGameServers.Delete(key)
 			} else if gs.ObjectMeta.ResourceVersion != gsCache.ObjectMeta.ResourceVersion {
 				c.readyGameServers.Store(key, gs)
 			}
-		} else if gs.DeletionTimestamp.IsZero() && gs.Status.State == agonesv1.GameServerStateReady {
+		} else if gs.DeletionTimestamp.IsZero() && isAllocatable(gs) {
 			c.readyGameServers.Store(key, gs)
 		}
 	}
@@ -296,3 +297,19 @@ func (c *ReadyGameServerCache) getKey(gs *agonesv1.GameServer) (string, bool) {
 	}
 	return key, ok
 }
+
+// isAllocatable returns true if the GameServer is in a state that an allocation can select it from:
+// Ready, or also Allocated if the StateAllocationFilter feature is enabled.
+// GameServers that are draining, when the SDKDrain feature is enabled, can't be allocated.
+func isAllocatable(gs *agonesv1.GameServer) bool {
+	if gs.IsDraining() && runtime.FeatureEnabled(runtime.FeatureSDKDrain) {
+		return false
+	}
+	switch gs.Status.State {
+	case agonesv1.GameServerStateReady:
+		return true
+	case agonesv1.GameServerStateAllocated:
+		return runtime.FeatureEnabled(runtime.FeatureStateAllocationFilter)
+	}
+	return false
+}
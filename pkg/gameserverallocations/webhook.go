@@ -0,0 +1,255 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserverallocations
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
+	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
+	"agones.dev/agones/pkg/util/runtime"
+	"github.com/pkg/errors"
+	admregv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// webhookTransportIdleTimeout is how long a cached webhook transport is kept after it was last used
+const webhookTransportIdleTimeout = 10 * time.Minute
+
+// webhookTransports caches a http.Transport per allocation webhook host and CA Bundle, so connections
+// to allocation webhooks can be reused across allocations. Transports that are no longer used,
+// such as the ones of rotated CA Bundles, are removed after webhookTransportIdleTimeout.
+var webhookTransports = struct {
+	sync.Mutex
+	byKey map[webhookTransportKey]*cachedWebhookTransport
+}{byKey: map[webhookTransportKey]*cachedWebhookTransport{}}
+
+// webhookTransportKey is the host of an allocation webhook, and the CA Bundle it is called with
+type webhookTransportKey struct {
+	host     string
+	caBundle string
+}
+
+// cachedWebhookTransport is a http.Transport, and when it was last used
+type cachedWebhookTransport struct {
+	transport *http.Transport
+	lastUsed  time.Time
+}
+
+// hasAllocationWebhook returns whether the allocation webhook of the GameServerAllocation should be called
+func hasAllocationWebhook(gsa *allocationv1.GameServerAllocation) bool {
+	return gsa.Spec.Webhook != nil && runtime.FeatureEnabled(runtime.FeatureAllocationWebhook)
+}
+
+// reviewAllocationThenUpdate reviews the GameServer of the response with the allocation webhook, once one of the
+// reviewSlots is free, and passes it on to the updateQueue to be allocated. The GameServer is out of the
+// Ready GameServer cache, so the webhook can be called without it being allocated by another request.
+// If the review fails, the GameServer is put back in the cache, and the error is returned to the request.
+func (c *Allocator) reviewAllocationThenUpdate(res response, reviewSlots chan struct{}, updateQueue chan<- response, stop <-chan struct{}) {
+	select {
+	case reviewSlots <- struct{}{}:
+	case <-stop:
+		return
+	}
+	metaPatch, err := c.reviewAllocation(res.request.gsa, res.gs)
+	<-reviewSlots
+
+	if err != nil {
+		c.readyGameServerCache.AddToReadyGameServer(res.gs)
+		res.err = err
+		res.request.response <- res
+		return
+	}
+	res.metaPatch = metaPatch
+	select {
+	case updateQueue <- res:
+	case <-stop:
+	}
+}
+
+// reviewAllocation calls the allocation webhook of the GameServerAllocation, if it has one, with the GameServer
+// that is about to be allocated. It returns the metadata to patch the GameServer with on allocation,
+// or ErrAllocationVetoed if the webhook vetoed the GameServer.
+func (c *Allocator) reviewAllocation(gsa *allocationv1.GameServerAllocation, gs *agonesv1.GameServer) (allocationv1.MetaPatch, error) {
+	if !hasAllocationWebhook(gsa) {
+		return gsa.Spec.MetaPatch, nil
+	}
+	w := gsa.Spec.Webhook
+
+	review := &allocationv1.GameServerAllocationReview{
+		Request: &allocationv1.GameServerAllocationReviewRequest{
+			UID:        uuid.NewUUID(),
+			Namespace:  gsa.ObjectMeta.Namespace,
+			MetaPatch:  gsa.Spec.MetaPatch,
+			GameServer: gs,
+		},
+	}
+	res, err := c.allocationWebhookCallback(w, review)
+	if err != nil {
+		if w.FailurePolicy != nil && *w.FailurePolicy == admregv1.Ignore {
+			c.loggerForGameServerAllocation(gsa).WithError(err).Warn("allocation webhook failed, ignoring")
+			return gsa.Spec.MetaPatch, nil
+		}
+		return allocationv1.MetaPatch{}, errors.Wrap(err, "allocation webhook failed")
+	}
+
+	if !res.Allowed {
+		c.loggerForGameServerAllocation(gsa).WithField("gs", gs.ObjectMeta.Name).WithField("reason", res.Reason).
+			Debug("allocation webhook vetoed GameServer")
+		return allocationv1.MetaPatch{}, ErrAllocationVetoed
+	}
+
+	return mergeMetaPatch(gsa.Spec.MetaPatch, res.MetaPatch), nil
+}
+
+// callAllocationWebhook sends the GameServerAllocationReview to the allocation webhook,
+// and returns its response
+func callAllocationWebhook(w *allocationv1.AllocationWebhook, review *allocationv1.GameServerAllocationReview) (*allocationv1.GameServerAllocationReviewResponse, error) {
+	u, err := buildURLFromAllocationWebhook(w)
+	if err != nil {
+		return nil, err
+	}
+
+	client := http.Client{Timeout: 5 * time.Second}
+	if w.TimeoutSeconds != nil {
+		client.Timeout = time.Duration(*w.TimeoutSeconds) * time.Second
+	}
+	if w.CABundle != nil {
+		if client.Transport, err = webhookTransport(u.Host, w.CABundle); err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := json.Marshal(review)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Post(u.String(), "application/json", bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close() // nolint: errcheck
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status code %d from the server: %s", res.StatusCode, u.String())
+	}
+	result, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resReview allocationv1.GameServerAllocationReview
+	if err := json.Unmarshal(result, &resReview); err != nil {
+		return nil, err
+	}
+	if resReview.Response == nil {
+		return nil, errors.New("allocation webhook returned no response")
+	}
+	if resReview.Response.UID != review.Request.UID {
+		return nil, fmt.Errorf("allocation webhook returned the response uid %s, expected %s", resReview.Response.UID, review.Request.UID)
+	}
+	return resReview.Response, nil
+}
+
+// buildURLFromAllocationWebhook builds the URL of the allocation webhook, from either its URL or Service.
+// Services default to the "default" namespace and port 8000.
+func buildURLFromAllocationWebhook(w *allocationv1.AllocationWebhook) (*url.URL, error) {
+	if w.URL != nil {
+		return url.ParseRequestURI(*w.URL)
+	}
+	if w.Service == nil || w.Service.Name == "" {
+		return nil, errors.New("service was not provided, either URL or Service must be provided")
+	}
+
+	scheme := "http"
+	if w.CABundle != nil {
+		scheme = "https"
+	}
+	namespace := w.Service.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	var port int32 = 8000
+	if w.Service.Port != nil {
+		port = *w.Service.Port
+	}
+	u := &url.URL{Scheme: scheme, Host: fmt.Sprintf("%s.%s.svc:%d", w.Service.Name, namespace, port)}
+	if w.Service.Path != nil {
+		u.Path = *w.Service.Path
+	}
+	return u, nil
+}
+
+// webhookTransport returns the http.Transport for the webhook host that trusts the given CA Bundle.
+// The transports that have not been used for webhookTransportIdleTimeout are removed,
+// and their idle connections are closed.
+func webhookTransport(host string, caBundle []byte) (http.RoundTripper, error) {
+	webhookTransports.Lock()
+	defer webhookTransports.Unlock()
+
+	now := time.Now()
+	for k, cached := range webhookTransports.byKey {
+		if now.Sub(cached.lastUsed) > webhookTransportIdleTimeout {
+			cached.transport.CloseIdleConnections()
+			delete(webhookTransports.byKey, k)
+		}
+	}
+
+	key := webhookTransportKey{host: host, caBundle: string(caBundle)}
+	if cached, ok := webhookTransports.byKey[key]; ok {
+		cached.lastUsed = now
+		return cached.transport, nil
+	}
+
+	rootCAs := x509.NewCertPool()
+	if ok := rootCAs.AppendCertsFromPEM(caBundle); !ok {
+		return nil, errors.New("no certs were appended from caBundle")
+	}
+	t := &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs}}
+	webhookTransports.byKey[key] = &cachedWebhookTransport{transport: t, lastUsed: now}
+	return t, nil
+}
+
+// mergeMetaPatch returns a MetaPatch with the labels and annotations of both MetaPatches,
+// where the values of the override take precedence
+func mergeMetaPatch(base, override allocationv1.MetaPatch) allocationv1.MetaPatch {
+	merge := func(base, override map[string]string) map[string]string {
+		if len(override) == 0 {
+			return base
+		}
+		result := make(map[string]string, len(base)+len(override))
+		for k, v := range base {
+			result[k] = v
+		}
+		for k, v := range override {
+			result[k] = v
+		}
+		return result
+	}
+
+	return allocationv1.MetaPatch{
+		Labels:      merge(base.Labels, override.Labels),
+		Annotations: merge(base.Annotations, override.Annotations),
+	}
+}
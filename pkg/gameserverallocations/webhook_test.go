@@ -0,0 +1,359 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gameserverallocations
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
+	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
+	agtesting "agones.dev/agones/pkg/testing"
+	"agones.dev/agones/pkg/util/runtime"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admregv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestAllocatorAllocateWebhook(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+	require.NoError(t, runtime.ParseFeatures(string(runtime.FeatureAllocationWebhook)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	setup := func(t *testing.T, callback func(*allocationv1.AllocationWebhook, *allocationv1.GameServerAllocationReview) (*allocationv1.GameServerAllocationReviewResponse, error)) (*Controller, <-chan struct{}, func(), *sync.Map) {
+		_, gsList := defaultFixtures(3)
+		c, m := newFakeController()
+		c.allocator.allocationWebhookCallback = callback
+
+		m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+			return true, &agonesv1.GameServerList{Items: gsList}, nil
+		})
+		gsWatch := watch.NewFake()
+		m.AgonesClient.AddWatchReactor("gameservers", k8stesting.DefaultWatchReactor(gsWatch, nil))
+		updated := &sync.Map{}
+		m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+			gs := action.(k8stesting.UpdateAction).GetObject().(*agonesv1.GameServer)
+			updated.Store(gs.ObjectMeta.Name, gs)
+			gsWatch.Modify(gs)
+			return true, gs, nil
+		})
+
+		stop, cancel := agtesting.StartInformers(m)
+		require.NoError(t, c.Run(1, stop))
+		err := wait.PollImmediate(time.Second, 10*time.Second, func() (done bool, err error) {
+			return c.allocator.readyGameServerCache.workerqueue.RunCount() == 1, nil
+		})
+		require.NoError(t, err)
+		return c, stop, cancel, updated
+	}
+
+	newGameServerAllocation := func(policy admregv1.FailurePolicyType) *allocationv1.GameServerAllocation {
+		u := "http://localhost/allocate"
+		gsa := &allocationv1.GameServerAllocation{
+			ObjectMeta: metav1.ObjectMeta{Namespace: defaultNs},
+			Spec: allocationv1.GameServerAllocationSpec{
+				Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{agonesv1.FleetNameLabel: "fleet-1"}}},
+				MetaPatch: allocationv1.MetaPatch{Labels: map[string]string{"mode": "deathmatch", "map": "searide"}},
+				Webhook: &allocationv1.AllocationWebhook{
+					WebhookClientConfig: admregv1.WebhookClientConfig{URL: &u},
+					FailurePolicy:       &policy,
+				},
+			},
+		}
+		gsa.ApplyDefaults()
+		return gsa
+	}
+
+	t.Run("veto and enrich", func(t *testing.T) {
+		var mu sync.Mutex
+		var reviewed []string
+		c, stop, cancel, updated := setup(t, func(_ *allocationv1.AllocationWebhook, review *allocationv1.GameServerAllocationReview) (*allocationv1.GameServerAllocationReviewResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			reviewed = append(reviewed, review.Request.GameServer.ObjectMeta.Name)
+			// veto the first GameServer that is reviewed
+			return &allocationv1.GameServerAllocationReviewResponse{
+				UID:       review.Request.UID,
+				Allowed:   len(reviewed) > 1,
+				MetaPatch: allocationv1.MetaPatch{Labels: map[string]string{"map": "lighthouse"}},
+			}, nil
+		})
+		defer cancel()
+
+		gsa, err := c.allocator.allocateFromLocalCluster(newGameServerAllocation(admregv1.Fail), stop)
+		require.NoError(t, err)
+		assert.Equal(t, allocationv1.GameServerAllocationAllocated, gsa.Status.State)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, reviewed, 2)
+		assert.NotEqual(t, reviewed[0], reviewed[1])
+		assert.Equal(t, reviewed[1], gsa.Status.GameServerName)
+
+		obj, ok := updated.Load(gsa.Status.GameServerName)
+		require.True(t, ok)
+		gs := obj.(*agonesv1.GameServer)
+		assert.Equal(t, "deathmatch", gs.ObjectMeta.Labels["mode"])
+		assert.Equal(t, "lighthouse", gs.ObjectMeta.Labels["map"])
+	})
+
+	t.Run("veto everything", func(t *testing.T) {
+		c, stop, cancel, _ := setup(t, func(_ *allocationv1.AllocationWebhook, review *allocationv1.GameServerAllocationReview) (*allocationv1.GameServerAllocationReviewResponse, error) {
+			return &allocationv1.GameServerAllocationReviewResponse{UID: review.Request.UID, Reason: "bad node"}, nil
+		})
+		defer cancel()
+
+		gsa, err := c.allocator.allocateFromLocalCluster(newGameServerAllocation(admregv1.Fail), stop)
+		require.NoError(t, err)
+		assert.Equal(t, allocationv1.GameServerAllocationUnAllocated, gsa.Status.State)
+	})
+
+	failing := func(*allocationv1.AllocationWebhook, *allocationv1.GameServerAllocationReview) (*allocationv1.GameServerAllocationReviewResponse, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	t.Run("failure policy ignore", func(t *testing.T) {
+		c, stop, cancel, _ := setup(t, failing)
+		defer cancel()
+
+		gsa, err := c.allocator.allocateFromLocalCluster(newGameServerAllocation(admregv1.Ignore), stop)
+		require.NoError(t, err)
+		assert.Equal(t, allocationv1.GameServerAllocationAllocated, gsa.Status.State)
+	})
+
+	t.Run("failure policy fail", func(t *testing.T) {
+		c, stop, cancel, _ := setup(t, failing)
+		defer cancel()
+
+		_, err := c.allocator.allocateFromLocalCluster(newGameServerAllocation(admregv1.Fail), stop)
+		assert.EqualError(t, err, "allocation webhook failed: connection refused")
+	})
+}
+
+func TestAllocatorSlowWebhookDoesNotHoldUpAllocations(t *testing.T) {
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+	require.NoError(t, runtime.ParseFeatures(string(runtime.FeatureAllocationWebhook)+"=true"))
+	defer runtime.ParseFeatures("") // nolint: errcheck
+
+	f, gsList := defaultFixtures(3)
+	c, m := newFakeController()
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, &agonesv1.GameServerList{Items: gsList}, nil
+	})
+	m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		return true, action.(k8stesting.UpdateAction).GetObject(), nil
+	})
+
+	reviewing := make(chan struct{})
+	release := make(chan struct{})
+	c.allocator.allocationWebhookCallback = func(_ *allocationv1.AllocationWebhook, review *allocationv1.GameServerAllocationReview) (*allocationv1.GameServerAllocationReviewResponse, error) {
+		close(reviewing)
+		<-release
+		return &allocationv1.GameServerAllocationReviewResponse{UID: review.Request.UID, Allowed: true}, nil
+	}
+
+	stop, cancel := agtesting.StartInformers(m, c.allocator.readyGameServerCache.gameServerSynced)
+	defer cancel()
+	require.NoError(t, c.allocator.readyGameServerCache.syncReadyGSServerCache())
+	require.NoError(t, c.allocator.readyGameServerCache.counter.Run(0, stop))
+
+	gsa := &allocationv1.GameServerAllocation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: defaultNs},
+		Spec: allocationv1.GameServerAllocationSpec{
+			Required: allocationv1.GameServerSelector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{agonesv1.FleetNameLabel: f.ObjectMeta.Name}}},
+		}}
+	gsa.ApplyDefaults()
+	u := "http://localhost/allocate"
+	withWebhook := gsa.DeepCopy()
+	withWebhook.Spec.Webhook = &allocationv1.AllocationWebhook{WebhookClientConfig: admregv1.WebhookClientConfig{URL: &u}}
+
+	// a single update worker, which the slow webhook would hold if it was called on it
+	go c.allocator.ListenAndAllocate(1, stop)
+
+	slow := request{gsa: withWebhook, response: make(chan response)}
+	c.allocator.pendingRequests <- slow
+	<-reviewing
+
+	normal := request{gsa: gsa.DeepCopy(), response: make(chan response)}
+	c.allocator.pendingRequests <- normal
+	select {
+	case res := <-normal.response:
+		require.NoError(t, res.err)
+		assert.Equal(t, agonesv1.GameServerStateAllocated, res.gs.Status.State)
+	case <-time.After(5 * time.Second):
+		assert.FailNow(t, "the allocation was held up by the slow webhook")
+	}
+
+	close(release)
+	res := <-slow.response
+	require.NoError(t, res.err)
+	assert.Equal(t, agonesv1.GameServerStateAllocated, res.gs.Status.State)
+}
+
+func TestCallAllocationWebhook(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		review := allocationv1.GameServerAllocationReview{}
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&review)) {
+			return
+		}
+		review.Response = &allocationv1.GameServerAllocationReviewResponse{
+			UID:       review.Request.UID,
+			Allowed:   review.Request.GameServer.ObjectMeta.Name == "gs1",
+			MetaPatch: allocationv1.MetaPatch{Annotations: map[string]string{"node": review.Request.GameServer.Status.NodeName}},
+		}
+		if r.URL.Path == "/uid" {
+			review.Response.UID = "nope"
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(review))
+	}))
+	defer server.Close()
+
+	newReview := func() *allocationv1.GameServerAllocationReview {
+		return &allocationv1.GameServerAllocationReview{Request: &allocationv1.GameServerAllocationReviewRequest{
+			UID:        "1234",
+			Namespace:  defaultNs,
+			GameServer: &agonesv1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "gs1"}, Status: agonesv1.GameServerStatus{NodeName: n1}},
+		}}
+	}
+	webhook := func(path string) *allocationv1.AllocationWebhook {
+		u := server.URL + path
+		return &allocationv1.AllocationWebhook{WebhookClientConfig: admregv1.WebhookClientConfig{URL: &u}}
+	}
+
+	res, err := callAllocationWebhook(webhook("/"), newReview())
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, map[string]string{"node": n1}, res.MetaPatch.Annotations)
+
+	_, err = callAllocationWebhook(webhook("/error"), newReview())
+	assert.EqualError(t, err, "bad status code 500 from the server: "+server.URL+"/error")
+
+	_, err = callAllocationWebhook(webhook("/uid"), newReview())
+	assert.EqualError(t, err, "allocation webhook returned the response uid nope, expected 1234")
+}
+
+func TestWebhookTransport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	server.Close()
+
+	host := "hook.transport.svc:8000"
+	t1, err := webhookTransport(host, bundle)
+	require.NoError(t, err)
+	t2, err := webhookTransport(host, bundle)
+	require.NoError(t, err)
+	assert.Same(t, t1, t2, "the transport should be reused for the same CA Bundle")
+
+	// another CA Bundle for the same host gets its own transport, without replacing the first one
+	other := append(append([]byte{}, bundle...), bundle...)
+	t3, err := webhookTransport(host, other)
+	require.NoError(t, err)
+	assert.NotSame(t, t1, t3)
+	t4, err := webhookTransport(host, bundle)
+	require.NoError(t, err)
+	assert.Same(t, t1, t4)
+
+	// transports that are no longer used are removed
+	webhookTransports.Lock()
+	webhookTransports.byKey[webhookTransportKey{host: host, caBundle: string(other)}].lastUsed = time.Now().Add(-2 * webhookTransportIdleTimeout)
+	webhookTransports.Unlock()
+	_, err = webhookTransport(host, bundle)
+	require.NoError(t, err)
+	webhookTransports.Lock()
+	_, ok := webhookTransports.byKey[webhookTransportKey{host: host, caBundle: string(other)}]
+	webhookTransports.Unlock()
+	assert.False(t, ok)
+
+	_, err = webhookTransport(host, []byte("nope"))
+	assert.EqualError(t, err, "no certs were appended from caBundle")
+}
+
+func TestBuildURLFromAllocationWebhook(t *testing.T) {
+	t.Parallel()
+
+	path := "/allocate"
+	var port int32 = 9000
+	fixtures := map[string]struct {
+		webhook  admregv1.WebhookClientConfig
+		expected string
+	}{
+		"url": {
+			webhook:  admregv1.WebhookClientConfig{URL: &path},
+			expected: "/allocate",
+		},
+		"service with defaults": {
+			webhook:  admregv1.WebhookClientConfig{Service: &admregv1.ServiceReference{Name: "hook"}},
+			expected: "http://hook.default.svc:8000",
+		},
+		"service": {
+			webhook: admregv1.WebhookClientConfig{
+				Service:  &admregv1.ServiceReference{Name: "hook", Namespace: "ns", Path: &path, Port: &port},
+				CABundle: []byte("ca"),
+			},
+			expected: "https://hook.ns.svc:9000/allocate",
+		},
+	}
+
+	for k, v := range fixtures {
+		t.Run(k, func(t *testing.T) {
+			u, err := buildURLFromAllocationWebhook(&allocationv1.AllocationWebhook{WebhookClientConfig: v.webhook})
+			require.NoError(t, err)
+			assert.Equal(t, v.expected, u.String())
+		})
+	}
+
+	_, err := buildURLFromAllocationWebhook(&allocationv1.AllocationWebhook{})
+	assert.Error(t, err)
+}
+
+func TestMergeMetaPatch(t *testing.T) {
+	t.Parallel()
+
+	base := allocationv1.MetaPatch{Labels: map[string]string{"a": "1", "b": "2"}}
+	override := allocationv1.MetaPatch{
+		Labels:      map[string]string{"b": "3"},
+		Annotations: map[string]string{"c": "4"},
+	}
+
+	result := mergeMetaPatch(base, override)
+	assert.Equal(t, map[string]string{"a": "1", "b": "3"}, result.Labels)
+	assert.Equal(t, map[string]string{"c": "4"}, result.Annotations)
+	// the base is left untouched
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, base.Labels)
+
+	assert.Equal(t, base, mergeMetaPatch(base, allocationv1.MetaPatch{}))
+}
@@ -401,6 +401,9 @@ func (c *Controller) syncGameServer(key string) error {
 	if gs, err = c.syncDevelopmentGameServer(gs); err != nil {
 		return err
 	}
+	if gs, err = c.syncGameServerDrainDeadline(gs); err != nil {
+		return err
+	}
 	if err := c.syncGameServerShutdownState(gs); err != nil {
 		return err
 	}
@@ -642,6 +645,10 @@ func (c *Controller) sidecar(gs *agonesv1.GameServer) corev1.Container {
 		sidecar.Args = append(sidecar.Args, fmt.Sprintf("--http-port=%d", gs.Spec.SdkServer.HTTPPort))
 	}
 
+	if runtime.FeatureEnabled(runtime.FeatureSDKTrace) && gs.Spec.SdkServer.Trace {
+		sidecar.Args = append(sidecar.Args, "--trace=-")
+	}
+
 	requests := corev1.ResourceList{}
 	if !c.sidecarCPURequest.IsZero() {
 		requests[corev1.ResourceCPU] = c.sidecarCPURequest
@@ -861,6 +868,31 @@ func (c *Controller) syncGameServerShutdownState(gs *agonesv1.GameServer) error
 	return nil
 }
 
+// syncGameServerDrainDeadline moves a draining GameServer to the Shutdown state once its drain deadline
+// has passed, or checks it again when the deadline passes.
+// [Stage:Alpha]
+// [FeatureFlag:SDKDrain]
+func (c *Controller) syncGameServerDrainDeadline(gs *agonesv1.GameServer) (*agonesv1.GameServer, error) {
+	if !runtime.FeatureEnabled(runtime.FeatureSDKDrain) || !gs.IsDraining() || gs.IsBeingDeleted() {
+		return gs, nil
+	}
+
+	if remaining := time.Until(gs.Status.DrainDeadline.Time); remaining > 0 {
+		c.workerqueue.EnqueueAfter(gs, remaining)
+		return gs, nil
+	}
+
+	c.loggerForGameServer(gs).Debug("Syncing passed drain deadline")
+	gsCopy := gs.DeepCopy()
+	gsCopy.Status.State = agonesv1.GameServerStateShutdown
+	gs, err := c.gameServerGetter.GameServers(gs.ObjectMeta.Namespace).Update(gsCopy)
+	if err != nil {
+		return gs, errors.Wrapf(err, "error moving GameServer %s to Shutdown State after its drain deadline", gsCopy.ObjectMeta.Name)
+	}
+	c.recorder.Event(gs, corev1.EventTypeNormal, string(gs.Status.State), "Drain deadline passed")
+	return gs, nil
+}
+
 // moveToErrorState moves the GameServer to the error state
 func (c *Controller) moveToErrorState(gs *agonesv1.GameServer, msg string) (*agonesv1.GameServer, error) {
 	gsCopy := gs.DeepCopy()
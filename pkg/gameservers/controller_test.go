@@ -27,6 +27,7 @@ import (
 	"agones.dev/agones/pkg/apis/agones"
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
 	agtesting "agones.dev/agones/pkg/testing"
+	utilruntime "agones.dev/agones/pkg/util/runtime"
 	"agones.dev/agones/pkg/util/webhooks"
 	"github.com/heptiolabs/healthcheck"
 	"github.com/mattbaird/jsonpatch"
@@ -1443,6 +1444,91 @@ func TestControllerSyncGameServerShutdownState(t *testing.T) {
 	})
 }
 
+func TestControllerSyncGameServerDrainDeadline(t *testing.T) {
+	t.Parallel()
+	utilruntime.FeatureTestMutex.Lock()
+	defer utilruntime.FeatureTestMutex.Unlock()
+	require.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureSDKDrain)+"=true"))
+
+	newFixture := func(deadline time.Duration) *agonesv1.GameServer {
+		d := metav1.NewTime(time.Now().Add(deadline))
+		gs := &agonesv1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: newSingleContainerSpec(), Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady, DrainDeadline: &d}}
+		gs.ApplyDefaults()
+		return gs
+	}
+
+	t.Run("Drain deadline passed", func(t *testing.T) {
+		c, mocks := newFakeController()
+		gsFixture := newFixture(-time.Second)
+		updated := false
+
+		mocks.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			updated = true
+			ua := action.(k8stesting.UpdateAction)
+			gs := ua.GetObject().(*agonesv1.GameServer)
+			assert.Equal(t, agonesv1.GameServerStateShutdown, gs.Status.State)
+			return true, gs, nil
+		})
+
+		gs, err := c.syncGameServerDrainDeadline(gsFixture)
+		assert.NoError(t, err)
+		assert.True(t, updated, "GameServer should be updated")
+		assert.Equal(t, agonesv1.GameServerStateShutdown, gs.Status.State)
+		assert.Contains(t, <-mocks.FakeRecorder.Events, "Drain deadline passed")
+	})
+
+	t.Run("Drain deadline not passed", func(t *testing.T) {
+		c, mocks := newFakeController()
+		gsFixture := newFixture(time.Hour)
+		updated := false
+		mocks.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			updated = true
+			return true, nil, nil
+		})
+
+		gs, err := c.syncGameServerDrainDeadline(gsFixture)
+		assert.NoError(t, err)
+		assert.False(t, updated, "GameServer should not be updated")
+		assert.Equal(t, gsFixture, gs)
+	})
+
+	t.Run("Error on update", func(t *testing.T) {
+		c, mocks := newFakeController()
+		gsFixture := newFixture(-time.Second)
+		mocks.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("update-err")
+		})
+
+		_, err := c.syncGameServerDrainDeadline(gsFixture)
+		assert.EqualError(t, err, "error moving GameServer test to Shutdown State after its drain deadline: update-err")
+	})
+
+	t.Run("GameServer not draining", func(t *testing.T) {
+		testNoChange(t, agonesv1.GameServerStateReady, func(c *Controller, fixture *agonesv1.GameServer) (*agonesv1.GameServer, error) {
+			return c.syncGameServerDrainDeadline(fixture)
+		})
+	})
+
+	t.Run("Feature disabled", func(t *testing.T) {
+		require.NoError(t, utilruntime.ParseFeatures(""))
+		defer func() {
+			require.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureSDKDrain)+"=true"))
+		}()
+		c, mocks := newFakeController()
+		gsFixture := newFixture(-time.Second)
+		updated := false
+		mocks.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			updated = true
+			return true, nil, nil
+		})
+
+		_, err := c.syncGameServerDrainDeadline(gsFixture)
+		assert.NoError(t, err)
+		assert.False(t, updated, "GameServer should not be updated")
+	})
+}
+
 func TestControllerGameServerPod(t *testing.T) {
 	t.Parallel()
 
@@ -1733,6 +1819,26 @@ func TestControllerAddSDKServerEnvVars(t *testing.T) {
 	})
 }
 
+func TestControllerSidecarTrace(t *testing.T) {
+	t.Parallel()
+	utilruntime.FeatureTestMutex.Lock()
+	defer utilruntime.FeatureTestMutex.Unlock()
+	defer utilruntime.ParseFeatures("") // nolint: errcheck
+
+	c, _ := newFakeController()
+	gs := &agonesv1.GameServer{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}, Spec: newSingleContainerSpec()}
+	gs.ApplyDefaults()
+	require.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureSDKTrace)+"=true"))
+	assert.NotContains(t, c.sidecar(gs).Args, "--trace=-")
+
+	gs.Spec.SdkServer.Trace = true
+	assert.Contains(t, c.sidecar(gs).Args, "--trace=-")
+
+	// without the feature gate, the trace is not written
+	require.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureSDKTrace)+"=false"))
+	assert.NotContains(t, c.sidecar(gs).Args, "--trace=-")
+}
+
 // testNoChange runs a test with a state that doesn't exist, to ensure a handler
 // doesn't do process anything beyond the state it is meant to handle.
 func testNoChange(t *testing.T, state agonesv1.GameServerState, f func(*Controller, *agonesv1.GameServer) (*agonesv1.GameServer, error)) {
@@ -39,6 +39,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	extclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apiextclientv1 "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -574,7 +575,7 @@ func (c *Controller) syncGameServerSetStatus(gsSet *agonesv1.GameServerSet, list
 
 // updateStatusIfChanged updates GameServerSet status if it's different than provided.
 func (c *Controller) updateStatusIfChanged(gsSet *agonesv1.GameServerSet, status agonesv1.GameServerSetStatus) error {
-	if gsSet.Status != status {
+	if !apiequality.Semantic.DeepEqual(gsSet.Status, status) {
 		gsSetCopy := gsSet.DeepCopy()
 		gsSetCopy.Status = status
 		_, err := c.gameServerSetGetter.GameServerSets(gsSet.ObjectMeta.Namespace).UpdateStatus(gsSetCopy)
@@ -624,5 +625,54 @@ func computeStatus(list []*agonesv1.GameServer) agonesv1.GameServerSetStatus {
 		}
 	}
 
+	if runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		// TODO: integrate this extra loop into the above for loop when CountsAndLists moves to GA
+		for _, gs := range list {
+			if gs.ObjectMeta.DeletionTimestamp.IsZero() &&
+				(gs.Status.State == agonesv1.GameServerStateReady ||
+					gs.Status.State == agonesv1.GameServerStateReserved ||
+					gs.Status.State == agonesv1.GameServerStateAllocated) {
+				status.Counters = aggregateCounters(status.Counters, gs.Status.Counters)
+				status.Lists = aggregateLists(status.Lists, gs.Status.Lists)
+			}
+		}
+	}
+
 	return status
 }
+
+// aggregateCounters adds the count and capacity of each GameServer Counter
+// to the running aggregated totals
+func aggregateCounters(aggregated map[string]agonesv1.AggregatedCounterStatus, counters map[string]agonesv1.CounterStatus) map[string]agonesv1.AggregatedCounterStatus {
+	if len(counters) == 0 {
+		return aggregated
+	}
+	if aggregated == nil {
+		aggregated = make(map[string]agonesv1.AggregatedCounterStatus, len(counters))
+	}
+	for name, c := range counters {
+		a := aggregated[name]
+		a.Count += c.Count
+		a.Capacity += c.Capacity
+		aggregated[name] = a
+	}
+	return aggregated
+}
+
+// aggregateLists adds the number of values and capacity of each GameServer List
+// to the running aggregated totals
+func aggregateLists(aggregated map[string]agonesv1.AggregatedListStatus, lists map[string]agonesv1.ListStatus) map[string]agonesv1.AggregatedListStatus {
+	if len(lists) == 0 {
+		return aggregated
+	}
+	if aggregated == nil {
+		aggregated = make(map[string]agonesv1.AggregatedListStatus, len(lists))
+	}
+	for name, l := range lists {
+		a := aggregated[name]
+		a.Count += int64(len(l.Values))
+		a.Capacity += l.Capacity
+		aggregated[name] = a
+	}
+	return aggregated
+}
@@ -332,6 +332,39 @@ func TestComputeStatus(t *testing.T) {
 
 		assert.Equal(t, expected, computeStatus(list))
 	})
+
+	t.Run("counters and lists", func(t *testing.T) {
+		utilruntime.FeatureTestMutex.Lock()
+		defer utilruntime.FeatureTestMutex.Unlock()
+
+		require.NoError(t, utilruntime.ParseFeatures(string(utilruntime.FeatureCountsAndLists)+"=true"))
+
+		var list []*agonesv1.GameServer
+		gs1 := gsWithState(agonesv1.GameServerStateAllocated)
+		gs1.Status.Counters = map[string]agonesv1.CounterStatus{"rooms": {Count: 5, Capacity: 10}}
+		gs1.Status.Lists = map[string]agonesv1.ListStatus{"tokens": {Capacity: 5, Values: []string{"a", "b"}}}
+		gs2 := gsWithState(agonesv1.GameServerStateReady)
+		gs2.Status.Counters = map[string]agonesv1.CounterStatus{"rooms": {Count: 1, Capacity: 10}, "sessions": {Count: 2, Capacity: 3}}
+		gs2.Status.Lists = map[string]agonesv1.ListStatus{"tokens": {Capacity: 5, Values: []string{"c"}}}
+		gs3 := gsWithState(agonesv1.GameServerStateCreating)
+		gs3.Status.Counters = map[string]agonesv1.CounterStatus{"rooms": {Count: 20, Capacity: 30}}
+		list = append(list, gs1, gs2, gs3)
+
+		expected := agonesv1.GameServerSetStatus{
+			Replicas:          3,
+			ReadyReplicas:     1,
+			AllocatedReplicas: 1,
+			Counters: map[string]agonesv1.AggregatedCounterStatus{
+				"rooms":    {Count: 6, Capacity: 20},
+				"sessions": {Count: 2, Capacity: 3},
+			},
+			Lists: map[string]agonesv1.AggregatedListStatus{
+				"tokens": {Count: 3, Capacity: 10},
+			},
+		}
+
+		assert.Equal(t, expected, computeStatus(list))
+	})
 }
 
 func TestControllerWatchGameServers(t *testing.T) {
@@ -173,18 +173,23 @@ func (c *Controller) recordFleetAutoScalerChanges(old, next interface{}) {
 
 	ableToScale := 0
 	limited := 0
+	dryRun := 0
 	if fas.Status.AbleToScale {
 		ableToScale = 1
 	}
 	if fas.Status.ScalingLimited {
 		limited = 1
 	}
+	if fas.Status.DryRun {
+		dryRun = 1
+	}
 	// recording status
 	stats.Record(ctx,
 		fasCurrentReplicasStats.M(int64(fas.Status.CurrentReplicas)),
 		fasDesiredReplicasStats.M(int64(fas.Status.DesiredReplicas)),
 		fasAbleToScaleStats.M(int64(ableToScale)),
-		fasLimitedStats.M(int64(limited)))
+		fasLimitedStats.M(int64(limited)),
+		fasDryRunStats.M(int64(dryRun)))
 
 	// recording buffer policy
 	if fas.Spec.Policy.Buffer != nil {
@@ -225,7 +230,8 @@ func (c *Controller) recordFleetAutoScalerDeletion(obj interface{}) {
 		fasCurrentReplicasStats.M(int64(0)),
 		fasDesiredReplicasStats.M(int64(0)),
 		fasAbleToScaleStats.M(int64(0)),
-		fasLimitedStats.M(int64(0)))
+		fasLimitedStats.M(int64(0)),
+		fasDryRunStats.M(int64(0)))
 }
 
 func (c *Controller) recordFleetChanges(obj interface{}) {
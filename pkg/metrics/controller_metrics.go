@@ -29,6 +29,7 @@ var (
 	fasDesiredReplicasStats   = stats.Int64("fas/desired_replicas_count", "The desired replicas cout as seen by autoscalers", "1")
 	fasAbleToScaleStats       = stats.Int64("fas/able_to_scale", "The fleet autoscaler can access the fleet to scale (0 indicates false, 1 indicates true)", "1")
 	fasLimitedStats           = stats.Int64("fas/limited", "The fleet autoscaler is capped (0 indicates false, 1 indicates true)", "1")
+	fasDryRunStats            = stats.Int64("fas/dry_run", "The fleet autoscaler does not scale the fleet to its desired replicas (0 indicates false, 1 indicates true)", "1")
 	gameServerCountStats      = stats.Int64("gameservers/count", "The count of gameservers", "1")
 	gameServerTotalStats      = stats.Int64("gameservers/total", "The total of gameservers", "1")
 	nodesCountStats           = stats.Int64("nodes/count", "The count of nodes in the cluster", "1")
@@ -85,6 +86,13 @@ var (
 			Aggregation: view.LastValue(),
 			TagKeys:     []tag.Key{keyName, keyFleetName, keyNamespace},
 		},
+		&view.View{
+			Name:        "fleet_autoscalers_dry_run",
+			Measure:     fasDryRunStats,
+			Description: "The fleet autoscaler only recommends its desired replicas, without scaling the fleet",
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{keyName, keyFleetName, keyNamespace},
+		},
 		&view.View{
 			Name:        "gameservers_count",
 			Measure:     gameServerCountStats,
@@ -263,6 +263,7 @@ func TestControllerFleetAutoScalerState(t *testing.T) {
 	fasFleetNameChange.Status.CurrentReplicas = 20
 	fasFleetNameChange.Status.DesiredReplicas = 10
 	fasFleetNameChange.Status.ScalingLimited = true
+	fasFleetNameChange.Status.DryRun = true
 	c.fasWatch.Modify(fasFleetNameChange)
 	fasFleetNameChange = fasFleetNameChange.DeepCopy()
 	fasFleetNameChange.Spec.FleetName = "second-fleet"
@@ -329,6 +330,11 @@ func TestControllerFleetAutoScalerState(t *testing.T) {
 		{labels: []string{"second-fleet", "name-switch", defaultNs}, val: int64(1)},
 		{labels: []string{"deleted-fleet", "deleted", defaultNs}, val: int64(0)},
 	})
+	assertMetricData(t, exporter, "fleet_autoscalers_dry_run", []expectedMetricData{
+		{labels: []string{"first-fleet", "name-switch", defaultNs}, val: int64(0)},
+		{labels: []string{"second-fleet", "name-switch", defaultNs}, val: int64(1)},
+		{labels: []string{"deleted-fleet", "deleted", defaultNs}, val: int64(0)},
+	})
 }
 
 func TestControllerGameServersNodeState(t *testing.T) {
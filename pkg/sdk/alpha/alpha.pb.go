@@ -54,7 +54,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_alpha_85112ebd625f4899, []int{0}
+	return fileDescriptor_alpha_57177c99bfc81129, []int{0}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -74,6 +74,45 @@ func (m *Empty) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Empty proto.InternalMessageInfo
 
+// time duration, in seconds
+type Duration struct {
+	Seconds              int64    `protobuf:"varint,1,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Duration) Reset()         { *m = Duration{} }
+func (m *Duration) String() string { return proto.CompactTextString(m) }
+func (*Duration) ProtoMessage()    {}
+func (*Duration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_alpha_57177c99bfc81129, []int{1}
+}
+func (m *Duration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Duration.Unmarshal(m, b)
+}
+func (m *Duration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Duration.Marshal(b, m, deterministic)
+}
+func (dst *Duration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Duration.Merge(dst, src)
+}
+func (m *Duration) XXX_Size() int {
+	return xxx_messageInfo_Duration.Size(m)
+}
+func (m *Duration) XXX_DiscardUnknown() {
+	xxx_messageInfo_Duration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Duration proto.InternalMessageInfo
+
+func (m *Duration) GetSeconds() int64 {
+	if m != nil {
+		return m.Seconds
+	}
+	return 0
+}
+
 // Store a count variable.
 type Count struct {
 	Count                int64    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
@@ -86,7 +125,7 @@ func (m *Count) Reset()         { *m = Count{} }
 func (m *Count) String() string { return proto.CompactTextString(m) }
 func (*Count) ProtoMessage()    {}
 func (*Count) Descriptor() ([]byte, []int) {
-	return fileDescriptor_alpha_85112ebd625f4899, []int{1}
+	return fileDescriptor_alpha_57177c99bfc81129, []int{2}
 }
 func (m *Count) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Count.Unmarshal(m, b)
@@ -125,7 +164,7 @@ func (m *Bool) Reset()         { *m = Bool{} }
 func (m *Bool) String() string { return proto.CompactTextString(m) }
 func (*Bool) ProtoMessage()    {}
 func (*Bool) Descriptor() ([]byte, []int) {
-	return fileDescriptor_alpha_85112ebd625f4899, []int{2}
+	return fileDescriptor_alpha_57177c99bfc81129, []int{3}
 }
 func (m *Bool) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Bool.Unmarshal(m, b)
@@ -164,7 +203,7 @@ func (m *PlayerID) Reset()         { *m = PlayerID{} }
 func (m *PlayerID) String() string { return proto.CompactTextString(m) }
 func (*PlayerID) ProtoMessage()    {}
 func (*PlayerID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_alpha_85112ebd625f4899, []int{3}
+	return fileDescriptor_alpha_57177c99bfc81129, []int{4}
 }
 func (m *PlayerID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlayerID.Unmarshal(m, b)
@@ -203,7 +242,7 @@ func (m *PlayerIDList) Reset()         { *m = PlayerIDList{} }
 func (m *PlayerIDList) String() string { return proto.CompactTextString(m) }
 func (*PlayerIDList) ProtoMessage()    {}
 func (*PlayerIDList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_alpha_85112ebd625f4899, []int{4}
+	return fileDescriptor_alpha_57177c99bfc81129, []int{5}
 }
 func (m *PlayerIDList) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PlayerIDList.Unmarshal(m, b)
@@ -230,12 +269,357 @@ func (m *PlayerIDList) GetList() []string {
 	return nil
 }
 
+// The name of a Counter or List.
+type Key struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Key) Reset()         { *m = Key{} }
+func (m *Key) String() string { return proto.CompactTextString(m) }
+func (*Key) ProtoMessage()    {}
+func (*Key) Descriptor() ([]byte, []int) {
+	return fileDescriptor_alpha_57177c99bfc81129, []int{6}
+}
+func (m *Key) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Key.Unmarshal(m, b)
+}
+func (m *Key) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Key.Marshal(b, m, deterministic)
+}
+func (dst *Key) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Key.Merge(dst, src)
+}
+func (m *Key) XXX_Size() int {
+	return xxx_messageInfo_Key.Size(m)
+}
+func (m *Key) XXX_DiscardUnknown() {
+	xxx_messageInfo_Key.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Key proto.InternalMessageInfo
+
+func (m *Key) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// The current count and capacity of a named Counter.
+type Counter struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Capacity             int64    `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Counter) Reset()         { *m = Counter{} }
+func (m *Counter) String() string { return proto.CompactTextString(m) }
+func (*Counter) ProtoMessage()    {}
+func (*Counter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_alpha_57177c99bfc81129, []int{7}
+}
+func (m *Counter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Counter.Unmarshal(m, b)
+}
+func (m *Counter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Counter.Marshal(b, m, deterministic)
+}
+func (dst *Counter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Counter.Merge(dst, src)
+}
+func (m *Counter) XXX_Size() int {
+	return xxx_messageInfo_Counter.Size(m)
+}
+func (m *Counter) XXX_DiscardUnknown() {
+	xxx_messageInfo_Counter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Counter proto.InternalMessageInfo
+
+func (m *Counter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Counter) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *Counter) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+// A request to increment or decrement a named Counter by an amount.
+type CounterUpdateRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Amount               int64    `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CounterUpdateRequest) Reset()         { *m = CounterUpdateRequest{} }
+func (m *CounterUpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*CounterUpdateRequest) ProtoMessage()    {}
+func (*CounterUpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_alpha_57177c99bfc81129, []int{8}
+}
+func (m *CounterUpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CounterUpdateRequest.Unmarshal(m, b)
+}
+func (m *CounterUpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CounterUpdateRequest.Marshal(b, m, deterministic)
+}
+func (dst *CounterUpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CounterUpdateRequest.Merge(dst, src)
+}
+func (m *CounterUpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_CounterUpdateRequest.Size(m)
+}
+func (m *CounterUpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CounterUpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CounterUpdateRequest proto.InternalMessageInfo
+
+func (m *CounterUpdateRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CounterUpdateRequest) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+// A request to set the count of a named Counter.
+type CounterSetRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CounterSetRequest) Reset()         { *m = CounterSetRequest{} }
+func (m *CounterSetRequest) String() string { return proto.CompactTextString(m) }
+func (*CounterSetRequest) ProtoMessage()    {}
+func (*CounterSetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_alpha_57177c99bfc81129, []int{9}
+}
+func (m *CounterSetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CounterSetRequest.Unmarshal(m, b)
+}
+func (m *CounterSetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CounterSetRequest.Marshal(b, m, deterministic)
+}
+func (dst *CounterSetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CounterSetRequest.Merge(dst, src)
+}
+func (m *CounterSetRequest) XXX_Size() int {
+	return xxx_messageInfo_CounterSetRequest.Size(m)
+}
+func (m *CounterSetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CounterSetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CounterSetRequest proto.InternalMessageInfo
+
+func (m *CounterSetRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CounterSetRequest) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// A request to set the capacity of a named Counter or List.
+type CapacityRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Capacity             int64    `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CapacityRequest) Reset()         { *m = CapacityRequest{} }
+func (m *CapacityRequest) String() string { return proto.CompactTextString(m) }
+func (*CapacityRequest) ProtoMessage()    {}
+func (*CapacityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_alpha_57177c99bfc81129, []int{10}
+}
+func (m *CapacityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CapacityRequest.Unmarshal(m, b)
+}
+func (m *CapacityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CapacityRequest.Marshal(b, m, deterministic)
+}
+func (dst *CapacityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CapacityRequest.Merge(dst, src)
+}
+func (m *CapacityRequest) XXX_Size() int {
+	return xxx_messageInfo_CapacityRequest.Size(m)
+}
+func (m *CapacityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CapacityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CapacityRequest proto.InternalMessageInfo
+
+func (m *CapacityRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CapacityRequest) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+// The current values and capacity of a named List.
+type List struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Capacity             int64    `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Values               []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *List) Reset()         { *m = List{} }
+func (m *List) String() string { return proto.CompactTextString(m) }
+func (*List) ProtoMessage()    {}
+func (*List) Descriptor() ([]byte, []int) {
+	return fileDescriptor_alpha_57177c99bfc81129, []int{11}
+}
+func (m *List) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_List.Unmarshal(m, b)
+}
+func (m *List) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_List.Marshal(b, m, deterministic)
+}
+func (dst *List) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_List.Merge(dst, src)
+}
+func (m *List) XXX_Size() int {
+	return xxx_messageInfo_List.Size(m)
+}
+func (m *List) XXX_DiscardUnknown() {
+	xxx_messageInfo_List.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_List proto.InternalMessageInfo
+
+func (m *List) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *List) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *List) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// A request to add, remove or check for a value in a named List.
+type ListValueRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListValueRequest) Reset()         { *m = ListValueRequest{} }
+func (m *ListValueRequest) String() string { return proto.CompactTextString(m) }
+func (*ListValueRequest) ProtoMessage()    {}
+func (*ListValueRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_alpha_57177c99bfc81129, []int{12}
+}
+func (m *ListValueRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListValueRequest.Unmarshal(m, b)
+}
+func (m *ListValueRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListValueRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListValueRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListValueRequest.Merge(dst, src)
+}
+func (m *ListValueRequest) XXX_Size() int {
+	return xxx_messageInfo_ListValueRequest.Size(m)
+}
+func (m *ListValueRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListValueRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListValueRequest proto.InternalMessageInfo
+
+func (m *ListValueRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ListValueRequest) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "agones.dev.sdk.alpha.Empty")
+	proto.RegisterType((*Duration)(nil), "agones.dev.sdk.alpha.Duration")
 	proto.RegisterType((*Count)(nil), "agones.dev.sdk.alpha.Count")
 	proto.RegisterType((*Bool)(nil), "agones.dev.sdk.alpha.Bool")
 	proto.RegisterType((*PlayerID)(nil), "agones.dev.sdk.alpha.PlayerID")
 	proto.RegisterType((*PlayerIDList)(nil), "agones.dev.sdk.alpha.PlayerIDList")
+	proto.RegisterType((*Key)(nil), "agones.dev.sdk.alpha.Key")
+	proto.RegisterType((*Counter)(nil), "agones.dev.sdk.alpha.Counter")
+	proto.RegisterType((*CounterUpdateRequest)(nil), "agones.dev.sdk.alpha.CounterUpdateRequest")
+	proto.RegisterType((*CounterSetRequest)(nil), "agones.dev.sdk.alpha.CounterSetRequest")
+	proto.RegisterType((*CapacityRequest)(nil), "agones.dev.sdk.alpha.CapacityRequest")
+	proto.RegisterType((*List)(nil), "agones.dev.sdk.alpha.List")
+	proto.RegisterType((*ListValueRequest)(nil), "agones.dev.sdk.alpha.ListValueRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -303,6 +687,68 @@ type SDKClient interface {
 	//
 	// If GameServer.Status.Players.IDs is set manually through the Kubernetes API, use SDK.GameServer() or SDK.WatchGameServer() instead to view this value.
 	GetConnectedPlayers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PlayerIDList, error)
+	// Retrieves the current count and capacity of a named Counter. This is always accurate from what has been set through this SDK,
+	// even if the value has yet to be updated on the GameServer status resource.
+	//
+	// An error will be returned if the Counter does not exist on the GameServer.
+	GetCounter(ctx context.Context, in *Key, opts ...grpc.CallOption) (*Counter, error)
+	// Increases the named Counter's count by the given amount.
+	//
+	// GameServer.Status.Counters is then set to update the Counter a second from now,
+	// unless there is already an update pending, in which case the update joins that batch operation.
+	//
+	// An error will be returned if the Counter does not exist, the amount is negative, or the count would exceed the Counter's capacity.
+	IncrementCounter(ctx context.Context, in *CounterUpdateRequest, opts ...grpc.CallOption) (*Counter, error)
+	// Decreases the named Counter's count by the given amount.
+	//
+	// GameServer.Status.Counters is then set to update the Counter a second from now,
+	// unless there is already an update pending, in which case the update joins that batch operation.
+	//
+	// An error will be returned if the Counter does not exist, the amount is negative, or the count would drop below zero.
+	DecrementCounter(ctx context.Context, in *CounterUpdateRequest, opts ...grpc.CallOption) (*Counter, error)
+	// Sets the named Counter's count to the given value.
+	//
+	// An error will be returned if the Counter does not exist, or the value is outside the range of zero to the Counter's capacity.
+	SetCounterCount(ctx context.Context, in *CounterSetRequest, opts ...grpc.CallOption) (*Counter, error)
+	// Sets the named Counter's capacity to the given value.
+	//
+	// An error will be returned if the Counter does not exist, or the value is negative.
+	SetCounterCapacity(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*Counter, error)
+	// Retrieves the current values and capacity of a named List. This is always accurate from what has been set through this SDK,
+	// even if the value has yet to be updated on the GameServer status resource.
+	//
+	// An error will be returned if the List does not exist on the GameServer.
+	GetList(ctx context.Context, in *Key, opts ...grpc.CallOption) (*List, error)
+	// Appends a value to the named List, if it is not already present.
+	//
+	// GameServer.Status.Lists is then set to update the List a second from now,
+	// unless there is already an update pending, in which case the update joins that batch operation.
+	//
+	// An error will be returned if the List does not exist, the value already exists within the List,
+	// or the List is at capacity.
+	AddListValue(ctx context.Context, in *ListValueRequest, opts ...grpc.CallOption) (*List, error)
+	// Removes a value from the named List.
+	//
+	// GameServer.Status.Lists is then set to update the List a second from now,
+	// unless there is already an update pending, in which case the update joins that batch operation.
+	//
+	// An error will be returned if the List does not exist, or the value is not within the List.
+	RemoveListValue(ctx context.Context, in *ListValueRequest, opts ...grpc.CallOption) (*List, error)
+	// Sets the named List's capacity to the given value.
+	//
+	// An error will be returned if the List does not exist, or the capacity is negative or less than the current number of values.
+	SetListCapacity(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*List, error)
+	// Returns if the value is currently in the named List. This is always accurate from what has been set through this SDK,
+	// even if the value has yet to be updated on the GameServer status resource.
+	ListContains(ctx context.Context, in *ListValueRequest, opts ...grpc.CallOption) (*Bool, error)
+	// Drains the GameServer: it is no longer allocated, so no new players join it, and is moved to the Shutdown state
+	// once the drain deadline, Duration from now, passes. The game server should call Shutdown() itself once its current
+	// match ends, which can be before the deadline.
+	//
+	// GameServer.Status.DrainDeadline is set to the drain deadline. Calling Drain again moves the deadline.
+	//
+	// An error will be returned if the Duration is not greater than 0.
+	Drain(ctx context.Context, in *Duration, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type sDKClient struct {
@@ -376,6 +822,105 @@ func (c *sDKClient) GetConnectedPlayers(ctx context.Context, in *Empty, opts ...
 	return out, nil
 }
 
+func (c *sDKClient) GetCounter(ctx context.Context, in *Key, opts ...grpc.CallOption) (*Counter, error) {
+	out := new(Counter)
+	err := c.cc.Invoke(ctx, "/agones.dev.sdk.alpha.SDK/GetCounter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) IncrementCounter(ctx context.Context, in *CounterUpdateRequest, opts ...grpc.CallOption) (*Counter, error) {
+	out := new(Counter)
+	err := c.cc.Invoke(ctx, "/agones.dev.sdk.alpha.SDK/IncrementCounter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) DecrementCounter(ctx context.Context, in *CounterUpdateRequest, opts ...grpc.CallOption) (*Counter, error) {
+	out := new(Counter)
+	err := c.cc.Invoke(ctx, "/agones.dev.sdk.alpha.SDK/DecrementCounter", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) SetCounterCount(ctx context.Context, in *CounterSetRequest, opts ...grpc.CallOption) (*Counter, error) {
+	out := new(Counter)
+	err := c.cc.Invoke(ctx, "/agones.dev.sdk.alpha.SDK/SetCounterCount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) SetCounterCapacity(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*Counter, error) {
+	out := new(Counter)
+	err := c.cc.Invoke(ctx, "/agones.dev.sdk.alpha.SDK/SetCounterCapacity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) GetList(ctx context.Context, in *Key, opts ...grpc.CallOption) (*List, error) {
+	out := new(List)
+	err := c.cc.Invoke(ctx, "/agones.dev.sdk.alpha.SDK/GetList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) AddListValue(ctx context.Context, in *ListValueRequest, opts ...grpc.CallOption) (*List, error) {
+	out := new(List)
+	err := c.cc.Invoke(ctx, "/agones.dev.sdk.alpha.SDK/AddListValue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) RemoveListValue(ctx context.Context, in *ListValueRequest, opts ...grpc.CallOption) (*List, error) {
+	out := new(List)
+	err := c.cc.Invoke(ctx, "/agones.dev.sdk.alpha.SDK/RemoveListValue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) SetListCapacity(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*List, error) {
+	out := new(List)
+	err := c.cc.Invoke(ctx, "/agones.dev.sdk.alpha.SDK/SetListCapacity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) ListContains(ctx context.Context, in *ListValueRequest, opts ...grpc.CallOption) (*Bool, error) {
+	out := new(Bool)
+	err := c.cc.Invoke(ctx, "/agones.dev.sdk.alpha.SDK/ListContains", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sDKClient) Drain(ctx context.Context, in *Duration, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/agones.dev.sdk.alpha.SDK/Drain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SDKServer is the server API for SDK service.
 type SDKServer interface {
 	// PlayerConnect increases the SDK’s stored player count by one, and appends this playerID to GameServer.Status.Players.IDs.
@@ -431,6 +976,68 @@ type SDKServer interface {
 	//
 	// If GameServer.Status.Players.IDs is set manually through the Kubernetes API, use SDK.GameServer() or SDK.WatchGameServer() instead to view this value.
 	GetConnectedPlayers(context.Context, *Empty) (*PlayerIDList, error)
+	// Retrieves the current count and capacity of a named Counter. This is always accurate from what has been set through this SDK,
+	// even if the value has yet to be updated on the GameServer status resource.
+	//
+	// An error will be returned if the Counter does not exist on the GameServer.
+	GetCounter(context.Context, *Key) (*Counter, error)
+	// Increases the named Counter's count by the given amount.
+	//
+	// GameServer.Status.Counters is then set to update the Counter a second from now,
+	// unless there is already an update pending, in which case the update joins that batch operation.
+	//
+	// An error will be returned if the Counter does not exist, the amount is negative, or the count would exceed the Counter's capacity.
+	IncrementCounter(context.Context, *CounterUpdateRequest) (*Counter, error)
+	// Decreases the named Counter's count by the given amount.
+	//
+	// GameServer.Status.Counters is then set to update the Counter a second from now,
+	// unless there is already an update pending, in which case the update joins that batch operation.
+	//
+	// An error will be returned if the Counter does not exist, the amount is negative, or the count would drop below zero.
+	DecrementCounter(context.Context, *CounterUpdateRequest) (*Counter, error)
+	// Sets the named Counter's count to the given value.
+	//
+	// An error will be returned if the Counter does not exist, or the value is outside the range of zero to the Counter's capacity.
+	SetCounterCount(context.Context, *CounterSetRequest) (*Counter, error)
+	// Sets the named Counter's capacity to the given value.
+	//
+	// An error will be returned if the Counter does not exist, or the value is negative.
+	SetCounterCapacity(context.Context, *CapacityRequest) (*Counter, error)
+	// Retrieves the current values and capacity of a named List. This is always accurate from what has been set through this SDK,
+	// even if the value has yet to be updated on the GameServer status resource.
+	//
+	// An error will be returned if the List does not exist on the GameServer.
+	GetList(context.Context, *Key) (*List, error)
+	// Appends a value to the named List, if it is not already present.
+	//
+	// GameServer.Status.Lists is then set to update the List a second from now,
+	// unless there is already an update pending, in which case the update joins that batch operation.
+	//
+	// An error will be returned if the List does not exist, the value already exists within the List,
+	// or the List is at capacity.
+	AddListValue(context.Context, *ListValueRequest) (*List, error)
+	// Removes a value from the named List.
+	//
+	// GameServer.Status.Lists is then set to update the List a second from now,
+	// unless there is already an update pending, in which case the update joins that batch operation.
+	//
+	// An error will be returned if the List does not exist, or the value is not within the List.
+	RemoveListValue(context.Context, *ListValueRequest) (*List, error)
+	// Sets the named List's capacity to the given value.
+	//
+	// An error will be returned if the List does not exist, or the capacity is negative or less than the current number of values.
+	SetListCapacity(context.Context, *CapacityRequest) (*List, error)
+	// Returns if the value is currently in the named List. This is always accurate from what has been set through this SDK,
+	// even if the value has yet to be updated on the GameServer status resource.
+	ListContains(context.Context, *ListValueRequest) (*Bool, error)
+	// Drains the GameServer: it is no longer allocated, so no new players join it, and is moved to the Shutdown state
+	// once the drain deadline, Duration from now, passes. The game server should call Shutdown() itself once its current
+	// match ends, which can be before the deadline.
+	//
+	// GameServer.Status.DrainDeadline is set to the drain deadline. Calling Drain again moves the deadline.
+	//
+	// An error will be returned if the Duration is not greater than 0.
+	Drain(context.Context, *Duration) (*Empty, error)
 }
 
 func RegisterSDKServer(s *grpc.Server, srv SDKServer) {
@@ -563,6 +1170,204 @@ func _SDK_GetConnectedPlayers_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SDK_GetCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Key)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).GetCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agones.dev.sdk.alpha.SDK/GetCounter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).GetCounter(ctx, req.(*Key))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_IncrementCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CounterUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).IncrementCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agones.dev.sdk.alpha.SDK/IncrementCounter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).IncrementCounter(ctx, req.(*CounterUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_DecrementCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CounterUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).DecrementCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agones.dev.sdk.alpha.SDK/DecrementCounter",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).DecrementCounter(ctx, req.(*CounterUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_SetCounterCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CounterSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).SetCounterCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agones.dev.sdk.alpha.SDK/SetCounterCount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).SetCounterCount(ctx, req.(*CounterSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_SetCounterCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).SetCounterCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agones.dev.sdk.alpha.SDK/SetCounterCapacity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).SetCounterCapacity(ctx, req.(*CapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_GetList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Key)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).GetList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agones.dev.sdk.alpha.SDK/GetList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).GetList(ctx, req.(*Key))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_AddListValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).AddListValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agones.dev.sdk.alpha.SDK/AddListValue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).AddListValue(ctx, req.(*ListValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_RemoveListValue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).RemoveListValue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agones.dev.sdk.alpha.SDK/RemoveListValue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).RemoveListValue(ctx, req.(*ListValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_SetListCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).SetListCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agones.dev.sdk.alpha.SDK/SetListCapacity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).SetListCapacity(ctx, req.(*CapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_ListContains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListValueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).ListContains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agones.dev.sdk.alpha.SDK/ListContains",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).ListContains(ctx, req.(*ListValueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SDK_Drain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Duration)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SDKServer).Drain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/agones.dev.sdk.alpha.SDK/Drain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SDKServer).Drain(ctx, req.(*Duration))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SDK_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "agones.dev.sdk.alpha.SDK",
 	HandlerType: (*SDKServer)(nil),
@@ -595,39 +1400,109 @@ var _SDK_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetConnectedPlayers",
 			Handler:    _SDK_GetConnectedPlayers_Handler,
 		},
+		{
+			MethodName: "GetCounter",
+			Handler:    _SDK_GetCounter_Handler,
+		},
+		{
+			MethodName: "IncrementCounter",
+			Handler:    _SDK_IncrementCounter_Handler,
+		},
+		{
+			MethodName: "DecrementCounter",
+			Handler:    _SDK_DecrementCounter_Handler,
+		},
+		{
+			MethodName: "SetCounterCount",
+			Handler:    _SDK_SetCounterCount_Handler,
+		},
+		{
+			MethodName: "SetCounterCapacity",
+			Handler:    _SDK_SetCounterCapacity_Handler,
+		},
+		{
+			MethodName: "GetList",
+			Handler:    _SDK_GetList_Handler,
+		},
+		{
+			MethodName: "AddListValue",
+			Handler:    _SDK_AddListValue_Handler,
+		},
+		{
+			MethodName: "RemoveListValue",
+			Handler:    _SDK_RemoveListValue_Handler,
+		},
+		{
+			MethodName: "SetListCapacity",
+			Handler:    _SDK_SetListCapacity_Handler,
+		},
+		{
+			MethodName: "ListContains",
+			Handler:    _SDK_ListContains_Handler,
+		},
+		{
+			MethodName: "Drain",
+			Handler:    _SDK_Drain_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "alpha.proto",
 }
 
-func init() { proto.RegisterFile("alpha.proto", fileDescriptor_alpha_85112ebd625f4899) }
-
-var fileDescriptor_alpha_85112ebd625f4899 = []byte{
-	// 413 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0xcf, 0xae, 0xd2, 0x40,
-	0x14, 0xc6, 0x83, 0x50, 0xfe, 0x1c, 0xff, 0x44, 0x06, 0x50, 0x1c, 0x04, 0x71, 0x34, 0x86, 0xb0,
-	0x68, 0x13, 0xdd, 0xb9, 0x04, 0x0c, 0x21, 0xba, 0x30, 0x65, 0xe7, 0x6e, 0x68, 0x27, 0xb5, 0xb1,
-	0x74, 0x1a, 0x66, 0xd4, 0x10, 0xe2, 0xc6, 0x57, 0xf0, 0x29, 0x7c, 0x9e, 0xfb, 0x0a, 0xf7, 0x41,
-	0x6e, 0xe6, 0x0c, 0x70, 0x03, 0x29, 0xe4, 0xe6, 0xde, 0xbb, 0x3b, 0xd3, 0x6f, 0xfa, 0xfd, 0xbe,
-	0x93, 0x73, 0x06, 0x1e, 0xf2, 0x24, 0xfb, 0xce, 0xdd, 0x6c, 0x25, 0xb5, 0x24, 0x4d, 0x1e, 0xc9,
-	0x54, 0x28, 0x37, 0x14, 0xbf, 0x5c, 0x15, 0xfe, 0x70, 0x51, 0xa3, 0x2f, 0x23, 0x29, 0xa3, 0x44,
-	0x78, 0x3c, 0x8b, 0x3d, 0x9e, 0xa6, 0x52, 0x73, 0x1d, 0xcb, 0x54, 0xd9, 0x7f, 0x58, 0x05, 0x9c,
-	0x4f, 0xcb, 0x4c, 0xaf, 0x59, 0x17, 0x9c, 0xb1, 0xfc, 0x99, 0x6a, 0xd2, 0x04, 0x27, 0x30, 0x45,
-	0xbb, 0xd0, 0x2f, 0x0c, 0x8a, 0xbe, 0x3d, 0x30, 0x0a, 0xa5, 0x91, 0x94, 0x09, 0x21, 0x50, 0x5a,
-	0x48, 0x99, 0xa0, 0x58, 0xf5, 0xb1, 0x66, 0xef, 0xa0, 0xfa, 0x35, 0xe1, 0x6b, 0xb1, 0x9a, 0x4d,
-	0x08, 0x85, 0x6a, 0xb6, 0xad, 0xf1, 0x4e, 0xcd, 0xdf, 0x9f, 0x19, 0x83, 0x47, 0xbb, 0x7b, 0x5f,
-	0x62, 0xa5, 0x8d, 0x57, 0x12, 0x2b, 0x03, 0x2a, 0x0e, 0x6a, 0x3e, 0xd6, 0xef, 0xff, 0x97, 0xa1,
-	0x38, 0x9f, 0x7c, 0x26, 0x4b, 0x78, 0x6c, 0xef, 0x8e, 0x65, 0x9a, 0x8a, 0x40, 0x93, 0x9e, 0x9b,
-	0xd7, 0x9d, 0xbb, 0x33, 0xa4, 0x34, 0x5f, 0x37, 0xa1, 0x59, 0xff, 0xef, 0xc5, 0xe5, 0xbf, 0x07,
-	0x94, 0xb5, 0x3c, 0xfc, 0xe8, 0xd9, 0x44, 0x5e, 0x60, 0xad, 0x3f, 0x16, 0x86, 0x44, 0xc1, 0x53,
-	0xeb, 0x34, 0x89, 0x55, 0x70, 0x0f, 0xc4, 0x37, 0x48, 0xec, 0xb2, 0xf6, 0x21, 0x31, 0xdc, 0xbb,
-	0x1b, 0x68, 0x06, 0xf5, 0xb9, 0xd0, 0xdb, 0x36, 0x79, 0xc6, 0x83, 0x58, 0xaf, 0x49, 0x27, 0xdf,
-	0x15, 0x67, 0x43, 0x4f, 0x88, 0x76, 0x82, 0xaf, 0x91, 0xd9, 0xa1, 0xcf, 0x8e, 0xba, 0xdc, 0x3a,
-	0x1b, 0xe2, 0x12, 0xea, 0xd3, 0x9b, 0x12, 0xd1, 0x94, 0x9e, 0x8b, 0xc3, 0x7a, 0x48, 0x6c, 0x93,
-	0x13, 0x44, 0x12, 0xc1, 0x93, 0x6b, 0x1c, 0x2e, 0xd7, 0xed, 0x59, 0x1d, 0x64, 0xb5, 0x48, 0xe3,
-	0x78, 0x86, 0xc6, 0x76, 0x03, 0xf5, 0x99, 0x3a, 0xd8, 0x17, 0x11, 0xde, 0x69, 0x7e, 0x43, 0xa4,
-	0xbd, 0x25, 0x2c, 0x77, 0x63, 0x44, 0xe8, 0x6d, 0x76, 0x5b, 0xfd, 0x87, 0xfc, 0x86, 0xc6, 0x54,
-	0xe8, 0x3d, 0xd7, 0xfa, 0xab, 0xf3, 0xad, 0xb2, 0xf3, 0xd9, 0xcc, 0xf3, 0x60, 0xaf, 0x30, 0xc3,
-	0x0b, 0xf2, 0xfc, 0x44, 0x86, 0x51, 0xe5, 0x9b, 0x83, 0xca, 0xa2, 0x8c, 0x6f, 0xf9, 0xc3, 0x55,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x26, 0xa2, 0xb8, 0x96, 0x0e, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("alpha.proto", fileDescriptor_alpha_57177c99bfc81129) }
+
+var fileDescriptor_alpha_57177c99bfc81129 = []byte{
+	// 825 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x96, 0xdd, 0x6e, 0x1a, 0x39,
+	0x14, 0xc7, 0x45, 0x08, 0x81, 0x9c, 0x65, 0x17, 0x70, 0x48, 0x20, 0x93, 0x90, 0xcd, 0x3a, 0x1f,
+	0x1b, 0xb1, 0x12, 0x23, 0xed, 0xde, 0x45, 0xbb, 0x17, 0x49, 0x58, 0x45, 0x51, 0x56, 0xdb, 0x0a,
+	0xd4, 0x5e, 0x54, 0xed, 0x85, 0x33, 0x63, 0xd1, 0x51, 0x61, 0x3c, 0x9d, 0x31, 0xa9, 0x28, 0x4a,
+	0x2b, 0xe5, 0x15, 0xfa, 0x24, 0x7d, 0x96, 0xbe, 0x42, 0x1f, 0xa4, 0xf2, 0x19, 0x0f, 0x19, 0x08,
+	0x0c, 0x49, 0x13, 0xf5, 0x0a, 0x9f, 0x39, 0xf6, 0xff, 0x77, 0x8e, 0x7d, 0x8e, 0x0d, 0xfc, 0xc4,
+	0xba, 0xde, 0x6b, 0xd6, 0xf0, 0x7c, 0x21, 0x05, 0x29, 0xb3, 0x8e, 0x70, 0x79, 0xd0, 0xb0, 0xf9,
+	0x65, 0x23, 0xb0, 0xdf, 0x34, 0xd0, 0x67, 0x6c, 0x76, 0x84, 0xe8, 0x74, 0xb9, 0xc9, 0x3c, 0xc7,
+	0x64, 0xae, 0x2b, 0x24, 0x93, 0x8e, 0x70, 0x83, 0x70, 0x0d, 0xcd, 0x42, 0xe6, 0xdf, 0x9e, 0x27,
+	0x07, 0x74, 0x17, 0x72, 0xcd, 0xbe, 0x8f, 0x3e, 0x52, 0x85, 0x6c, 0xc0, 0x2d, 0xe1, 0xda, 0x41,
+	0x35, 0xb5, 0x9d, 0x3a, 0x48, 0xb7, 0x22, 0x93, 0xd6, 0x20, 0x73, 0x22, 0xfa, 0xae, 0x24, 0x65,
+	0xc8, 0x58, 0x6a, 0xa0, 0x27, 0x84, 0x06, 0x35, 0x60, 0xf1, 0x58, 0x88, 0x2e, 0x21, 0xb0, 0x78,
+	0x21, 0x44, 0x17, 0x9d, 0xb9, 0x16, 0x8e, 0xe9, 0x3e, 0xe4, 0x9e, 0x76, 0xd9, 0x80, 0xfb, 0x67,
+	0x4d, 0x62, 0x40, 0xce, 0xd3, 0x63, 0x9c, 0xb3, 0xdc, 0x1a, 0xd9, 0x94, 0x42, 0x3e, 0x9a, 0xf7,
+	0x9f, 0x13, 0x48, 0xa5, 0xd5, 0x75, 0x02, 0x05, 0x4a, 0x1f, 0x2c, 0xb7, 0x70, 0x4c, 0xd7, 0x21,
+	0x7d, 0xce, 0x07, 0xca, 0xe5, 0xb2, 0x1e, 0xd7, 0x12, 0x38, 0xa6, 0x4f, 0x20, 0x8b, 0x11, 0x72,
+	0x7f, 0x9a, 0xfb, 0x26, 0xee, 0x85, 0x58, 0xdc, 0x2a, 0x1e, 0x8b, 0x79, 0xcc, 0x72, 0xe4, 0xa0,
+	0x9a, 0x46, 0xc7, 0xc8, 0xa6, 0xc7, 0x50, 0xd6, 0x82, 0xcf, 0x3c, 0x9b, 0x49, 0xde, 0xe2, 0x6f,
+	0xfb, 0x3c, 0x8c, 0xeb, 0x96, 0xfa, 0x1a, 0x2c, 0xb1, 0x5e, 0x4c, 0x5e, 0x5b, 0xf4, 0x1f, 0x28,
+	0x69, 0x8d, 0x36, 0x97, 0x49, 0x02, 0x53, 0xc3, 0xa3, 0x47, 0x50, 0x38, 0xd1, 0xe1, 0x24, 0x2d,
+	0x8e, 0x67, 0xb1, 0x30, 0x91, 0xc5, 0xff, 0xb0, 0x18, 0xed, 0xe6, 0x7d, 0xd6, 0xa9, 0x8c, 0x2e,
+	0x59, 0xb7, 0xcf, 0x83, 0x6a, 0x1a, 0xf7, 0x5f, 0x5b, 0xf4, 0x6f, 0x28, 0x2a, 0xbd, 0xe7, 0xca,
+	0x9a, 0x93, 0x10, 0xae, 0x40, 0xe1, 0xe5, 0x56, 0x68, 0xfc, 0xf9, 0xb9, 0x08, 0xe9, 0x76, 0xf3,
+	0x9c, 0xf4, 0xe0, 0xe7, 0xf0, 0xac, 0x4f, 0x84, 0xeb, 0x72, 0x4b, 0x92, 0xad, 0xc6, 0xb4, 0x1a,
+	0x6e, 0x44, 0x05, 0x61, 0x18, 0xd3, 0xfd, 0xaa, 0xe8, 0xe8, 0xf6, 0xf5, 0x97, 0xaf, 0x9f, 0x16,
+	0x0c, 0xba, 0x6a, 0xe2, 0x47, 0x33, 0xac, 0x28, 0xd3, 0x0a, 0xa5, 0x0f, 0x53, 0x75, 0x12, 0x40,
+	0x31, 0x54, 0x6a, 0x3a, 0x81, 0xf5, 0x08, 0xc4, 0x1d, 0x24, 0xd6, 0x68, 0x75, 0x9c, 0x68, 0x3b,
+	0x41, 0x0c, 0xea, 0x41, 0xa9, 0xcd, 0xa5, 0x4e, 0x33, 0xda, 0xd6, 0x8d, 0xe9, 0xaa, 0x58, 0x24,
+	0xc6, 0x0c, 0x67, 0xd8, 0xa7, 0xbf, 0x21, 0x73, 0xc3, 0x58, 0x9b, 0xc8, 0x52, 0x2b, 0x2b, 0x62,
+	0x0f, 0x4a, 0xa7, 0x77, 0x25, 0xa2, 0xa8, 0x91, 0x14, 0x0e, 0xdd, 0x42, 0x62, 0x95, 0xcc, 0x20,
+	0x92, 0x0e, 0xfc, 0x72, 0x83, 0xc3, 0x76, 0xfa, 0x7e, 0xd6, 0x06, 0xb2, 0x56, 0xc9, 0xca, 0xe4,
+	0x19, 0x2a, 0xd9, 0x21, 0x94, 0xce, 0x82, 0xb1, 0x7a, 0xe1, 0xf6, 0x83, 0xce, 0xaf, 0x8e, 0xb4,
+	0x5d, 0x42, 0xa7, 0x56, 0x0c, 0xb7, 0xcd, 0x61, 0x74, 0x2b, 0x5d, 0x91, 0x77, 0xb0, 0x72, 0xca,
+	0xe5, 0x88, 0x1b, 0xea, 0x07, 0xc9, 0xa9, 0xd2, 0xe4, 0xd8, 0x54, 0x03, 0xd1, 0x5f, 0x31, 0x86,
+	0x75, 0x52, 0x99, 0x11, 0x03, 0xe1, 0x00, 0x08, 0x0e, 0xef, 0xb4, 0xf5, 0xe9, 0x92, 0xe7, 0x7c,
+	0x60, 0xd4, 0x12, 0x36, 0x96, 0xfb, 0xb4, 0x86, 0xa0, 0x0a, 0x89, 0xda, 0xc3, 0x0a, 0xbf, 0x9b,
+	0x43, 0xd5, 0xa7, 0x57, 0xe4, 0x3a, 0x05, 0xc5, 0x33, 0xd7, 0xf2, 0x79, 0x8f, 0xbb, 0x23, 0x5a,
+	0x3d, 0x51, 0x72, 0xec, 0x3e, 0x9c, 0x87, 0x9f, 0xec, 0x95, 0x08, 0xef, 0x44, 0x4c, 0x55, 0xb9,
+	0x2a, 0x88, 0x26, 0xff, 0xf1, 0x41, 0xd8, 0x3c, 0x16, 0xc4, 0x7b, 0x28, 0xb4, 0x47, 0x1b, 0x8e,
+	0x3f, 0xe4, 0xf7, 0x44, 0xd9, 0x9b, 0x3b, 0x7d, 0x1e, 0x5f, 0x1f, 0xb6, 0x51, 0x9e, 0xe0, 0xe3,
+	0xaf, 0x62, 0x7f, 0x00, 0x12, 0x63, 0x47, 0x1d, 0xb6, 0x37, 0x43, 0x75, 0xfc, 0x4d, 0x98, 0x07,
+	0xa7, 0x08, 0xdf, 0x34, 0x2a, 0x93, 0xf0, 0xd8, 0xd5, 0xf1, 0x12, 0xb2, 0xa7, 0x5c, 0xe2, 0x4b,
+	0x91, 0x50, 0x69, 0x33, 0x7a, 0x0a, 0xeb, 0xd9, 0x40, 0x4a, 0x99, 0x10, 0x4d, 0x51, 0xef, 0x75,
+	0x54, 0x63, 0x2e, 0xe4, 0x8f, 0x6c, 0x7b, 0xf4, 0x6e, 0x90, 0xfd, 0xd9, 0x3a, 0xf1, 0x87, 0xe5,
+	0x2e, 0x3c, 0x5a, 0x88, 0xf3, 0x98, 0x6d, 0xab, 0x6c, 0x24, 0x14, 0x5a, 0xbc, 0x27, 0x2e, 0xf9,
+	0xe3, 0x22, 0x75, 0x27, 0xd1, 0xb1, 0x14, 0x7d, 0x04, 0x29, 0x6a, 0x1f, 0xeb, 0x47, 0xcd, 0xbc,
+	0xef, 0x01, 0x26, 0x41, 0x27, 0x4b, 0x07, 0xa1, 0xf1, 0xa3, 0xfb, 0x08, 0x79, 0x64, 0x0a, 0x57,
+	0x32, 0xc7, 0x0d, 0x1e, 0x9a, 0x29, 0x5e, 0x90, 0x7f, 0x20, 0x74, 0x8f, 0xec, 0xdc, 0x3e, 0x4c,
+	0xd3, 0xd2, 0x20, 0x73, 0x88, 0x6f, 0xfa, 0x15, 0x79, 0x05, 0x99, 0xa6, 0xcf, 0x1c, 0x77, 0xd6,
+	0x95, 0x1c, 0xfd, 0xbd, 0x4c, 0x7e, 0xdf, 0x2a, 0x88, 0x2c, 0xd1, 0xbc, 0x46, 0xda, 0x4a, 0xf2,
+	0x30, 0x55, 0x3f, 0xce, 0xbe, 0xc8, 0xe0, 0x97, 0x8b, 0x25, 0xfc, 0xe7, 0xfa, 0xd7, 0xb7, 0x01,
+	0x00, 0xc7, 0x2d, 0xb7, 0xd5, 0xfc, 0x0a, 0x00, 0x00,
 }
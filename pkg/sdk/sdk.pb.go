@@ -54,7 +54,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sdk_e2e289ce0b77ca44, []int{0}
+	return fileDescriptor_sdk_b17decd9442a137f, []int{0}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -87,7 +87,7 @@ func (m *KeyValue) Reset()         { *m = KeyValue{} }
 func (m *KeyValue) String() string { return proto.CompactTextString(m) }
 func (*KeyValue) ProtoMessage()    {}
 func (*KeyValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sdk_e2e289ce0b77ca44, []int{1}
+	return fileDescriptor_sdk_b17decd9442a137f, []int{1}
 }
 func (m *KeyValue) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KeyValue.Unmarshal(m, b)
@@ -133,7 +133,7 @@ func (m *Duration) Reset()         { *m = Duration{} }
 func (m *Duration) String() string { return proto.CompactTextString(m) }
 func (*Duration) ProtoMessage()    {}
 func (*Duration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sdk_e2e289ce0b77ca44, []int{2}
+	return fileDescriptor_sdk_b17decd9442a137f, []int{2}
 }
 func (m *Duration) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Duration.Unmarshal(m, b)
@@ -176,7 +176,7 @@ func (m *GameServer) Reset()         { *m = GameServer{} }
 func (m *GameServer) String() string { return proto.CompactTextString(m) }
 func (*GameServer) ProtoMessage()    {}
 func (*GameServer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sdk_e2e289ce0b77ca44, []int{3}
+	return fileDescriptor_sdk_b17decd9442a137f, []int{3}
 }
 func (m *GameServer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GameServer.Unmarshal(m, b)
@@ -239,7 +239,7 @@ func (m *GameServer_ObjectMeta) Reset()         { *m = GameServer_ObjectMeta{} }
 func (m *GameServer_ObjectMeta) String() string { return proto.CompactTextString(m) }
 func (*GameServer_ObjectMeta) ProtoMessage()    {}
 func (*GameServer_ObjectMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sdk_e2e289ce0b77ca44, []int{3, 0}
+	return fileDescriptor_sdk_b17decd9442a137f, []int{3, 0}
 }
 func (m *GameServer_ObjectMeta) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GameServer_ObjectMeta.Unmarshal(m, b)
@@ -333,7 +333,7 @@ func (m *GameServer_Spec) Reset()         { *m = GameServer_Spec{} }
 func (m *GameServer_Spec) String() string { return proto.CompactTextString(m) }
 func (*GameServer_Spec) ProtoMessage()    {}
 func (*GameServer_Spec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sdk_e2e289ce0b77ca44, []int{3, 1}
+	return fileDescriptor_sdk_b17decd9442a137f, []int{3, 1}
 }
 func (m *GameServer_Spec) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GameServer_Spec.Unmarshal(m, b)
@@ -374,7 +374,7 @@ func (m *GameServer_Spec_Health) Reset()         { *m = GameServer_Spec_Health{}
 func (m *GameServer_Spec_Health) String() string { return proto.CompactTextString(m) }
 func (*GameServer_Spec_Health) ProtoMessage()    {}
 func (*GameServer_Spec_Health) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sdk_e2e289ce0b77ca44, []int{3, 1, 0}
+	return fileDescriptor_sdk_b17decd9442a137f, []int{3, 1, 0}
 }
 func (m *GameServer_Spec_Health) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GameServer_Spec_Health.Unmarshal(m, b)
@@ -428,17 +428,27 @@ type GameServer_Status struct {
 	Ports   []*GameServer_Status_Port `protobuf:"bytes,3,rep,name=ports,proto3" json:"ports,omitempty"`
 	// [Stage:Alpha]
 	// [FeatureFlag:PlayerTracking]
-	Players              *GameServer_Status_PlayerStatus `protobuf:"bytes,4,opt,name=players,proto3" json:"players,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
-	XXX_unrecognized     []byte                          `json:"-"`
-	XXX_sizecache        int32                           `json:"-"`
+	Players *GameServer_Status_PlayerStatus `protobuf:"bytes,4,opt,name=players,proto3" json:"players,omitempty"`
+	// [Stage:Alpha]
+	// [FeatureFlag:CountsAndLists]
+	Counters map[string]*GameServer_Status_CounterStatus `protobuf:"bytes,5,rep,name=counters,proto3" json:"counters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// [Stage:Alpha]
+	// [FeatureFlag:CountsAndLists]
+	Lists map[string]*GameServer_Status_ListStatus `protobuf:"bytes,6,rep,name=lists,proto3" json:"lists,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// [Stage:Alpha]
+	// [FeatureFlag:SDKDrain]
+	// the unix time, in seconds, after which the draining GameServer is moved to Shutdown, or 0 if it isn't draining
+	DrainDeadline        int64    `protobuf:"varint,7,opt,name=drain_deadline,json=drainDeadline,proto3" json:"drain_deadline,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GameServer_Status) Reset()         { *m = GameServer_Status{} }
 func (m *GameServer_Status) String() string { return proto.CompactTextString(m) }
 func (*GameServer_Status) ProtoMessage()    {}
 func (*GameServer_Status) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sdk_e2e289ce0b77ca44, []int{3, 2}
+	return fileDescriptor_sdk_b17decd9442a137f, []int{3, 2}
 }
 func (m *GameServer_Status) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GameServer_Status.Unmarshal(m, b)
@@ -486,6 +496,27 @@ func (m *GameServer_Status) GetPlayers() *GameServer_Status_PlayerStatus {
 	return nil
 }
 
+func (m *GameServer_Status) GetCounters() map[string]*GameServer_Status_CounterStatus {
+	if m != nil {
+		return m.Counters
+	}
+	return nil
+}
+
+func (m *GameServer_Status) GetLists() map[string]*GameServer_Status_ListStatus {
+	if m != nil {
+		return m.Lists
+	}
+	return nil
+}
+
+func (m *GameServer_Status) GetDrainDeadline() int64 {
+	if m != nil {
+		return m.DrainDeadline
+	}
+	return 0
+}
+
 type GameServer_Status_Port struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Port                 int32    `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
@@ -498,7 +529,7 @@ func (m *GameServer_Status_Port) Reset()         { *m = GameServer_Status_Port{}
 func (m *GameServer_Status_Port) String() string { return proto.CompactTextString(m) }
 func (*GameServer_Status_Port) ProtoMessage()    {}
 func (*GameServer_Status_Port) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sdk_e2e289ce0b77ca44, []int{3, 2, 0}
+	return fileDescriptor_sdk_b17decd9442a137f, []int{3, 2, 0}
 }
 func (m *GameServer_Status_Port) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GameServer_Status_Port.Unmarshal(m, b)
@@ -547,7 +578,7 @@ func (m *GameServer_Status_PlayerStatus) Reset()         { *m = GameServer_Statu
 func (m *GameServer_Status_PlayerStatus) String() string { return proto.CompactTextString(m) }
 func (*GameServer_Status_PlayerStatus) ProtoMessage()    {}
 func (*GameServer_Status_PlayerStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_sdk_e2e289ce0b77ca44, []int{3, 2, 1}
+	return fileDescriptor_sdk_b17decd9442a137f, []int{3, 2, 1}
 }
 func (m *GameServer_Status_PlayerStatus) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GameServer_Status_PlayerStatus.Unmarshal(m, b)
@@ -588,6 +619,102 @@ func (m *GameServer_Status_PlayerStatus) GetIds() []string {
 	return nil
 }
 
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+type GameServer_Status_CounterStatus struct {
+	Count                int64    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Capacity             int64    `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GameServer_Status_CounterStatus) Reset()         { *m = GameServer_Status_CounterStatus{} }
+func (m *GameServer_Status_CounterStatus) String() string { return proto.CompactTextString(m) }
+func (*GameServer_Status_CounterStatus) ProtoMessage()    {}
+func (*GameServer_Status_CounterStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sdk_b17decd9442a137f, []int{3, 2, 2}
+}
+func (m *GameServer_Status_CounterStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GameServer_Status_CounterStatus.Unmarshal(m, b)
+}
+func (m *GameServer_Status_CounterStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GameServer_Status_CounterStatus.Marshal(b, m, deterministic)
+}
+func (dst *GameServer_Status_CounterStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GameServer_Status_CounterStatus.Merge(dst, src)
+}
+func (m *GameServer_Status_CounterStatus) XXX_Size() int {
+	return xxx_messageInfo_GameServer_Status_CounterStatus.Size(m)
+}
+func (m *GameServer_Status_CounterStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_GameServer_Status_CounterStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GameServer_Status_CounterStatus proto.InternalMessageInfo
+
+func (m *GameServer_Status_CounterStatus) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *GameServer_Status_CounterStatus) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+type GameServer_Status_ListStatus struct {
+	Capacity             int64    `protobuf:"varint,1,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Values               []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GameServer_Status_ListStatus) Reset()         { *m = GameServer_Status_ListStatus{} }
+func (m *GameServer_Status_ListStatus) String() string { return proto.CompactTextString(m) }
+func (*GameServer_Status_ListStatus) ProtoMessage()    {}
+func (*GameServer_Status_ListStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sdk_b17decd9442a137f, []int{3, 2, 3}
+}
+func (m *GameServer_Status_ListStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GameServer_Status_ListStatus.Unmarshal(m, b)
+}
+func (m *GameServer_Status_ListStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GameServer_Status_ListStatus.Marshal(b, m, deterministic)
+}
+func (dst *GameServer_Status_ListStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GameServer_Status_ListStatus.Merge(dst, src)
+}
+func (m *GameServer_Status_ListStatus) XXX_Size() int {
+	return xxx_messageInfo_GameServer_Status_ListStatus.Size(m)
+}
+func (m *GameServer_Status_ListStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_GameServer_Status_ListStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GameServer_Status_ListStatus proto.InternalMessageInfo
+
+func (m *GameServer_Status_ListStatus) GetCapacity() int64 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *GameServer_Status_ListStatus) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "agones.dev.sdk.Empty")
 	proto.RegisterType((*KeyValue)(nil), "agones.dev.sdk.KeyValue")
@@ -599,8 +726,12 @@ func init() {
 	proto.RegisterType((*GameServer_Spec)(nil), "agones.dev.sdk.GameServer.Spec")
 	proto.RegisterType((*GameServer_Spec_Health)(nil), "agones.dev.sdk.GameServer.Spec.Health")
 	proto.RegisterType((*GameServer_Status)(nil), "agones.dev.sdk.GameServer.Status")
+	proto.RegisterMapType((map[string]*GameServer_Status_CounterStatus)(nil), "agones.dev.sdk.GameServer.Status.CountersEntry")
+	proto.RegisterMapType((map[string]*GameServer_Status_ListStatus)(nil), "agones.dev.sdk.GameServer.Status.ListsEntry")
 	proto.RegisterType((*GameServer_Status_Port)(nil), "agones.dev.sdk.GameServer.Status.Port")
 	proto.RegisterType((*GameServer_Status_PlayerStatus)(nil), "agones.dev.sdk.GameServer.Status.PlayerStatus")
+	proto.RegisterType((*GameServer_Status_CounterStatus)(nil), "agones.dev.sdk.GameServer.Status.CounterStatus")
+	proto.RegisterType((*GameServer_Status_ListStatus)(nil), "agones.dev.sdk.GameServer.Status.ListStatus")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1019,65 +1150,72 @@ var _SDK_serviceDesc = grpc.ServiceDesc{
 	Metadata: "sdk.proto",
 }
 
-func init() { proto.RegisterFile("sdk.proto", fileDescriptor_sdk_e2e289ce0b77ca44) }
-
-var fileDescriptor_sdk_e2e289ce0b77ca44 = []byte{
-	// 903 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x96, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0xc7, 0xe5, 0x4d, 0xe2, 0x24, 0xc7, 0xdd, 0xaf, 0xd9, 0xad, 0xe4, 0x5a, 0x15, 0x2d, 0x16,
-	0x45, 0xcb, 0x22, 0x6c, 0x48, 0x25, 0x44, 0x57, 0xa8, 0x52, 0xd1, 0x96, 0x2e, 0x6a, 0xa1, 0xc8,
-	0xa9, 0x5a, 0xc4, 0x4d, 0x34, 0x6b, 0x1f, 0x12, 0xb3, 0x8e, 0xc7, 0x9a, 0x99, 0x6c, 0x95, 0x5b,
-	0x2e, 0x78, 0x01, 0xae, 0xb8, 0xe2, 0x05, 0x78, 0x0c, 0xde, 0x80, 0x57, 0xe0, 0x15, 0xb8, 0x47,
-	0x73, 0xc6, 0x4e, 0xc2, 0xd2, 0x6d, 0x37, 0xf4, 0x2a, 0x33, 0xe7, 0x9c, 0xff, 0x6f, 0xc6, 0xc7,
-	0xe7, 0x1c, 0x07, 0xfa, 0x2a, 0x3b, 0x8b, 0x2a, 0x29, 0xb4, 0x60, 0x5b, 0x7c, 0x2c, 0x4a, 0x54,
-	0x51, 0x86, 0xe7, 0x91, 0xca, 0xce, 0x82, 0x9b, 0x63, 0x21, 0xc6, 0x05, 0xc6, 0xbc, 0xca, 0x63,
-	0x5e, 0x96, 0x42, 0x73, 0x9d, 0x8b, 0x52, 0xd9, 0xe8, 0xb0, 0x0b, 0x9d, 0x87, 0xd3, 0x4a, 0xcf,
-	0xc3, 0x01, 0xf4, 0x1e, 0xe3, 0xfc, 0x39, 0x2f, 0x66, 0xc8, 0x76, 0xa0, 0x75, 0x86, 0x73, 0xdf,
-	0xb9, 0xed, 0x1c, 0xf4, 0x13, 0xb3, 0x64, 0xfb, 0xd0, 0x39, 0x37, 0x2e, 0x7f, 0x83, 0x6c, 0x76,
-	0x13, 0xbe, 0x07, 0xbd, 0xe3, 0x99, 0x24, 0x1e, 0xf3, 0xa1, 0xab, 0x30, 0x15, 0x65, 0xa6, 0x48,
-	0xd7, 0x4a, 0x9a, 0x6d, 0xf8, 0x1b, 0x00, 0x3c, 0xe2, 0x53, 0x1c, 0xa2, 0x3c, 0x47, 0xc9, 0xbe,
-	0x04, 0x4f, 0x9c, 0xfe, 0x88, 0xa9, 0x1e, 0x4d, 0x51, 0x73, 0x0a, 0xf6, 0x06, 0x77, 0xa2, 0x7f,
-	0xdf, 0x3a, 0x5a, 0x0a, 0xa2, 0xa7, 0x14, 0xfd, 0x35, 0x6a, 0x9e, 0x80, 0x58, 0xac, 0xd9, 0x5d,
-	0x68, 0xab, 0x0a, 0x53, 0xba, 0x91, 0x37, 0xb8, 0xf5, 0x1a, 0xc0, 0xb0, 0xc2, 0x34, 0xa1, 0x60,
-	0x76, 0x0f, 0x5c, 0xa5, 0xb9, 0x9e, 0x29, 0xbf, 0x45, 0xb2, 0x77, 0x5f, 0x27, 0xa3, 0xc0, 0xa4,
-	0x16, 0x04, 0xbf, 0xb6, 0x01, 0x96, 0x57, 0x61, 0x0c, 0xda, 0x25, 0x9f, 0x62, 0x9d, 0x24, 0x5a,
-	0xb3, 0x9b, 0xd0, 0x37, 0xbf, 0xaa, 0xe2, 0x69, 0x93, 0xa9, 0xa5, 0xc1, 0x64, 0x75, 0x96, 0x67,
-	0x74, 0x70, 0x3f, 0x31, 0x4b, 0xf6, 0x01, 0xec, 0x48, 0x54, 0x62, 0x26, 0x53, 0x1c, 0x9d, 0xa3,
-	0x54, 0xb9, 0x28, 0xfd, 0x36, 0xb9, 0xb7, 0x1b, 0xfb, 0x73, 0x6b, 0x66, 0xef, 0x00, 0x8c, 0xb1,
-	0x44, 0x9b, 0x6c, 0xbf, 0x43, 0x19, 0x5e, 0xb1, 0xb0, 0x8f, 0x80, 0xa5, 0x12, 0x69, 0x3d, 0xd2,
-	0xf9, 0x14, 0x95, 0xe6, 0xd3, 0xca, 0x77, 0x29, 0x6e, 0xb7, 0xf1, 0x3c, 0x6b, 0x1c, 0x26, 0x3c,
-	0xc3, 0x02, 0x2f, 0x84, 0x77, 0x6d, 0x78, 0xe3, 0x59, 0x86, 0x7f, 0x07, 0xde, 0x4a, 0xe9, 0xf8,
-	0xbd, 0xdb, 0xad, 0x03, 0x6f, 0xf0, 0xe9, 0x95, 0xde, 0x59, 0xf4, 0x60, 0x29, 0x7c, 0x58, 0x6a,
-	0x39, 0x4f, 0x56, 0x51, 0xec, 0x2b, 0x70, 0x0b, 0x7e, 0x8a, 0x85, 0xf2, 0xfb, 0x04, 0xfd, 0xe4,
-	0x6a, 0xd0, 0x27, 0xa4, 0xb1, 0xbc, 0x1a, 0x10, 0xdc, 0x87, 0x9d, 0x8b, 0x67, 0x5d, 0xb5, 0x92,
-	0x8f, 0x36, 0x3e, 0x73, 0x82, 0x7b, 0xe0, 0xad, 0x60, 0xd7, 0x92, 0xfe, 0xed, 0x40, 0xdb, 0x54,
-	0x19, 0xbb, 0x0f, 0xee, 0x04, 0x79, 0xa1, 0x27, 0x75, 0x5d, 0xbf, 0xff, 0x86, 0xb2, 0x8c, 0x4e,
-	0x28, 0x3a, 0xa9, 0x55, 0xc1, 0xef, 0x0e, 0xb8, 0xd6, 0xc4, 0x02, 0xe8, 0x65, 0xb9, 0xe2, 0xa7,
-	0x05, 0x66, 0x04, 0xeb, 0x25, 0x8b, 0x3d, 0xbb, 0x03, 0x5b, 0x15, 0xca, 0x5c, 0x64, 0xa3, 0xa6,
-	0xe7, 0xcc, 0x95, 0x3a, 0xc9, 0xa6, 0xb5, 0x0e, 0xad, 0x91, 0x7d, 0x08, 0xbb, 0x3f, 0xf0, 0xbc,
-	0x98, 0x49, 0x1c, 0xe9, 0x89, 0x44, 0x35, 0x11, 0x85, 0xad, 0xbf, 0x4e, 0xb2, 0x53, 0x3b, 0x9e,
-	0x35, 0x76, 0x36, 0x80, 0xeb, 0x79, 0x99, 0xeb, 0x9c, 0x17, 0xa3, 0x0c, 0x0b, 0x3e, 0x5f, 0xa0,
-	0xdb, 0x24, 0xd8, 0xab, 0x9d, 0xc7, 0xc6, 0x57, 0x1f, 0x10, 0xfc, 0xb1, 0x01, 0xae, 0x6d, 0x13,
-	0x93, 0x1c, 0xd3, 0x28, 0x4d, 0x43, 0xd8, 0x8d, 0x99, 0x0a, 0x3c, 0xcb, 0x24, 0x2a, 0x55, 0x27,
-	0xad, 0xd9, 0xb2, 0xcf, 0xa1, 0x53, 0x09, 0xa9, 0x4d, 0x23, 0xb6, 0xde, 0x94, 0x28, 0x3a, 0x21,
-	0xfa, 0x56, 0x48, 0x9d, 0x58, 0x11, 0x3b, 0x81, 0x6e, 0x55, 0xf0, 0x39, 0x4a, 0x7b, 0x3d, 0x6f,
-	0x10, 0x5d, 0x41, 0x4f, 0x82, 0xba, 0xab, 0x1b, 0x79, 0x10, 0x41, 0xdb, 0x80, 0x5f, 0xd9, 0xcf,
-	0x0c, 0xda, 0xe6, 0xb8, 0x3a, 0xb9, 0xb4, 0x0e, 0x12, 0xb8, 0xb6, 0x0a, 0x32, 0xcf, 0x9d, 0x8a,
-	0x59, 0xa9, 0xeb, 0xa9, 0x67, 0x37, 0xe6, 0xe5, 0xa5, 0xbc, 0xe2, 0x69, 0xae, 0xe7, 0xa4, 0x6e,
-	0x25, 0x8b, 0xbd, 0x29, 0xac, 0x3c, 0xb3, 0xcf, 0xdd, 0x4f, 0xcc, 0x72, 0xf0, 0xb3, 0x0b, 0xad,
-	0xe1, 0xf1, 0x63, 0x76, 0x02, 0x9d, 0x04, 0x79, 0x36, 0x67, 0xd7, 0x2f, 0x3e, 0x0d, 0xcd, 0xe8,
-	0xe0, 0xd5, 0xe6, 0x70, 0xf7, 0xa7, 0x3f, 0xff, 0xfa, 0x65, 0xc3, 0x0b, 0xdd, 0x58, 0x1a, 0xf5,
-	0x91, 0x73, 0xc8, 0xbe, 0x81, 0xde, 0x83, 0xa2, 0x10, 0xa9, 0x79, 0x07, 0xeb, 0xc1, 0xf6, 0x09,
-	0xb6, 0x15, 0xf6, 0x63, 0x5e, 0x03, 0x6a, 0xde, 0x70, 0x32, 0xd3, 0x99, 0x78, 0x59, 0xfe, 0x6f,
-	0x9e, 0xaa, 0x01, 0x86, 0xf7, 0x64, 0x51, 0xe6, 0xeb, 0xd1, 0x18, 0xd1, 0xae, 0x85, 0xdd, 0xd8,
-	0x36, 0xcc, 0x91, 0x73, 0x78, 0xe0, 0xb0, 0x17, 0xb0, 0xf9, 0x08, 0xf5, 0xca, 0x37, 0xe6, 0x12,
-	0x68, 0x70, 0x79, 0x91, 0x84, 0x7b, 0x44, 0xde, 0x64, 0x5e, 0x3c, 0x36, 0x13, 0xdb, 0x72, 0x38,
-	0x6c, 0xbf, 0xe0, 0x3a, 0x9d, 0xbc, 0x1d, 0xfa, 0x06, 0xa1, 0xf7, 0xd8, 0x6e, 0xfc, 0xd2, 0xc0,
-	0x56, 0x0e, 0xf8, 0xd8, 0xdc, 0xbd, 0x37, 0x44, 0x4d, 0x83, 0x87, 0xf9, 0x17, 0x21, 0xcd, 0x17,
-	0xf9, 0xb2, 0x74, 0x04, 0x44, 0xde, 0x0f, 0xb6, 0x63, 0xf3, 0x2d, 0xcd, 0xb8, 0xe6, 0x31, 0x0d,
-	0x43, 0x93, 0x62, 0x0e, 0x9b, 0x43, 0xd4, 0xcb, 0x89, 0xb8, 0x3e, 0xfd, 0x16, 0xd1, 0x6f, 0x04,
-	0xfb, 0x4b, 0xfa, 0x72, 0x74, 0x9b, 0x23, 0x9e, 0x42, 0x37, 0xb1, 0x4f, 0xf2, 0x5f, 0x78, 0xf3,
-	0xc7, 0xe0, 0x32, 0x78, 0x9d, 0xef, 0xb0, 0x17, 0x4b, 0x8b, 0x38, 0x72, 0x0e, 0xbf, 0xe8, 0x7c,
-	0xdf, 0x52, 0xd9, 0xd9, 0xa9, 0x4b, 0xff, 0x4d, 0xee, 0xfe, 0x13, 0x00, 0x00, 0xff, 0xff, 0x0a,
-	0xee, 0x7c, 0x21, 0xd6, 0x08, 0x00, 0x00,
+func init() { proto.RegisterFile("sdk.proto", fileDescriptor_sdk_b17decd9442a137f) }
+
+var fileDescriptor_sdk_b17decd9442a137f = []byte{
+	// 1019 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x96, 0x5f, 0x6f, 0x1b, 0x45,
+	0x10, 0xc0, 0x75, 0xb1, 0xef, 0x6c, 0x8f, 0xeb, 0xfc, 0xd9, 0xa4, 0xe8, 0x7a, 0xaa, 0x68, 0x39,
+	0x51, 0x14, 0x02, 0xdc, 0x81, 0x2b, 0x21, 0x1a, 0xa1, 0x8a, 0x84, 0x84, 0x06, 0x25, 0x50, 0x74,
+	0xae, 0x5a, 0xc4, 0x8b, 0xb5, 0xb9, 0x9d, 0xc6, 0x47, 0xce, 0x77, 0xa7, 0xdb, 0x75, 0x2a, 0xbf,
+	0xf2, 0xc0, 0x17, 0xe0, 0x89, 0xef, 0xc0, 0x13, 0x5f, 0x85, 0x07, 0xbe, 0x00, 0x5f, 0x81, 0x77,
+	0xb4, 0x7f, 0xce, 0xbe, 0xa6, 0x75, 0x13, 0x97, 0x27, 0xef, 0xcc, 0xce, 0xfc, 0x66, 0x3c, 0x3b,
+	0x73, 0xbb, 0xd0, 0xe1, 0xec, 0x3c, 0x28, 0xca, 0x5c, 0xe4, 0x64, 0x95, 0x9e, 0xe5, 0x19, 0xf2,
+	0x80, 0xe1, 0x45, 0xc0, 0xd9, 0xb9, 0x77, 0xfb, 0x2c, 0xcf, 0xcf, 0x52, 0x0c, 0x69, 0x91, 0x84,
+	0x34, 0xcb, 0x72, 0x41, 0x45, 0x92, 0x67, 0x5c, 0x5b, 0xfb, 0x2d, 0xb0, 0x0f, 0xc7, 0x85, 0x98,
+	0xfa, 0x7d, 0x68, 0x1f, 0xe3, 0xf4, 0x29, 0x4d, 0x27, 0x48, 0xd6, 0xa1, 0x71, 0x8e, 0x53, 0xd7,
+	0xba, 0x6b, 0x6d, 0x77, 0x22, 0xb9, 0x24, 0x5b, 0x60, 0x5f, 0xc8, 0x2d, 0x77, 0x45, 0xe9, 0xb4,
+	0xe0, 0xbf, 0x0f, 0xed, 0x83, 0x49, 0xa9, 0x78, 0xc4, 0x85, 0x16, 0xc7, 0x38, 0xcf, 0x18, 0x57,
+	0x7e, 0x8d, 0xa8, 0x12, 0xfd, 0x3f, 0x57, 0x01, 0x1e, 0xd1, 0x31, 0x0e, 0xb0, 0xbc, 0xc0, 0x92,
+	0x7c, 0x03, 0xdd, 0xfc, 0xf4, 0x67, 0x8c, 0xc5, 0x70, 0x8c, 0x82, 0x2a, 0xe3, 0x6e, 0xff, 0x5e,
+	0xf0, 0x72, 0xd6, 0xc1, 0xdc, 0x21, 0x78, 0xac, 0xac, 0xbf, 0x43, 0x41, 0x23, 0xc8, 0x67, 0x6b,
+	0x72, 0x1f, 0x9a, 0xbc, 0xc0, 0x58, 0x65, 0xd4, 0xed, 0xdf, 0x79, 0x03, 0x60, 0x50, 0x60, 0x1c,
+	0x29, 0x63, 0xf2, 0x00, 0x1c, 0x2e, 0xa8, 0x98, 0x70, 0xb7, 0xa1, 0xdc, 0xde, 0x7b, 0x93, 0x9b,
+	0x32, 0x8c, 0x8c, 0x83, 0xf7, 0x7b, 0x13, 0x60, 0x9e, 0x0a, 0x21, 0xd0, 0xcc, 0xe8, 0x18, 0x4d,
+	0x91, 0xd4, 0x9a, 0xdc, 0x86, 0x8e, 0xfc, 0xe5, 0x05, 0x8d, 0xab, 0x4a, 0xcd, 0x15, 0xb2, 0xaa,
+	0x93, 0x84, 0xa9, 0xc0, 0x9d, 0x48, 0x2e, 0xc9, 0x87, 0xb0, 0x5e, 0x22, 0xcf, 0x27, 0x65, 0x8c,
+	0xc3, 0x0b, 0x2c, 0x79, 0x92, 0x67, 0x6e, 0x53, 0x6d, 0xaf, 0x55, 0xfa, 0xa7, 0x5a, 0x4d, 0xde,
+	0x05, 0x38, 0xc3, 0x0c, 0x75, 0xb1, 0x5d, 0x5b, 0x55, 0xb8, 0xa6, 0x21, 0x9f, 0x00, 0x89, 0x4b,
+	0x54, 0xeb, 0xa1, 0x48, 0xc6, 0xc8, 0x05, 0x1d, 0x17, 0xae, 0xa3, 0xec, 0x36, 0xaa, 0x9d, 0x27,
+	0xd5, 0x86, 0x34, 0x67, 0x98, 0xe2, 0x25, 0xf3, 0x96, 0x36, 0xaf, 0x76, 0xe6, 0xe6, 0x3f, 0x42,
+	0xb7, 0xd6, 0x3a, 0x6e, 0xfb, 0x6e, 0x63, 0xbb, 0xdb, 0xff, 0xfc, 0x5a, 0x67, 0x16, 0xec, 0xcd,
+	0x1d, 0x0f, 0x33, 0x51, 0x4e, 0xa3, 0x3a, 0x8a, 0x7c, 0x0b, 0x4e, 0x4a, 0x4f, 0x31, 0xe5, 0x6e,
+	0x47, 0x41, 0x3f, 0xbb, 0x1e, 0xf4, 0x44, 0xf9, 0x68, 0x9e, 0x01, 0x78, 0x0f, 0x61, 0xfd, 0x72,
+	0xac, 0xeb, 0x76, 0xf2, 0xee, 0xca, 0x17, 0x96, 0xf7, 0x00, 0xba, 0x35, 0xec, 0x52, 0xae, 0xff,
+	0x5a, 0xd0, 0x94, 0x5d, 0x46, 0x1e, 0x82, 0x33, 0x42, 0x9a, 0x8a, 0x91, 0xe9, 0xeb, 0x0f, 0xae,
+	0x68, 0xcb, 0xe0, 0x48, 0x59, 0x47, 0xc6, 0xcb, 0xfb, 0xc3, 0x02, 0x47, 0xab, 0x88, 0x07, 0x6d,
+	0x96, 0x70, 0x7a, 0x9a, 0x22, 0x53, 0xb0, 0x76, 0x34, 0x93, 0xc9, 0x3d, 0x58, 0x2d, 0xb0, 0x4c,
+	0x72, 0x36, 0xac, 0x66, 0x4e, 0xa6, 0x64, 0x47, 0x3d, 0xad, 0x1d, 0x68, 0x25, 0xf9, 0x08, 0x36,
+	0x9e, 0xd3, 0x24, 0x9d, 0x94, 0x38, 0x14, 0xa3, 0x12, 0xf9, 0x28, 0x4f, 0x75, 0xff, 0xd9, 0xd1,
+	0xba, 0xd9, 0x78, 0x52, 0xe9, 0x49, 0x1f, 0x6e, 0x26, 0x59, 0x22, 0x12, 0x9a, 0x0e, 0x19, 0xa6,
+	0x74, 0x3a, 0x43, 0x37, 0x95, 0xc3, 0xa6, 0xd9, 0x3c, 0x90, 0x7b, 0x26, 0x80, 0xf7, 0xb7, 0x03,
+	0x8e, 0x1e, 0x13, 0x59, 0x1c, 0x39, 0x28, 0xd5, 0x40, 0x68, 0x41, 0x7e, 0x15, 0x28, 0x63, 0x25,
+	0x72, 0x6e, 0x8a, 0x56, 0x89, 0xe4, 0x4b, 0xb0, 0x8b, 0xbc, 0x14, 0x72, 0x10, 0x1b, 0x57, 0x15,
+	0x4a, 0x45, 0x08, 0x7e, 0xc8, 0x4b, 0x11, 0x69, 0x27, 0x72, 0x04, 0xad, 0x22, 0xa5, 0x53, 0x2c,
+	0x75, 0x7a, 0xdd, 0x7e, 0x70, 0x0d, 0x7f, 0xe5, 0xa0, 0x85, 0xa8, 0x72, 0x27, 0xc7, 0xd0, 0x8e,
+	0xf3, 0x49, 0x26, 0x24, 0xca, 0x56, 0xa9, 0x84, 0x57, 0xa3, 0xbe, 0x36, 0x1e, 0xba, 0x01, 0x67,
+	0x00, 0xb2, 0x0f, 0x76, 0x9a, 0x70, 0xc1, 0x5d, 0x47, 0x91, 0x3e, 0xbe, 0x9a, 0x74, 0x22, 0xcd,
+	0x35, 0x46, 0xbb, 0xca, 0xb3, 0x65, 0x25, 0x4d, 0xb2, 0x21, 0x43, 0xca, 0xd2, 0x24, 0x43, 0x33,
+	0x96, 0x3d, 0xa5, 0x3d, 0x30, 0x4a, 0x2f, 0x80, 0xa6, 0x2c, 0xc8, 0x6b, 0xbf, 0x43, 0x04, 0x9a,
+	0xb2, 0x4c, 0xa6, 0x29, 0xd4, 0xda, 0x8b, 0xe0, 0x46, 0xbd, 0x00, 0xf2, 0xbc, 0x54, 0xda, 0xe6,
+	0x6b, 0xad, 0x05, 0xd9, 0x74, 0x31, 0x2d, 0x68, 0x9c, 0x88, 0xa9, 0xf2, 0x6e, 0x44, 0x33, 0x59,
+	0x0e, 0x44, 0xc2, 0xf4, 0x79, 0x75, 0x22, 0xb9, 0xf4, 0xf6, 0xa0, 0x67, 0x2a, 0xf1, 0xb6, 0x50,
+	0xef, 0x2b, 0x00, 0x59, 0x02, 0xe3, 0x5f, 0xb7, 0xb4, 0x2e, 0x85, 0x7f, 0x07, 0x1c, 0x35, 0x70,
+	0xb2, 0x93, 0x64, 0x06, 0x46, 0xf2, 0xd2, 0x59, 0x12, 0x0b, 0x07, 0xf7, 0xb0, 0x3e, 0xb8, 0xcb,
+	0x1c, 0xb0, 0x96, 0xea, 0x93, 0xfe, 0x5c, 0xe7, 0xbb, 0x30, 0xd4, 0xfe, 0xcb, 0xa1, 0xae, 0xd9,
+	0x01, 0xaf, 0xc4, 0xe9, 0xff, 0xea, 0x40, 0x63, 0x70, 0x70, 0x4c, 0x8e, 0xc0, 0x8e, 0x90, 0xb2,
+	0x29, 0xb9, 0x79, 0x99, 0xa4, 0xae, 0x6d, 0xef, 0xf5, 0x6a, 0x7f, 0xe3, 0x97, 0xbf, 0xfe, 0xf9,
+	0x6d, 0xa5, 0xeb, 0x3b, 0x61, 0x29, 0xbd, 0x77, 0xad, 0x1d, 0xf2, 0x3d, 0xb4, 0xf7, 0xd2, 0x34,
+	0x8f, 0xe5, 0x58, 0x2e, 0x07, 0xdb, 0x52, 0xb0, 0x55, 0xbf, 0x13, 0x52, 0x03, 0x30, 0xbc, 0xc1,
+	0x68, 0x22, 0x58, 0xfe, 0x22, 0x7b, 0x6b, 0x1e, 0x37, 0x00, 0xc9, 0x3b, 0x99, 0x7d, 0xf9, 0x96,
+	0xa3, 0x11, 0x45, 0xbb, 0xe1, 0xb7, 0x42, 0xfd, 0x0d, 0xdd, 0xb5, 0x76, 0xb6, 0x2d, 0xf2, 0x0c,
+	0x7a, 0x8f, 0x50, 0xd4, 0x9e, 0x1d, 0x0b, 0xa0, 0xde, 0xe2, 0x03, 0xf2, 0x37, 0x15, 0xb9, 0x47,
+	0xba, 0xe1, 0x99, 0xbc, 0xc4, 0x35, 0x87, 0xc2, 0xda, 0x33, 0x2a, 0xe2, 0xd1, 0xff, 0x43, 0xdf,
+	0x52, 0xe8, 0x4d, 0xb2, 0x11, 0xbe, 0x90, 0xb0, 0x5a, 0x80, 0x4f, 0x65, 0xee, 0xed, 0x01, 0x0a,
+	0x75, 0x17, 0x11, 0xf7, 0x32, 0xa4, 0x7a, 0xa4, 0x2d, 0x2a, 0x87, 0xa7, 0xc8, 0x5b, 0xde, 0x5a,
+	0x28, 0x9f, 0x57, 0x8c, 0x0a, 0x1a, 0xaa, 0xfb, 0x51, 0x96, 0x98, 0x42, 0x6f, 0x80, 0x62, 0x7e,
+	0x49, 0x2e, 0x4f, 0xbf, 0xa3, 0xe8, 0xb7, 0xbc, 0xad, 0x39, 0x7d, 0x7e, 0x9b, 0xcb, 0x10, 0x8f,
+	0xa1, 0x15, 0xe9, 0x7f, 0xf2, 0x2a, 0xbc, 0x7a, 0x2b, 0x2e, 0x82, 0x9b, 0x7a, 0xfb, 0xed, 0xb0,
+	0xd4, 0x88, 0x5d, 0x6b, 0x67, 0xdf, 0xfe, 0xa9, 0xc1, 0xd9, 0xf9, 0xa9, 0xa3, 0x9e, 0xab, 0xf7,
+	0xff, 0x1b, 0x00, 0x39, 0xca, 0x21, 0x8e, 0xe9, 0x0a, 0x00, 0x00,
 }
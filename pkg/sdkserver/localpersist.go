@@ -0,0 +1,153 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdkserver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
+	"agones.dev/agones/pkg/sdk"
+	"agones.dev/agones/pkg/util/runtime"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// SetPersistFilePath makes the GameServer persist across restarts of the local SDK server: if the yaml or json
+// file at filePath exists, the GameServer is resumed from it, and the GameServer is saved to it after each change.
+// The file is written as json if its extension is .json, and as yaml otherwise.
+func (l *LocalSDKServer) SetPersistFilePath(filePath string) error {
+	gs, err := readPersistedGameServer(filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error reading persisted GameServer from %s", filePath)
+	}
+
+	l.gsMutex.Lock()
+	defer l.gsMutex.Unlock()
+	if l.persistFilePath == "" {
+		go l.runPersist()
+	}
+	l.persistFilePath = filePath
+	if gs == nil {
+		l.logger.WithField("persistFilePath", filePath).Info("No persisted GameServer, GameServer will be persisted on change")
+		return nil
+	}
+
+	l.logger.WithField("persistFilePath", filePath).Info("Resuming persisted GameServer")
+	l.gs = gs
+	l.gsState = agonesv1.GameServerState(gs.Status.State)
+	return nil
+}
+
+// readPersistedGameServer reads the sdk.GameServer persisted in the yaml or json file at filePath
+func readPersistedGameServer(filePath string) (*sdk.GameServer, error) {
+	reader, err := os.Open(filePath) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close() // nolint: errcheck
+
+	gs := &sdk.GameServer{}
+	if err := yaml.NewYAMLOrJSONDecoder(reader, 4096).Decode(gs); err != nil {
+		return nil, err
+	}
+
+	// a GameServer without some of its parts can't be served, so they start from the default GameServer
+	def := defaultGs()
+	if gs.ObjectMeta == nil {
+		gs.ObjectMeta = def.ObjectMeta
+	}
+	if gs.Spec == nil {
+		gs.Spec = def.Spec
+	}
+	if gs.Status == nil {
+		gs.Status = def.Status
+	}
+	if runtime.FeatureEnabled(runtime.FeaturePlayerTracking) && gs.Status.Players == nil {
+		gs.Status.Players = &sdk.GameServer_Status_PlayerStatus{}
+	}
+	return gs, nil
+}
+
+// requestPersist asks for the GameServer to be persisted, without blocking.
+// Updates are sent while the GameServer lock is held, so the update dispatch can't wait on that lock itself.
+func (l *LocalSDKServer) requestPersist() {
+	select {
+	case l.persistPending <- struct{}{}:
+	default:
+		// a persist is already pending, and it will pick up this change as well
+	}
+}
+
+// runPersist persists the GameServer each time it is requested, until the server is closed
+func (l *LocalSDKServer) runPersist() {
+	for {
+		select {
+		case <-l.persistPending:
+			l.persist()
+		case <-l.stop:
+			return
+		}
+	}
+}
+
+// persist saves the GameServer to the persist file, if one is set.
+// The file is replaced as a whole, so it is never left half written if the process exits.
+func (l *LocalSDKServer) persist() {
+	l.gsMutex.RLock()
+	filePath := l.persistFilePath
+	if filePath == "" {
+		l.gsMutex.RUnlock()
+		return
+	}
+	b, err := json.Marshal(l.gs)
+	l.gsMutex.RUnlock()
+	if err != nil {
+		l.logger.WithError(err).Error("error marshalling GameServer to persist")
+		return
+	}
+
+	if !strings.EqualFold(filepath.Ext(filePath), ".json") {
+		if b, err = sigsyaml.JSONToYAML(b); err != nil {
+			l.logger.WithError(err).Error("error converting GameServer to yaml to persist")
+			return
+		}
+	}
+	if err := writeFileAtomic(filePath, b); err != nil {
+		l.logger.WithError(err).WithField("persistFilePath", filePath).Error("error persisting GameServer")
+	}
+}
+
+// writeFileAtomic writes data to a temporary file next to filePath, and then renames it to filePath
+func writeFileAtomic(filePath string, data []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(filePath), "."+filepath.Base(filePath)+"-")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()           // nolint: errcheck,gosec
+		os.Remove(file.Name()) // nolint: errcheck,gosec
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name()) // nolint: errcheck,gosec
+		return err
+	}
+	return os.Rename(file.Name(), filePath)
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdkserver
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
+	"agones.dev/agones/pkg/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestLocalSDKServerPersist(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir(os.TempDir(), "persist-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	for _, name := range []string{"gameserver.yaml", "gameserver.json"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			ctx := context.Background()
+
+			l, err := NewLocalSDKServer("")
+			require.NoError(t, err)
+			l.SetStrictMode(true)
+			require.NoError(t, l.SetPersistFilePath(path))
+			_, err = os.Stat(path)
+			assert.True(t, os.IsNotExist(err), "nothing is persisted until the GameServer changes")
+
+			_, err = l.SetLabel(ctx, &sdk.KeyValue{Key: "foo", Value: "bar"})
+			assert.NoError(t, err)
+			_, err = l.Reserve(ctx, &sdk.Duration{})
+			assert.NoError(t, err)
+
+			var persisted *sdk.GameServer
+			err = wait.PollImmediate(100*time.Millisecond, 10*time.Second, func() (bool, error) {
+				persisted, err = readPersistedGameServer(path)
+				if err != nil {
+					return false, nil
+				}
+				return persisted.Status.State == string(agonesv1.GameServerStateReserved), nil
+			})
+			require.NoError(t, err, "GameServer should be persisted")
+			assert.Equal(t, "bar", persisted.ObjectMeta.Labels[metadataPrefix+"foo"])
+			l.Close()
+
+			// a restarted local SDK server resumes from the persisted GameServer
+			l, err = NewLocalSDKServer("")
+			require.NoError(t, err)
+			l.SetStrictMode(true)
+			require.NoError(t, l.SetPersistFilePath(path))
+			defer l.Close()
+
+			gs, err := l.GetGameServer(ctx, &sdk.Empty{})
+			assert.NoError(t, err)
+			assert.Equal(t, "bar", gs.ObjectMeta.Labels[metadataPrefix+"foo"])
+			assert.Equal(t, "true", gs.ObjectMeta.Labels["islocal"])
+			assertStrictState(t, l, agonesv1.GameServerStateReserved)
+
+			// the resumed state follows the GameServer lifecycle
+			_, err = l.Allocate(ctx, &sdk.Empty{})
+			assert.NoError(t, err)
+			assertStrictState(t, l, agonesv1.GameServerStateAllocated)
+		})
+	}
+}
+
+func TestLocalSDKServerPersistInvalid(t *testing.T) {
+	t.Parallel()
+
+	file, err := ioutil.TempFile(os.TempDir(), "persist-")
+	require.NoError(t, err)
+	defer os.Remove(file.Name()) // nolint: errcheck
+	_, err = file.WriteString("{not json")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	l, err := NewLocalSDKServer("")
+	require.NoError(t, err)
+	err = l.SetPersistFilePath(file.Name())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error reading persisted GameServer from "+file.Name())
+}
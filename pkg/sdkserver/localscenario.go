@@ -0,0 +1,314 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdkserver
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// scenarioRequests are the SDK requests that a scenario can inject faults into
+var scenarioRequests = map[string]bool{
+	"ready":         true,
+	"allocate":      true,
+	"shutdown":      true,
+	"health":        true,
+	"setlabel":      true,
+	"setannotation": true,
+	"gameserver":    true,
+	"watch":         true,
+	"reserve":       true,
+}
+
+// Scenario is a script run by the local SDK server, to test how a game server handles failures:
+// faults are injected into its SDK requests, and its GameServer is changed from outside, on a schedule
+type Scenario struct {
+	// Faults are the failures injected into the SDK requests of the game server
+	Faults []ScenarioFault `json:"faults,omitempty"`
+	// Events are the changes made to the GameServer from outside of the game server
+	Events []ScenarioEvent `json:"events,omitempty"`
+	// Expected is the list of SDK requests the game server is expected to make during the scenario
+	Expected []string `json:"expected,omitempty"`
+}
+
+// ScenarioFault is a failure injected into an SDK request, from AfterSeconds to UntilSeconds
+// after the start of the scenario
+type ScenarioFault struct {
+	// Request is the name of the SDK request, e.g. ready, setlabel or watch
+	Request string `json:"request"`
+	// AfterSeconds is when the fault starts to be injected
+	AfterSeconds int64 `json:"afterSeconds,omitempty"`
+	// UntilSeconds is when the fault stops being injected. Defaults to the end of the scenario.
+	UntilSeconds int64 `json:"untilSeconds,omitempty"`
+	// Times is how many requests the fault is injected into. Defaults to every request.
+	Times int64 `json:"times,omitempty"`
+	// DelaySeconds delays the request
+	DelaySeconds int64 `json:"delaySeconds,omitempty"`
+	// Error fails the request with this message, after any delay.
+	// For the health and watch requests, the stream is dropped with the error.
+	Error string `json:"error,omitempty"`
+}
+
+// ScenarioEvent is a change made to the GameServer from outside of the game server,
+// AtSeconds after the start of the scenario. Only one change can be set per event.
+type ScenarioEvent struct {
+	// AtSeconds is when the event happens
+	AtSeconds int64 `json:"atSeconds,omitempty"`
+	// Allocation allocates the GameServer as a GameServerAllocation with this spec would
+	Allocation *allocationv1.GameServerAllocationSpec `json:"allocation,omitempty"`
+	// MetaPatch applies these labels and annotations to the GameServer
+	MetaPatch *allocationv1.MetaPatch `json:"metaPatch,omitempty"`
+	// DropWatch drops the open WatchGameServer streams with an error
+	DropWatch bool `json:"dropWatch,omitempty"`
+}
+
+// scenarioRun is the state of the Scenario being run by the local SDK server
+type scenarioRun struct {
+	mu       sync.Mutex
+	scenario Scenario
+	start    time.Time
+	// injected is the number of requests each fault was injected into
+	injected []int64
+	// results is the result of each event, or empty if it hasn't happened yet
+	results  []string
+	requests []string
+}
+
+// Validate validates the Scenario, and returns an error describing the first issue found
+func (s *Scenario) Validate() error {
+	for i, f := range s.Faults {
+		if !scenarioRequests[f.Request] {
+			return errors.Errorf("faults[%d]: request %q does not support faults", i, f.Request)
+		}
+		if f.AfterSeconds < 0 || f.UntilSeconds < 0 || f.Times < 0 || f.DelaySeconds < 0 {
+			return errors.Errorf("faults[%d]: values must be greater than or equal to 0", i)
+		}
+		if f.UntilSeconds != 0 && f.UntilSeconds <= f.AfterSeconds {
+			return errors.Errorf("faults[%d]: untilSeconds must be greater than afterSeconds", i)
+		}
+		if f.DelaySeconds == 0 && f.Error == "" {
+			return errors.Errorf("faults[%d]: delaySeconds or error must be set", i)
+		}
+	}
+	for i, e := range s.Events {
+		if e.AtSeconds < 0 {
+			return errors.Errorf("events[%d]: atSeconds must be greater than or equal to 0", i)
+		}
+		changes := 0
+		if e.Allocation != nil {
+			changes++
+		}
+		if e.MetaPatch != nil {
+			changes++
+		}
+		if e.DropWatch {
+			changes++
+		}
+		if changes != 1 {
+			return errors.Errorf("events[%d]: exactly one of allocation, metaPatch or dropWatch must be set", i)
+		}
+	}
+	return nil
+}
+
+// SetScenario reads the Scenario in the yaml or json file at filePath, and starts running it
+func (l *LocalSDKServer) SetScenario(filePath string) error {
+	l.logger.WithField("filePath", filePath).Info("Reading Scenario")
+
+	reader, err := os.Open(filePath) // nolint: gosec
+	if err != nil {
+		return err
+	}
+	defer reader.Close() // nolint: errcheck
+
+	var s Scenario
+	if err := yaml.NewYAMLOrJSONDecoder(reader, 4096).Decode(&s); err != nil {
+		return errors.Wrap(err, "error decoding Scenario")
+	}
+	if err := s.Validate(); err != nil {
+		return errors.Wrap(err, "Scenario is invalid")
+	}
+
+	l.runScenario(s)
+	return nil
+}
+
+// runScenario starts running the Scenario, and schedules its events
+func (l *LocalSDKServer) runScenario(s Scenario) {
+	run := &scenarioRun{
+		scenario: s,
+		start:    l.clock.Now(),
+		injected: make([]int64, len(s.Faults)),
+		results:  make([]string, len(s.Events)),
+	}
+	l.scenarioMutex.Lock()
+	l.scenario = run
+	l.scenarioMutex.Unlock()
+
+	for i := range s.Events {
+		i := i
+		after := l.clock.After(time.Duration(s.Events[i].AtSeconds) * time.Second)
+		go func() {
+			select {
+			case <-after:
+				l.runScenarioEvent(run, i)
+			case <-l.stop:
+			}
+		}()
+	}
+}
+
+// runScenarioEvent makes the change of an event of the scenario to the GameServer, and records its result
+func (l *LocalSDKServer) runScenarioEvent(run *scenarioRun, i int) {
+	e := run.scenario.Events[i]
+	logger := l.logger.WithField("event", i)
+
+	var result string
+	switch {
+	case e.Allocation != nil:
+		gsa := &allocationv1.GameServerAllocation{Spec: *e.Allocation.DeepCopy()}
+		gsa.ApplyDefaults()
+		l.allocate(gsa)
+		result = string(gsa.Status.State)
+	case e.MetaPatch != nil:
+		l.gsMutex.Lock()
+		l.applyMetaPatch(*e.MetaPatch)
+		l.update <- struct{}{}
+		l.gsMutex.Unlock()
+		result = "Applied"
+	case e.DropWatch:
+		l.gsMutex.Lock()
+		close(l.dropWatch)
+		l.dropWatch = make(chan struct{})
+		l.gsMutex.Unlock()
+		result = "Dropped"
+	}
+
+	logger.WithField("result", result).Info("Scenario event happened")
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	run.results[i] = result
+}
+
+// injectFault injects the active faults of the scenario into a request: it delays the request,
+// and returns the error the request should fail with, if any.
+// Should be called without the gsMutex locked, as the request may be delayed.
+func (l *LocalSDKServer) injectFault(request string) error {
+	run := l.currentScenario()
+	if run == nil {
+		return nil
+	}
+
+	delay, err := run.fault(request, l.clock.Now())
+	if delay > 0 {
+		l.logger.WithField("request", request).WithField("delay", delay).Info("Delaying request")
+		l.clock.Sleep(delay)
+	}
+	if err != nil {
+		l.logger.WithField("request", request).WithError(err).Info("Failing request")
+	}
+	return err
+}
+
+// currentScenario returns the scenario being run, if any
+func (l *LocalSDKServer) currentScenario() *scenarioRun {
+	l.scenarioMutex.RLock()
+	defer l.scenarioMutex.RUnlock()
+	return l.scenario
+}
+
+// recordScenarioRequest records that the game server made a request during the scenario, if any
+func (l *LocalSDKServer) recordScenarioRequest(request string) {
+	if run := l.currentScenario(); run != nil {
+		run.record(request)
+	}
+}
+
+// fault returns the delay and the error of the faults that are active for the request at the given time,
+// and counts them as injected
+func (r *scenarioRun) fault(request string, now time.Time) (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	elapsed := now.Sub(r.start)
+	var delay time.Duration
+	var err error
+	for i, f := range r.scenario.Faults {
+		if f.Request != request || elapsed < time.Duration(f.AfterSeconds)*time.Second {
+			continue
+		}
+		if f.UntilSeconds != 0 && elapsed >= time.Duration(f.UntilSeconds)*time.Second {
+			continue
+		}
+		if f.Times != 0 && r.injected[i] >= f.Times {
+			continue
+		}
+		r.injected[i]++
+		delay += time.Duration(f.DelaySeconds) * time.Second
+		if err == nil && f.Error != "" {
+			err = errors.New(f.Error)
+		}
+	}
+	return delay, err
+}
+
+// record records that the game server made a request during the scenario
+func (r *scenarioRun) record(request string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, request)
+}
+
+// reportScenario logs the report of the scenario, and returns whether it passed: every event happened, and
+// the game server made the expected requests. Returns true if there is no scenario.
+func (l *LocalSDKServer) reportScenario() bool {
+	run := l.currentScenario()
+	if run == nil {
+		return true
+	}
+
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	passed := true
+	for i, f := range run.scenario.Faults {
+		l.logger.WithField("fault", i).WithField("request", f.Request).WithField("injected", run.injected[i]).
+			Info("Scenario fault report")
+	}
+	for i, result := range run.results {
+		if result == "" {
+			l.logger.WithField("event", i).Error("Scenario event did not happen")
+			passed = false
+			continue
+		}
+		l.logger.WithField("event", i).WithField("result", result).Info("Scenario event report")
+	}
+	if len(run.scenario.Expected) > 0 && !l.EqualSets(run.scenario.Expected, run.requests) {
+		l.logger.WithField("expected", run.scenario.Expected).WithField("received", run.requests).
+			Error("Requests do not match the expected requests of the Scenario")
+		passed = false
+	}
+
+	if passed {
+		l.logger.Info("Scenario passed")
+	} else {
+		l.logger.Error("Scenario failed")
+	}
+	return passed
+}
@@ -0,0 +1,191 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdkserver
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
+	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
+	"agones.dev/agones/pkg/sdk"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestScenarioValidate(t *testing.T) {
+	t.Parallel()
+
+	fixtures := map[string]struct {
+		scenario Scenario
+		err      string
+	}{
+		"valid": {
+			scenario: Scenario{
+				Faults: []ScenarioFault{{Request: "ready", DelaySeconds: 5}, {Request: "watch", Error: "dropped", AfterSeconds: 5, UntilSeconds: 10}},
+				Events: []ScenarioEvent{{AtSeconds: 10, DropWatch: true}},
+			},
+		},
+		"unsupported request": {
+			scenario: Scenario{Faults: []ScenarioFault{{Request: "playerconnect", Error: "failed"}}},
+			err:      `faults[0]: request "playerconnect" does not support faults`,
+		},
+		"negative value": {
+			scenario: Scenario{Faults: []ScenarioFault{{Request: "ready", Error: "failed", Times: -1}}},
+			err:      "faults[0]: values must be greater than or equal to 0",
+		},
+		"until before after": {
+			scenario: Scenario{Faults: []ScenarioFault{{Request: "ready", Error: "failed", AfterSeconds: 10, UntilSeconds: 5}}},
+			err:      "faults[0]: untilSeconds must be greater than afterSeconds",
+		},
+		"no failure": {
+			scenario: Scenario{Faults: []ScenarioFault{{Request: "ready"}}},
+			err:      "faults[0]: delaySeconds or error must be set",
+		},
+		"no change": {
+			scenario: Scenario{Events: []ScenarioEvent{{AtSeconds: 10}}},
+			err:      "events[0]: exactly one of allocation, metaPatch or dropWatch must be set",
+		},
+		"two changes": {
+			scenario: Scenario{Events: []ScenarioEvent{{DropWatch: true, MetaPatch: &allocationv1.MetaPatch{}}}},
+			err:      "events[0]: exactly one of allocation, metaPatch or dropWatch must be set",
+		},
+	}
+
+	for k, v := range fixtures {
+		v := v
+		t.Run(k, func(t *testing.T) {
+			err := v.scenario.Validate()
+			if v.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, v.err)
+			}
+		})
+	}
+}
+
+func TestLocalSDKServerScenario(t *testing.T) {
+	t.Parallel()
+
+	path, err := scenarioToTmpFile(`
+faults:
+- request: setlabel
+  error: label failure
+  times: 1
+- request: ready
+  delaySeconds: 5
+events:
+- atSeconds: 30
+  allocation:
+    required:
+      matchLabels:
+        islocal: "true"
+- atSeconds: 40
+  metaPatch:
+    labels:
+      patched: "true"
+- atSeconds: 50
+  dropWatch: true
+expected: [setlabel, ready, watch]
+`)
+	assert.NoError(t, err)
+
+	l, err := NewLocalSDKServer("")
+	assert.NoError(t, err)
+	fc := clock.NewFakeClock(time.Now())
+	l.clock = fc
+	assert.NoError(t, l.SetScenario(path))
+
+	ctx := context.Background()
+	e := &sdk.Empty{}
+	_, err = l.SetLabel(ctx, &sdk.KeyValue{Key: "foo", Value: "bar"})
+	assert.EqualError(t, err, "label failure")
+	_, err = l.SetLabel(ctx, &sdk.KeyValue{Key: "foo", Value: "bar"})
+	assert.NoError(t, err)
+
+	_, err = l.Ready(ctx, e)
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, fc.Since(l.scenario.start))
+
+	stream := newGameServerMockStream()
+	watchErr := make(chan error)
+	go func() {
+		watchErr <- l.WatchGameServer(e, stream)
+	}()
+	// wait for watching to begin
+	err = wait.Poll(time.Second, 10*time.Second, func() (bool, error) {
+		found := false
+		l.updateObservers.Range(func(_, _ interface{}) bool {
+			found = true
+			return false
+		})
+		return found, nil
+	})
+	assert.NoError(t, err)
+
+	fc.Step(25 * time.Second)
+	assertWatchUpdate(t, stream, string(agonesv1.GameServerStateAllocated), func(gs *sdk.GameServer) interface{} {
+		return gs.Status.State
+	})
+
+	fc.Step(10 * time.Second)
+	assertWatchUpdate(t, stream, "true", func(gs *sdk.GameServer) interface{} {
+		return gs.ObjectMeta.Labels["patched"]
+	})
+
+	fc.Step(10 * time.Second)
+	select {
+	case err := <-watchErr:
+		assert.EqualError(t, err, "WatchGameServer stream dropped by the Scenario")
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "watch stream should be dropped")
+	}
+
+	assert.True(t, l.reportScenario())
+	assert.Equal(t, []int64{1, 1}, l.scenario.injected)
+	assert.Equal(t, []string{"Allocated", "Applied", "Dropped"}, l.scenario.results)
+}
+
+func TestLocalSDKServerScenarioFailed(t *testing.T) {
+	t.Parallel()
+
+	l, err := NewLocalSDKServer("")
+	assert.NoError(t, err)
+	l.runScenario(Scenario{Expected: []string{"ready"}})
+	assert.False(t, l.reportScenario(), "ready was not requested")
+
+	_, err = l.Ready(context.Background(), &sdk.Empty{})
+	assert.NoError(t, err)
+	assert.True(t, l.reportScenario())
+
+	l.runScenario(Scenario{Events: []ScenarioEvent{{AtSeconds: 3600, DropWatch: true}}})
+	assert.False(t, l.reportScenario(), "event did not happen")
+}
+
+// scenarioToTmpFile writes the scenario to a temporary file, and returns its path
+func scenarioToTmpFile(scenario string) (string, error) {
+	file, err := ioutil.TempFile(os.TempDir(), "scenario-")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close() // nolint: errcheck
+	_, err = file.WriteString(scenario)
+	return file.Name(), err
+}
@@ -15,9 +15,11 @@
 package sdkserver
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"math/rand"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -25,6 +27,7 @@ import (
 	"time"
 
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
+	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
 	"agones.dev/agones/pkg/sdk"
 	"agones.dev/agones/pkg/sdk/alpha"
 	"agones.dev/agones/pkg/sdk/beta"
@@ -33,6 +36,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
@@ -40,6 +46,17 @@ var (
 	_ sdk.SDKServer   = &LocalSDKServer{}
 	_ alpha.SDKServer = &LocalSDKServer{}
 	_ beta.SDKServer  = &LocalSDKServer{}
+
+	// strictTransitions are the states that the GameServer can be in to be moved to each state
+	// in strict mode, following the GameServer state diagram. Any state can be moved to Shutdown.
+	strictTransitions = map[agonesv1.GameServerState][]agonesv1.GameServerState{
+		agonesv1.GameServerStateReady: {agonesv1.GameServerStateScheduled, agonesv1.GameServerStateRequestReady,
+			agonesv1.GameServerStateReady, agonesv1.GameServerStateReserved, agonesv1.GameServerStateAllocated},
+		agonesv1.GameServerStateReserved: {agonesv1.GameServerStateScheduled, agonesv1.GameServerStateReady,
+			agonesv1.GameServerStateReserved},
+		agonesv1.GameServerStateAllocated: {agonesv1.GameServerStateReady, agonesv1.GameServerStateReserved,
+			agonesv1.GameServerStateAllocated},
+	}
 )
 
 func defaultGs() *sdk.GameServer {
@@ -74,19 +91,29 @@ func defaultGs() *sdk.GameServer {
 // is being run for local development, and doesn't connect to the
 // Kubernetes cluster
 type LocalSDKServer struct {
-	gsMutex           sync.RWMutex
-	gs                *sdk.GameServer
-	logger            *logrus.Entry
-	update            chan struct{}
-	updateObservers   sync.Map
-	testMutex         sync.Mutex
-	requestSequence   []string
-	expectedSequence  []string
-	gsState           agonesv1.GameServerState
-	gsReserveDuration *time.Duration
-	reserveTimer      *time.Timer
-	testMode          bool
-	testSdkName       string
+	gsMutex            sync.RWMutex
+	gs                 *sdk.GameServer
+	logger             *logrus.Entry
+	update             chan struct{}
+	updateObservers    sync.Map
+	testMutex          sync.Mutex
+	requestSequence    []string
+	expectedSequence   []string
+	gsState            agonesv1.GameServerState
+	gsReserveDuration  *time.Duration
+	reserveTimer       *time.Timer
+	testMode           bool
+	testSdkName        string
+	strict             bool
+	clock              clock.Clock
+	healthLastUpdated  time.Time
+	healthFailureCount int32
+	stop               chan struct{}
+	dropWatch          chan struct{}
+	scenarioMutex      sync.RWMutex
+	scenario           *scenarioRun
+	persistFilePath    string
+	persistPending     chan struct{}
 }
 
 // NewLocalSDKServer returns the default LocalSDKServer
@@ -101,6 +128,10 @@ func NewLocalSDKServer(filePath string) (*LocalSDKServer, error) {
 		testMode:        false,
 		testSdkName:     "",
 		gsState:         agonesv1.GameServerStateScheduled,
+		clock:           clock.RealClock{},
+		stop:            make(chan struct{}),
+		dropWatch:       make(chan struct{}),
+		persistPending:  make(chan struct{}, 1),
 	}
 	l.logger = runtime.NewLoggerWithType(l)
 
@@ -143,6 +174,7 @@ func NewLocalSDKServer(filePath string) (*LocalSDKServer, error) {
 	go func() {
 		for value := range l.update {
 			l.logger.Info("Gameserver update received")
+			l.requestPersist()
 			l.updateObservers.Range(func(observer, _ interface{}) bool {
 				observer.(chan struct{}) <- value
 				return true
@@ -179,8 +211,27 @@ func (l *LocalSDKServer) SetSdkName(sdkName string) {
 	l.logger = l.logger.WithField("sdkName", l.testSdkName)
 }
 
+// SetStrictMode makes the GameServer follow the lifecycle it would have in a cluster, as managed by
+// the SDKServer and the controllers: it starts as Scheduled, moves through RequestReady on its way to Ready,
+// moves to Unhealthy when its health checks fail, can be allocated through HandleAllocation, and
+// requests that would move it through an illegal transition return an error
+func (l *LocalSDKServer) SetStrictMode(strict bool) {
+	l.gsMutex.Lock()
+	defer l.gsMutex.Unlock()
+	l.strict = strict
+	if !strict {
+		return
+	}
+
+	l.updateState(agonesv1.GameServerStateScheduled)
+	l.healthLastUpdated = l.clock.Now().UTC().Add(time.Duration(l.health().InitialDelaySeconds) * time.Second)
+	l.healthFailureCount = 0
+	go wait.Until(l.runHealth, time.Second, l.stop)
+}
+
 // recordRequest append request name to slice
 func (l *LocalSDKServer) recordRequest(request string) {
+	l.recordScenarioRequest(request)
 	if l.testMode {
 		l.testMutex.Lock()
 		defer l.testMutex.Unlock()
@@ -194,6 +245,7 @@ func (l *LocalSDKServer) recordRequest(request string) {
 // recordRequestWithValue append request name to slice only if
 // value equals to objMetaField: creationTimestamp or UID
 func (l *LocalSDKServer) recordRequestWithValue(request string, value string, objMetaField string) {
+	l.recordScenarioRequest(request)
 	if l.testMode {
 		fieldVal := ""
 		switch objMetaField {
@@ -234,22 +286,53 @@ func (l *LocalSDKServer) updateState(newState agonesv1.GameServerState) {
 func (l *LocalSDKServer) Ready(context.Context, *sdk.Empty) (*sdk.Empty, error) {
 	l.logger.Info("Ready request has been received!")
 	l.recordRequest("ready")
+	if err := l.injectFault("ready"); err != nil {
+		return nil, err
+	}
 	l.gsMutex.Lock()
 	defer l.gsMutex.Unlock()
+	if err := l.checkTransition(agonesv1.GameServerStateReady); err != nil {
+		return nil, err
+	}
 
 	// Follow the GameServer state diagram
+	if l.strict {
+		// the SDKServer requests to be Ready, and the controller then moves the GameServer to Ready
+		l.updateState(agonesv1.GameServerStateRequestReady)
+		l.stopReserveTimer()
+		l.update <- struct{}{}
+		go l.syncRequestReady()
+		return &sdk.Empty{}, nil
+	}
 	l.updateState(agonesv1.GameServerStateReady)
 	l.stopReserveTimer()
 	l.update <- struct{}{}
 	return &sdk.Empty{}, nil
 }
 
+// syncRequestReady moves the GameServer from RequestReady to Ready, as the controller would
+func (l *LocalSDKServer) syncRequestReady() {
+	l.gsMutex.Lock()
+	defer l.gsMutex.Unlock()
+	if l.gsState != agonesv1.GameServerStateRequestReady {
+		return
+	}
+	l.updateState(agonesv1.GameServerStateReady)
+	l.update <- struct{}{}
+}
+
 // Allocate logs that an allocate request has been received
 func (l *LocalSDKServer) Allocate(context.Context, *sdk.Empty) (*sdk.Empty, error) {
 	l.logger.Info("Allocate request has been received!")
 	l.recordRequest("allocate")
+	if err := l.injectFault("allocate"); err != nil {
+		return nil, err
+	}
 	l.gsMutex.Lock()
 	defer l.gsMutex.Unlock()
+	if err := l.checkTransition(agonesv1.GameServerStateAllocated); err != nil {
+		return nil, err
+	}
 	l.updateState(agonesv1.GameServerStateAllocated)
 	l.stopReserveTimer()
 	l.update <- struct{}{}
@@ -261,6 +344,9 @@ func (l *LocalSDKServer) Allocate(context.Context, *sdk.Empty) (*sdk.Empty, erro
 func (l *LocalSDKServer) Shutdown(context.Context, *sdk.Empty) (*sdk.Empty, error) {
 	l.logger.Info("Shutdown request has been received!")
 	l.recordRequest("shutdown")
+	if err := l.injectFault("shutdown"); err != nil {
+		return nil, err
+	}
 	l.gsMutex.Lock()
 	defer l.gsMutex.Unlock()
 	l.updateState(agonesv1.GameServerStateShutdown)
@@ -281,13 +367,20 @@ func (l *LocalSDKServer) Health(stream sdk.SDK_HealthServer) error {
 			return errors.Wrap(err, "Error with Health check")
 		}
 		l.recordRequest("health")
+		if err := l.injectFault("health"); err != nil {
+			return err
+		}
 		l.logger.Info("Health Ping Received!")
+		l.touchHealthLastUpdated()
 	}
 }
 
 // SetLabel applies a Label to the backing GameServer metadata
 func (l *LocalSDKServer) SetLabel(_ context.Context, kv *sdk.KeyValue) (*sdk.Empty, error) {
 	l.logger.WithField("values", kv).Info("Setting label")
+	if err := l.injectFault("setlabel"); err != nil {
+		return nil, err
+	}
 	l.gsMutex.Lock()
 	defer l.gsMutex.Unlock()
 
@@ -307,6 +400,9 @@ func (l *LocalSDKServer) SetLabel(_ context.Context, kv *sdk.KeyValue) (*sdk.Emp
 // SetAnnotation applies a Annotation to the backing GameServer metadata
 func (l *LocalSDKServer) SetAnnotation(_ context.Context, kv *sdk.KeyValue) (*sdk.Empty, error) {
 	l.logger.WithField("values", kv).Info("Setting annotation")
+	if err := l.injectFault("setannotation"); err != nil {
+		return nil, err
+	}
 	l.gsMutex.Lock()
 	defer l.gsMutex.Unlock()
 
@@ -327,6 +423,9 @@ func (l *LocalSDKServer) SetAnnotation(_ context.Context, kv *sdk.KeyValue) (*sd
 func (l *LocalSDKServer) GetGameServer(context.Context, *sdk.Empty) (*sdk.GameServer, error) {
 	l.logger.Info("Getting GameServer details")
 	l.recordRequest("gameserver")
+	if err := l.injectFault("gameserver"); err != nil {
+		return nil, err
+	}
 	l.gsMutex.RLock()
 	defer l.gsMutex.RUnlock()
 	return l.gs, nil
@@ -335,6 +434,10 @@ func (l *LocalSDKServer) GetGameServer(context.Context, *sdk.Empty) (*sdk.GameSe
 // WatchGameServer will return current GameServer configuration, 3 times, every 5 seconds
 func (l *LocalSDKServer) WatchGameServer(_ *sdk.Empty, stream sdk.SDK_WatchGameServerServer) error {
 	l.logger.Info("Connected to watch GameServer...")
+	l.recordRequest("watch")
+	if err := l.injectFault("watch"); err != nil {
+		return err
+	}
 	observer := make(chan struct{})
 
 	defer func() {
@@ -343,26 +446,41 @@ func (l *LocalSDKServer) WatchGameServer(_ *sdk.Empty, stream sdk.SDK_WatchGameS
 
 	l.updateObservers.Store(observer, true)
 
-	l.recordRequest("watch")
-	for range observer {
-		l.gsMutex.RLock()
-		err := stream.Send(l.gs)
-		l.gsMutex.RUnlock()
-		if err != nil {
-			l.logger.WithError(err).Error("error sending gameserver")
-			return err
+	l.gsMutex.RLock()
+	drop := l.dropWatch
+	l.gsMutex.RUnlock()
+	for {
+		select {
+		case _, ok := <-observer:
+			if !ok {
+				return nil
+			}
+			l.gsMutex.RLock()
+			err := stream.Send(l.gs)
+			l.gsMutex.RUnlock()
+			if err != nil {
+				l.logger.WithError(err).Error("error sending gameserver")
+				return err
+			}
+		case <-drop:
+			l.logger.Info("Dropping watch GameServer stream")
+			return errors.New("WatchGameServer stream dropped by the Scenario")
 		}
 	}
-
-	return nil
 }
 
 // Reserve moves this GameServer to the Reserved state for the Duration specified
 func (l *LocalSDKServer) Reserve(ctx context.Context, d *sdk.Duration) (*sdk.Empty, error) {
 	l.logger.WithField("duration", d).Info("Reserve request has been received!")
 	l.recordRequest("reserve")
+	if err := l.injectFault("reserve"); err != nil {
+		return nil, err
+	}
 	l.gsMutex.Lock()
 	defer l.gsMutex.Unlock()
+	if err := l.checkTransition(agonesv1.GameServerStateReserved); err != nil {
+		return nil, err
+	}
 	if d.Seconds > 0 {
 		duration := time.Duration(d.Seconds) * time.Second
 		l.gsReserveDuration = &duration
@@ -394,6 +512,239 @@ func (l *LocalSDKServer) stopReserveTimer() {
 	l.gsReserveDuration = nil
 }
 
+// checkTransition returns an error if the GameServer can't be moved to the given state in strict mode.
+// Should be called with the gsMutex locked.
+func (l *LocalSDKServer) checkTransition(state agonesv1.GameServerState) error {
+	if !l.strict || state == agonesv1.GameServerStateShutdown {
+		return nil
+	}
+	for _, from := range strictTransitions[state] {
+		if l.gsState == from {
+			return nil
+		}
+	}
+	return errors.Errorf("GameServer cannot be moved from %s to %s", l.gsState, state)
+}
+
+// health returns the health checking configuration of the GameServer,
+// with the defaults of a GameServer in a cluster applied.
+// Should be called with the gsMutex locked.
+func (l *LocalSDKServer) health() sdk.GameServer_Spec_Health {
+	var health sdk.GameServer_Spec_Health
+	if l.gs.Spec != nil && l.gs.Spec.Health != nil {
+		health = *l.gs.Spec.Health
+	}
+	if !health.Disabled {
+		if health.PeriodSeconds <= 0 {
+			health.PeriodSeconds = 5
+		}
+		if health.FailureThreshold <= 0 {
+			health.FailureThreshold = 3
+		}
+		if health.InitialDelaySeconds <= 0 {
+			health.InitialDelaySeconds = 5
+		}
+	}
+	return health
+}
+
+// touchHealthLastUpdated records a health ping, and resets the health check failures
+func (l *LocalSDKServer) touchHealthLastUpdated() {
+	l.gsMutex.Lock()
+	defer l.gsMutex.Unlock()
+	l.healthLastUpdated = l.clock.Now().UTC()
+	l.healthFailureCount = 0
+}
+
+// runHealth counts a health check failure for each PeriodSeconds that passed without a health ping,
+// and moves the GameServer to Unhealthy once the failures reach the FailureThreshold, as the SDKServer
+// and the controller would in strict mode
+func (l *LocalSDKServer) runHealth() {
+	l.gsMutex.Lock()
+	defer l.gsMutex.Unlock()
+
+	health := l.health()
+	if !l.strict || health.Disabled ||
+		l.gsState == agonesv1.GameServerStateUnhealthy || l.gsState == agonesv1.GameServerStateShutdown {
+		return
+	}
+
+	period := time.Duration(health.PeriodSeconds) * time.Second
+	now := l.clock.Now().UTC()
+	for l.healthLastUpdated.Add(period).Before(now) {
+		l.healthLastUpdated = l.healthLastUpdated.Add(period)
+		l.healthFailureCount++
+		l.logger.WithField("failureCount", l.healthFailureCount).Warn("GameServer Health Check failed")
+	}
+	if l.healthFailureCount < health.FailureThreshold {
+		return
+	}
+
+	l.logger.Warn("GameServer has failed health check")
+	l.updateState(agonesv1.GameServerStateUnhealthy)
+	l.stopReserveTimer()
+	l.update <- struct{}{}
+}
+
+// HandleAllocation mimics the creation of a GameServerAllocation. If the GameServer matches one of the
+// preferred or required selectors of the GameServerAllocation, and can be moved to Allocated, it is allocated
+// with the metadata patch applied, and returned in the status of the GameServerAllocation.
+// Otherwise the GameServerAllocation is returned as UnAllocated.
+func (l *LocalSDKServer) HandleAllocation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	gsa := &allocationv1.GameServerAllocation{}
+	if err := json.NewDecoder(r.Body).Decode(gsa); err != nil {
+		http.Error(w, errors.Wrap(err, "error decoding body").Error(), http.StatusBadRequest)
+		return
+	}
+	gsa.ApplyDefaults()
+
+	var result interface{} = gsa
+	w.Header().Set("Content-Type", "application/json")
+	if causes, ok := gsa.Validate(); ok {
+		l.allocate(gsa)
+	} else {
+		result = &metav1.Status{
+			TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+			Status:   metav1.StatusFailure,
+			Message:  "GameServerAllocation is invalid",
+			Reason:   metav1.StatusReasonInvalid,
+			Details: &metav1.StatusDetails{
+				Kind:   "GameServerAllocation",
+				Group:  allocationv1.SchemeGroupVersion.Group,
+				Causes: causes,
+			},
+			Code: http.StatusUnprocessableEntity,
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		l.logger.WithError(err).Error("error encoding GameServerAllocation")
+	}
+}
+
+// allocate moves the GameServer to Allocated if it matches the GameServerAllocation,
+// and sets the status of the GameServerAllocation
+func (l *LocalSDKServer) allocate(gsa *allocationv1.GameServerAllocation) {
+	l.logger.WithField("gsa", gsa).Info("GameServerAllocation has been received!")
+	l.gsMutex.Lock()
+	defer l.gsMutex.Unlock()
+
+	gsa.TypeMeta = metav1.TypeMeta{Kind: "GameServerAllocation", APIVersion: allocationv1.SchemeGroupVersion.String()}
+	gsa.Status = allocationv1.GameServerAllocationStatus{State: allocationv1.GameServerAllocationUnAllocated}
+
+	gs := l.gameServer()
+	selectors := append(append([]allocationv1.GameServerSelector{}, gsa.Spec.Preferred...), gsa.Spec.Required)
+	matched := false
+	for i := range selectors {
+		if selectors[i].Matches(gs) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		l.logger.WithField("state", l.gsState).Info("GameServer does not match the GameServerAllocation")
+		return
+	}
+	if l.gs.Status.DrainDeadline != 0 {
+		l.logger.Info("GameServer is draining, and cannot be allocated")
+		return
+	}
+	if err := l.checkTransition(agonesv1.GameServerStateAllocated); err != nil {
+		l.logger.WithError(err).Info("GameServer cannot be allocated")
+		return
+	}
+
+	l.applyMetaPatch(gsa.Spec.MetaPatch)
+	l.updateState(agonesv1.GameServerStateAllocated)
+	l.stopReserveTimer()
+	l.update <- struct{}{}
+
+	gs = l.gameServer()
+	now := metav1.NewTime(l.clock.Now())
+	gsa.Status = allocationv1.GameServerAllocationStatus{
+		State:               allocationv1.GameServerAllocationAllocated,
+		GameServerName:      gs.ObjectMeta.Name,
+		Ports:               gs.Status.Ports,
+		Address:             gs.Status.Address,
+		Metadata:            &allocationv1.GameServerMetadata{Labels: gs.ObjectMeta.Labels, Annotations: gs.ObjectMeta.Annotations},
+		Players:             gs.Status.Players,
+		AllocationTimestamp: &now,
+	}
+}
+
+// applyMetaPatch applies the labels and annotations of the MetaPatch to the GameServer.
+// Should be called with the gsMutex locked.
+func (l *LocalSDKServer) applyMetaPatch(patch allocationv1.MetaPatch) {
+	if l.gs.ObjectMeta == nil {
+		l.gs.ObjectMeta = &sdk.GameServer_ObjectMeta{}
+	}
+	if l.gs.ObjectMeta.Labels == nil {
+		l.gs.ObjectMeta.Labels = map[string]string{}
+	}
+	if l.gs.ObjectMeta.Annotations == nil {
+		l.gs.ObjectMeta.Annotations = map[string]string{}
+	}
+	for k, v := range patch.Labels {
+		l.gs.ObjectMeta.Labels[k] = v
+	}
+	for k, v := range patch.Annotations {
+		l.gs.ObjectMeta.Annotations[k] = v
+	}
+}
+
+// gameServer returns a copy of the GameServer as a Kubernetes GameServer object,
+// with the fields used to allocate it.
+// Should be called with the gsMutex locked.
+func (l *LocalSDKServer) gameServer() *agonesv1.GameServer {
+	gs := &agonesv1.GameServer{Status: agonesv1.GameServerStatus{State: l.gsState}}
+	if meta := l.gs.ObjectMeta; meta != nil {
+		gs.ObjectMeta.Name = meta.Name
+		gs.ObjectMeta.Labels = map[string]string{}
+		for k, v := range meta.Labels {
+			gs.ObjectMeta.Labels[k] = v
+		}
+		gs.ObjectMeta.Annotations = map[string]string{}
+		for k, v := range meta.Annotations {
+			gs.ObjectMeta.Annotations[k] = v
+		}
+	}
+
+	status := l.gs.Status
+	if status == nil {
+		return gs
+	}
+	gs.Status.Address = status.Address
+	for _, p := range status.Ports {
+		gs.Status.Ports = append(gs.Status.Ports, agonesv1.GameServerStatusPort{Name: p.Name, Port: p.Port})
+	}
+	if status.Players != nil {
+		gs.Status.Players = &agonesv1.PlayerStatus{
+			Count:    status.Players.Count,
+			Capacity: status.Players.Capacity,
+			IDs:      append([]string{}, status.Players.Ids...),
+		}
+	}
+	if status.Counters != nil {
+		gs.Status.Counters = make(map[string]agonesv1.CounterStatus, len(status.Counters))
+		for name, c := range status.Counters {
+			gs.Status.Counters[name] = agonesv1.CounterStatus{Count: c.Count, Capacity: c.Capacity}
+		}
+	}
+	if status.Lists != nil {
+		gs.Status.Lists = make(map[string]agonesv1.ListStatus, len(status.Lists))
+		for name, list := range status.Lists {
+			gs.Status.Lists[name] = agonesv1.ListStatus{Capacity: list.Capacity, Values: append([]string{}, list.Values...)}
+		}
+	}
+	return gs
+}
+
 // PlayerConnect should be called when a player connects.
 // [Stage:Alpha]
 // [FeatureFlag:PlayerTracking]
@@ -579,13 +930,277 @@ func (l *LocalSDKServer) GetPlayerCapacity(_ context.Context, _ *alpha.Empty) (*
 	return result, nil
 }
 
+// GetCounter returns the current count and capacity of the named Counter.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (l *LocalSDKServer) GetCounter(_ context.Context, key *alpha.Key) (*alpha.Counter, error) {
+	if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		return nil, errors.Errorf("%s not enabled", runtime.FeatureCountsAndLists)
+	}
+	l.logger.WithField("name", key.Name).Info("Getting Counter")
+	l.recordRequest("getcounter")
+	l.gsMutex.RLock()
+	defer l.gsMutex.RUnlock()
+
+	c, ok := l.gs.Status.Counters[key.Name]
+	if !ok {
+		return nil, errors.Errorf("counter %q not found", key.Name)
+	}
+	return &alpha.Counter{Name: key.Name, Count: c.Count, Capacity: c.Capacity}, nil
+}
+
+// IncrementCounter increases the count of the named Counter by the requested amount.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (l *LocalSDKServer) IncrementCounter(_ context.Context, in *alpha.CounterUpdateRequest) (*alpha.Counter, error) {
+	return l.setCounter("incrementcounter", in.Name, func(c *sdk.GameServer_Status_CounterStatus) error {
+		if in.Amount < 0 {
+			return errors.Errorf("amount must be a positive value, found %d", in.Amount)
+		}
+		if c.Count+in.Amount > c.Capacity {
+			return errors.Errorf("counter %q cannot be incremented by %d, as it would exceed capacity %d", in.Name, in.Amount, c.Capacity)
+		}
+		c.Count += in.Amount
+		return nil
+	})
+}
+
+// DecrementCounter decreases the count of the named Counter by the requested amount.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (l *LocalSDKServer) DecrementCounter(_ context.Context, in *alpha.CounterUpdateRequest) (*alpha.Counter, error) {
+	return l.setCounter("decrementcounter", in.Name, func(c *sdk.GameServer_Status_CounterStatus) error {
+		if in.Amount < 0 {
+			return errors.Errorf("amount must be a positive value, found %d", in.Amount)
+		}
+		if c.Count-in.Amount < 0 {
+			return errors.Errorf("counter %q cannot be decremented by %d, as it would be less than 0", in.Name, in.Amount)
+		}
+		c.Count -= in.Amount
+		return nil
+	})
+}
+
+// SetCounterCount sets the count of the named Counter.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (l *LocalSDKServer) SetCounterCount(_ context.Context, in *alpha.CounterSetRequest) (*alpha.Counter, error) {
+	return l.setCounter("setcountercount", in.Name, func(c *sdk.GameServer_Status_CounterStatus) error {
+		if in.Count < 0 || in.Count > c.Capacity {
+			return errors.Errorf("counter %q count must be between 0 and capacity %d, found %d", in.Name, c.Capacity, in.Count)
+		}
+		c.Count = in.Count
+		return nil
+	})
+}
+
+// SetCounterCapacity sets the capacity of the named Counter.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (l *LocalSDKServer) SetCounterCapacity(_ context.Context, in *alpha.CapacityRequest) (*alpha.Counter, error) {
+	return l.setCounter("setcountercapacity", in.Name, func(c *sdk.GameServer_Status_CounterStatus) error {
+		if in.Capacity < 0 {
+			return errors.Errorf("counter %q capacity must be greater than or equal to 0, found %d", in.Name, in.Capacity)
+		}
+		c.Capacity = in.Capacity
+		return nil
+	})
+}
+
+// setCounter applies the update func to a copy of the named Counter, and if successful,
+// stores it and notifies any watchers.
+func (l *LocalSDKServer) setCounter(request, name string, update func(c *sdk.GameServer_Status_CounterStatus) error) (*alpha.Counter, error) {
+	if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		return nil, errors.Errorf("%s not enabled", runtime.FeatureCountsAndLists)
+	}
+	l.logger.WithField("name", name).Info("Updating Counter")
+	l.gsMutex.Lock()
+	defer l.gsMutex.Unlock()
+
+	current, ok := l.gs.Status.Counters[name]
+	if !ok {
+		return nil, errors.Errorf("counter %q not found", name)
+	}
+	c := *current
+	if err := update(&c); err != nil {
+		return nil, err
+	}
+	l.gs.Status.Counters[name] = &c
+
+	l.update <- struct{}{}
+	l.recordRequest(request)
+	return &alpha.Counter{Name: name, Count: c.Count, Capacity: c.Capacity}, nil
+}
+
+// GetList returns the current values and capacity of the named List.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (l *LocalSDKServer) GetList(_ context.Context, key *alpha.Key) (*alpha.List, error) {
+	if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		return nil, errors.Errorf("%s not enabled", runtime.FeatureCountsAndLists)
+	}
+	l.logger.WithField("name", key.Name).Info("Getting List")
+	l.recordRequest("getlist")
+	l.gsMutex.RLock()
+	defer l.gsMutex.RUnlock()
+
+	list, ok := l.gs.Status.Lists[key.Name]
+	if !ok {
+		return nil, errors.Errorf("list %q not found", key.Name)
+	}
+	return &alpha.List{Name: key.Name, Capacity: list.Capacity, Values: append([]string{}, list.Values...)}, nil
+}
+
+// AddListValue appends the value to the named List.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (l *LocalSDKServer) AddListValue(_ context.Context, in *alpha.ListValueRequest) (*alpha.List, error) {
+	return l.setList("addlistvalue", in.Name, func(list *sdk.GameServer_Status_ListStatus) error {
+		for _, v := range list.Values {
+			if v == in.Value {
+				return errors.Errorf("value %q already exists in list %q", in.Value, in.Name)
+			}
+		}
+		if int64(len(list.Values)) >= list.Capacity {
+			return errors.Errorf("list %q is already at capacity %d", in.Name, list.Capacity)
+		}
+		list.Values = append(list.Values, in.Value)
+		return nil
+	})
+}
+
+// RemoveListValue removes the value from the named List.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (l *LocalSDKServer) RemoveListValue(_ context.Context, in *alpha.ListValueRequest) (*alpha.List, error) {
+	return l.setList("removelistvalue", in.Name, func(list *sdk.GameServer_Status_ListStatus) error {
+		for i, v := range list.Values {
+			if v == in.Value {
+				list.Values = append(list.Values[:i], list.Values[i+1:]...)
+				return nil
+			}
+		}
+		return errors.Errorf("value %q not found in list %q", in.Value, in.Name)
+	})
+}
+
+// SetListCapacity sets the capacity of the named List.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (l *LocalSDKServer) SetListCapacity(_ context.Context, in *alpha.CapacityRequest) (*alpha.List, error) {
+	return l.setList("setlistcapacity", in.Name, func(list *sdk.GameServer_Status_ListStatus) error {
+		if in.Capacity < 0 || in.Capacity < int64(len(list.Values)) {
+			return errors.Errorf("list %q capacity must be greater than or equal to the number of values %d, found %d", in.Name, len(list.Values), in.Capacity)
+		}
+		list.Capacity = in.Capacity
+		return nil
+	})
+}
+
+// ListContains returns if the value is currently in the named List.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (l *LocalSDKServer) ListContains(_ context.Context, in *alpha.ListValueRequest) (*alpha.Bool, error) {
+	if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		return nil, errors.Errorf("%s not enabled", runtime.FeatureCountsAndLists)
+	}
+	l.logger.WithField("name", in.Name).WithField("value", in.Value).Info("Checking List")
+	l.recordRequest("listcontains")
+	l.gsMutex.RLock()
+	defer l.gsMutex.RUnlock()
+
+	list, ok := l.gs.Status.Lists[in.Name]
+	if !ok {
+		return nil, errors.Errorf("list %q not found", in.Name)
+	}
+	for _, v := range list.Values {
+		if v == in.Value {
+			return &alpha.Bool{Bool: true}, nil
+		}
+	}
+	return &alpha.Bool{Bool: false}, nil
+}
+
+// setList applies the update func to a copy of the named List, and if successful,
+// stores it and notifies any watchers.
+func (l *LocalSDKServer) setList(request, name string, update func(list *sdk.GameServer_Status_ListStatus) error) (*alpha.List, error) {
+	if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		return nil, errors.Errorf("%s not enabled", runtime.FeatureCountsAndLists)
+	}
+	l.logger.WithField("name", name).Info("Updating List")
+	l.gsMutex.Lock()
+	defer l.gsMutex.Unlock()
+
+	current, ok := l.gs.Status.Lists[name]
+	if !ok {
+		return nil, errors.Errorf("list %q not found", name)
+	}
+	list := &sdk.GameServer_Status_ListStatus{Capacity: current.Capacity, Values: append([]string{}, current.Values...)}
+	if err := update(list); err != nil {
+		return nil, err
+	}
+	l.gs.Status.Lists[name] = list
+
+	l.update <- struct{}{}
+	l.recordRequest(request)
+	return &alpha.List{Name: name, Capacity: list.Capacity, Values: append([]string{}, list.Values...)}, nil
+}
+
+// Drain stops the GameServer from being allocated, and moves it to Shutdown once the Duration passes.
+// [Stage:Alpha]
+// [FeatureFlag:SDKDrain]
+func (l *LocalSDKServer) Drain(_ context.Context, d *alpha.Duration) (*alpha.Empty, error) {
+	if !runtime.FeatureEnabled(runtime.FeatureSDKDrain) {
+		return nil, errors.Errorf("%s not enabled", runtime.FeatureSDKDrain)
+	}
+	l.logger.WithField("duration", d).Info("Drain request has been received!")
+	l.recordRequest("drain")
+	if d.Seconds <= 0 {
+		return nil, errors.Errorf("drain duration must be greater than 0, found %d seconds", d.Seconds)
+	}
+
+	l.gsMutex.Lock()
+	defer l.gsMutex.Unlock()
+	deadline := l.clock.Now().Add(time.Duration(d.Seconds) * time.Second)
+	l.gs.Status.DrainDeadline = deadline.Unix()
+	l.update <- struct{}{}
+
+	after := l.clock.After(time.Duration(d.Seconds) * time.Second)
+	go func() {
+		select {
+		case <-after:
+			l.shutdownAfterDrain(deadline.Unix())
+		case <-l.stop:
+		}
+	}()
+	return &alpha.Empty{}, nil
+}
+
+// shutdownAfterDrain moves the GameServer to Shutdown once its drain deadline passes, as the controller would,
+// unless the deadline has been moved since.
+func (l *LocalSDKServer) shutdownAfterDrain(deadline int64) {
+	l.gsMutex.Lock()
+	defer l.gsMutex.Unlock()
+	if l.gs.Status.DrainDeadline != deadline || l.gsState == agonesv1.GameServerStateShutdown {
+		return
+	}
+	l.logger.Info("Drain deadline has passed, moving GameServer to Shutdown")
+	l.updateState(agonesv1.GameServerStateShutdown)
+	l.stopReserveTimer()
+	l.update <- struct{}{}
+}
+
 // Close tears down all the things
 func (l *LocalSDKServer) Close() {
+	close(l.stop)
 	l.updateObservers.Range(func(observer, _ interface{}) bool {
 		close(observer.(chan struct{}))
 		return true
 	})
 	l.compare()
+	if !l.reportScenario() {
+		os.Exit(1)
+	}
 }
 
 // EqualSets tells whether expected and received slices contain the same elements.
@@ -651,8 +1266,23 @@ func (l *LocalSDKServer) setGameServerFromFilePath(filePath string) error {
 		return err
 	}
 
+	// mirror GameServer.ApplyDefaults(), so the initial Counters and Lists
+	// are available without having to set them on the status
+	if runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		if gs.Status.Counters == nil {
+			gs.Status.Counters = gs.Spec.Counters
+		}
+		if gs.Status.Lists == nil {
+			gs.Status.Lists = gs.Spec.Lists
+		}
+	}
+
 	l.gsMutex.Lock()
 	defer l.gsMutex.Unlock()
 	l.gs = convert(&gs)
+	if l.strict {
+		// the state is managed by the lifecycle of the GameServer, not by its configuration
+		l.gs.Status.State = string(l.gsState)
+	}
 	return nil
 }
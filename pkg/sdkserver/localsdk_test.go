@@ -15,10 +15,13 @@
 package sdkserver
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
 	"sync"
@@ -26,11 +29,13 @@ import (
 	"time"
 
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
+	allocationv1 "agones.dev/agones/pkg/apis/allocation/v1"
 	"agones.dev/agones/pkg/sdk"
 	"agones.dev/agones/pkg/sdk/alpha"
 	"agones.dev/agones/pkg/util/runtime"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
@@ -344,6 +349,126 @@ func TestLocalSDKServerPlayerCapacity(t *testing.T) {
 	assert.Equal(t, int64(10), gs.Status.Players.Capacity)
 }
 
+func TestLocalSDKServerCountersAndLists(t *testing.T) {
+	t.Parallel()
+
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureCountsAndLists)+"=true"))
+
+	fixture := &agonesv1.GameServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "stuff"},
+		Spec: agonesv1.GameServerSpec{
+			Counters: map[string]agonesv1.CounterStatus{"rooms": {Count: 1, Capacity: 5}},
+			Lists:    map[string]agonesv1.ListStatus{"tokens": {Capacity: 1}},
+		},
+	}
+
+	path, err := gsToTmpFile(fixture)
+	assert.NoError(t, err)
+	l, err := NewLocalSDKServer(path)
+	assert.Nil(t, err)
+
+	stream := newGameServerMockStream()
+	go func() {
+		err := l.WatchGameServer(&sdk.Empty{}, stream)
+		assert.Nil(t, err)
+	}()
+
+	// wait for watching to begin
+	err = wait.Poll(time.Second, 10*time.Second, func() (bool, error) {
+		found := false
+		l.updateObservers.Range(func(_, _ interface{}) bool {
+			found = true
+			return false
+		})
+		return found, nil
+	})
+	assert.NoError(t, err)
+
+	c, err := l.GetCounter(context.Background(), &alpha.Key{Name: "rooms"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), c.Count)
+
+	_, err = l.IncrementCounter(context.Background(), &alpha.CounterUpdateRequest{Name: "rooms", Amount: 3})
+	assert.NoError(t, err)
+	assertWatchUpdate(t, stream, int64(4), func(gs *sdk.GameServer) interface{} {
+		return gs.Status.Counters["rooms"].Count
+	})
+
+	_, err = l.IncrementCounter(context.Background(), &alpha.CounterUpdateRequest{Name: "rooms", Amount: 2})
+	assert.Error(t, err)
+	_, err = l.DecrementCounter(context.Background(), &alpha.CounterUpdateRequest{Name: "missing", Amount: 1})
+	assert.Error(t, err)
+
+	_, err = l.AddListValue(context.Background(), &alpha.ListValueRequest{Name: "tokens", Value: "one"})
+	assert.NoError(t, err)
+	assertWatchUpdate(t, stream, []string{"one"}, func(gs *sdk.GameServer) interface{} {
+		return gs.Status.Lists["tokens"].Values
+	})
+
+	_, err = l.AddListValue(context.Background(), &alpha.ListValueRequest{Name: "tokens", Value: "two"})
+	assert.Error(t, err)
+
+	ok, err := l.ListContains(context.Background(), &alpha.ListValueRequest{Name: "tokens", Value: "one"})
+	assert.NoError(t, err)
+	assert.True(t, ok.Bool)
+
+	gs, err := l.GetGameServer(context.Background(), &sdk.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), gs.Status.Counters["rooms"].Count)
+	assert.Equal(t, []string{"one"}, gs.Status.Lists["tokens"].Values)
+}
+
+func TestLocalSDKServerDrain(t *testing.T) {
+	t.Parallel()
+
+	runtime.FeatureTestMutex.Lock()
+	defer runtime.FeatureTestMutex.Unlock()
+	assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureSDKDrain)+"=true"))
+
+	l, err := NewLocalSDKServer("")
+	assert.Nil(t, err)
+	fc := clock.NewFakeClock(time.Now())
+	l.clock = fc
+
+	stream := newGameServerMockStream()
+	go func() {
+		err := l.WatchGameServer(&sdk.Empty{}, stream)
+		assert.Nil(t, err)
+	}()
+
+	// wait for watching to begin
+	err = wait.Poll(time.Second, 10*time.Second, func() (bool, error) {
+		found := false
+		l.updateObservers.Range(func(_, _ interface{}) bool {
+			found = true
+			return false
+		})
+		return found, nil
+	})
+	assert.NoError(t, err)
+
+	_, err = l.Drain(context.Background(), &alpha.Duration{Seconds: 0})
+	assert.EqualError(t, err, "drain duration must be greater than 0, found 0 seconds")
+
+	_, err = l.Drain(context.Background(), &alpha.Duration{Seconds: 60})
+	assert.NoError(t, err)
+	assertWatchUpdate(t, stream, fc.Now().Add(time.Minute).Unix(), func(gs *sdk.GameServer) interface{} {
+		return gs.Status.DrainDeadline
+	})
+
+	gsa := &allocationv1.GameServerAllocation{}
+	gsa.ApplyDefaults()
+	l.allocate(gsa)
+	assert.Equal(t, allocationv1.GameServerAllocationUnAllocated, gsa.Status.State, "draining GameServers are not allocated")
+
+	fc.Step(time.Minute)
+	assertWatchUpdate(t, stream, string(agonesv1.GameServerStateShutdown), func(gs *sdk.GameServer) interface{} {
+		return gs.Status.State
+	})
+}
+
 func TestLocalSDKServerPlayerConnectAndDisconnect(t *testing.T) {
 	t.Parallel()
 
@@ -580,6 +705,169 @@ func TestLocalSDKServerStateUpdates(t *testing.T) {
 	assert.Equal(t, gs.Status.State, string(agonesv1.GameServerStateShutdown))
 }
 
+// TestLocalSDKServerStrictMode verify that SDK functions follow the GameServer state diagram in strict mode
+func TestLocalSDKServerStrictMode(t *testing.T) {
+	t.Parallel()
+	l, err := NewLocalSDKServer("")
+	assert.Nil(t, err)
+	defer l.Close()
+	l.SetStrictMode(true)
+
+	ctx := context.Background()
+	e := &sdk.Empty{}
+	gs, err := l.GetGameServer(ctx, e)
+	assert.Nil(t, err)
+	assert.Equal(t, string(agonesv1.GameServerStateScheduled), gs.Status.State)
+
+	_, err = l.Allocate(ctx, e)
+	assert.EqualError(t, err, "GameServer cannot be moved from Scheduled to Allocated")
+
+	stream := newGameServerMockStream()
+	go func() {
+		err := l.WatchGameServer(e, stream)
+		assert.Nil(t, err)
+	}()
+	// wait for watching to begin
+	err = wait.Poll(time.Second, 10*time.Second, func() (bool, error) {
+		found := false
+		l.updateObservers.Range(func(_, _ interface{}) bool {
+			found = true
+			return false
+		})
+		return found, nil
+	})
+	assert.NoError(t, err)
+
+	_, err = l.Ready(ctx, e)
+	assert.Nil(t, err)
+	// the GameServer moves to RequestReady, then to Ready, but the update of the first move
+	// may only be sent after the second one
+	state := func(gs *sdk.GameServer) interface{} { return gs.Status.State }
+	<-stream.msgs
+	assertWatchUpdate(t, stream, string(agonesv1.GameServerStateReady), state)
+
+	_, err = l.Allocate(ctx, e)
+	assert.Nil(t, err)
+	assertWatchUpdate(t, stream, string(agonesv1.GameServerStateAllocated), state)
+
+	_, err = l.Reserve(ctx, &sdk.Duration{})
+	assert.EqualError(t, err, "GameServer cannot be moved from Allocated to Reserved")
+
+	_, err = l.Shutdown(ctx, e)
+	assert.Nil(t, err)
+	assertWatchUpdate(t, stream, string(agonesv1.GameServerStateShutdown), state)
+
+	_, err = l.Ready(ctx, e)
+	assert.EqualError(t, err, "GameServer cannot be moved from Shutdown to Ready")
+}
+
+// TestLocalSDKServerStrictHealth verify that the GameServer moves to Unhealthy in strict mode
+// when its health checks fail
+func TestLocalSDKServerStrictHealth(t *testing.T) {
+	t.Parallel()
+	l, err := NewLocalSDKServer("")
+	assert.Nil(t, err)
+	defer l.Close()
+
+	now := time.Now().UTC()
+	fc := clock.NewFakeClock(now)
+	l.clock = fc
+	l.SetStrictMode(true)
+
+	ctx := context.Background()
+	e := &sdk.Empty{}
+	_, err = l.Ready(ctx, e)
+	assert.Nil(t, err)
+	assertStrictState(t, l, agonesv1.GameServerStateReady)
+
+	// the default GameServer checks health every 3 seconds, after 10 seconds, and is unhealthy after 5 failures
+	fc.Step(10 * time.Second)
+	l.runHealth()
+	assert.Equal(t, int32(0), l.healthFailureCount)
+
+	fc.Step(13 * time.Second)
+	l.runHealth()
+	assert.Equal(t, int32(4), l.healthFailureCount)
+
+	l.touchHealthLastUpdated()
+	fc.Step(4 * time.Second)
+	l.runHealth()
+	assert.Equal(t, int32(1), l.healthFailureCount)
+	gs, err := l.GetGameServer(ctx, e)
+	assert.Nil(t, err)
+	assert.Equal(t, string(agonesv1.GameServerStateReady), gs.Status.State)
+
+	fc.Step(12 * time.Second)
+	l.runHealth()
+	gs, err = l.GetGameServer(ctx, e)
+	assert.Nil(t, err)
+	assert.Equal(t, string(agonesv1.GameServerStateUnhealthy), gs.Status.State)
+
+	_, err = l.Ready(ctx, e)
+	assert.EqualError(t, err, "GameServer cannot be moved from Unhealthy to Ready")
+}
+
+// TestLocalSDKServerHandleAllocation verify that a GameServerAllocation allocates the GameServer
+// when it matches
+func TestLocalSDKServerHandleAllocation(t *testing.T) {
+	t.Parallel()
+	l, err := NewLocalSDKServer("")
+	assert.Nil(t, err)
+	defer l.Close()
+	l.SetStrictMode(true)
+
+	allocate := func(gsa *allocationv1.GameServerAllocation) (int, *allocationv1.GameServerAllocation) {
+		b, err := json.Marshal(gsa)
+		assert.Nil(t, err)
+		w := httptest.NewRecorder()
+		l.HandleAllocation(w, httptest.NewRequest(http.MethodPost, "/apis/allocation.agones.dev/v1/namespaces/default/gameserverallocations", bytes.NewReader(b)))
+		result := &allocationv1.GameServerAllocation{}
+		if w.Code == http.StatusOK {
+			assert.Nil(t, json.NewDecoder(w.Body).Decode(result))
+		}
+		return w.Code, result
+	}
+	gsa := &allocationv1.GameServerAllocation{Spec: allocationv1.GameServerAllocationSpec{
+		Required: allocationv1.GameServerSelector{
+			LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"islocal": "true"}},
+		},
+		MetaPatch: allocationv1.MetaPatch{Labels: map[string]string{"player": "1"}},
+	}}
+
+	// not Ready yet
+	code, result := allocate(gsa)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, allocationv1.GameServerAllocationUnAllocated, result.Status.State)
+
+	ctx := context.Background()
+	e := &sdk.Empty{}
+	_, err = l.Ready(ctx, e)
+	assert.Nil(t, err)
+	assertStrictState(t, l, agonesv1.GameServerStateReady)
+
+	code, result = allocate(gsa)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, allocationv1.GameServerAllocationAllocated, result.Status.State)
+	assert.Equal(t, "local", result.Status.GameServerName)
+	assert.Equal(t, "127.0.0.1", result.Status.Address)
+	assert.Equal(t, []agonesv1.GameServerStatusPort{{Name: "default", Port: 7777}}, result.Status.Ports)
+	assert.Equal(t, "1", result.Status.Metadata.Labels["player"])
+
+	gs, err := l.GetGameServer(ctx, e)
+	assert.Nil(t, err)
+	assert.Equal(t, string(agonesv1.GameServerStateAllocated), gs.Status.State)
+	assert.Equal(t, "1", gs.ObjectMeta.Labels["player"])
+
+	// already Allocated
+	code, result = allocate(gsa)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, allocationv1.GameServerAllocationUnAllocated, result.Status.State)
+
+	gsa.Spec.Scheduling = "Invalid"
+	code, _ = allocate(gsa)
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
+
 // TestSDKConformanceFunctionality - run a number of record requests in parallel
 func TestSDKConformanceFunctionality(t *testing.T) {
 	t.Parallel()
@@ -656,6 +944,16 @@ func assertWatchUpdate(t *testing.T, stream *gameServerMockStream, expected inte
 	}
 }
 
+// assertStrictState waits for the GameServer to be moved to the given state in strict mode
+func assertStrictState(t *testing.T, l *LocalSDKServer, state agonesv1.GameServerState) {
+	err := wait.PollImmediate(100*time.Millisecond, 10*time.Second, func() (bool, error) {
+		l.gsMutex.RLock()
+		defer l.gsMutex.RUnlock()
+		return l.gsState == state, nil
+	})
+	assert.NoError(t, err, "GameServer should be moved to %s", state)
+}
+
 // assertNoWatchUpdate checks that no update message has been sent for changes to the GameServer
 func assertNoWatchUpdate(t *testing.T, stream *gameServerMockStream) {
 	select {
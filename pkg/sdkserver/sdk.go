@@ -77,5 +77,30 @@ func convert(gs *agonesv1.GameServer) *sdk.GameServer {
 		}
 	}
 
+	if runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		if gs.Status.Counters != nil {
+			result.Status.Counters = make(map[string]*sdk.GameServer_Status_CounterStatus, len(gs.Status.Counters))
+			for name, c := range gs.Status.Counters {
+				result.Status.Counters[name] = &sdk.GameServer_Status_CounterStatus{
+					Count:    c.Count,
+					Capacity: c.Capacity,
+				}
+			}
+		}
+		if gs.Status.Lists != nil {
+			result.Status.Lists = make(map[string]*sdk.GameServer_Status_ListStatus, len(gs.Status.Lists))
+			for name, l := range gs.Status.Lists {
+				result.Status.Lists[name] = &sdk.GameServer_Status_ListStatus{
+					Capacity: l.Capacity,
+					Values:   l.Values,
+				}
+			}
+		}
+	}
+
+	if runtime.FeatureEnabled(runtime.FeatureSDKDrain) && gs.Status.DrainDeadline != nil {
+		result.Status.DrainDeadline = gs.Status.DrainDeadline.Unix()
+	}
+
 	return result
 }
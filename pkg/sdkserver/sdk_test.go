@@ -16,6 +16,7 @@ package sdkserver
 
 import (
 	"testing"
+	"time"
 
 	agonesv1 "agones.dev/agones/pkg/apis/agones/v1"
 	"agones.dev/agones/pkg/sdk"
@@ -104,6 +105,44 @@ func TestConvert(t *testing.T) {
 		assert.Equal(t, gs.Status.Players.IDs, sdkGs.Status.Players.Ids)
 	})
 
+	t.Run(string(runtime.FeatureCountsAndLists)+" enabled", func(t *testing.T) {
+		assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureCountsAndLists)+"=true"))
+
+		gs := fixture.DeepCopy()
+		gs.Status.Counters = map[string]agonesv1.CounterStatus{"rooms": {Count: 2, Capacity: 10}}
+		gs.Status.Lists = map[string]agonesv1.ListStatus{"tokens": {Capacity: 5, Values: []string{"one"}}}
+
+		sdkGs := convert(gs)
+		eq(t, fixture, sdkGs)
+		assert.Equal(t, int64(2), sdkGs.Status.Counters["rooms"].Count)
+		assert.Equal(t, int64(10), sdkGs.Status.Counters["rooms"].Capacity)
+		assert.Equal(t, int64(5), sdkGs.Status.Lists["tokens"].Capacity)
+		assert.Equal(t, []string{"one"}, sdkGs.Status.Lists["tokens"].Values)
+
+		assert.NoError(t, runtime.ParseFeatures(""))
+		sdkGs = convert(gs)
+		assert.Nil(t, sdkGs.Status.Counters)
+		assert.Nil(t, sdkGs.Status.Lists)
+	})
+
+	t.Run(string(runtime.FeatureSDKDrain)+" enabled", func(t *testing.T) {
+		assert.NoError(t, runtime.ParseFeatures(string(runtime.FeatureSDKDrain)+"=true"))
+
+		gs := fixture.DeepCopy()
+		sdkGs := convert(gs)
+		eq(t, fixture, sdkGs)
+		assert.Zero(t, sdkGs.Status.DrainDeadline)
+
+		deadline := metav1.NewTime(time.Now().Add(time.Minute))
+		gs.Status.DrainDeadline = &deadline
+		sdkGs = convert(gs)
+		assert.Equal(t, deadline.Unix(), sdkGs.Status.DrainDeadline)
+
+		assert.NoError(t, runtime.ParseFeatures(""))
+		sdkGs = convert(gs)
+		assert.Zero(t, sdkGs.Status.DrainDeadline)
+	})
+
 	t.Run("DeletionTimestamp", func(t *testing.T) {
 		gs := fixture.DeepCopy()
 
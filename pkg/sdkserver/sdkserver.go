@@ -59,6 +59,9 @@ const (
 	updateAnnotation        Operation     = "updateAnnotation"
 	updatePlayerCapacity    Operation     = "updatePlayerCapacity"
 	updateConnectedPlayers  Operation     = "updateConnectedPlayers"
+	updateCounters          Operation     = "updateCounters"
+	updateLists             Operation     = "updateLists"
+	updateDrainDeadline     Operation     = "updateDrainDeadline"
 	playerCountUpdatePeriod time.Duration = time.Second
 )
 
@@ -100,6 +103,9 @@ type SDKServer struct {
 	gsReserveDuration  *time.Duration
 	gsPlayerCapacity   int64
 	gsConnectedPlayers []string
+	gsCounters         map[string]agonesv1.CounterStatus
+	gsLists            map[string]agonesv1.ListStatus
+	gsDrainDeadline    *metav1.Time
 }
 
 // NewSDKServer creates a SDKServer that sets up an
@@ -134,6 +140,8 @@ func NewSDKServer(gameServerName, namespace string, kubeClient kubernetes.Interf
 		gsUpdateMutex:      sync.RWMutex{},
 		gsWaitForSync:      sync.WaitGroup{},
 		gsConnectedPlayers: []string{},
+		gsCounters:         map[string]agonesv1.CounterStatus{},
+		gsLists:            map[string]agonesv1.ListStatus{},
 	}
 
 	s.informerFactory = factory
@@ -245,6 +253,18 @@ func (s *SDKServer) Run(stop <-chan struct{}) error {
 		s.gsUpdateMutex.Unlock()
 	}
 
+	// populate counter and list values
+	if runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		s.gsUpdateMutex.Lock()
+		for name, c := range gs.Status.Counters {
+			s.gsCounters[name] = c
+		}
+		for name, l := range gs.Status.Lists {
+			s.gsLists[name] = *l.DeepCopy()
+		}
+		s.gsUpdateMutex.Unlock()
+	}
+
 	// then start the http endpoints
 	s.logger.Debug("Starting SDKServer http health check...")
 	go func() {
@@ -280,6 +300,12 @@ func (s *SDKServer) syncGameServer(key string) error {
 		return s.updatePlayerCapacity()
 	case updateConnectedPlayers:
 		return s.updateConnectedPlayers()
+	case updateCounters:
+		return s.updateCounters()
+	case updateLists:
+		return s.updateLists()
+	case updateDrainDeadline:
+		return s.updateDrainDeadline()
 	}
 
 	return errors.Errorf("could not sync game server key: %s", key)
@@ -322,6 +348,10 @@ func (s *SDKServer) updateState() error {
 	} else {
 		gsCopy.Status.ReservedUntil = nil
 	}
+	// a GameServer that is Ready again starts over, so the player slots reserved by its allocations are released
+	if gsCopy.Status.State == agonesv1.GameServerStateReady && gsCopy.Status.Players != nil {
+		gsCopy.Status.Players.Reserved = 0
+	}
 	s.gsUpdateMutex.RUnlock()
 
 	gs, err = gameServers.Update(gsCopy)
@@ -699,6 +729,248 @@ func (s *SDKServer) GetPlayerCapacity(ctx context.Context, _ *alpha.Empty) (*alp
 	return &alpha.Count{Count: s.gsPlayerCapacity}, nil
 }
 
+// GetCounter returns the current count and capacity of the named Counter.
+// This is always accurate, even if the value hasn’t been updated to the GameServer status yet.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (s *SDKServer) GetCounter(ctx context.Context, key *alpha.Key) (*alpha.Counter, error) {
+	if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		return nil, errors.Errorf("%s not enabled", runtime.FeatureCountsAndLists)
+	}
+	s.gsUpdateMutex.RLock()
+	defer s.gsUpdateMutex.RUnlock()
+
+	c, ok := s.gsCounters[key.Name]
+	if !ok {
+		return nil, errors.Errorf("counter %q not found", key.Name)
+	}
+	return &alpha.Counter{Name: key.Name, Count: c.Count, Capacity: c.Capacity}, nil
+}
+
+// IncrementCounter increases the count of the named Counter by the requested amount.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (s *SDKServer) IncrementCounter(ctx context.Context, in *alpha.CounterUpdateRequest) (*alpha.Counter, error) {
+	return s.setCounter(in.Name, func(c *agonesv1.CounterStatus) error {
+		if in.Amount < 0 {
+			return errors.Errorf("amount must be a positive value, found %d", in.Amount)
+		}
+		if c.Count+in.Amount > c.Capacity {
+			return errors.Errorf("counter %q cannot be incremented by %d, as it would exceed capacity %d", in.Name, in.Amount, c.Capacity)
+		}
+		c.Count += in.Amount
+		return nil
+	})
+}
+
+// DecrementCounter decreases the count of the named Counter by the requested amount.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (s *SDKServer) DecrementCounter(ctx context.Context, in *alpha.CounterUpdateRequest) (*alpha.Counter, error) {
+	return s.setCounter(in.Name, func(c *agonesv1.CounterStatus) error {
+		if in.Amount < 0 {
+			return errors.Errorf("amount must be a positive value, found %d", in.Amount)
+		}
+		if c.Count-in.Amount < 0 {
+			return errors.Errorf("counter %q cannot be decremented by %d, as it would be less than 0", in.Name, in.Amount)
+		}
+		c.Count -= in.Amount
+		return nil
+	})
+}
+
+// SetCounterCount sets the count of the named Counter.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (s *SDKServer) SetCounterCount(ctx context.Context, in *alpha.CounterSetRequest) (*alpha.Counter, error) {
+	return s.setCounter(in.Name, func(c *agonesv1.CounterStatus) error {
+		if in.Count < 0 || in.Count > c.Capacity {
+			return errors.Errorf("counter %q count must be between 0 and capacity %d, found %d", in.Name, c.Capacity, in.Count)
+		}
+		c.Count = in.Count
+		return nil
+	})
+}
+
+// SetCounterCapacity sets the capacity of the named Counter.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (s *SDKServer) SetCounterCapacity(ctx context.Context, in *alpha.CapacityRequest) (*alpha.Counter, error) {
+	return s.setCounter(in.Name, func(c *agonesv1.CounterStatus) error {
+		if in.Capacity < 0 {
+			return errors.Errorf("counter %q capacity must be greater than or equal to 0, found %d", in.Name, in.Capacity)
+		}
+		c.Capacity = in.Capacity
+		return nil
+	})
+}
+
+// setCounter applies the update func to the named Counter, and if successful,
+// enqueues a batched update of the GameServer's Counters.
+func (s *SDKServer) setCounter(name string, update func(c *agonesv1.CounterStatus) error) (*alpha.Counter, error) {
+	if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		return nil, errors.Errorf("%s not enabled", runtime.FeatureCountsAndLists)
+	}
+	s.logger.WithField("name", name).Debug("Updating Counter")
+
+	s.gsUpdateMutex.Lock()
+	defer s.gsUpdateMutex.Unlock()
+
+	c, ok := s.gsCounters[name]
+	if !ok {
+		return nil, errors.Errorf("counter %q not found", name)
+	}
+	if err := update(&c); err != nil {
+		return nil, err
+	}
+	s.gsCounters[name] = c
+	s.workerqueue.EnqueueAfter(cache.ExplicitKey(string(updateCounters)), playerCountUpdatePeriod)
+
+	return &alpha.Counter{Name: name, Count: c.Count, Capacity: c.Capacity}, nil
+}
+
+// GetList returns the current values and capacity of the named List.
+// This is always accurate, even if the value hasn’t been updated to the GameServer status yet.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (s *SDKServer) GetList(ctx context.Context, key *alpha.Key) (*alpha.List, error) {
+	if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		return nil, errors.Errorf("%s not enabled", runtime.FeatureCountsAndLists)
+	}
+	s.gsUpdateMutex.RLock()
+	defer s.gsUpdateMutex.RUnlock()
+
+	l, ok := s.gsLists[key.Name]
+	if !ok {
+		return nil, errors.Errorf("list %q not found", key.Name)
+	}
+	return convertList(key.Name, l), nil
+}
+
+// AddListValue appends the value to the named List.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (s *SDKServer) AddListValue(ctx context.Context, in *alpha.ListValueRequest) (*alpha.List, error) {
+	return s.setList(in.Name, func(l *agonesv1.ListStatus) error {
+		for _, v := range l.Values {
+			if v == in.Value {
+				return errors.Errorf("value %q already exists in list %q", in.Value, in.Name)
+			}
+		}
+		if int64(len(l.Values)) >= l.Capacity {
+			return errors.Errorf("list %q is already at capacity %d", in.Name, l.Capacity)
+		}
+		// let's retain the original order, as it should be a smaller patch on data change
+		l.Values = append(l.Values, in.Value)
+		return nil
+	})
+}
+
+// RemoveListValue removes the value from the named List.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (s *SDKServer) RemoveListValue(ctx context.Context, in *alpha.ListValueRequest) (*alpha.List, error) {
+	return s.setList(in.Name, func(l *agonesv1.ListStatus) error {
+		for i, v := range l.Values {
+			if v == in.Value {
+				l.Values = append(l.Values[:i], l.Values[i+1:]...)
+				return nil
+			}
+		}
+		return errors.Errorf("value %q not found in list %q", in.Value, in.Name)
+	})
+}
+
+// SetListCapacity sets the capacity of the named List.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (s *SDKServer) SetListCapacity(ctx context.Context, in *alpha.CapacityRequest) (*alpha.List, error) {
+	return s.setList(in.Name, func(l *agonesv1.ListStatus) error {
+		if in.Capacity < 0 || in.Capacity < int64(len(l.Values)) {
+			return errors.Errorf("list %q capacity must be greater than or equal to the number of values %d, found %d", in.Name, len(l.Values), in.Capacity)
+		}
+		l.Capacity = in.Capacity
+		return nil
+	})
+}
+
+// ListContains returns if the value is currently in the named List.
+// This is always accurate, even if the value hasn’t been updated to the GameServer status yet.
+// [Stage:Alpha]
+// [FeatureFlag:CountsAndLists]
+func (s *SDKServer) ListContains(ctx context.Context, in *alpha.ListValueRequest) (*alpha.Bool, error) {
+	if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		return nil, errors.Errorf("%s not enabled", runtime.FeatureCountsAndLists)
+	}
+	s.gsUpdateMutex.RLock()
+	defer s.gsUpdateMutex.RUnlock()
+
+	l, ok := s.gsLists[in.Name]
+	if !ok {
+		return nil, errors.Errorf("list %q not found", in.Name)
+	}
+	for _, v := range l.Values {
+		if v == in.Value {
+			return &alpha.Bool{Bool: true}, nil
+		}
+	}
+	return &alpha.Bool{Bool: false}, nil
+}
+
+// setList applies the update func to a copy of the named List, and if successful,
+// enqueues a batched update of the GameServer's Lists.
+func (s *SDKServer) setList(name string, update func(l *agonesv1.ListStatus) error) (*alpha.List, error) {
+	if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		return nil, errors.Errorf("%s not enabled", runtime.FeatureCountsAndLists)
+	}
+	s.logger.WithField("name", name).Debug("Updating List")
+
+	s.gsUpdateMutex.Lock()
+	defer s.gsUpdateMutex.Unlock()
+
+	l, ok := s.gsLists[name]
+	if !ok {
+		return nil, errors.Errorf("list %q not found", name)
+	}
+	// copy, so a failed update doesn't modify the stored values
+	l = *l.DeepCopy()
+	if err := update(&l); err != nil {
+		return nil, err
+	}
+	s.gsLists[name] = l
+	s.workerqueue.EnqueueAfter(cache.ExplicitKey(string(updateLists)), playerCountUpdatePeriod)
+
+	return convertList(name, l), nil
+}
+
+// Drain stops the GameServer from being allocated, and has it moved to Shutdown once the Duration passes.
+// [Stage:Alpha]
+// [FeatureFlag:SDKDrain]
+func (s *SDKServer) Drain(ctx context.Context, d *alpha.Duration) (*alpha.Empty, error) {
+	if !runtime.FeatureEnabled(runtime.FeatureSDKDrain) {
+		return nil, errors.Errorf("%s not enabled", runtime.FeatureSDKDrain)
+	}
+	if d.Seconds <= 0 {
+		return nil, errors.Errorf("drain duration must be greater than 0, found %d seconds", d.Seconds)
+	}
+
+	deadline := metav1.NewTime(s.clock.Now().Add(time.Duration(d.Seconds) * time.Second))
+	s.logger.WithField("deadline", deadline).Debug("Received Drain request, adding to queue")
+	s.gsUpdateMutex.Lock()
+	s.gsDrainDeadline = &deadline
+	s.gsUpdateMutex.Unlock()
+	s.workerqueue.Enqueue(cache.ExplicitKey(string(updateDrainDeadline)))
+
+	return &alpha.Empty{}, nil
+}
+
+// convertList converts a ListStatus into its SDK representation
+func convertList(name string, l agonesv1.ListStatus) *alpha.List {
+	values := make([]string, len(l.Values))
+	copy(values, l.Values)
+	return &alpha.List{Name: name, Capacity: l.Capacity, Values: values}
+}
+
 // sendGameServerUpdate sends a watch game server event
 func (s *SDKServer) sendGameServerUpdate(gs *agonesv1.GameServer) {
 	s.logger.Debug("Sending GameServer Event to connectedStreams")
@@ -805,6 +1077,13 @@ func (s *SDKServer) updateConnectedPlayers() error {
 	s.logger.WithField("playerIDs", s.gsConnectedPlayers).Debug("updating connected players")
 	same = apiequality.Semantic.DeepEqual(gsCopy.Status.Players.IDs, s.gsConnectedPlayers)
 	gsCopy.Status.Players.IDs = s.gsConnectedPlayers
+	// players that connect take up the slots reserved for them on allocation
+	if joined := int64(len(s.gsConnectedPlayers)) - gsCopy.Status.Players.Count; joined > 0 {
+		gsCopy.Status.Players.Reserved -= joined
+		if gsCopy.Status.Players.Reserved < 0 {
+			gsCopy.Status.Players.Reserved = 0
+		}
+	}
 	gsCopy.Status.Players.Count = int64(len(s.gsConnectedPlayers))
 	s.gsUpdateMutex.RUnlock()
 	// if there is no change, then don't update
@@ -821,3 +1100,101 @@ func (s *SDKServer) updateConnectedPlayers() error {
 	s.recorder.Event(gs, corev1.EventTypeNormal, "PlayerCount", fmt.Sprintf("Set to %d", gs.Status.Players.Count))
 	return nil
 }
+
+// updateCounters updates the Counters field in the GameServer's Status.
+func (s *SDKServer) updateCounters() error {
+	if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		return errors.Errorf("%s not enabled", runtime.FeatureCountsAndLists)
+	}
+	gs, err := s.gameServer()
+	if err != nil {
+		return err
+	}
+
+	gsCopy := gs.DeepCopy()
+	s.gsUpdateMutex.RLock()
+	s.logger.WithField("counters", s.gsCounters).Debug("updating counters")
+	counters := make(map[string]agonesv1.CounterStatus, len(s.gsCounters))
+	for name, c := range s.gsCounters {
+		counters[name] = c
+	}
+	s.gsUpdateMutex.RUnlock()
+	// if there is no change, then don't update
+	if apiequality.Semantic.DeepEqual(gsCopy.Status.Counters, counters) {
+		return nil
+	}
+	gsCopy.Status.Counters = counters
+
+	gs, err = s.gameServerGetter.GameServers(s.namespace).Update(gsCopy)
+	if err != nil {
+		return err
+	}
+	s.recorder.Event(gs, corev1.EventTypeNormal, "UpdateCounters", "Counters updated")
+	return nil
+}
+
+// updateLists updates the Lists field in the GameServer's Status.
+func (s *SDKServer) updateLists() error {
+	if !runtime.FeatureEnabled(runtime.FeatureCountsAndLists) {
+		return errors.Errorf("%s not enabled", runtime.FeatureCountsAndLists)
+	}
+	gs, err := s.gameServer()
+	if err != nil {
+		return err
+	}
+
+	gsCopy := gs.DeepCopy()
+	s.gsUpdateMutex.RLock()
+	s.logger.WithField("lists", s.gsLists).Debug("updating lists")
+	lists := make(map[string]agonesv1.ListStatus, len(s.gsLists))
+	for name, l := range s.gsLists {
+		lists[name] = *l.DeepCopy()
+	}
+	s.gsUpdateMutex.RUnlock()
+	// if there is no change, then don't update
+	if apiequality.Semantic.DeepEqual(gsCopy.Status.Lists, lists) {
+		return nil
+	}
+	gsCopy.Status.Lists = lists
+
+	gs, err = s.gameServerGetter.GameServers(s.namespace).Update(gsCopy)
+	if err != nil {
+		return err
+	}
+	s.recorder.Event(gs, corev1.EventTypeNormal, "UpdateLists", "Lists updated")
+	return nil
+}
+
+// updateDrainDeadline sets the DrainDeadline of the GameServer's Status to the one persisted in SDKServer,
+// i.e. SDKServer.gsDrainDeadline.
+func (s *SDKServer) updateDrainDeadline() error {
+	if !runtime.FeatureEnabled(runtime.FeatureSDKDrain) {
+		return errors.Errorf("%s not enabled", runtime.FeatureSDKDrain)
+	}
+	gs, err := s.gameServer()
+	if err != nil {
+		return err
+	}
+
+	// If we are currently in shutdown/being deleted, there is nothing to drain.
+	if gs.IsBeingDeleted() {
+		s.logger.Debug("GameServerState being shutdown. Skipping drain.")
+		return nil
+	}
+
+	gsCopy := gs.DeepCopy()
+	s.gsUpdateMutex.RLock()
+	gsCopy.Status.DrainDeadline = s.gsDrainDeadline.DeepCopy()
+	s.gsUpdateMutex.RUnlock()
+	// if there is no change, then don't update
+	if apiequality.Semantic.DeepEqual(gs.Status.DrainDeadline, gsCopy.Status.DrainDeadline) {
+		return nil
+	}
+
+	gs, err = s.gameServerGetter.GameServers(s.namespace).Update(gsCopy)
+	if err != nil {
+		return errors.Wrapf(err, "could not update GameServer %s/%s drain deadline", s.namespace, s.gameServerName)
+	}
+	s.recorder.Event(gs, corev1.EventTypeNormal, "Draining", fmt.Sprintf("Drain requested, until %s", gs.Status.DrainDeadline.Format(time.RFC3339)))
+	return nil
+}
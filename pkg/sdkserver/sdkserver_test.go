@@ -368,6 +368,42 @@ func TestSidecarUpdateState(t *testing.T) {
 	}
 }
 
+func TestSidecarUpdateStateReleasesReservedPlayerSlots(t *testing.T) {
+	t.Parallel()
+
+	m := agtesting.NewMocks()
+	sc, err := defaultSidecar(m)
+	require.NoError(t, err)
+	sc.gsState = agonesv1.GameServerStateReady
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gs := agonesv1.GameServer{
+			ObjectMeta: metav1.ObjectMeta{Name: sc.gameServerName, Namespace: sc.namespace},
+			Status: agonesv1.GameServerStatus{State: agonesv1.GameServerStateAllocated,
+				Players: &agonesv1.PlayerStatus{Capacity: 10, Count: 2, Reserved: 4}},
+		}
+		return true, &agonesv1.GameServerList{Items: []agonesv1.GameServer{gs}}, nil
+	})
+	var updated *agonesv1.GameServer
+	m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		updated = action.(k8stesting.UpdateAction).GetObject().(*agonesv1.GameServer)
+		return true, updated, nil
+	})
+
+	stop := make(chan struct{})
+	defer close(stop)
+	sc.informerFactory.Start(stop)
+	assert.True(t, cache.WaitForCacheSync(stop, sc.gameServerSynced))
+	sc.gsWaitForSync.Done()
+
+	err = sc.updateState()
+	assert.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, agonesv1.GameServerStateReady, updated.Status.State)
+	assert.Equal(t, int64(0), updated.Status.Players.Reserved)
+	assert.Equal(t, int64(2), updated.Status.Players.Count)
+}
+
 func TestSidecarHealthLastUpdated(t *testing.T) {
 	t.Parallel()
 	now := time.Now().UTC()
@@ -1115,6 +1151,8 @@ func TestSDKServerPlayerConnectAndDisconnect(t *testing.T) {
 			},
 		}
 		gs.ApplyDefaults()
+		// slots reserved by allocations, before the players connected
+		gs.Status.Players.Reserved = 5
 		return true, &agonesv1.GameServerList{Items: []agonesv1.GameServer{gs}}, nil
 	})
 	updated := make(chan *agonesv1.PlayerStatus, 10)
@@ -1179,6 +1217,7 @@ func TestSDKServerPlayerConnectAndDisconnect(t *testing.T) {
 	case value := <-updated:
 		assert.Equal(t, capacity, value.Count)
 		assert.Equal(t, []string{"0", "1", "2"}, value.IDs)
+		assert.Equal(t, int64(2), value.Reserved, "connected players should take up their reserved slots")
 	case <-time.After(5 * time.Second):
 		assert.Fail(t, "Should have been updated")
 	}
@@ -1299,3 +1338,266 @@ func asyncWatchGameServer(t *testing.T, sc *SDKServer, stream sdk.SDK_WatchGameS
 		assert.Nil(t, err)
 	}()
 }
+
+func TestSDKServerCounters(t *testing.T) {
+	t.Parallel()
+	agruntime.FeatureTestMutex.Lock()
+	defer agruntime.FeatureTestMutex.Unlock()
+
+	err := agruntime.ParseFeatures(string(agruntime.FeatureCountsAndLists) + "=true")
+	require.NoError(t, err, "Can not parse FeatureCountsAndLists feature")
+
+	m := agtesting.NewMocks()
+	stop := make(chan struct{})
+	defer close(stop)
+
+	sc, err := defaultSidecar(m)
+	require.NoError(t, err)
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gs := agonesv1.GameServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test", Namespace: "default",
+			},
+			Spec: agonesv1.GameServerSpec{
+				SdkServer: agonesv1.SdkServer{
+					LogLevel: "Debug",
+				},
+				Counters: map[string]agonesv1.CounterStatus{
+					"rooms": {Count: 1, Capacity: 10},
+				},
+			},
+		}
+		gs.ApplyDefaults()
+		return true, &agonesv1.GameServerList{Items: []agonesv1.GameServer{gs}}, nil
+	})
+
+	updated := make(chan map[string]agonesv1.CounterStatus, 10)
+	m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		ua := action.(k8stesting.UpdateAction)
+		gs := ua.GetObject().(*agonesv1.GameServer)
+		updated <- gs.Status.Counters
+		return true, gs, nil
+	})
+
+	sc.informerFactory.Start(stop)
+	assert.True(t, cache.WaitForCacheSync(stop, sc.gameServerSynced))
+
+	go func() {
+		err = sc.Run(stop)
+		assert.NoError(t, err)
+	}()
+
+	// check initial value comes through
+	// async, so check after a period
+	key := &alpha.Key{Name: "rooms"}
+	err = wait.Poll(time.Second, 10*time.Second, func() (bool, error) {
+		c, err := sc.GetCounter(context.Background(), key)
+		return err == nil && c.Count == 1, nil
+	})
+	assert.NoError(t, err)
+
+	_, err = sc.GetCounter(context.Background(), &alpha.Key{Name: "missing"})
+	assert.EqualError(t, err, `counter "missing" not found`)
+
+	// sdk value should always be correct, even if we send more than one update per second.
+	c, err := sc.IncrementCounter(context.Background(), &alpha.CounterUpdateRequest{Name: "rooms", Amount: 5})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), c.Count)
+	c, err = sc.DecrementCounter(context.Background(), &alpha.CounterUpdateRequest{Name: "rooms", Amount: 2})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), c.Count)
+	c, err = sc.SetCounterCapacity(context.Background(), &alpha.CapacityRequest{Name: "rooms", Capacity: 20})
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), c.Capacity)
+
+	_, err = sc.IncrementCounter(context.Background(), &alpha.CounterUpdateRequest{Name: "rooms", Amount: 17})
+	assert.EqualError(t, err, `counter "rooms" cannot be incremented by 17, as it would exceed capacity 20`)
+	_, err = sc.DecrementCounter(context.Background(), &alpha.CounterUpdateRequest{Name: "rooms", Amount: 5})
+	assert.EqualError(t, err, `counter "rooms" cannot be decremented by 5, as it would be less than 0`)
+	_, err = sc.SetCounterCount(context.Background(), &alpha.CounterSetRequest{Name: "rooms", Count: 21})
+	assert.Error(t, err)
+
+	c, err = sc.GetCounter(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), c.Count)
+	assert.Equal(t, int64(20), c.Capacity)
+
+	// on an update, confirm that the update hits the K8s api, only once
+	select {
+	case value := <-updated:
+		assert.Equal(t, map[string]agonesv1.CounterStatus{"rooms": {Count: 4, Capacity: 20}}, value)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "Should have been updated")
+	}
+	agtesting.AssertEventContains(t, m.FakeRecorder.Events, "UpdateCounters")
+
+	select {
+	case <-updated:
+		assert.Fail(t, "There should be only one update for the counters")
+	case <-time.After(2 * time.Second):
+	}
+}
+
+func TestSDKServerLists(t *testing.T) {
+	t.Parallel()
+	agruntime.FeatureTestMutex.Lock()
+	defer agruntime.FeatureTestMutex.Unlock()
+
+	err := agruntime.ParseFeatures(string(agruntime.FeatureCountsAndLists) + "=true")
+	require.NoError(t, err, "Can not parse FeatureCountsAndLists feature")
+
+	m := agtesting.NewMocks()
+	stop := make(chan struct{})
+	defer close(stop)
+
+	sc, err := defaultSidecar(m)
+	require.NoError(t, err)
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gs := agonesv1.GameServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test", Namespace: "default",
+			},
+			Spec: agonesv1.GameServerSpec{
+				SdkServer: agonesv1.SdkServer{
+					LogLevel: "Debug",
+				},
+				Lists: map[string]agonesv1.ListStatus{
+					"tokens": {Capacity: 2, Values: []string{"a"}},
+				},
+			},
+		}
+		gs.ApplyDefaults()
+		return true, &agonesv1.GameServerList{Items: []agonesv1.GameServer{gs}}, nil
+	})
+
+	updated := make(chan map[string]agonesv1.ListStatus, 10)
+	m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		ua := action.(k8stesting.UpdateAction)
+		gs := ua.GetObject().(*agonesv1.GameServer)
+		updated <- gs.Status.Lists
+		return true, gs, nil
+	})
+
+	sc.informerFactory.Start(stop)
+	assert.True(t, cache.WaitForCacheSync(stop, sc.gameServerSynced))
+
+	go func() {
+		err = sc.Run(stop)
+		assert.NoError(t, err)
+	}()
+
+	// check initial value comes through
+	// async, so check after a period
+	key := &alpha.Key{Name: "tokens"}
+	err = wait.Poll(time.Second, 10*time.Second, func() (bool, error) {
+		l, err := sc.GetList(context.Background(), key)
+		return err == nil && l.Capacity == 2, nil
+	})
+	assert.NoError(t, err)
+
+	ok, err := sc.ListContains(context.Background(), &alpha.ListValueRequest{Name: "tokens", Value: "a"})
+	require.NoError(t, err)
+	assert.True(t, ok.Bool)
+
+	l, err := sc.AddListValue(context.Background(), &alpha.ListValueRequest{Name: "tokens", Value: "b"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, l.Values)
+
+	_, err = sc.AddListValue(context.Background(), &alpha.ListValueRequest{Name: "tokens", Value: "c"})
+	assert.EqualError(t, err, `list "tokens" is already at capacity 2`)
+	_, err = sc.AddListValue(context.Background(), &alpha.ListValueRequest{Name: "tokens", Value: "a"})
+	assert.EqualError(t, err, `value "a" already exists in list "tokens"`)
+	_, err = sc.SetListCapacity(context.Background(), &alpha.CapacityRequest{Name: "tokens", Capacity: 1})
+	assert.Error(t, err)
+
+	l, err = sc.RemoveListValue(context.Background(), &alpha.ListValueRequest{Name: "tokens", Value: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, l.Values)
+	_, err = sc.RemoveListValue(context.Background(), &alpha.ListValueRequest{Name: "tokens", Value: "a"})
+	assert.EqualError(t, err, `value "a" not found in list "tokens"`)
+
+	l, err = sc.SetListCapacity(context.Background(), &alpha.CapacityRequest{Name: "tokens", Capacity: 5})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), l.Capacity)
+
+	_, err = sc.GetList(context.Background(), &alpha.Key{Name: "missing"})
+	assert.EqualError(t, err, `list "missing" not found`)
+
+	// on an update, confirm that the update hits the K8s api, only once
+	select {
+	case value := <-updated:
+		assert.Equal(t, map[string]agonesv1.ListStatus{"tokens": {Capacity: 5, Values: []string{"b"}}}, value)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "Should have been updated")
+	}
+	agtesting.AssertEventContains(t, m.FakeRecorder.Events, "UpdateLists")
+
+	select {
+	case <-updated:
+		assert.Fail(t, "There should be only one update for the lists")
+	case <-time.After(2 * time.Second):
+	}
+}
+
+func TestSDKServerDrain(t *testing.T) {
+	t.Parallel()
+	agruntime.FeatureTestMutex.Lock()
+	defer agruntime.FeatureTestMutex.Unlock()
+
+	m := agtesting.NewMocks()
+	stop := make(chan struct{})
+	defer close(stop)
+
+	sc, err := defaultSidecar(m)
+	require.NoError(t, err)
+	now := time.Now()
+	sc.clock = clock.NewFakeClock(now)
+
+	require.NoError(t, agruntime.ParseFeatures(""))
+	_, err = sc.Drain(context.Background(), &alpha.Duration{Seconds: 60})
+	assert.EqualError(t, err, "SDKDrain not enabled")
+	require.NoError(t, agruntime.ParseFeatures(string(agruntime.FeatureSDKDrain)+"=true"))
+
+	m.AgonesClient.AddReactor("list", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gs := agonesv1.GameServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec:       agonesv1.GameServerSpec{SdkServer: agonesv1.SdkServer{LogLevel: "Debug"}},
+			Status:     agonesv1.GameServerStatus{State: agonesv1.GameServerStateReady},
+		}
+		gs.ApplyDefaults()
+		return true, &agonesv1.GameServerList{Items: []agonesv1.GameServer{gs}}, nil
+	})
+
+	updated := make(chan *metav1.Time, 10)
+	m.AgonesClient.AddReactor("update", "gameservers", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		ua := action.(k8stesting.UpdateAction)
+		gs := ua.GetObject().(*agonesv1.GameServer)
+		updated <- gs.Status.DrainDeadline
+		return true, gs, nil
+	})
+
+	sc.informerFactory.Start(stop)
+	assert.True(t, cache.WaitForCacheSync(stop, sc.gameServerSynced))
+
+	go func() {
+		err = sc.Run(stop)
+		assert.NoError(t, err)
+	}()
+
+	_, err = sc.Drain(context.Background(), &alpha.Duration{Seconds: -1})
+	assert.EqualError(t, err, "drain duration must be greater than 0, found -1 seconds")
+
+	_, err = sc.Drain(context.Background(), &alpha.Duration{Seconds: 60})
+	require.NoError(t, err)
+
+	select {
+	case value := <-updated:
+		require.NotNil(t, value)
+		assert.Equal(t, now.Add(time.Minute).Unix(), value.Unix())
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "Should have been updated")
+	}
+	agtesting.AssertEventContains(t, m.FakeRecorder.Events, "Draining")
+}
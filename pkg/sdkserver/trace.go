@@ -0,0 +1,346 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdkserver
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"agones.dev/agones/pkg/sdk"
+	"agones.dev/agones/pkg/util/runtime"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+const (
+	// TraceEventCall is the call of a unary SDK request
+	TraceEventCall TraceEvent = "call"
+	// TraceEventRecv is a message received by the SDK server on a stream, such as a health ping
+	TraceEventRecv TraceEvent = "recv"
+	// TraceEventSend is a message sent by the SDK server on a stream, such as a GameServer update
+	TraceEventSend TraceEvent = "send"
+	// TraceEventClose is the end of a stream
+	TraceEventClose TraceEvent = "close"
+
+	// replayCloseTimeout is how long a replayed stream is given to end by itself once closed,
+	// before it is cancelled
+	replayCloseTimeout = time.Second
+)
+
+// TraceEvent is the kind of event of a TraceEntry
+type TraceEvent string
+
+// TraceEntry is an event of an SDK request, written as a line of a trace
+type TraceEntry struct {
+	// Time is when the event happened. For calls, it is when the request was received.
+	Time time.Time `json:"time"`
+	// Method is the full gRPC method of the SDK request, e.g. /agones.dev.sdk.SDK/Ready
+	Method string     `json:"method"`
+	Event  TraceEvent `json:"event"`
+	// Stream identifies the stream of stream events
+	Stream int64 `json:"stream,omitempty"`
+	// RequestType is the protobuf message name of the Request
+	RequestType string          `json:"requestType,omitempty"`
+	Request     json.RawMessage `json:"request,omitempty"`
+	// ResponseType is the protobuf message name of the Response
+	ResponseType string          `json:"responseType,omitempty"`
+	Response     json.RawMessage `json:"response,omitempty"`
+	// Code is the gRPC status code of the result, e.g. OK or Unknown
+	Code  string `json:"code,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// Tracer writes a trace of the SDK requests made to an SDK server, with their arguments and results,
+// as a line of JSON per TraceEntry
+type Tracer struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	clock   clock.Clock
+	logger  *logrus.Entry
+	streams int64
+}
+
+// NewTracer returns a Tracer that writes the trace to w
+func NewTracer(w io.Writer) *Tracer {
+	t := &Tracer{encoder: json.NewEncoder(w), clock: clock.RealClock{}}
+	t.logger = runtime.NewLoggerWithType(t)
+	return t
+}
+
+// ServerOptions returns the options that make a gRPC server trace its SDK requests with this Tracer
+func (t *Tracer) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{grpc.UnaryInterceptor(t.interceptUnary), grpc.StreamInterceptor(t.interceptStream)}
+}
+
+// interceptUnary traces the call of a unary SDK request
+func (t *Tracer) interceptUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	entry := TraceEntry{Time: t.clock.Now().UTC(), Method: info.FullMethod, Event: TraceEventCall}
+	entry.RequestType, entry.Request = t.marshal(req)
+	resp, err := handler(ctx, req)
+	if err == nil {
+		entry.ResponseType, entry.Response = t.marshal(resp)
+	}
+	entry.setResult(err)
+	t.write(entry)
+	return resp, err
+}
+
+// interceptStream traces the messages of an SDK request stream, and its end
+func (t *Tracer) interceptStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	stream := &tracedStream{ServerStream: ss, tracer: t, method: info.FullMethod, id: atomic.AddInt64(&t.streams, 1)}
+	err := handler(srv, stream)
+	entry := stream.entry(TraceEventClose)
+	entry.setResult(err)
+	t.write(entry)
+	return err
+}
+
+// marshal returns the protobuf message name of the message, and the message as JSON
+func (t *Tracer) marshal(m interface{}) (string, json.RawMessage) {
+	msg, ok := m.(proto.Message)
+	if !ok || reflect.ValueOf(msg).IsNil() {
+		return "", nil
+	}
+	b, err := json.Marshal(msg)
+	if err != nil {
+		t.logger.WithError(err).WithField("message", msg).Error("could not marshal message for the trace")
+		return proto.MessageName(msg), nil
+	}
+	return proto.MessageName(msg), b
+}
+
+// write writes the entry as a line of the trace
+func (t *Tracer) write(entry TraceEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.encoder.Encode(entry); err != nil {
+		t.logger.WithError(err).Error("could not write trace entry")
+	}
+}
+
+// setResult sets the status code and the error of the entry from the result of a request
+func (e *TraceEntry) setResult(err error) {
+	e.Code = status.Code(err).String()
+	if err != nil {
+		e.Error = err.Error()
+	}
+}
+
+// tracedStream is a grpc.ServerStream that traces the messages received and sent on it
+type tracedStream struct {
+	grpc.ServerStream
+	tracer *Tracer
+	method string
+	id     int64
+}
+
+// entry returns a TraceEntry for an event of the stream, happening now
+func (s *tracedStream) entry(event TraceEvent) TraceEntry {
+	return TraceEntry{Time: s.tracer.clock.Now().UTC(), Method: s.method, Event: event, Stream: s.id}
+}
+
+// RecvMsg receives a message, and traces it
+func (s *tracedStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		// the end of the stream, or its failure, is traced when the stream closes
+		return err
+	}
+	entry := s.entry(TraceEventRecv)
+	entry.RequestType, entry.Request = s.tracer.marshal(m)
+	entry.setResult(nil)
+	s.tracer.write(entry)
+	return nil
+}
+
+// SendMsg sends a message, and traces it
+func (s *tracedStream) SendMsg(m interface{}) error {
+	entry := s.entry(TraceEventSend)
+	err := s.ServerStream.SendMsg(m)
+	entry.ResponseType, entry.Response = s.tracer.marshal(m)
+	entry.setResult(err)
+	s.tracer.write(entry)
+	return err
+}
+
+// ReplayTrace drives a fake game server from a trace read from r: it makes the SDK requests of the trace
+// to the SDK server at conn, at the same pace as they were traced. It returns the number of unary requests
+// whose status code is different from the one in the trace.
+func ReplayTrace(ctx context.Context, conn *grpc.ClientConn, r io.Reader, logger *logrus.Entry) (int, error) {
+	streams := map[int64]*replayStream{}
+	defer func() {
+		for _, s := range streams {
+			s.cancel()
+		}
+	}()
+
+	decoder := json.NewDecoder(r)
+	var traceStart, replayStart time.Time
+	mismatches := 0
+	for {
+		var entry TraceEntry
+		err := decoder.Decode(&entry)
+		if err == io.EOF {
+			return mismatches, nil
+		}
+		if err != nil {
+			return mismatches, errors.Wrap(err, "error decoding trace")
+		}
+
+		if traceStart.IsZero() {
+			traceStart = entry.Time
+			replayStart = time.Now()
+		}
+		if wait := time.Until(replayStart.Add(entry.Time.Sub(traceStart))); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+				return mismatches, ctx.Err()
+			}
+		}
+
+		log := logger.WithField("method", entry.Method).WithField("event", entry.Event)
+		switch entry.Event {
+		case TraceEventCall:
+			code, err := replayCall(ctx, conn, entry)
+			if err != nil {
+				return mismatches, err
+			}
+			if code.String() != entry.Code {
+				log.WithField("traced", entry.Code).WithField("replayed", code.String()).
+					Warn("Replayed request has a different status code than in the trace")
+				mismatches++
+				continue
+			}
+			log.WithField("code", entry.Code).Info("Replayed request")
+		case TraceEventRecv:
+			s, ok := streams[entry.Stream]
+			if !ok {
+				s, err = openReplayStream(ctx, conn, entry.Method)
+				if err != nil {
+					return mismatches, err
+				}
+				streams[entry.Stream] = s
+			}
+			msg, err := newTraceMessage(entry.RequestType, entry.Request)
+			if err != nil {
+				return mismatches, err
+			}
+			if err := s.stream.SendMsg(msg); err != nil {
+				log.WithError(err).Warn("Could not replay stream message")
+				continue
+			}
+			log.Info("Replayed stream message")
+		case TraceEventClose:
+			s, ok := streams[entry.Stream]
+			if !ok {
+				continue
+			}
+			delete(streams, entry.Stream)
+			log.WithField("traced", entry.Code).WithField("replayed", s.close()).Info("Replayed end of stream")
+		}
+		// the messages sent by the SDK server are received in the background by the replayed streams
+	}
+}
+
+// replayCall makes the unary SDK request of the entry, and returns its status code
+func replayCall(ctx context.Context, conn *grpc.ClientConn, entry TraceEntry) (codes.Code, error) {
+	req, err := newTraceMessage(entry.RequestType, entry.Request)
+	if err != nil {
+		return codes.Unknown, err
+	}
+	resp, err := newTraceMessage(entry.ResponseType, nil)
+	if err != nil {
+		return codes.Unknown, err
+	}
+	return status.Code(conn.Invoke(ctx, entry.Method, req, resp)), nil
+}
+
+// newTraceMessage returns a new protobuf message with the given message name, set from its JSON.
+// A message with no name is returned as an empty message, that keeps the fields it is unmarshalled from.
+func newTraceMessage(name string, data json.RawMessage) (proto.Message, error) {
+	if name == "" {
+		return &sdk.Empty{}, nil
+	}
+	t := proto.MessageType(name)
+	if t == nil {
+		return nil, errors.Errorf("unknown message type %s in the trace", name)
+	}
+	msg := reflect.New(t.Elem()).Interface().(proto.Message)
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, msg); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal %s from the trace", name)
+		}
+	}
+	return msg, nil
+}
+
+// replayStream is a stream of SDK requests being replayed
+type replayStream struct {
+	stream grpc.ClientStream
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// openReplayStream opens a stream for the SDK request method, and receives the messages the SDK server sends
+// on it in the background
+func openReplayStream(ctx context.Context, conn *grpc.ClientConn, method string) (*replayStream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, method)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "could not open stream %s", method)
+	}
+
+	s := &replayStream{stream: stream, cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		for {
+			if err := stream.RecvMsg(&sdk.Empty{}); err != nil {
+				if err != io.EOF {
+					s.err = err
+				}
+				return
+			}
+		}
+	}()
+	return s, nil
+}
+
+// close ends the stream, and returns the status code it ended with.
+// Streams that don't end by themselves once closed are cancelled.
+func (s *replayStream) close() string {
+	defer s.cancel()
+	if err := s.stream.CloseSend(); err != nil {
+		return status.Code(err).String()
+	}
+	select {
+	case <-s.done:
+		return status.Code(s.err).String()
+	case <-time.After(replayCloseTimeout):
+		return codes.Canceled.String()
+	}
+}
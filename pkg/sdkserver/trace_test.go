@@ -0,0 +1,145 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdkserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"agones.dev/agones/pkg/sdk"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func TestTracerAndReplayTrace(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	e := &sdk.Empty{}
+
+	buf := &lockedBuffer{}
+	tracer := NewTracer(buf)
+	tracer.clock = clock.NewFakeClock(time.Now())
+	conn, stop := startLocalSDKServer(t, true, tracer.ServerOptions()...)
+	client := sdk.NewSDKClient(conn)
+
+	_, err := client.Ready(ctx, e)
+	assert.NoError(t, err)
+	_, err = client.SetLabel(ctx, &sdk.KeyValue{Key: "foo", Value: "bar"})
+	assert.NoError(t, err)
+	health, err := client.Health(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, health.Send(e))
+	_, err = health.CloseAndRecv()
+	assert.NoError(t, err)
+	_, err = client.Shutdown(ctx, e)
+	assert.NoError(t, err)
+	_, err = client.Ready(ctx, e)
+	assert.Error(t, err, "Shutdown can't move back to Ready in strict mode")
+	stop()
+
+	var entries []TraceEntry
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry TraceEntry
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	require.Len(t, entries, 7)
+
+	assert.Equal(t, "/agones.dev.sdk.SDK/Ready", entries[0].Method)
+	assert.Equal(t, TraceEventCall, entries[0].Event)
+	assert.Equal(t, "agones.dev.sdk.Empty", entries[0].RequestType)
+	assert.Equal(t, "OK", entries[0].Code)
+
+	assert.Equal(t, "/agones.dev.sdk.SDK/SetLabel", entries[1].Method)
+	assert.Equal(t, "agones.dev.sdk.KeyValue", entries[1].RequestType)
+	assert.JSONEq(t, `{"key":"foo","value":"bar"}`, string(entries[1].Request))
+
+	assert.Equal(t, "/agones.dev.sdk.SDK/Health", entries[2].Method)
+	assert.Equal(t, TraceEventRecv, entries[2].Event)
+	assert.Equal(t, TraceEventSend, entries[3].Event)
+	assert.Equal(t, TraceEventClose, entries[4].Event)
+	assert.Equal(t, entries[2].Stream, entries[4].Stream)
+	assert.Equal(t, "OK", entries[4].Code)
+
+	assert.Equal(t, "/agones.dev.sdk.SDK/Ready", entries[6].Method)
+	assert.Equal(t, "Unknown", entries[6].Code)
+	assert.Equal(t, "GameServer cannot be moved from Shutdown to Ready", entries[6].Error)
+
+	logger := logrus.NewEntry(logrus.New())
+	conn, stop = startLocalSDKServer(t, true)
+	mismatches, err := ReplayTrace(ctx, conn, strings.NewReader(buf.String()), logger)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, mismatches)
+	stop()
+
+	// without strict mode, moving back to Ready succeeds
+	conn, stop = startLocalSDKServer(t, false)
+	mismatches, err = ReplayTrace(ctx, conn, strings.NewReader(buf.String()), logger)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mismatches)
+	stop()
+
+	_, err = ReplayTrace(ctx, conn, strings.NewReader(`{"method":"/agones.dev.sdk.SDK/Ready","event":"call","requestType":"unknown"}`), logger)
+	assert.EqualError(t, err, "unknown message type unknown in the trace")
+}
+
+// startLocalSDKServer serves a LocalSDKServer over gRPC, and returns a connection to it,
+// and a func that stops the server
+func startLocalSDKServer(t *testing.T, strict bool, opts ...grpc.ServerOption) (*grpc.ClientConn, func()) {
+	l, err := NewLocalSDKServer("")
+	require.NoError(t, err)
+	l.SetStrictMode(strict)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer(opts...)
+	sdk.RegisterSDKServer(grpcServer, l)
+	go grpcServer.Serve(lis) // nolint: errcheck
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	require.NoError(t, err)
+	return conn, func() {
+		assert.NoError(t, conn.Close())
+		grpcServer.GracefulStop()
+		l.Close()
+	}
+}
+
+// lockedBuffer is a bytes.Buffer that can be read while it is written
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 Google LLC All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for _, spec := range []string{"* * * * *", "0 18 * * 5", "*/15 9-17 * * MON-FRI", "0,30 0-6/2 1,15 jan-jun *", "@daily", "@Hourly", "0 0 * * 7"} {
+		_, err := Parse(spec)
+		assert.NoError(t, err, spec)
+	}
+
+	for _, spec := range []string{"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8",
+		"*/0 * * * *", "5-1 * * * *", "a * * * *", "* * * FOO *", "@fortnightly"} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	t.Parallel()
+
+	// a Thursday
+	from := time.Date(2020, 10, 1, 12, 30, 15, 0, time.UTC)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	fixtures := map[string]struct {
+		spec     string
+		from     time.Time
+		expected time.Time
+	}{
+		"every minute": {
+			spec:     "* * * * *",
+			from:     from,
+			expected: time.Date(2020, 10, 1, 12, 31, 0, 0, time.UTC),
+		},
+		"strictly after": {
+			spec:     "31 12 * * *",
+			from:     time.Date(2020, 10, 1, 12, 31, 0, 0, time.UTC),
+			expected: time.Date(2020, 10, 2, 12, 31, 0, 0, time.UTC),
+		},
+		"every 15 minutes in office hours": {
+			spec:     "*/15 9-17 * * MON-FRI",
+			from:     time.Date(2020, 10, 2, 17, 50, 0, 0, time.UTC),
+			expected: time.Date(2020, 10, 5, 9, 0, 0, 0, time.UTC),
+		},
+		"friday evening": {
+			spec:     "0 18 * * 5",
+			from:     from,
+			expected: time.Date(2020, 10, 2, 18, 0, 0, 0, time.UTC),
+		},
+		"sunday as 7": {
+			spec:     "0 0 * * 7",
+			from:     from,
+			expected: time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC),
+		},
+		"day of month or day of week": {
+			spec:     "0 0 15 * 1",
+			from:     from,
+			expected: time.Date(2020, 10, 5, 0, 0, 0, 0, time.UTC),
+		},
+		"next year": {
+			spec:     "@yearly",
+			from:     from,
+			expected: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"leap day": {
+			spec:     "0 0 29 2 *",
+			from:     from,
+			expected: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		"never": {
+			spec:     "0 0 30 2 *",
+			from:     from,
+			expected: time.Time{},
+		},
+		"time zone": {
+			spec:     "0 9 * * *",
+			from:     from.In(newYork),
+			expected: time.Date(2020, 10, 1, 9, 0, 0, 0, newYork),
+		},
+	}
+
+	for k, v := range fixtures {
+		t.Run(k, func(t *testing.T) {
+			s, err := Parse(v.spec)
+			require.NoError(t, err)
+			next := s.Next(v.from)
+			assert.True(t, v.expected.Equal(next), "expected %s, got %s", v.expected, next)
+		})
+	}
+}
@@ -43,6 +43,81 @@ const (
 	// FeatureRollingUpdateOnReady is a feature flag to enable/disable rolling update fix of scale down, when ReadyReplicas
 	// count is taken into account
 	FeatureRollingUpdateOnReady Feature = "RollingUpdateOnReady"
+
+	// FeatureStateAllocationFilter is a feature flag to enable/disable allocation of GameServers
+	// that are already Allocated, through the GameServerState allocation selector
+	FeatureStateAllocationFilter Feature = "StateAllocationFilter"
+
+	// FeatureCountsAndLists is a feature flag to enable/disable generic Counters and Lists
+	// tracking on GameServers
+	FeatureCountsAndLists Feature = "CountsAndLists"
+
+	// FeatureAllocationPriorities is a feature flag to enable/disable sorting GameServers during allocation
+	// by their labels, annotations or player capacity
+	FeatureAllocationPriorities Feature = "AllocationPriorities"
+
+	// FeatureAllocationWebhook is a feature flag to enable/disable calling a webhook to veto or
+	// add metadata to each GameServer that is about to be allocated
+	FeatureAllocationWebhook Feature = "AllocationWebhook"
+
+	// FeatureAllocationEndpointHealth is a feature flag to enable/disable tracking the health of
+	// multi-cluster allocation endpoints, and skipping the ones that keep failing
+	FeatureAllocationEndpointHealth Feature = "AllocationEndpointHealth"
+
+	// FeatureAllocationCapacityWeighting is a feature flag to enable/disable scaling the weight of
+	// multi-cluster allocation policies by the observed capacity of their cluster
+	FeatureAllocationCapacityWeighting Feature = "AllocationCapacityWeighting"
+
+	// FeatureAllocationLatency is a feature flag to enable/disable ordering the clusters of multi-cluster
+	// allocation policies by the latencies the player measured to them
+	FeatureAllocationLatency Feature = "AllocationLatency"
+
+	// FeatureScheduledAutoscaler is a feature flag to enable/disable the Schedule FleetAutoscaler policy,
+	// which applies a different buffer policy during scheduled time windows
+	FeatureScheduledAutoscaler Feature = "ScheduledAutoscaler"
+
+	// FeatureChainedAutoscaler is a feature flag to enable/disable the Chain FleetAutoscaler policy,
+	// which combines the desired replicas of several policies
+	FeatureChainedAutoscaler Feature = "ChainedAutoscaler"
+
+	// FeatureFleetAutoscalerBehavior is a feature flag to enable/disable limiting the rate at which
+	// FleetAutoscalers scale their Fleet, and stabilizing their scale downs
+	FeatureFleetAutoscalerBehavior Feature = "FleetAutoscalerBehavior"
+
+	// FeatureCustomFasSyncInterval is a feature flag to enable/disable a custom sync interval for each FleetAutoscaler,
+	// and syncing them as soon as the allocated replicas of their Fleet change
+	FeatureCustomFasSyncInterval Feature = "CustomFasSyncInterval"
+
+	// FeaturePlayerBufferAutoscaler is a feature flag to enable/disable the PlayerBuffer FleetAutoscaler policy,
+	// which keeps a buffer of free player slots across the Fleet. It also requires FeaturePlayerTracking.
+	FeaturePlayerBufferAutoscaler Feature = "PlayerBufferAutoscaler"
+
+	// FeatureFleetAutoscalerWebhookTransport is a feature flag to enable/disable configuring how the webhook of a
+	// FleetAutoscaler is called: with a client certificate, a timeout, retries, or with gRPC instead of HTTP
+	FeatureFleetAutoscalerWebhookTransport Feature = "FleetAutoscalerWebhookTransport"
+
+	// FeatureFleetAutoscaleRequestDetails is a feature flag to enable/disable sending the labels, annotations,
+	// GameServer states and allocation rate of the Fleet, and the spec of the FleetAutoscaler, to its webhook
+	FeatureFleetAutoscaleRequestDetails Feature = "FleetAutoscaleRequestDetails"
+
+	// FeatureFleetAutoscalerDryRun is a feature flag to enable/disable the DryRun mode of FleetAutoscalers
+	FeatureFleetAutoscalerDryRun Feature = "FleetAutoscalerDryRun"
+
+	// FeaturePredictiveAutoscaler is a feature flag to enable/disable the Predictive FleetAutoscaler policy,
+	// which forecasts the allocations of the Fleet from their history
+	FeaturePredictiveAutoscaler Feature = "PredictiveAutoscaler"
+
+	// FeatureFleetAutoscalerBudget is a feature flag to enable/disable FleetAutoscalers that scale the Fleets
+	// selected by a label selector, within a shared budget of replicas
+	FeatureFleetAutoscalerBudget Feature = "FleetAutoscalerBudget"
+
+	// FeatureSDKTrace is a feature flag to enable/disable the Trace of the SdkServer of a GameServer, which makes
+	// its SDK Server write a trace of every SDK request to its logs
+	FeatureSDKTrace Feature = "SDKTrace"
+
+	// FeatureSDKDrain is a feature flag to enable/disable the SDK Drain() request, which stops a GameServer
+	// from being allocated, and moves it to Shutdown once its drain deadline passes
+	FeatureSDKDrain Feature = "SDKDrain"
 )
 
 var (
@@ -50,11 +125,30 @@ var (
 	// operational in Agones, and what their default configuration is.
 	// alpha features are disabled
 	featureDefaults = map[Feature]bool{
-		FeatureExample:                 true,
-		FeaturePlayerTracking:          false,
-		FeatureContainerPortAllocation: true,
-		FeatureSDKWatchSendOnExecute:   false,
-		FeatureRollingUpdateOnReady:    false,
+		FeatureExample:                         true,
+		FeaturePlayerTracking:                  false,
+		FeatureContainerPortAllocation:         true,
+		FeatureSDKWatchSendOnExecute:           false,
+		FeatureRollingUpdateOnReady:            false,
+		FeatureStateAllocationFilter:           false,
+		FeatureCountsAndLists:                  false,
+		FeatureAllocationPriorities:            false,
+		FeatureAllocationWebhook:               false,
+		FeatureAllocationEndpointHealth:        false,
+		FeatureAllocationCapacityWeighting:     false,
+		FeatureAllocationLatency:               false,
+		FeatureScheduledAutoscaler:             false,
+		FeatureChainedAutoscaler:               false,
+		FeatureFleetAutoscalerBehavior:         false,
+		FeatureCustomFasSyncInterval:           false,
+		FeaturePlayerBufferAutoscaler:          false,
+		FeatureFleetAutoscalerWebhookTransport: false,
+		FeatureFleetAutoscaleRequestDetails:    false,
+		FeatureFleetAutoscalerDryRun:           false,
+		FeaturePredictiveAutoscaler:            false,
+		FeatureFleetAutoscalerBudget:           false,
+		FeatureSDKTrace:                        false,
+		FeatureSDKDrain:                        false,
 	}
 
 	// featureGates is the storage of what features are enabled
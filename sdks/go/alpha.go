@@ -16,6 +16,7 @@ package sdk
 
 import (
 	"context"
+	"time"
 
 	"agones.dev/agones/pkg/sdk/alpha"
 	"github.com/pkg/errors"
@@ -82,3 +83,91 @@ func (a *Alpha) GetConnectedPlayers() ([]string, error) {
 	list, err := a.client.GetConnectedPlayers(context.Background(), &alpha.Empty{})
 	return list.List, errors.Wrap(err, "could not list connected players")
 }
+
+// GetCounterCount returns the current count of the named Counter.
+// This is always accurate, even if the value hasn’t been updated to the GameServer status yet.
+func (a *Alpha) GetCounterCount(name string) (int64, error) {
+	c, err := a.client.GetCounter(context.Background(), &alpha.Key{Name: name})
+	return c.GetCount(), errors.Wrapf(err, "could not get counter %s count", name)
+}
+
+// GetCounterCapacity returns the current capacity of the named Counter.
+// This is always accurate, even if the value hasn’t been updated to the GameServer status yet.
+func (a *Alpha) GetCounterCapacity(name string) (int64, error) {
+	c, err := a.client.GetCounter(context.Background(), &alpha.Key{Name: name})
+	return c.GetCapacity(), errors.Wrapf(err, "could not get counter %s capacity", name)
+}
+
+// IncrementCounter increases the count of the named Counter by the given amount.
+// Returns an error if the count would exceed the Counter's capacity.
+func (a *Alpha) IncrementCounter(name string, amount int64) error {
+	_, err := a.client.IncrementCounter(context.Background(), &alpha.CounterUpdateRequest{Name: name, Amount: amount})
+	return errors.Wrapf(err, "could not increment counter %s", name)
+}
+
+// DecrementCounter decreases the count of the named Counter by the given amount.
+// Returns an error if the count would drop below zero.
+func (a *Alpha) DecrementCounter(name string, amount int64) error {
+	_, err := a.client.DecrementCounter(context.Background(), &alpha.CounterUpdateRequest{Name: name, Amount: amount})
+	return errors.Wrapf(err, "could not decrement counter %s", name)
+}
+
+// SetCounterCount sets the count of the named Counter to a new value.
+func (a *Alpha) SetCounterCount(name string, count int64) error {
+	_, err := a.client.SetCounterCount(context.Background(), &alpha.CounterSetRequest{Name: name, Count: count})
+	return errors.Wrapf(err, "could not set counter %s count", name)
+}
+
+// SetCounterCapacity sets the capacity of the named Counter to a new value.
+func (a *Alpha) SetCounterCapacity(name string, capacity int64) error {
+	_, err := a.client.SetCounterCapacity(context.Background(), &alpha.CapacityRequest{Name: name, Capacity: capacity})
+	return errors.Wrapf(err, "could not set counter %s capacity", name)
+}
+
+// GetListCapacity returns the current capacity of the named List.
+// This is always accurate, even if the value hasn’t been updated to the GameServer status yet.
+func (a *Alpha) GetListCapacity(name string) (int64, error) {
+	l, err := a.client.GetList(context.Background(), &alpha.Key{Name: name})
+	return l.GetCapacity(), errors.Wrapf(err, "could not get list %s capacity", name)
+}
+
+// GetListValues returns the current values of the named List.
+// This is always accurate, even if the value hasn’t been updated to the GameServer status yet.
+func (a *Alpha) GetListValues(name string) ([]string, error) {
+	l, err := a.client.GetList(context.Background(), &alpha.Key{Name: name})
+	return l.GetValues(), errors.Wrapf(err, "could not get list %s values", name)
+}
+
+// ListContains returns if the value is currently in the named List.
+// This is always accurate, even if the value hasn’t been updated to the GameServer status yet.
+func (a *Alpha) ListContains(name, value string) (bool, error) {
+	ok, err := a.client.ListContains(context.Background(), &alpha.ListValueRequest{Name: name, Value: value})
+	return ok.GetBool(), errors.Wrapf(err, "could not check list %s for value", name)
+}
+
+// AddListValue appends the value to the named List.
+// Returns an error if the value already exists, or the List is at capacity.
+func (a *Alpha) AddListValue(name, value string) error {
+	_, err := a.client.AddListValue(context.Background(), &alpha.ListValueRequest{Name: name, Value: value})
+	return errors.Wrapf(err, "could not add value to list %s", name)
+}
+
+// RemoveListValue removes the value from the named List.
+// Returns an error if the value does not exist in the List.
+func (a *Alpha) RemoveListValue(name, value string) error {
+	_, err := a.client.RemoveListValue(context.Background(), &alpha.ListValueRequest{Name: name, Value: value})
+	return errors.Wrapf(err, "could not remove value from list %s", name)
+}
+
+// SetListCapacity sets the capacity of the named List to a new value.
+func (a *Alpha) SetListCapacity(name string, capacity int64) error {
+	_, err := a.client.SetListCapacity(context.Background(), &alpha.CapacityRequest{Name: name, Capacity: capacity})
+	return errors.Wrapf(err, "could not set list %s capacity", name)
+}
+
+// Drain stops the GameServer from being allocated, and has it moved to Shutdown once the duration passes.
+// Call Shutdown() once the current match ends, if it ends before then.
+func (a *Alpha) Drain(d time.Duration) error {
+	_, err := a.client.Drain(context.Background(), &alpha.Duration{Seconds: int64(d.Seconds())})
+	return errors.Wrap(err, "could not send Drain message")
+}
@@ -17,8 +17,10 @@ package sdk
 import (
 	"context"
 	"testing"
+	"time"
 
 	"agones.dev/agones/pkg/sdk/alpha"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 )
@@ -71,11 +73,86 @@ func TestAlphaGetAndSetPlayerCapacity(t *testing.T) {
 	assert.Equal(t, []string{playerID}, list)
 }
 
+func TestAlphaCountersAndLists(t *testing.T) {
+	mock := &alphaMock{
+		counters: map[string]*alpha.Counter{"rooms": {Name: "rooms", Count: 1, Capacity: 10}},
+		lists:    map[string]*alpha.List{"tokens": {Name: "tokens", Capacity: 10}},
+	}
+	a := Alpha{
+		client: mock,
+	}
+
+	err := a.IncrementCounter("rooms", 3)
+	assert.NoError(t, err)
+	count, err := a.GetCounterCount("rooms")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+
+	err = a.DecrementCounter("rooms", 2)
+	assert.NoError(t, err)
+	count, err = a.GetCounterCount("rooms")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	err = a.SetCounterCount("rooms", 5)
+	assert.NoError(t, err)
+	count, err = a.GetCounterCount("rooms")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+
+	err = a.SetCounterCapacity("rooms", 20)
+	assert.NoError(t, err)
+	capacity, err := a.GetCounterCapacity("rooms")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), capacity)
+
+	_, err = a.GetCounterCount("missing")
+	assert.Error(t, err)
+
+	err = a.AddListValue("tokens", "one")
+	assert.NoError(t, err)
+	ok, err := a.ListContains("tokens", "one")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	values, err := a.GetListValues("tokens")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"one"}, values)
+
+	err = a.RemoveListValue("tokens", "one")
+	assert.NoError(t, err)
+	ok, err = a.ListContains("tokens", "one")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	err = a.SetListCapacity("tokens", 5)
+	assert.NoError(t, err)
+	capacity, err = a.GetListCapacity("tokens")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), capacity)
+
+	_, err = a.GetListValues("missing")
+	assert.Error(t, err)
+}
+
+func TestAlphaDrain(t *testing.T) {
+	mock := &alphaMock{}
+	a := Alpha{
+		client: mock,
+	}
+
+	err := a.Drain(5 * time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), mock.drainSeconds)
+}
+
 type alphaMock struct {
 	capacity           int64
 	playerCount        int64
 	playerConnected    string
 	playerDisconnected string
+	counters           map[string]*alpha.Counter
+	lists              map[string]*alpha.List
+	drainSeconds       int64
 }
 
 func (a *alphaMock) PlayerConnect(ctx context.Context, id *alpha.PlayerID, opts ...grpc.CallOption) (*alpha.Bool, error) {
@@ -110,3 +187,105 @@ func (a *alphaMock) GetPlayerCapacity(ctx context.Context, in *alpha.Empty, opts
 func (a *alphaMock) GetPlayerCount(ctx context.Context, in *alpha.Empty, opts ...grpc.CallOption) (*alpha.Count, error) {
 	return &alpha.Count{Count: a.playerCount}, nil
 }
+
+func (a *alphaMock) GetCounter(ctx context.Context, in *alpha.Key, opts ...grpc.CallOption) (*alpha.Counter, error) {
+	c, ok := a.counters[in.Name]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return c, nil
+}
+
+func (a *alphaMock) IncrementCounter(ctx context.Context, in *alpha.CounterUpdateRequest, opts ...grpc.CallOption) (*alpha.Counter, error) {
+	c, err := a.GetCounter(ctx, &alpha.Key{Name: in.Name})
+	if err != nil {
+		return nil, err
+	}
+	c.Count += in.Amount
+	return c, nil
+}
+
+func (a *alphaMock) DecrementCounter(ctx context.Context, in *alpha.CounterUpdateRequest, opts ...grpc.CallOption) (*alpha.Counter, error) {
+	c, err := a.GetCounter(ctx, &alpha.Key{Name: in.Name})
+	if err != nil {
+		return nil, err
+	}
+	c.Count -= in.Amount
+	return c, nil
+}
+
+func (a *alphaMock) SetCounterCount(ctx context.Context, in *alpha.CounterSetRequest, opts ...grpc.CallOption) (*alpha.Counter, error) {
+	c, err := a.GetCounter(ctx, &alpha.Key{Name: in.Name})
+	if err != nil {
+		return nil, err
+	}
+	c.Count = in.Count
+	return c, nil
+}
+
+func (a *alphaMock) SetCounterCapacity(ctx context.Context, in *alpha.CapacityRequest, opts ...grpc.CallOption) (*alpha.Counter, error) {
+	c, err := a.GetCounter(ctx, &alpha.Key{Name: in.Name})
+	if err != nil {
+		return nil, err
+	}
+	c.Capacity = in.Capacity
+	return c, nil
+}
+
+func (a *alphaMock) GetList(ctx context.Context, in *alpha.Key, opts ...grpc.CallOption) (*alpha.List, error) {
+	l, ok := a.lists[in.Name]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return l, nil
+}
+
+func (a *alphaMock) AddListValue(ctx context.Context, in *alpha.ListValueRequest, opts ...grpc.CallOption) (*alpha.List, error) {
+	l, err := a.GetList(ctx, &alpha.Key{Name: in.Name})
+	if err != nil {
+		return nil, err
+	}
+	l.Values = append(l.Values, in.Value)
+	return l, nil
+}
+
+func (a *alphaMock) RemoveListValue(ctx context.Context, in *alpha.ListValueRequest, opts ...grpc.CallOption) (*alpha.List, error) {
+	l, err := a.GetList(ctx, &alpha.Key{Name: in.Name})
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range l.Values {
+		if v == in.Value {
+			l.Values = append(l.Values[:i], l.Values[i+1:]...)
+			break
+		}
+	}
+	return l, nil
+}
+
+func (a *alphaMock) SetListCapacity(ctx context.Context, in *alpha.CapacityRequest, opts ...grpc.CallOption) (*alpha.List, error) {
+	l, err := a.GetList(ctx, &alpha.Key{Name: in.Name})
+	if err != nil {
+		return nil, err
+	}
+	l.Capacity = in.Capacity
+	return l, nil
+}
+
+func (a *alphaMock) ListContains(ctx context.Context, in *alpha.ListValueRequest, opts ...grpc.CallOption) (*alpha.Bool, error) {
+	l, err := a.GetList(ctx, &alpha.Key{Name: in.Name})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range l.Values {
+		if v == in.Value {
+			return &alpha.Bool{Bool: true}, nil
+		}
+	}
+	return &alpha.Bool{Bool: false}, nil
+}
+
+func (a *alphaMock) Drain(ctx context.Context, in *alpha.Duration, opts ...grpc.CallOption) (*alpha.Empty, error) {
+	a.drainSeconds = in.Seconds
+	return &alpha.Empty{}, nil
+}